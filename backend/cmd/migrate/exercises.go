@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+const exerciseForeignKey = "fk_workout_records_exercise"
+
+// fixExerciseForeignKey は ON DELETE SET NULL で作成された記録→種目の外部キーを張り直す。
+// 種目が削除されても記録が孤立しないよう、記録が残っている種目は削除できないようにする。
+func fixExerciseForeignKey(conn *gorm.DB) error {
+	if conn.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	var deleteRule string
+	if err := conn.Raw(`
+		SELECT delete_rule FROM information_schema.referential_constraints
+		WHERE constraint_name = ?`, exerciseForeignKey).
+		Scan(&deleteRule).Error; err != nil {
+		return err
+	}
+	if deleteRule != "SET NULL" {
+		return nil
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if err := m.DropConstraint(&models.WorkoutRecord{}, exerciseForeignKey); err != nil {
+			return err
+		}
+		return m.CreateConstraint(&models.WorkoutRecord{}, "Exercise")
+	})
+}
@@ -0,0 +1,46 @@
+package migrate
+
+import "gorm.io/gorm"
+
+// timelineIndexes はタイムラインのキーセットページネーション（日付・ID の降順）と絞り込みに使うインデックス。
+// 部分インデックスと降順指定は構造体タグで表せないため SQL で作成する。
+var timelineIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS idx_workout_sessions_timeline
+		ON workout_sessions (trained_on DESC, id DESC)
+		WHERE is_public = true AND deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_timeline
+		ON workout_sessions (user_id, trained_on DESC, id DESC)
+		WHERE is_public = true AND deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_workout_records_exercise_session
+		ON workout_records (exercise_id, session_id)
+		WHERE deleted_at IS NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_recaps_timeline
+		ON recaps (published_at DESC, id DESC)
+		WHERE is_public = true`,
+}
+
+func createTimelineIndexes(conn *gorm.DB) error {
+	for _, stmt := range timelineIndexes {
+		if err := conn.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exerciseIndexes は種目のインデックス。NULL 同士は一意制約で重複とみなされないため、
+// ux_exercise_owner_name では共通種目（owner_id が NULL）の同名を防げず、部分インデックスで補う。
+var exerciseIndexes = []string{
+	`CREATE UNIQUE INDEX IF NOT EXISTS ux_exercises_global_name
+		ON exercises (name)
+		WHERE owner_id IS NULL`,
+}
+
+func createExerciseIndexes(conn *gorm.DB) error {
+	for _, stmt := range exerciseIndexes {
+		if err := conn.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,11 +6,64 @@ import (
 )
 
 func Migrate(conn *gorm.DB) error {
-	return conn.AutoMigrate(
+	if err := conn.AutoMigrate(
 		&models.User{},
+		&models.WorkoutSession{},
 		&models.WorkoutRecord{},
 		&models.WorkoutSet{},
 		&models.Exercise{},
+		&models.ExerciseMuscle{},
+		&models.ExerciseAlias{},
+		&models.PersonalRecord{},
+		&models.WorkoutTemplate{},
+		&models.WorkoutTemplateExercise{},
+		&models.WorkoutTemplateSet{},
+		&models.UserProgram{},
+		&models.UserProgramLift{},
+		&models.BodyMetric{},
+		&models.Goal{},
+		&models.Recap{},
+		&models.RankingSnapshot{},
+	); err != nil {
+		return err
+	}
+
+	if err := fixExerciseForeignKey(conn); err != nil {
+		return err
+	}
+
+	if err := backfillProgramStartingMax(conn); err != nil {
+		return err
+	}
+
+	if err := createExerciseIndexes(conn); err != nil {
+		return err
+	}
+
+	if err := backfillWorkoutSessions(conn); err != nil {
+		return err
+	}
+
+	if err := backfillPersonalRecords(conn); err != nil {
+		return err
+	}
+
+	if err := migrateLikesToSessions(conn); err != nil {
+		return err
+	}
+
+	if err := conn.AutoMigrate(
 		&models.WorkoutLike{},
-	)
+		&models.Follow{},
+		&models.WorkoutComment{},
+		&models.Notification{},
+	); err != nil {
+		return err
+	}
+
+	if err := backfillReactionCounts(conn); err != nil {
+		return err
+	}
+
+	return createTimelineIndexes(conn)
 }
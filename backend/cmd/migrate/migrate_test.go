@@ -0,0 +1,209 @@
+package migrate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// legacyWorkoutLike はセッション導入前（記録単位）の workout_likes テーブル定義
+type legacyWorkoutLike struct {
+	gorm.Model
+	UserID   uint `gorm:"not null;index;uniqueIndex:ux_user_record"`
+	RecordID uint `gorm:"not null;index;uniqueIndex:ux_user_record"`
+
+	User   models.User          `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Record models.WorkoutRecord `gorm:"foreignKey:RecordID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (legacyWorkoutLike) TableName() string { return "workout_likes" }
+
+func newMigrateTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func TestMigrate_BackfillsSessionsFromLegacyRecords(t *testing.T) {
+	db := newMigrateTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+		&legacyWorkoutLike{},
+	))
+
+	u1 := models.User{Email: "u1@example.com"}
+	u2 := models.User{Email: "u2@example.com"}
+	require.NoError(t, db.Create(&u1).Error)
+	require.NoError(t, db.Create(&u2).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	records := []models.WorkoutRecord{
+		{UserID: u1.ID, ExerciseID: bench.ID, TrainedOn: day, BodyWeight: 70, Comment: "胸の日"},
+		{UserID: u1.ID, ExerciseID: squat.ID, TrainedOn: day, BodyWeight: 70.4, IsPublic: true, Comment: "脚も"},
+		{UserID: u1.ID, ExerciseID: bench.ID, TrainedOn: day.AddDate(0, 0, 1), BodyWeight: 70.2, IsPublic: true},
+		{UserID: u2.ID, ExerciseID: bench.ID, TrainedOn: day, BodyWeight: 60},
+	}
+	require.NoError(t, db.Create(&records).Error)
+
+	likes := []legacyWorkoutLike{
+		{UserID: u2.ID, RecordID: records[0].ID},
+		{UserID: u2.ID, RecordID: records[1].ID},
+		{UserID: u1.ID, RecordID: records[3].ID},
+	}
+	require.NoError(t, db.Create(&likes).Error)
+
+	require.NoError(t, Migrate(db))
+
+	var sessions []models.WorkoutSession
+	require.NoError(t, db.Preload("Records", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Order("id ASC").Find(&sessions).Error)
+	require.Len(t, sessions, 3)
+
+	first := sessions[0]
+	require.Equal(t, u1.ID, first.UserID)
+	require.Len(t, first.Records, 2)
+	require.Equal(t, records[0].ID, first.Records[0].ID)
+	require.Equal(t, 0, first.Records[0].Position)
+	require.Equal(t, records[1].ID, first.Records[1].ID)
+	require.Equal(t, 1, first.Records[1].Position)
+	require.InDelta(t, 70.4, first.BodyWeight, 1e-6)
+	require.False(t, first.IsPublic, "非公開の記録を含む日は公開しない")
+	require.Equal(t, "胸の日\n脚も", first.Comment)
+
+	require.Len(t, sessions[1].Records, 1)
+	require.True(t, sessions[1].IsPublic)
+	require.Len(t, sessions[2].Records, 1)
+	require.Equal(t, u2.ID, sessions[2].UserID)
+
+	require.False(t, db.Migrator().HasColumn("workout_likes", "record_id"))
+
+	var migrated []models.WorkoutLike
+	require.NoError(t, db.Order("id ASC").Find(&migrated).Error)
+	require.Len(t, migrated, 2)
+	require.Equal(t, first.ID, migrated[0].SessionID)
+	require.Equal(t, sessions[2].ID, migrated[1].SessionID)
+	require.Equal(t, 1, first.LikeCount)
+	require.Equal(t, 0, sessions[1].LikeCount)
+	require.Equal(t, 1, sessions[2].LikeCount)
+
+	// 再実行しても変化しないこと
+	require.NoError(t, Migrate(db))
+	var cnt int64
+	require.NoError(t, db.Model(&models.WorkoutSession{}).Count(&cnt).Error)
+	require.EqualValues(t, 3, cnt)
+}
+
+func TestMigrate_BackfillsPersonalRecords(t *testing.T) {
+	db := newMigrateTestDB(t)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+	))
+
+	u := models.User{Email: "u1@example.com"}
+	require.NoError(t, db.Create(&u).Error)
+	bench := models.Exercise{Name: "ベンチプレス", MeasurementKind: models.MeasurementRepsWeight}
+	require.NoError(t, db.Create(&bench).Error)
+
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	records := []models.WorkoutRecord{
+		{UserID: u.ID, ExerciseID: bench.ID, TrainedOn: day, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 60}}},
+		{UserID: u.ID, ExerciseID: bench.ID, TrainedOn: day.AddDate(0, 0, 7), Sets: []models.WorkoutSet{
+			{SetNo: 1, Reps: 5, ExerciseWeight: 65},
+			// ウォームアップは自己ベストに数えない
+			{SetNo: 2, Reps: 1, ExerciseWeight: 100, SetType: models.SetTypeWarmup},
+		}},
+	}
+	require.NoError(t, db.Create(&records).Error)
+
+	// 種目が未設定・物理削除済みの記録があっても移行できること
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.Exec("PRAGMA foreign_keys = OFF").Error)
+	require.NoError(t, db.Exec("INSERT INTO workout_records (user_id, exercise_id, trained_on) VALUES (?, NULL, ?)", u.ID, day).Error)
+	require.NoError(t, db.Exec("INSERT INTO workout_records (user_id, exercise_id, trained_on) VALUES (?, ?, ?)", u.ID, 999, day).Error)
+	require.NoError(t, db.Exec("PRAGMA foreign_keys = ON").Error)
+
+	require.NoError(t, Migrate(db))
+
+	var prs []models.PersonalRecord
+	require.NoError(t, db.Where("type = ?", models.PRMaxWeight).Order("id ASC").Find(&prs).Error)
+	require.Len(t, prs, 2)
+	require.Equal(t, records[0].ID, prs[0].WorkoutRecordID)
+	require.InDelta(t, 65, prs[1].Value, 1e-6)
+
+	// 再実行しても重複しないこと
+	var before, after int64
+	require.NoError(t, db.Model(&models.PersonalRecord{}).Count(&before).Error)
+	require.NoError(t, Migrate(db))
+	require.NoError(t, db.Model(&models.PersonalRecord{}).Count(&after).Error)
+	require.Equal(t, before, after)
+}
+
+func TestMigrate_CreatesTimelineIndexes(t *testing.T) {
+	db := newMigrateTestDB(t)
+	require.NoError(t, Migrate(db))
+	// 2回目の実行でも失敗しないこと
+	require.NoError(t, Migrate(db))
+
+	require.True(t, db.Migrator().HasIndex("workout_sessions", "idx_workout_sessions_timeline"))
+	require.True(t, db.Migrator().HasIndex("workout_sessions", "idx_workout_sessions_user_timeline"))
+	require.True(t, db.Migrator().HasIndex("workout_records", "idx_workout_records_exercise_session"))
+	require.True(t, db.Migrator().HasIndex("recaps", "idx_recaps_timeline"))
+}
+
+func TestMigrate_RejectsDuplicateGlobalExerciseNames(t *testing.T) {
+	db := newMigrateTestDB(t)
+	require.NoError(t, Migrate(db))
+	require.True(t, db.Migrator().HasIndex("exercises", "ux_exercises_global_name"))
+
+	u := models.User{Email: "u@example.com", Password: "x"}
+	require.NoError(t, db.Create(&u).Error)
+	require.NoError(t, db.Create(&models.Exercise{Name: "ベンチプレス"}).Error)
+
+	// 共通種目の同名は作成できない
+	require.Error(t, db.Create(&models.Exercise{Name: "ベンチプレス"}).Error)
+	// 独自種目は共通種目と同名でも作成できる
+	require.NoError(t, db.Create(&models.Exercise{Name: "ベンチプレス", OwnerID: &u.ID}).Error)
+}
+
+func TestMigrate_BackfillsProgramStartingMax(t *testing.T) {
+	db := newMigrateTestDB(t)
+	require.NoError(t, Migrate(db))
+
+	u := models.User{Email: "u@example.com", Password: "x"}
+	require.NoError(t, db.Create(&u).Error)
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&squat).Error)
+	up := models.UserProgram{
+		UserID:     u.ID,
+		ProgramKey: "linear",
+		StartedOn:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Lifts:      []models.UserProgramLift{{Slot: "squat", ExerciseID: squat.ID, TrainingMax: 80}},
+	}
+	require.NoError(t, db.Omit("Lifts.Exercise").Create(&up).Error)
+
+	require.NoError(t, Migrate(db))
+
+	var lift models.UserProgramLift
+	require.NoError(t, db.First(&lift, up.Lifts[0].ID).Error)
+	require.Equal(t, 80.0, lift.StartingTrainingMax)
+}
@@ -0,0 +1,71 @@
+package migrate
+
+import (
+	"errors"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// backfillPersonalRecords は自己ベストが未作成のとき、既存の記録から「ユーザー×種目」単位で履歴を作成する。
+// 種目が未設定・削除済みの記録は対象外とする。
+func backfillPersonalRecords(conn *gorm.DB) error {
+	var cnt int64
+	if err := conn.Model(&models.PersonalRecord{}).Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return nil
+	}
+
+	type pair struct {
+		UserID     uint
+		ExerciseID uint
+	}
+	var pairs []pair
+	if err := conn.Model(&models.WorkoutRecord{}).
+		Distinct("user_id", "exercise_id").
+		Where("exercise_id IS NOT NULL").
+		Order("user_id ASC, exercise_id ASC").
+		Scan(&pairs).Error; err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		for _, p := range pairs {
+			var ex models.Exercise
+			err := tx.Unscoped().Select("id", "measurement_kind").First(&ex, p.ExerciseID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// 種目が物理削除された記録は自己ベストの対象外
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			var records []models.WorkoutRecord
+			if err := tx.
+				Preload("Sets", func(db *gorm.DB) *gorm.DB {
+					return db.Order("set_no ASC, id ASC")
+				}).
+				Where("user_id = ? AND exercise_id = ?", p.UserID, p.ExerciseID).
+				Order("trained_on ASC, id ASC").
+				Find(&records).Error; err != nil {
+				return err
+			}
+
+			prs := models.DetectPersonalRecords(ex.MeasurementKind, records)
+			if len(prs) == 0 {
+				continue
+			}
+			if err := tx.Omit(clause.Associations).Create(&prs).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,14 @@
+package migrate
+
+import (
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+// backfillProgramStartingMax は参加時のトレーニングマックスが未設定のリフトに、現在のトレーニングマックスを設定する。
+// 記録から進行状況を作り直す際の起点になるため、列の追加前に参加したプログラムも 0 のままにしない。
+func backfillProgramStartingMax(conn *gorm.DB) error {
+	return conn.Model(&models.UserProgramLift{}).
+		Where("starting_training_max = 0").
+		Update("starting_training_max", gorm.Expr("training_max")).Error
+}
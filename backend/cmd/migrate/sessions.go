@@ -0,0 +1,143 @@
+package migrate
+
+import (
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+// backfillWorkoutSessions はセッション未所属の記録を「ユーザー×トレーニング日」単位でまとめ、セッションを作成する。
+func backfillWorkoutSessions(conn *gorm.DB) error {
+	var records []models.WorkoutRecord
+	if err := conn.
+		Where("session_id IS NULL").
+		Order("user_id ASC, trained_on ASC, id ASC").
+		Find(&records).Error; err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	type dayKey struct {
+		userID uint
+		day    time.Time
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		sessions := make(map[dayKey]*models.WorkoutSession)
+		positions := make(map[uint]int)
+
+		for i := range records {
+			rec := &records[i]
+			key := dayKey{userID: rec.UserID, day: rec.TrainedOn}
+
+			session, ok := sessions[key]
+			if !ok {
+				session = models.NewSessionFromRecord(rec)
+				if err := tx.Create(session).Error; err != nil {
+					return err
+				}
+				sessions[key] = session
+			} else {
+				session.MergeRecord(rec)
+			}
+
+			if err := tx.Model(&models.WorkoutRecord{}).
+				Where("id = ?", rec.ID).
+				Updates(map[string]any{
+					"session_id": session.ID,
+					"position":   positions[session.ID],
+				}).Error; err != nil {
+				return err
+			}
+			positions[session.ID]++
+		}
+
+		for _, session := range sessions {
+			if err := tx.Model(session).Updates(map[string]any{
+				"body_weight": session.BodyWeight,
+				"is_public":   session.IsPublic,
+				"comment":     session.Comment,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateLikesToSessions は記録単位だった workout_likes をセッション単位へ付け替える。
+// 同じセッション内の複数記録へのいいねは1件にまとめる。
+func migrateLikesToSessions(conn *gorm.DB) error {
+	m := conn.Migrator()
+	if !m.HasTable("workout_likes") || !m.HasColumn("workout_likes", "record_id") {
+		return nil
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		m := tx.Migrator()
+		if !m.HasColumn("workout_likes", "session_id") {
+			if err := tx.Exec("ALTER TABLE workout_likes ADD COLUMN session_id bigint").Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(`
+			UPDATE workout_likes
+			SET session_id = (
+				SELECT workout_records.session_id
+				FROM workout_records
+				WHERE workout_records.id = workout_likes.record_id
+			)`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM workout_likes WHERE session_id IS NULL").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			DELETE FROM workout_likes
+			WHERE id NOT IN (
+				SELECT keep_id FROM (
+					SELECT MIN(id) AS keep_id FROM workout_likes GROUP BY user_id, session_id
+				) AS keep
+			)`).Error; err != nil {
+			return err
+		}
+
+		if m.HasConstraint("workout_likes", "fk_workout_likes_record") {
+			if err := m.DropConstraint("workout_likes", "fk_workout_likes_record"); err != nil {
+				return err
+			}
+		}
+		for _, idx := range []string{"ux_user_record", "idx_workout_likes_record_id"} {
+			if m.HasIndex("workout_likes", idx) {
+				if err := m.DropIndex("workout_likes", idx); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Exec("ALTER TABLE workout_likes DROP COLUMN record_id").Error
+	})
+}
+
+// backfillReactionCounts は workout_sessions の like_count・comment_count を
+// workout_likes・workout_comments の件数に合わせる。列の追加前のデータと、件数がずれた場合の修復を兼ねる。
+func backfillReactionCounts(conn *gorm.DB) error {
+	return conn.Exec(`
+		UPDATE workout_sessions
+		SET like_count = (
+				SELECT COUNT(*)
+				FROM workout_likes
+				WHERE workout_likes.session_id = workout_sessions.id
+					AND workout_likes.deleted_at IS NULL
+			),
+			comment_count = (
+				SELECT COUNT(*)
+				FROM workout_comments
+				WHERE workout_comments.session_id = workout_sessions.id
+					AND workout_comments.deleted_at IS NULL
+			)`).Error
+}
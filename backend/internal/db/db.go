@@ -19,7 +19,8 @@ func New() (*gorm.DB, error) {
 			os.Getenv("POSTGRES_PORT"), os.Getenv("POSTGRES_DB"))
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// 一意制約・外部キー制約の違反を gorm.ErrDuplicatedKey / gorm.ErrForeignKeyViolated に変換する
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
@@ -1,27 +1,145 @@
 package db
 
 import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"gorm.io/gorm"
 )
 
-var defalutExercises = []models.Exercise{
-	{Name: "ベンチプレス"},
-	{Name: "スクワット"},
-	{Name: "デッドリフト"},
+//go:embed data/exercises.json
+var exerciseCatalogJSON []byte
+
+// catalogExercise は data/exercises.json の1件分
+type catalogExercise struct {
+	Name             string                 `json:"name"`
+	MeasurementKind  models.MeasurementKind `json:"measurement_kind"`
+	PrimaryMuscle    models.MuscleGroup     `json:"primary_muscle"`
+	SecondaryMuscles []models.MuscleGroup   `json:"secondary_muscles"`
+	Equipment        models.Equipment       `json:"equipment"`
+	MovementPattern  models.MovementPattern `json:"movement_pattern"`
+	Aliases          []catalogAlias         `json:"aliases"`
+}
+
+type catalogAlias struct {
+	Locale string `json:"locale"`
+	Name   string `json:"name"`
+}
+
+// loadExerciseCatalog は埋め込みの種目カタログを読み込み、値を検証する。
+func loadExerciseCatalog() ([]catalogExercise, error) {
+	var catalog []catalogExercise
+	if err := json.Unmarshal(exerciseCatalogJSON, &catalog); err != nil {
+		return nil, fmt.Errorf("種目カタログの読み込みに失敗しました: %w", err)
+	}
+
+	names := make(map[string]bool, len(catalog))
+	for _, c := range catalog {
+		if c.Name == "" || names[c.Name] {
+			return nil, fmt.Errorf("種目カタログの種目名が不正です: %q", c.Name)
+		}
+		names[c.Name] = true
+
+		if !c.MeasurementKind.Valid() || !c.PrimaryMuscle.Valid() ||
+			!c.Equipment.Valid() || !c.MovementPattern.Valid() {
+			return nil, fmt.Errorf("種目カタログの分類が不正です: %s", c.Name)
+		}
+		for _, m := range c.SecondaryMuscles {
+			if !m.Valid() || m == c.PrimaryMuscle {
+				return nil, fmt.Errorf("種目カタログの補助部位が不正です: %s", c.Name)
+			}
+		}
+		for _, a := range c.Aliases {
+			if a.Locale == "" || a.Name == "" {
+				return nil, fmt.Errorf("種目カタログの別名が不正です: %s", c.Name)
+			}
+		}
+	}
+	return catalog, nil
+}
+
+func (c catalogExercise) muscles() []models.ExerciseMuscle {
+	xs := make([]models.ExerciseMuscle, 0, len(c.SecondaryMuscles))
+	for _, m := range c.SecondaryMuscles {
+		xs = append(xs, models.ExerciseMuscle{MuscleGroup: m})
+	}
+	return xs
+}
+
+func (c catalogExercise) aliases() []models.ExerciseAlias {
+	xs := make([]models.ExerciseAlias, 0, len(c.Aliases))
+	for _, a := range c.Aliases {
+		xs = append(xs, models.ExerciseAlias{Locale: a.Locale, Name: a.Name})
+	}
+	return xs
 }
 
+// Seed は共通種目をカタログと同期する。
+// 既存の共通種目は分類・補助部位・別名のみ更新し、計測方法は記録との整合のため変更しない。
 func Seed(db *gorm.DB) error {
-	for _, exercise := range defalutExercises {
-		var count int64
+	catalog, err := loadExerciseCatalog()
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range catalog {
+			if err := seedExercise(tx, c); err != nil {
+				return fmt.Errorf("種目 %s の投入に失敗しました: %w", c.Name, err)
+			}
+		}
+		return nil
+	})
+}
 
-		if err := db.Model(&models.Exercise{}).Where("name = ?", exercise.Name).Count(&count).Error; err != nil {
+func seedExercise(tx *gorm.DB, c catalogExercise) error {
+	var ex models.Exercise
+	err := tx.Where("name = ? AND owner_id IS NULL", c.Name).First(&ex).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Create(&models.Exercise{
+			Name:             c.Name,
+			MeasurementKind:  c.MeasurementKind,
+			PrimaryMuscle:    c.PrimaryMuscle,
+			SecondaryMuscles: c.muscles(),
+			Equipment:        c.Equipment,
+			MovementPattern:  c.MovementPattern,
+			Aliases:          c.aliases(),
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Model(&ex).Updates(map[string]any{
+		"primary_muscle":   c.PrimaryMuscle,
+		"equipment":        c.Equipment,
+		"movement_pattern": c.MovementPattern,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("exercise_id = ?", ex.ID).Delete(&models.ExerciseMuscle{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("exercise_id = ?", ex.ID).Delete(&models.ExerciseAlias{}).Error; err != nil {
+		return err
+	}
+	if muscles := c.muscles(); len(muscles) > 0 {
+		for i := range muscles {
+			muscles[i].ExerciseID = ex.ID
+		}
+		if err := tx.Create(&muscles).Error; err != nil {
 			return err
 		}
-		if count == 0 {
-			if err := db.Create(&exercise).Error; err != nil {
-				return err
-			}
+	}
+	if aliases := c.aliases(); len(aliases) > 0 {
+		for i := range aliases {
+			aliases[i].ExerciseID = ex.ID
+		}
+		if err := tx.Create(&aliases).Error; err != nil {
+			return err
 		}
 	}
 	return nil
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newSeedTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.ExerciseMuscle{},
+		&models.ExerciseAlias{},
+	))
+	return db
+}
+
+func TestLoadExerciseCatalog(t *testing.T) {
+	catalog, err := loadExerciseCatalog()
+	require.NoError(t, err)
+	require.NotEmpty(t, catalog)
+
+	names := make([]string, 0, len(catalog))
+	for _, c := range catalog {
+		names = append(names, c.Name)
+	}
+	// 既存の共通種目はカタログから消さない
+	require.Subset(t, names, []string{"ベンチプレス", "スクワット", "デッドリフト", "懸垂", "腕立て伏せ", "プランク", "ランニング"})
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, db *gorm.DB)
+		check   func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name:    "【正常系】カタログの全種目が分類・別名付きで投入されること",
+			prepare: func(t *testing.T, db *gorm.DB) {},
+			check: func(t *testing.T, db *gorm.DB) {
+				catalog, err := loadExerciseCatalog()
+				require.NoError(t, err)
+
+				var cnt int64
+				require.NoError(t, db.Model(&models.Exercise{}).Where("owner_id IS NULL").Count(&cnt).Error)
+				require.Equal(t, int64(len(catalog)), cnt)
+
+				var bench models.Exercise
+				require.NoError(t, db.Preload("SecondaryMuscles").Preload("Aliases").
+					Where("name = ?", "ベンチプレス").First(&bench).Error)
+				require.Equal(t, models.MuscleChest, bench.PrimaryMuscle)
+				require.Equal(t, models.EquipmentBarbell, bench.Equipment)
+				require.Equal(t, models.MovementHorizontalPush, bench.MovementPattern)
+				require.NotEmpty(t, bench.SecondaryMuscles)
+				require.Equal(t, "Bench Press", bench.Aliases[0].Name)
+
+				aliases := 0
+				for _, c := range catalog {
+					aliases += len(c.Aliases)
+				}
+				require.NoError(t, db.Model(&models.ExerciseAlias{}).Count(&cnt).Error)
+				require.Equal(t, int64(aliases), cnt)
+			},
+		},
+		{
+			name: "【正常系】既存の共通種目は重複させずに分類を補完し、計測方法は変更しないこと",
+			prepare: func(t *testing.T, db *gorm.DB) {
+				require.NoError(t, db.Create(&models.Exercise{Name: "懸垂", MeasurementKind: models.MeasurementRepsOnly}).Error)
+			},
+			check: func(t *testing.T, db *gorm.DB) {
+				var xs []models.Exercise
+				require.NoError(t, db.Preload("Aliases").Where("name = ?", "懸垂").Find(&xs).Error)
+				require.Len(t, xs, 1)
+				require.Equal(t, models.MeasurementRepsOnly, xs[0].MeasurementKind)
+				require.Equal(t, models.MuscleBack, xs[0].PrimaryMuscle)
+				require.NotEmpty(t, xs[0].Aliases)
+			},
+		},
+		{
+			name: "【正常系】ユーザー独自の同名種目には影響しないこと",
+			prepare: func(t *testing.T, db *gorm.DB) {
+				u := models.User{Email: "me@example.com"}
+				require.NoError(t, db.Create(&u).Error)
+				require.NoError(t, db.Create(&models.Exercise{Name: "ベンチプレス", OwnerID: &u.ID}).Error)
+			},
+			check: func(t *testing.T, db *gorm.DB) {
+				var custom models.Exercise
+				require.NoError(t, db.Where("name = ? AND owner_id IS NOT NULL", "ベンチプレス").First(&custom).Error)
+				require.Empty(t, custom.PrimaryMuscle)
+
+				var cnt int64
+				require.NoError(t, db.Model(&models.Exercise{}).Where("name = ? AND owner_id IS NULL", "ベンチプレス").Count(&cnt).Error)
+				require.Equal(t, int64(1), cnt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSeedTestDB(t)
+			tt.prepare(t, db)
+
+			require.NoError(t, Seed(db))
+			// 2回目の実行でも重複しないこと
+			require.NoError(t, Seed(db))
+
+			tt.check(t, db)
+		})
+	}
+}
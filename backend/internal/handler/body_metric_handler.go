@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type BodyMetricHandler interface {
+	CreateMetric(c echo.Context) error
+	ListMetrics(c echo.Context) error
+	GetMetric(c echo.Context) error
+	UpdateMetric(c echo.Context) error
+	DeleteMetric(c echo.Context) error
+	ListBodyComposition(c echo.Context) error
+}
+
+type bodyMetricHandler struct {
+	svc service.BodyMetricService
+}
+
+// BodyMetricRequest の measured_on を省略した場合は今日（日本時間）とする。
+type BodyMetricRequest struct {
+	MeasuredOn     string   `json:"measured_on"`
+	Weight         *float64 `json:"weight"`
+	BodyFatPercent *float64 `json:"body_fat_percent"`
+	MuscleMass     *float64 `json:"muscle_mass"`
+	Neck           *float64 `json:"neck"`
+	Chest          *float64 `json:"chest"`
+	Waist          *float64 `json:"waist"`
+	Hips           *float64 `json:"hips"`
+	Arm            *float64 `json:"arm"`
+	Thigh          *float64 `json:"thigh"`
+	Note           string   `json:"note"`
+}
+
+type bodyMetricDTO struct {
+	ID             uint     `json:"id"`
+	MeasuredOn     string   `json:"measured_on"`
+	Weight         *float64 `json:"weight"`
+	BodyFatPercent *float64 `json:"body_fat_percent"`
+	MuscleMass     *float64 `json:"muscle_mass"`
+	Neck           *float64 `json:"neck"`
+	Chest          *float64 `json:"chest"`
+	Waist          *float64 `json:"waist"`
+	Hips           *float64 `json:"hips"`
+	Arm            *float64 `json:"arm"`
+	Thigh          *float64 `json:"thigh"`
+	Note           string   `json:"note"`
+}
+
+type bodyCompositionDTO struct {
+	MeasuredOn     string   `json:"measured_on"`
+	Weight         float64  `json:"weight"`
+	BodyFatPercent *float64 `json:"body_fat_percent"`
+	BMI            *float64 `json:"bmi"`
+	FFMI           *float64 `json:"ffmi"`
+	NormalizedFFMI *float64 `json:"normalized_ffmi"`
+}
+
+func NewBodyMetricHandler(svc service.BodyMetricService) BodyMetricHandler {
+	return &bodyMetricHandler{svc: svc}
+}
+
+func bindBodyMetricRequest(c echo.Context) (service.BodyMetricData, error) {
+	var req BodyMetricRequest
+	if err := c.Bind(&req); err != nil {
+		return service.BodyMetricData{}, httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	measuredOn := todayJST()
+	if req.MeasuredOn != "" {
+		d, err := time.Parse("2006-01-02", req.MeasuredOn)
+		if err != nil {
+			return service.BodyMetricData{}, httpx.BadRequest("InvalidDate", "measured_on の形式が不正です（YYYY-MM-DD）", err)
+		}
+		measuredOn = d
+	}
+
+	return service.BodyMetricData{
+		MeasuredOn:     measuredOn,
+		Weight:         req.Weight,
+		BodyFatPercent: req.BodyFatPercent,
+		MuscleMass:     req.MuscleMass,
+		Neck:           req.Neck,
+		Chest:          req.Chest,
+		Waist:          req.Waist,
+		Hips:           req.Hips,
+		Arm:            req.Arm,
+		Thigh:          req.Thigh,
+		Note:           req.Note,
+	}, nil
+}
+
+func parseBodyMetricPathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "記録IDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func bodyMetricError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrEmptyBodyMetric):
+		return httpx.BadRequest("ValidationError", "少なくとも1つの項目を入力してください", err)
+	case errors.Is(err, service.ErrInvalidBodyMetric):
+		return httpx.BadRequest("ValidationError", "入力値が範囲外です", err)
+	case errors.Is(err, service.ErrInvalidBodyMetricQuery):
+		return httpx.BadRequest("InvalidQuery", "from は to 以前を指定してください", err)
+	case errors.Is(err, service.ErrBodyMetricExists):
+		return httpx.Conflict("BodyMetricExists", "この日の記録は既に存在します", err)
+	case errors.Is(err, service.ErrBodyMetricNotFound):
+		return httpx.NotFound("BodyMetricNotFound", "指定の記録が見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toBodyMetricDTO(m models.BodyMetric) bodyMetricDTO {
+	return bodyMetricDTO{
+		ID:             m.ID,
+		MeasuredOn:     m.MeasuredOn.Format("2006-01-02"),
+		Weight:         m.Weight,
+		BodyFatPercent: m.BodyFatPercent,
+		MuscleMass:     m.MuscleMass,
+		Neck:           m.Neck,
+		Chest:          m.Chest,
+		Waist:          m.Waist,
+		Hips:           m.Hips,
+		Arm:            m.Arm,
+		Thigh:          m.Thigh,
+		Note:           m.Note,
+	}
+}
+
+func (h *bodyMetricHandler) CreateMetric(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	data, err := bindBodyMetricRequest(c)
+	if err != nil {
+		return err
+	}
+
+	metric, err := h.svc.CreateMetric(userID, data)
+	if err != nil {
+		return bodyMetricError(err)
+	}
+
+	slog.InfoContext(ctx, "body_metric_created",
+		"body_metric_id", metric.ID,
+		"measured_on", metric.MeasuredOn.Format("2006-01-02"),
+	)
+
+	return c.JSON(http.StatusCreated, toBodyMetricDTO(*metric))
+}
+
+// ListMetrics は from / to（YYYY-MM-DD、省略可）の範囲の記録を計測日の昇順で返す。
+func (h *bodyMetricHandler) ListMetrics(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return err
+	}
+
+	metrics, err := h.svc.ListMetrics(userID, from, to)
+	if err != nil {
+		return bodyMetricError(err)
+	}
+
+	slog.InfoContext(ctx, "body_metrics_fetched",
+		"count", len(metrics),
+	)
+
+	out := make([]bodyMetricDTO, 0, len(metrics))
+	for _, m := range metrics {
+		out = append(out, toBodyMetricDTO(m))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *bodyMetricHandler) GetMetric(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	id, err := parseBodyMetricPathID(c)
+	if err != nil {
+		return err
+	}
+
+	metric, err := h.svc.GetMetric(userID, id)
+	if err != nil {
+		return bodyMetricError(err)
+	}
+	return c.JSON(http.StatusOK, toBodyMetricDTO(*metric))
+}
+
+func (h *bodyMetricHandler) UpdateMetric(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	id, err := parseBodyMetricPathID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := bindBodyMetricRequest(c)
+	if err != nil {
+		return err
+	}
+
+	metric, err := h.svc.UpdateMetric(userID, id, data)
+	if err != nil {
+		return bodyMetricError(err)
+	}
+
+	slog.InfoContext(ctx, "body_metric_updated",
+		"body_metric_id", metric.ID,
+	)
+
+	return c.JSON(http.StatusOK, toBodyMetricDTO(*metric))
+}
+
+func (h *bodyMetricHandler) DeleteMetric(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	id, err := parseBodyMetricPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteMetric(userID, id); err != nil {
+		return bodyMetricError(err)
+	}
+
+	slog.InfoContext(ctx, "body_metric_deleted",
+		"body_metric_id", id,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Body metric deleted successfully",
+	})
+}
+
+// ListBodyComposition は from / to（YYYY-MM-DD、省略可）の範囲の BMI / FFMI の推移を返す。身長が未設定なら指数は null。
+func (h *bodyMetricHandler) ListBodyComposition(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return err
+	}
+
+	points, err := h.svc.ListBodyComposition(userID, from, to)
+	if err != nil {
+		return bodyMetricError(err)
+	}
+
+	slog.InfoContext(ctx, "body_composition_fetched",
+		"count", len(points),
+	)
+
+	out := make([]bodyCompositionDTO, 0, len(points))
+	for _, p := range points {
+		out = append(out, bodyCompositionDTO{
+			MeasuredOn:     p.MeasuredOn.Format("2006-01-02"),
+			Weight:         p.Weight,
+			BodyFatPercent: p.BodyFatPercent,
+			BMI:            p.BMI,
+			FFMI:           p.FFMI,
+			NormalizedFFMI: p.NormalizedFFMI,
+		})
+	}
+	return c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBodyMetricService struct {
+	CreateMetricFunc        func(userID uint, data service.BodyMetricData) (*models.BodyMetric, error)
+	ListMetricsFunc         func(userID uint, from, to *time.Time) ([]models.BodyMetric, error)
+	GetMetricFunc           func(userID uint, id uint) (*models.BodyMetric, error)
+	UpdateMetricFunc        func(userID uint, id uint, data service.BodyMetricData) (*models.BodyMetric, error)
+	DeleteMetricFunc        func(userID uint, id uint) error
+	ListBodyCompositionFunc func(userID uint, from, to *time.Time) ([]service.BodyCompositionPoint, error)
+}
+
+func (m *mockBodyMetricService) CreateMetric(u uint, d service.BodyMetricData) (*models.BodyMetric, error) {
+	return m.CreateMetricFunc(u, d)
+}
+func (m *mockBodyMetricService) ListMetrics(u uint, from, to *time.Time) ([]models.BodyMetric, error) {
+	return m.ListMetricsFunc(u, from, to)
+}
+func (m *mockBodyMetricService) GetMetric(u uint, id uint) (*models.BodyMetric, error) {
+	return m.GetMetricFunc(u, id)
+}
+func (m *mockBodyMetricService) UpdateMetric(u uint, id uint, d service.BodyMetricData) (*models.BodyMetric, error) {
+	return m.UpdateMetricFunc(u, id, d)
+}
+func (m *mockBodyMetricService) DeleteMetric(u uint, id uint) error {
+	return m.DeleteMetricFunc(u, id)
+}
+
+func (m *mockBodyMetricService) ListBodyComposition(u uint, from, to *time.Time) ([]service.BodyCompositionPoint, error) {
+	return m.ListBodyCompositionFunc(u, from, to)
+}
+
+func TestBodyMetricHandler_CreateMetric(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockBodyMetricService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】記録を作成して201を返すこと",
+			body: `{"measured_on":"2025-10-01","weight":70.5,"waist":81}`,
+			mock: &mockBodyMetricService{
+				CreateMetricFunc: func(userID uint, d service.BodyMetricData) (*models.BodyMetric, error) {
+					require.Equal(t, uint(1), userID)
+					require.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), d.MeasuredOn)
+					require.Equal(t, 70.5, *d.Weight)
+					require.Nil(t, d.BodyFatPercent)
+					return &models.BodyMetric{ID: 3, MeasuredOn: d.MeasuredOn, Weight: d.Weight, Waist: d.Waist}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: []string{`"id":3`, `"measured_on":"2025-10-01"`, `"weight":70.5`, `"body_fat_percent":null`},
+		},
+		{
+			name:         "【異常系】計測日の形式が不正な場合は InvalidDate を返すこと",
+			body:         `{"measured_on":"2025/10/01","weight":70}`,
+			mock:         &mockBodyMetricService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidDate"`},
+		},
+		{
+			name: "【異常系】範囲外の値は ValidationError を返すこと",
+			body: `{"weight":-1}`,
+			mock: &mockBodyMetricService{
+				CreateMetricFunc: func(uint, service.BodyMetricData) (*models.BodyMetric, error) {
+					return nil, service.ErrInvalidBodyMetric
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"ValidationError"`},
+		},
+		{
+			name: "【異常系】同じ日の記録がある場合は BodyMetricExists を返すこと",
+			body: `{"measured_on":"2025-10-01","weight":70}`,
+			mock: &mockBodyMetricService{
+				CreateMetricFunc: func(uint, service.BodyMetricData) (*models.BodyMetric, error) {
+					return nil, service.ErrBodyMetricExists
+				},
+			},
+			wantCode:     http.StatusConflict,
+			wantContains: []string{`"code":"BodyMetricExists"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewBodyMetricHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/body_metrics", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.CreateMetric(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestBodyMetricHandler_DeleteMetric(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		mock         *mockBodyMetricService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】記録を削除できること",
+			id:   "3",
+			mock: &mockBodyMetricService{
+				DeleteMetricFunc: func(userID uint, id uint) error {
+					require.Equal(t, uint(3), id)
+					return nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{"Body metric deleted successfully"},
+		},
+		{
+			name:         "【異常系】IDが不正な場合は InvalidID を返すこと",
+			id:           "abc",
+			mock:         &mockBodyMetricService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidID"`},
+		},
+		{
+			name: "【異常系】記録が無い場合は BodyMetricNotFound を返すこと",
+			id:   "99",
+			mock: &mockBodyMetricService{
+				DeleteMetricFunc: func(uint, uint) error { return service.ErrBodyMetricNotFound },
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"BodyMetricNotFound"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewBodyMetricHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodDelete, "/body_metrics/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			c.Set("user_id", uint(1))
+
+			if err := h.DeleteMetric(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestBodyMetricHandler_ListBodyComposition(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockBodyMetricService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:  "【正常系】期間内の BMI / FFMI の推移を返すこと",
+			query: "?from=2025-10-01",
+			mock: &mockBodyMetricService{
+				ListBodyCompositionFunc: func(userID uint, from, to *time.Time) ([]service.BodyCompositionPoint, error) {
+					require.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), *from)
+					require.Nil(t, to)
+					return []service.BodyCompositionPoint{{
+						MeasuredOn:      time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC),
+						Weight:          70,
+						BodyFatPercent:  utils.Ptr(15.0),
+						BodyComposition: models.BodyComposition{BMI: utils.Ptr(22.9), FFMI: utils.Ptr(19.4), NormalizedFFMI: utils.Ptr(19.7)},
+					}}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"measured_on":"2025-10-03"`, `"weight":70`, `"bmi":22.9`, `"ffmi":19.4`, `"normalized_ffmi":19.7`,
+			},
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate を返すこと",
+			query:        "?to=2025/10/31",
+			mock:         &mockBodyMetricService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidDate"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewBodyMetricHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/body_metrics/composition"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.ListBodyComposition(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
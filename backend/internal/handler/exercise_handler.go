@@ -1,31 +1,93 @@
 package handler
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 type ExerciseHandler interface {
 	List(c echo.Context) error
+	Create(c echo.Context) error
+	Update(c echo.Context) error
+	Archive(c echo.Context) error
+	Unarchive(c echo.Context) error
+	Delete(c echo.Context) error
 }
 
 type exerciseHandler struct {
 	svc service.ExerciseService
 }
 
+type CreateExerciseRequest struct {
+	Name             string   `json:"name"`
+	MeasurementKind  string   `json:"measurement_kind"`
+	PrimaryMuscle    string   `json:"primary_muscle"`
+	SecondaryMuscles []string `json:"secondary_muscles"`
+	Equipment        string   `json:"equipment"`
+	MovementPattern  string   `json:"movement_pattern"`
+	IsShared         bool     `json:"is_shared"`
+}
+
+type UpdateExerciseRequest struct {
+	Name     string `json:"name"`
+	IsShared bool   `json:"is_shared"`
+}
+
 func NewExerciseHandler(svc service.ExerciseService) ExerciseHandler {
 	return &exerciseHandler{svc: svc}
 }
 
+func parseExercisePathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "種目IDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func exerciseWriteError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidExerciseName):
+		return httpx.BadRequest("ValidationError", "種目名が不正です", err)
+	case errors.Is(err, service.ErrInvalidMeasurementKind):
+		return httpx.BadRequest("ValidationError", "計測方法が不正です", err)
+	case errors.Is(err, service.ErrInvalidExerciseTaxonomy):
+		return httpx.BadRequest("ValidationError", "部位・器具・動作パターンが不正です", err)
+	case errors.Is(err, service.ErrExerciseNameTaken):
+		return httpx.Conflict("ExerciseNameTaken", "同じ名前の種目が既に存在します", err)
+	case errors.Is(err, service.ErrExerciseInUse):
+		return httpx.Conflict("ExerciseInUse", "記録がある種目は削除できません。アーカイブしてください", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
 func (h *exerciseHandler) List(c echo.Context) error {
 	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+	filter := service.ExerciseFilter{
+		Query:           c.QueryParam("q"),
+		MuscleGroup:     models.MuscleGroup(c.QueryParam("muscle")),
+		Equipment:       models.Equipment(c.QueryParam("equipment")),
+		MovementPattern: models.MovementPattern(c.QueryParam("pattern")),
+		IncludeArchived: c.QueryParam("include_archived") == "true",
+	}
 
-	items, err := h.svc.List(c.Request().Context())
+	items, err := h.svc.List(ctx, userID, filter)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidExerciseTaxonomy) {
+			return httpx.BadRequest("InvalidQuery", "検索条件が不正です", err)
+		}
 		return httpx.Internal("システムエラーが発生しました", err)
 	}
 
@@ -33,3 +95,115 @@ func (h *exerciseHandler) List(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, items)
 }
+
+func (h *exerciseHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	var req CreateExerciseRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	secondary := make([]models.MuscleGroup, 0, len(req.SecondaryMuscles))
+	for _, m := range req.SecondaryMuscles {
+		secondary = append(secondary, models.MuscleGroup(m))
+	}
+
+	ex, err := h.svc.Create(ctx, userID, service.ExerciseInput{
+		Name:             req.Name,
+		MeasurementKind:  models.MeasurementKind(req.MeasurementKind),
+		PrimaryMuscle:    models.MuscleGroup(req.PrimaryMuscle),
+		SecondaryMuscles: secondary,
+		Equipment:        models.Equipment(req.Equipment),
+		MovementPattern:  models.MovementPattern(req.MovementPattern),
+		IsShared:         req.IsShared,
+	})
+	if err != nil {
+		return exerciseWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "exercise_created", "exercise_id", ex.ID)
+
+	return c.JSON(http.StatusCreated, ex)
+}
+
+func (h *exerciseHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := parseExercisePathID(c)
+	if err != nil {
+		return err
+	}
+	var req UpdateExerciseRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	ex, err := h.svc.Update(ctx, userID, exerciseID, req.Name, req.IsShared)
+	if err != nil {
+		return exerciseWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "exercise_updated", "exercise_id", exerciseID)
+
+	return c.JSON(http.StatusOK, ex)
+}
+
+func (h *exerciseHandler) Archive(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := parseExercisePathID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.Archive(ctx, userID, exerciseID); err != nil {
+		return exerciseWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "exercise_archived", "exercise_id", exerciseID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Exercise archived successfully",
+	})
+}
+
+func (h *exerciseHandler) Unarchive(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := parseExercisePathID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.Unarchive(ctx, userID, exerciseID); err != nil {
+		return exerciseWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "exercise_unarchived", "exercise_id", exerciseID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Exercise unarchived successfully",
+	})
+}
+
+func (h *exerciseHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := parseExercisePathID(c)
+	if err != nil {
+		return err
+	}
+	if err := h.svc.Delete(ctx, userID, exerciseID); err != nil {
+		return exerciseWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "exercise_deleted", "exercise_id", exerciseID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Exercise deleted successfully",
+	})
+}
@@ -7,9 +7,11 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
@@ -17,11 +19,31 @@ import (
 )
 
 type fakeExerciseService struct {
-	listFunc func(ctx context.Context) ([]service.ExerciseDTO, error)
+	listFunc      func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error)
+	createFunc    func(ctx context.Context, userID uint, input service.ExerciseInput) (*service.ExerciseDTO, error)
+	updateFunc    func(ctx context.Context, userID uint, exerciseID uint, name string, isShared bool) (*service.ExerciseDTO, error)
+	archiveFunc   func(ctx context.Context, userID uint, exerciseID uint) error
+	unarchiveFunc func(ctx context.Context, userID uint, exerciseID uint) error
+	deleteFunc    func(ctx context.Context, userID uint, exerciseID uint) error
 }
 
-func (f *fakeExerciseService) List(ctx context.Context) ([]service.ExerciseDTO, error) {
-	return f.listFunc(ctx)
+func (f *fakeExerciseService) List(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
+	return f.listFunc(ctx, userID, filter)
+}
+func (f *fakeExerciseService) Create(ctx context.Context, userID uint, input service.ExerciseInput) (*service.ExerciseDTO, error) {
+	return f.createFunc(ctx, userID, input)
+}
+func (f *fakeExerciseService) Update(ctx context.Context, userID uint, exerciseID uint, name string, isShared bool) (*service.ExerciseDTO, error) {
+	return f.updateFunc(ctx, userID, exerciseID, name, isShared)
+}
+func (f *fakeExerciseService) Archive(ctx context.Context, userID uint, exerciseID uint) error {
+	return f.archiveFunc(ctx, userID, exerciseID)
+}
+func (f *fakeExerciseService) Unarchive(ctx context.Context, userID uint, exerciseID uint) error {
+	return f.unarchiveFunc(ctx, userID, exerciseID)
+}
+func (f *fakeExerciseService) Delete(ctx context.Context, userID uint, exerciseID uint) error {
+	return f.deleteFunc(ctx, userID, exerciseID)
 }
 
 func TestExerciseHandler_List(t *testing.T) {
@@ -31,6 +53,7 @@ func TestExerciseHandler_List(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		query       string
 		mockSvc     fakeExerciseService
 		wantStatus  int
 		wantCount   int
@@ -40,7 +63,7 @@ func TestExerciseHandler_List(t *testing.T) {
 		{
 			name: "【正常系】種目一覧を取得できること",
 			mockSvc: fakeExerciseService{
-				listFunc: func(ctx context.Context) ([]service.ExerciseDTO, error) {
+				listFunc: func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
 					return []service.ExerciseDTO{
 						{ID: 1, Name: "Bench Press"},
 						{ID: 2, Name: "Squat"},
@@ -54,17 +77,47 @@ func TestExerciseHandler_List(t *testing.T) {
 		{
 			name: "【正常系】0件の場合は空配列を返すこと",
 			mockSvc: fakeExerciseService{
-				listFunc: func(ctx context.Context) ([]service.ExerciseDTO, error) {
+				listFunc: func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
 					return []service.ExerciseDTO{}, nil
 				},
 			},
 			wantStatus: http.StatusOK,
 			wantCount:  0,
 		},
+		{
+			name:  "【正常系】検索・絞り込みのクエリパラメータがサービスに渡されること",
+			query: "?q=%E3%83%99%E3%83%B3%E3%83%81&muscle=chest&equipment=barbell&pattern=horizontal_push&include_archived=true",
+			mockSvc: fakeExerciseService{
+				listFunc: func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
+					require.Equal(t, service.ExerciseFilter{
+						Query:           "ベンチ",
+						MuscleGroup:     models.MuscleChest,
+						Equipment:       models.EquipmentBarbell,
+						MovementPattern: models.MovementHorizontalPush,
+						IncludeArchived: true,
+					}, filter)
+					return []service.ExerciseDTO{{ID: 1, Name: "ベンチプレス"}}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantCount:   1,
+			wantContain: []string{"ベンチプレス"},
+		},
+		{
+			name:  "【異常系】絞り込み条件が不正な場合は InvalidQuery を返すこと",
+			query: "?muscle=wings",
+			mockSvc: fakeExerciseService{
+				listFunc: func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
+					return nil, service.ErrInvalidExerciseTaxonomy
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantErrKey: "InvalidQuery",
+		},
 		{
 			name: "【異常系】サービスエラー時は InternalError を返すこと",
 			mockSvc: fakeExerciseService{
-				listFunc: func(ctx context.Context) ([]service.ExerciseDTO, error) {
+				listFunc: func(ctx context.Context, userID uint, filter service.ExerciseFilter) ([]service.ExerciseDTO, error) {
 					return nil, errors.New("db down")
 				},
 			},
@@ -75,9 +128,10 @@ func TestExerciseHandler_List(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			req := httptest.NewRequest(http.MethodGet, "/exercises"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
 
 			h := NewExerciseHandler(&tt.mockSvc)
 			err := h.List(c)
@@ -109,3 +163,212 @@ func TestExerciseHandler_List(t *testing.T) {
 		})
 	}
 }
+
+func TestExerciseHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mockSvc      fakeExerciseService
+		wantStatus   int
+		wantContains string
+	}{
+		{
+			name: "【正常系】独自種目を作成できること",
+			body: `{"name":"ブルガリアンスクワット","measurement_kind":"reps_weight","is_shared":true}`,
+			mockSvc: fakeExerciseService{
+				createFunc: func(ctx context.Context, userID uint, input service.ExerciseInput) (*service.ExerciseDTO, error) {
+					require.Equal(t, uint(1), userID)
+					require.Equal(t, "ブルガリアンスクワット", input.Name)
+					require.True(t, input.IsShared)
+					return &service.ExerciseDTO{ID: 10, Name: input.Name, MeasurementKind: "reps_weight", IsCustom: true, IsShared: true}, nil
+				},
+			},
+			wantStatus:   http.StatusCreated,
+			wantContains: `"is_custom":true`,
+		},
+		{
+			name:         "【異常系】リクエストの形式が不正な場合は InvalidBody を返すこと",
+			body:         `{"name":123}`,
+			wantStatus:   http.StatusBadRequest,
+			wantContains: `"code":"InvalidBody"`,
+		},
+		{
+			name: "【異常系】種目名が重複する場合は ExerciseNameTaken を返すこと",
+			body: `{"name":"ベンチプレス"}`,
+			mockSvc: fakeExerciseService{
+				createFunc: func(context.Context, uint, service.ExerciseInput) (*service.ExerciseDTO, error) {
+					return nil, service.ErrExerciseNameTaken
+				},
+			},
+			wantStatus:   http.StatusConflict,
+			wantContains: `"code":"ExerciseNameTaken"`,
+		},
+		{
+			name: "【異常系】計測方法が不正な場合は ValidationError を返すこと",
+			body: `{"name":"種目","measurement_kind":"calories"}`,
+			mockSvc: fakeExerciseService{
+				createFunc: func(context.Context, uint, service.ExerciseInput) (*service.ExerciseDTO, error) {
+					return nil, service.ErrInvalidMeasurementKind
+				},
+			},
+			wantStatus:   http.StatusBadRequest,
+			wantContains: `"code":"ValidationError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			req := httptest.NewRequest(http.MethodPost, "/exercises", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			h := NewExerciseHandler(&tt.mockSvc)
+			if err := h.Create(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestExerciseHandler_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		pathID       string
+		mockSvc      fakeExerciseService
+		wantStatus   int
+		wantContains string
+	}{
+		{
+			name:   "【正常系】名前と共有設定を更新できること",
+			pathID: "5",
+			mockSvc: fakeExerciseService{
+				updateFunc: func(ctx context.Context, userID uint, exerciseID uint, name string, isShared bool) (*service.ExerciseDTO, error) {
+					require.Equal(t, uint(5), exerciseID)
+					return &service.ExerciseDTO{ID: exerciseID, Name: name, IsCustom: true, IsShared: isShared}, nil
+				},
+			},
+			wantStatus:   http.StatusOK,
+			wantContains: `"name":"改名"`,
+		},
+		{
+			name:         "【異常系】ID が不正な場合は InvalidID を返すこと",
+			pathID:       "abc",
+			wantStatus:   http.StatusBadRequest,
+			wantContains: `"code":"InvalidID"`,
+		},
+		{
+			name:   "【異常系】自分の種目でない場合は ExerciseNotFound を返すこと",
+			pathID: "5",
+			mockSvc: fakeExerciseService{
+				updateFunc: func(context.Context, uint, uint, string, bool) (*service.ExerciseDTO, error) {
+					return nil, service.ErrExerciseNotFound
+				},
+			},
+			wantStatus:   http.StatusNotFound,
+			wantContains: `"code":"ExerciseNotFound"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			req := httptest.NewRequest(http.MethodPut, "/exercises/"+tt.pathID, strings.NewReader(`{"name":"改名","is_shared":true}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.pathID)
+			c.Set("user_id", uint(1))
+
+			h := NewExerciseHandler(&tt.mockSvc)
+			if err := h.Update(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestExerciseHandler_ArchiveAndDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(h ExerciseHandler, c echo.Context) error
+		mockSvc      fakeExerciseService
+		wantStatus   int
+		wantContains string
+	}{
+		{
+			name: "【正常系】種目をアーカイブできること",
+			call: func(h ExerciseHandler, c echo.Context) error { return h.Archive(c) },
+			mockSvc: fakeExerciseService{
+				archiveFunc: func(context.Context, uint, uint) error { return nil },
+			},
+			wantStatus:   http.StatusOK,
+			wantContains: "Exercise archived successfully",
+		},
+		{
+			name: "【正常系】種目のアーカイブを解除できること",
+			call: func(h ExerciseHandler, c echo.Context) error { return h.Unarchive(c) },
+			mockSvc: fakeExerciseService{
+				unarchiveFunc: func(context.Context, uint, uint) error { return nil },
+			},
+			wantStatus:   http.StatusOK,
+			wantContains: "Exercise unarchived successfully",
+		},
+		{
+			name: "【正常系】記録の無い種目を削除できること",
+			call: func(h ExerciseHandler, c echo.Context) error { return h.Delete(c) },
+			mockSvc: fakeExerciseService{
+				deleteFunc: func(context.Context, uint, uint) error { return nil },
+			},
+			wantStatus:   http.StatusOK,
+			wantContains: "Exercise deleted successfully",
+		},
+		{
+			name: "【異常系】記録がある種目の削除は ExerciseInUse を返すこと",
+			call: func(h ExerciseHandler, c echo.Context) error { return h.Delete(c) },
+			mockSvc: fakeExerciseService{
+				deleteFunc: func(context.Context, uint, uint) error { return service.ErrExerciseInUse },
+			},
+			wantStatus:   http.StatusConflict,
+			wantContains: `"code":"ExerciseInUse"`,
+		},
+		{
+			name: "【異常系】サービスエラー時は InternalError を返すこと",
+			call: func(h ExerciseHandler, c echo.Context) error { return h.Archive(c) },
+			mockSvc: fakeExerciseService{
+				archiveFunc: func(context.Context, uint, uint) error { return errors.New("db down") },
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			req := httptest.NewRequest(http.MethodPost, "/exercises/5/archive", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("5")
+			c.Set("user_id", uint(1))
+
+			h := NewExerciseHandler(&tt.mockSvc)
+			if err := tt.call(h, c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type FollowHandler interface {
+	Follow(c echo.Context) error
+	Unfollow(c echo.Context) error
+	ListFollowers(c echo.Context) error
+	ListFollowing(c echo.Context) error
+	GetFollowSummary(c echo.Context) error
+	ListFollowRequests(c echo.Context) error
+	ApproveFollowRequest(c echo.Context) error
+	RejectFollowRequest(c echo.Context) error
+	UpdatePrivacy(c echo.Context) error
+}
+
+type followHandler struct {
+	svc service.FollowService
+}
+
+func NewFollowHandler(svc service.FollowService) FollowHandler {
+	return &followHandler{svc: svc}
+}
+
+// FollowResponse の status は pending（承認待ち）/ accepted。フォローしていない場合は null。
+type FollowResponse struct {
+	UserID uint    `json:"user_id"`
+	Status *string `json:"status"`
+}
+
+type followUserDTO struct {
+	UserID     uint   `json:"user_id"`
+	Email      string `json:"email"`
+	FollowedAt string `json:"followed_at"`
+}
+
+type followSummaryDTO struct {
+	UserID         uint    `json:"user_id"`
+	IsPrivate      bool    `json:"is_private"`
+	FollowersCount int64   `json:"followers_count"`
+	FollowingCount int64   `json:"following_count"`
+	MyStatus       *string `json:"my_status"`
+}
+
+type UpdatePrivacyRequest struct {
+	IsPrivate *bool `json:"is_private"`
+}
+
+func parseUserPathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "ユーザーIDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func followError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrCannotFollowSelf):
+		return httpx.BadRequest("CannotFollowSelf", "自分自身はフォローできません", err)
+	case errors.Is(err, service.ErrUserNotFound):
+		return httpx.NotFound("UserNotFound", "指定のユーザーが見つかりません", err)
+	case errors.Is(err, service.ErrFollowRequestNotFound):
+		return httpx.NotFound("FollowRequestNotFound", "承認待ちのフォロー申請が見つかりません", err)
+	case errors.Is(err, service.ErrPrivateAccount):
+		return httpx.Forbidden("非公開アカウントのフォロー関係は承認されたフォロワーのみ閲覧できます", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toFollowUserDTOs(users []service.FollowUser) []followUserDTO {
+	out := make([]followUserDTO, 0, len(users))
+	for _, u := range users {
+		out = append(out, followUserDTO{
+			UserID:     u.UserID,
+			Email:      u.Email,
+			FollowedAt: u.FollowedAt.Format(time.RFC3339),
+		})
+	}
+	return out
+}
+
+// Follow は :id のユーザーをフォローする。非公開アカウントの場合は承認待ち（pending）になる。
+func (h *followHandler) Follow(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	targetID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := h.svc.Follow(userID, targetID)
+	if err != nil {
+		return followError(err)
+	}
+
+	slog.InfoContext(ctx, "follow_created",
+		"target_id", targetID,
+		"status", status,
+	)
+
+	s := string(status)
+	return c.JSON(http.StatusOK, FollowResponse{UserID: targetID, Status: &s})
+}
+
+// Unfollow は :id のユーザーのフォロー（承認待ちの申請を含む）を取り消す。
+func (h *followHandler) Unfollow(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	targetID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.Unfollow(userID, targetID); err != nil {
+		return followError(err)
+	}
+
+	slog.InfoContext(ctx, "follow_deleted",
+		"target_id", targetID,
+	)
+
+	return c.JSON(http.StatusOK, FollowResponse{UserID: targetID})
+}
+
+func (h *followHandler) ListFollowers(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	targetID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	users, err := h.svc.ListFollowers(userID, targetID)
+	if err != nil {
+		return followError(err)
+	}
+	return c.JSON(http.StatusOK, toFollowUserDTOs(users))
+}
+
+func (h *followHandler) ListFollowing(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	targetID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	users, err := h.svc.ListFollowing(userID, targetID)
+	if err != nil {
+		return followError(err)
+	}
+	return c.JSON(http.StatusOK, toFollowUserDTOs(users))
+}
+
+// GetFollowSummary は :id のユーザーのフォロワー数・フォロー数と、自分からのフォローの状態を返す。
+func (h *followHandler) GetFollowSummary(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	targetID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.svc.GetFollowSummary(userID, targetID)
+	if err != nil {
+		return followError(err)
+	}
+
+	dto := followSummaryDTO{
+		UserID:         summary.UserID,
+		IsPrivate:      summary.IsPrivate,
+		FollowersCount: summary.FollowersCount,
+		FollowingCount: summary.FollowingCount,
+	}
+	if summary.MyStatus != nil {
+		s := string(*summary.MyStatus)
+		dto.MyStatus = &s
+	}
+	return c.JSON(http.StatusOK, dto)
+}
+
+// ListFollowRequests は自分への承認待ちのフォロー申請を返す。
+func (h *followHandler) ListFollowRequests(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	users, err := h.svc.ListFollowRequests(userID)
+	if err != nil {
+		return followError(err)
+	}
+	return c.JSON(http.StatusOK, toFollowUserDTOs(users))
+}
+
+// ApproveFollowRequest は :id のユーザーからのフォロー申請を承認する。
+func (h *followHandler) ApproveFollowRequest(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	followerID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.ApproveFollowRequest(userID, followerID); err != nil {
+		return followError(err)
+	}
+
+	slog.InfoContext(ctx, "follow_request_approved",
+		"follower_id", followerID,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Follow request approved successfully",
+	})
+}
+
+// RejectFollowRequest は :id のユーザーからのフォロー申請を却下する。
+func (h *followHandler) RejectFollowRequest(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	followerID, err := parseUserPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.RejectFollowRequest(userID, followerID); err != nil {
+		return followError(err)
+	}
+
+	slog.InfoContext(ctx, "follow_request_rejected",
+		"follower_id", followerID,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Follow request rejected successfully",
+	})
+}
+
+// UpdatePrivacy はアカウントの公開設定を変更する。公開に戻すと承認待ちの申請はすべて承認される。
+func (h *followHandler) UpdatePrivacy(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	var req UpdatePrivacyRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+	if req.IsPrivate == nil {
+		return httpx.BadRequest("ValidationError", "is_private を指定してください", nil)
+	}
+
+	if err := h.svc.UpdatePrivacy(userID, *req.IsPrivate); err != nil {
+		return followError(err)
+	}
+
+	slog.InfoContext(ctx, "privacy_updated",
+		"is_private", *req.IsPrivate,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"is_private": *req.IsPrivate,
+	})
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFollowService struct {
+	FollowFunc               func(userID uint, targetID uint) (models.FollowStatus, error)
+	UnfollowFunc             func(userID uint, targetID uint) error
+	ListFollowersFunc        func(viewerID uint, userID uint) ([]service.FollowUser, error)
+	ListFollowingFunc        func(viewerID uint, userID uint) ([]service.FollowUser, error)
+	GetFollowSummaryFunc     func(viewerID uint, userID uint) (*service.FollowSummary, error)
+	ListFollowRequestsFunc   func(userID uint) ([]service.FollowUser, error)
+	ApproveFollowRequestFunc func(userID uint, followerID uint) error
+	RejectFollowRequestFunc  func(userID uint, followerID uint) error
+	UpdatePrivacyFunc        func(userID uint, isPrivate bool) error
+}
+
+func (m *mockFollowService) Follow(u uint, t uint) (models.FollowStatus, error) {
+	return m.FollowFunc(u, t)
+}
+func (m *mockFollowService) Unfollow(u uint, t uint) error { return m.UnfollowFunc(u, t) }
+func (m *mockFollowService) ListFollowers(v uint, u uint) ([]service.FollowUser, error) {
+	return m.ListFollowersFunc(v, u)
+}
+func (m *mockFollowService) ListFollowing(v uint, u uint) ([]service.FollowUser, error) {
+	return m.ListFollowingFunc(v, u)
+}
+func (m *mockFollowService) GetFollowSummary(v uint, u uint) (*service.FollowSummary, error) {
+	return m.GetFollowSummaryFunc(v, u)
+}
+func (m *mockFollowService) ListFollowRequests(u uint) ([]service.FollowUser, error) {
+	return m.ListFollowRequestsFunc(u)
+}
+func (m *mockFollowService) ApproveFollowRequest(u uint, f uint) error {
+	return m.ApproveFollowRequestFunc(u, f)
+}
+func (m *mockFollowService) RejectFollowRequest(u uint, f uint) error {
+	return m.RejectFollowRequestFunc(u, f)
+}
+func (m *mockFollowService) UpdatePrivacy(u uint, p bool) error { return m.UpdatePrivacyFunc(u, p) }
+
+func TestFollowHandler_Follow(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		mock         *mockFollowService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】非公開アカウントへのフォローは pending を返すこと",
+			id:   "3",
+			mock: &mockFollowService{
+				FollowFunc: func(userID uint, targetID uint) (models.FollowStatus, error) {
+					require.Equal(t, uint(1), userID)
+					require.Equal(t, uint(3), targetID)
+					return models.FollowPending, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `{"user_id":3,"status":"pending"}`,
+		},
+		{
+			name:         "【異常系】IDが不正な場合は InvalidID を返すこと",
+			id:           "abc",
+			mock:         &mockFollowService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidID"`,
+		},
+		{
+			name: "【異常系】自分自身は CannotFollowSelf を返すこと",
+			id:   "1",
+			mock: &mockFollowService{
+				FollowFunc: func(uint, uint) (models.FollowStatus, error) { return "", service.ErrCannotFollowSelf },
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"CannotFollowSelf"`,
+		},
+		{
+			name: "【異常系】存在しないユーザーは UserNotFound を返すこと",
+			id:   "99",
+			mock: &mockFollowService{
+				FollowFunc: func(uint, uint) (models.FollowStatus, error) { return "", service.ErrUserNotFound },
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: `"code":"UserNotFound"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewFollowHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.id+"/follow", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			c.Set("user_id", uint(1))
+
+			if err := h.Follow(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestFollowHandler_ListFollowers(t *testing.T) {
+	tests := []struct {
+		name         string
+		mock         *mockFollowService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】フォロワーの一覧を返すこと",
+			mock: &mockFollowService{
+				ListFollowersFunc: func(viewerID uint, userID uint) ([]service.FollowUser, error) {
+					return []service.FollowUser{{UserID: 5, Email: "a@example.com", FollowedAt: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `[{"user_id":5,"email":"a@example.com","followed_at":"2025-10-01T09:00:00Z"}]`,
+		},
+		{
+			name: "【異常系】承認されていない非公開アカウントは403を返すこと",
+			mock: &mockFollowService{
+				ListFollowersFunc: func(uint, uint) ([]service.FollowUser, error) { return nil, service.ErrPrivateAccount },
+			},
+			wantCode:     http.StatusForbidden,
+			wantContains: `"code":"Forbidden"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewFollowHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/3/followers", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("3")
+			c.Set("user_id", uint(1))
+
+			if err := h.ListFollowers(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestFollowHandler_GetFollowSummary(t *testing.T) {
+	e := newEchoForTest()
+	status := models.FollowAccepted
+	h := NewFollowHandler(&mockFollowService{
+		GetFollowSummaryFunc: func(uint, uint) (*service.FollowSummary, error) {
+			return &service.FollowSummary{UserID: 3, IsPrivate: true, FollowersCount: 4, FollowingCount: 2, MyStatus: &status}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/3/follow_summary", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", uint(1))
+
+	require.NoError(t, h.GetFollowSummary(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"is_private":true,"followers_count":4,"following_count":2,"my_status":"accepted"`)
+}
+
+func TestFollowHandler_ApproveFollowRequest(t *testing.T) {
+	e := newEchoForTest()
+	h := NewFollowHandler(&mockFollowService{
+		ApproveFollowRequestFunc: func(uint, uint) error { return service.ErrFollowRequestNotFound },
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/follow_requests/5/approve", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+	c.Set("user_id", uint(1))
+
+	if err := h.ApproveFollowRequest(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), `"code":"FollowRequestNotFound"`)
+}
+
+func TestFollowHandler_UpdatePrivacy(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockFollowService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】非公開に変更できること",
+			body: `{"is_private":true}`,
+			mock: &mockFollowService{
+				UpdatePrivacyFunc: func(userID uint, isPrivate bool) error {
+					require.True(t, isPrivate)
+					return nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"is_private":true`,
+		},
+		{
+			name:         "【異常系】is_private が無い場合は ValidationError を返すこと",
+			body:         `{}`,
+			mock:         &mockFollowService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"ValidationError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewFollowHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPut, "/profile/privacy", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.UpdatePrivacy(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type GoalHandler interface {
+	CreateGoal(c echo.Context) error
+	ListGoals(c echo.Context) error
+	GetGoal(c echo.Context) error
+	UpdateGoal(c echo.Context) error
+	DeleteGoal(c echo.Context) error
+}
+
+type goalHandler struct {
+	svc service.GoalService
+}
+
+// GoalRequest の target_value は type ごとに重量(kg)・体重の倍率・日数を表す。
+// starts_on を省略した場合は今日（日本時間）とする。
+type GoalRequest struct {
+	Type        string  `json:"type"`
+	ExerciseID  *uint   `json:"exercise_id"`
+	TargetValue float64 `json:"target_value"`
+	StartsOn    string  `json:"starts_on"`
+	Deadline    string  `json:"deadline"`
+}
+
+type goalDTO struct {
+	ID              uint    `json:"id"`
+	Type            string  `json:"type"`
+	ExerciseID      *uint   `json:"exercise_id"`
+	ExerciseName    *string `json:"exercise_name"`
+	TargetValue     float64 `json:"target_value"`
+	StartsOn        string  `json:"starts_on"`
+	Deadline        *string `json:"deadline"`
+	Status          string  `json:"status"`
+	AchievedAt      *string `json:"achieved_at"`
+	CurrentValue    float64 `json:"current_value"`
+	ProgressPercent float64 `json:"progress_percent"`
+}
+
+func NewGoalHandler(svc service.GoalService) GoalHandler {
+	return &goalHandler{svc: svc}
+}
+
+func bindGoalRequest(c echo.Context) (service.GoalData, error) {
+	var req GoalRequest
+	if err := c.Bind(&req); err != nil {
+		return service.GoalData{}, httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	data := service.GoalData{
+		Type:        models.GoalType(req.Type),
+		ExerciseID:  req.ExerciseID,
+		TargetValue: req.TargetValue,
+	}
+	if req.StartsOn != "" {
+		d, err := time.Parse("2006-01-02", req.StartsOn)
+		if err != nil {
+			return service.GoalData{}, httpx.BadRequest("InvalidDate", "starts_on の形式が不正です（YYYY-MM-DD）", err)
+		}
+		data.StartsOn = d
+	}
+	if req.Deadline != "" {
+		d, err := time.Parse("2006-01-02", req.Deadline)
+		if err != nil {
+			return service.GoalData{}, httpx.BadRequest("InvalidDate", "deadline の形式が不正です（YYYY-MM-DD）", err)
+		}
+		data.Deadline = &d
+	}
+	return data, nil
+}
+
+func parseGoalPathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "目標IDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func goalError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidGoal):
+		return httpx.BadRequest("ValidationError", "目標の種類・目標値・期間を確認してください", err)
+	case errors.Is(err, service.ErrInvalidGoalQuery):
+		return httpx.BadRequest("InvalidQuery", "status は active/achieved/expired を指定してください", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	case errors.Is(err, service.ErrGoalNotFound):
+		return httpx.NotFound("GoalNotFound", "指定の目標が見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toGoalDTO(eg service.EvaluatedGoal) goalDTO {
+	g := eg.Goal
+	dto := goalDTO{
+		ID:              g.ID,
+		Type:            string(g.Type),
+		ExerciseID:      g.ExerciseID,
+		TargetValue:     g.TargetValue,
+		StartsOn:        g.StartsOn.Format("2006-01-02"),
+		Deadline:        formatDatePtr(g.Deadline),
+		Status:          string(eg.Status),
+		CurrentValue:    eg.CurrentValue,
+		ProgressPercent: eg.ProgressPercent,
+	}
+	if g.Exercise != nil {
+		dto.ExerciseName = &g.Exercise.Name
+	}
+	if g.AchievedAt != nil {
+		at := g.AchievedAt.Format(time.RFC3339)
+		dto.AchievedAt = &at
+	}
+	return dto
+}
+
+func (h *goalHandler) CreateGoal(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	data, err := bindGoalRequest(c)
+	if err != nil {
+		return err
+	}
+
+	goal, err := h.svc.CreateGoal(userID, data, todayJST())
+	if err != nil {
+		return goalError(err)
+	}
+
+	slog.InfoContext(ctx, "goal_created",
+		"goal_id", goal.Goal.ID,
+		"type", goal.Goal.Type,
+		"status", goal.Status,
+	)
+
+	return c.JSON(http.StatusCreated, toGoalDTO(*goal))
+}
+
+// ListGoals は status（active/achieved/expired、省略可）で絞り込んだ目標を期限の近い順に返す。
+func (h *goalHandler) ListGoals(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	goals, err := h.svc.ListGoals(userID, models.GoalStatus(c.QueryParam("status")), todayJST())
+	if err != nil {
+		return goalError(err)
+	}
+
+	slog.InfoContext(ctx, "goals_fetched",
+		"count", len(goals),
+	)
+
+	out := make([]goalDTO, 0, len(goals))
+	for _, g := range goals {
+		out = append(out, toGoalDTO(g))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *goalHandler) GetGoal(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	id, err := parseGoalPathID(c)
+	if err != nil {
+		return err
+	}
+
+	goal, err := h.svc.GetGoal(userID, id, todayJST())
+	if err != nil {
+		return goalError(err)
+	}
+	return c.JSON(http.StatusOK, toGoalDTO(*goal))
+}
+
+func (h *goalHandler) UpdateGoal(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	id, err := parseGoalPathID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := bindGoalRequest(c)
+	if err != nil {
+		return err
+	}
+
+	goal, err := h.svc.UpdateGoal(userID, id, data, todayJST())
+	if err != nil {
+		return goalError(err)
+	}
+
+	slog.InfoContext(ctx, "goal_updated",
+		"goal_id", goal.Goal.ID,
+		"status", goal.Status,
+	)
+
+	return c.JSON(http.StatusOK, toGoalDTO(*goal))
+}
+
+func (h *goalHandler) DeleteGoal(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	id, err := parseGoalPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteGoal(userID, id); err != nil {
+		return goalError(err)
+	}
+
+	slog.InfoContext(ctx, "goal_deleted",
+		"goal_id", id,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Goal deleted successfully",
+	})
+}
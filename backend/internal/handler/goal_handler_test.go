@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGoalService struct {
+	CreateGoalFunc func(userID uint, data service.GoalData, today time.Time) (*service.EvaluatedGoal, error)
+	ListGoalsFunc  func(userID uint, status models.GoalStatus, today time.Time) ([]service.EvaluatedGoal, error)
+	GetGoalFunc    func(userID uint, id uint, today time.Time) (*service.EvaluatedGoal, error)
+	UpdateGoalFunc func(userID uint, id uint, data service.GoalData, today time.Time) (*service.EvaluatedGoal, error)
+	DeleteGoalFunc func(userID uint, id uint) error
+}
+
+func (m *mockGoalService) CreateGoal(u uint, d service.GoalData, today time.Time) (*service.EvaluatedGoal, error) {
+	return m.CreateGoalFunc(u, d, today)
+}
+func (m *mockGoalService) ListGoals(u uint, s models.GoalStatus, today time.Time) ([]service.EvaluatedGoal, error) {
+	return m.ListGoalsFunc(u, s, today)
+}
+func (m *mockGoalService) GetGoal(u uint, id uint, today time.Time) (*service.EvaluatedGoal, error) {
+	return m.GetGoalFunc(u, id, today)
+}
+func (m *mockGoalService) UpdateGoal(u uint, id uint, d service.GoalData, today time.Time) (*service.EvaluatedGoal, error) {
+	return m.UpdateGoalFunc(u, id, d, today)
+}
+func (m *mockGoalService) DeleteGoal(u uint, id uint) error {
+	return m.DeleteGoalFunc(u, id)
+}
+
+func TestGoalHandler_CreateGoal(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockGoalService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】目標を作成して進捗付きで201を返すこと",
+			body: `{"type":"lift_weight","exercise_id":1,"target_value":100,"deadline":"2026-03-31"}`,
+			mock: &mockGoalService{
+				CreateGoalFunc: func(userID uint, d service.GoalData, today time.Time) (*service.EvaluatedGoal, error) {
+					require.Equal(t, models.GoalLiftWeight, d.Type)
+					require.Equal(t, uint(1), *d.ExerciseID)
+					require.True(t, d.StartsOn.IsZero())
+					require.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), *d.Deadline)
+					return &service.EvaluatedGoal{
+						Goal: models.Goal{
+							ID: 2, Type: d.Type, ExerciseID: d.ExerciseID, TargetValue: 100,
+							StartsOn: time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC), Deadline: d.Deadline,
+							Exercise: &models.Exercise{Name: "ベンチプレス"},
+						},
+						Status:          models.GoalActive,
+						CurrentValue:    90,
+						ProgressPercent: 90,
+					}, nil
+				},
+			},
+			wantCode: http.StatusCreated,
+			wantContains: []string{
+				`"id":2`, `"exercise_name":"ベンチプレス"`, `"starts_on":"2025-10-15"`, `"deadline":"2026-03-31"`,
+				`"status":"active"`, `"achieved_at":null`, `"progress_percent":90`,
+			},
+		},
+		{
+			name:         "【異常系】期限の形式が不正な場合は InvalidDate を返すこと",
+			body:         `{"type":"training_days","target_value":12,"deadline":"2025/10/31"}`,
+			mock:         &mockGoalService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidDate"`},
+		},
+		{
+			name: "【異常系】目標の内容が不正な場合は ValidationError を返すこと",
+			body: `{"type":"training_days","target_value":12}`,
+			mock: &mockGoalService{
+				CreateGoalFunc: func(uint, service.GoalData, time.Time) (*service.EvaluatedGoal, error) {
+					return nil, service.ErrInvalidGoal
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"ValidationError"`},
+		},
+		{
+			name: "【異常系】種目が無い場合は ExerciseNotFound を返すこと",
+			body: `{"type":"lift_weight","exercise_id":99,"target_value":100}`,
+			mock: &mockGoalService{
+				CreateGoalFunc: func(uint, service.GoalData, time.Time) (*service.EvaluatedGoal, error) {
+					return nil, service.ErrExerciseNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"ExerciseNotFound"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewGoalHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/goals", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.CreateGoal(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestGoalHandler_ListGoals(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockGoalService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:  "【正常系】状態で絞り込んだ目標を返すこと",
+			query: "?status=achieved",
+			mock: &mockGoalService{
+				ListGoalsFunc: func(userID uint, status models.GoalStatus, today time.Time) ([]service.EvaluatedGoal, error) {
+					require.Equal(t, models.GoalAchieved, status)
+					achievedAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+					return []service.EvaluatedGoal{{
+						Goal:            models.Goal{ID: 5, Type: models.GoalTrainingDays, TargetValue: 12, AchievedAt: &achievedAt},
+						Status:          models.GoalAchieved,
+						CurrentValue:    12,
+						ProgressPercent: 100,
+					}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{`"type":"training_days"`, `"achieved_at":"2025-10-20T09:00:00Z"`, `"exercise_id":null`},
+		},
+		{
+			name:  "【異常系】未知の状態は InvalidQuery を返すこと",
+			query: "?status=done",
+			mock: &mockGoalService{
+				ListGoalsFunc: func(uint, models.GoalStatus, time.Time) ([]service.EvaluatedGoal, error) {
+					return nil, service.ErrInvalidGoalQuery
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidQuery"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewGoalHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/goals"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.ListGoals(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestGoalHandler_DeleteGoal(t *testing.T) {
+	e := newEchoForTest()
+	h := NewGoalHandler(&mockGoalService{
+		DeleteGoalFunc: func(uint, uint) error { return service.ErrGoalNotFound },
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/goals/9", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+	c.Set("user_id", uint(1))
+
+	if err := h.DeleteGoal(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), `"code":"GoalNotFound"`)
+}
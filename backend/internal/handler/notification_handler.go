@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler interface {
+	ListNotifications(c echo.Context) error
+	MarkRead(c echo.Context) error
+	MarkAllRead(c echo.Context) error
+}
+
+type notificationHandler struct {
+	svc service.NotificationService
+}
+
+func NewNotificationHandler(svc service.NotificationService) NotificationHandler {
+	return &notificationHandler{svc: svc}
+}
+
+type notificationDTO struct {
+	ID           uint    `json:"id"`
+	Type         string  `json:"type"`
+	ActorID      *uint   `json:"actor_id"`
+	ActorEmail   *string `json:"actor_email"`
+	ActorCount   int     `json:"actor_count"`
+	IsRead       bool    `json:"is_read"`
+	SessionID    *uint   `json:"session_id"`
+	CommentID    *uint   `json:"comment_id"`
+	GoalID       *uint   `json:"goal_id"`
+	ExerciseName *string `json:"exercise_name"`
+	Message      string  `json:"message"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// NotificationsResponse は通知一覧の1ページ。unread_count は未読の通知の件数（まとめた通知は1件と数える）。
+// next_cursor を cursor に指定すると続きを取得できる（続きが無ければ null）。
+type NotificationsResponse struct {
+	Items       []notificationDTO `json:"items"`
+	UnreadCount int64             `json:"unread_count"`
+	NextCursor  *string           `json:"next_cursor"`
+}
+
+func toNotificationDTO(n service.Notification) notificationDTO {
+	return notificationDTO{
+		ID:           n.ID,
+		Type:         string(n.Type),
+		ActorID:      n.ActorID,
+		ActorEmail:   n.ActorEmail,
+		ActorCount:   n.ActorCount,
+		IsRead:       n.IsRead,
+		SessionID:    n.SessionID,
+		CommentID:    n.CommentID,
+		GoalID:       n.GoalID,
+		ExerciseName: n.ExerciseName,
+		Message:      n.Message,
+		CreatedAt:    n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func notificationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidNotificationQuery):
+		return httpx.BadRequest("InvalidQuery", "limit は1〜100、cursor は前のページの next_cursor を指定してください", err)
+	case errors.Is(err, service.ErrNotificationNotFound):
+		return httpx.NotFound("NotificationNotFound", "指定の通知が見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+// ListNotifications は同じ対象への通知をまとめ、新しい順に limit（1〜100、省略時は20）件ずつ返す。
+func (h *notificationHandler) ListNotifications(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	q := service.NotificationQuery{Cursor: c.QueryParam("cursor")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return httpx.BadRequest("InvalidQuery", "limit が不正です", err)
+		}
+		q.Limit = limit
+	}
+
+	page, err := h.svc.ListNotifications(userID, q)
+	if err != nil {
+		return notificationError(err)
+	}
+
+	res := NotificationsResponse{
+		Items:       make([]notificationDTO, 0, len(page.Items)),
+		UnreadCount: page.UnreadCount,
+	}
+	if page.NextCursor != "" {
+		res.NextCursor = &page.NextCursor
+	}
+	for _, n := range page.Items {
+		res.Items = append(res.Items, toNotificationDTO(n))
+	}
+
+	slog.InfoContext(ctx, "notifications_fetched",
+		"count", len(res.Items),
+		"unread_count", res.UnreadCount,
+		"has_next", res.NextCursor != nil,
+	)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// MarkRead は指定の通知を、まとめた通知ごと既読にする。
+func (h *notificationHandler) MarkRead(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return httpx.BadRequest("InvalidID", "通知IDが不正です", err)
+	}
+
+	if err := h.svc.MarkRead(userID, uint(id64)); err != nil {
+		return notificationError(err)
+	}
+
+	slog.InfoContext(ctx, "notification_read",
+		"notification_id", id64,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Notification marked as read",
+	})
+}
+
+func (h *notificationHandler) MarkAllRead(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	if err := h.svc.MarkAllRead(userID); err != nil {
+		return notificationError(err)
+	}
+
+	slog.InfoContext(ctx, "notifications_all_read")
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "All notifications marked as read",
+	})
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type mockNotificationService struct {
+	ListNotificationsFunc func(userID uint, q service.NotificationQuery) (*service.NotificationPage, error)
+	MarkReadFunc          func(userID uint, id uint) error
+	MarkAllReadFunc       func(userID uint) error
+}
+
+func (m *mockNotificationService) ListNotifications(u uint, q service.NotificationQuery) (*service.NotificationPage, error) {
+	return m.ListNotificationsFunc(u, q)
+}
+func (m *mockNotificationService) MarkRead(u uint, id uint) error { return m.MarkReadFunc(u, id) }
+func (m *mockNotificationService) MarkAllRead(u uint) error       { return m.MarkAllReadFunc(u) }
+
+func TestNotificationHandler_ListNotifications(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockNotificationService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:  "【正常系】まとめた通知と未読数を返すこと",
+			query: "?cursor=12&limit=1",
+			mock: &mockNotificationService{
+				ListNotificationsFunc: func(userID uint, q service.NotificationQuery) (*service.NotificationPage, error) {
+					require.Equal(t, uint(1), userID)
+					require.Equal(t, service.NotificationQuery{Cursor: "12", Limit: 1}, q)
+					return &service.NotificationPage{
+						Items: []service.Notification{{
+							ID:           9,
+							Type:         models.NotificationLike,
+							ActorID:      utils.Ptr(uint(2)),
+							ActorEmail:   utils.Ptr("a@example.com"),
+							ActorCount:   5,
+							SessionID:    utils.Ptr(uint(3)),
+							ExerciseName: utils.Ptr("ベンチプレス"),
+							Message:      "a@example.comさん他4人があなたのベンチプレスにいいねしました",
+							CreatedAt:    time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC),
+						}},
+						UnreadCount: 3,
+						NextCursor:  "9",
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"id":9`, `"type":"like"`, `"actor_count":5`, `"is_read":false`, `"session_id":3`,
+				`"message":"a@example.comさん他4人があなたのベンチプレスにいいねしました"`,
+				`"unread_count":3`, `"next_cursor":"9"`,
+			},
+		},
+		{
+			name:  "【正常系】通知が無い場合は空の配列を返すこと",
+			query: "",
+			mock: &mockNotificationService{
+				ListNotificationsFunc: func(uint, service.NotificationQuery) (*service.NotificationPage, error) {
+					return &service.NotificationPage{}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{`"items":[]`, `"unread_count":0`, `"next_cursor":null`},
+		},
+		{
+			name:         "【異常系】limit が数値でない場合は InvalidQuery を返すこと",
+			query:        "?limit=abc",
+			mock:         &mockNotificationService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidQuery"`},
+		},
+		{
+			name:  "【異常系】cursor が不正な場合は InvalidQuery を返すこと",
+			query: "?cursor=abc",
+			mock: &mockNotificationService{
+				ListNotificationsFunc: func(uint, service.NotificationQuery) (*service.NotificationPage, error) {
+					return nil, service.ErrInvalidNotificationQuery
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidQuery"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewNotificationHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/notifications"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.ListNotifications(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestNotificationHandler_MarkRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		mock     *mockNotificationService
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "【正常系】通知を既読にできること",
+			id:   "9",
+			mock: &mockNotificationService{
+				MarkReadFunc: func(userID uint, id uint) error {
+					require.Equal(t, uint(9), id)
+					return nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantBody: "Notification marked as read",
+		},
+		{
+			name:     "【異常系】ID が不正な場合は InvalidID を返すこと",
+			id:       "abc",
+			mock:     &mockNotificationService{},
+			wantCode: http.StatusBadRequest,
+			wantBody: `"code":"InvalidID"`,
+		},
+		{
+			name: "【異常系】他人の通知は NotificationNotFound を返すこと",
+			id:   "9",
+			mock: &mockNotificationService{
+				MarkReadFunc: func(uint, uint) error { return service.ErrNotificationNotFound },
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `"code":"NotificationNotFound"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewNotificationHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/notifications/"+tt.id+"/read", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			c.Set("user_id", uint(1))
+
+			if err := h.MarkRead(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestNotificationHandler_MarkAllRead(t *testing.T) {
+	e := newEchoForTest()
+	called := false
+	h := NewNotificationHandler(&mockNotificationService{
+		MarkAllReadFunc: func(userID uint) error {
+			called = true
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/read_all", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uint(1))
+
+	require.NoError(t, h.MarkAllRead(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, called)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type PersonalRecordHandler interface {
+	GetPersonalRecords(c echo.Context) error
+	GetExercisePersonalRecords(c echo.Context) error
+}
+
+type personalRecordHandler struct {
+	svc service.PersonalRecordService
+}
+
+type personalRecordDTO struct {
+	ID           uint    `json:"id"`
+	ExerciseID   uint    `json:"exercise_id"`
+	ExerciseName string  `json:"exercise_name"`
+	Type         string  `json:"type"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Reps         int     `json:"reps"`
+	RecordID     uint    `json:"record_id"`
+	TrainedOn    string  `json:"trained_on"`
+}
+
+func NewPersonalRecordHandler(svc service.PersonalRecordService) PersonalRecordHandler {
+	return &personalRecordHandler{svc: svc}
+}
+
+func toPersonalRecordDTOs(prs []models.PersonalRecord) []personalRecordDTO {
+	out := make([]personalRecordDTO, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, personalRecordDTO{
+			ID:           pr.ID,
+			ExerciseID:   pr.ExerciseID,
+			ExerciseName: pr.Exercise.Name,
+			Type:         string(pr.Type),
+			Value:        pr.Value,
+			Weight:       pr.Weight,
+			Reps:         pr.Reps,
+			RecordID:     pr.WorkoutRecordID,
+			TrainedOn:    pr.TrainedOn.Format("2006-01-02"),
+		})
+	}
+	return out
+}
+
+func (h *personalRecordHandler) GetPersonalRecords(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	prs, err := h.svc.GetCurrentRecords(userID)
+	if err != nil {
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	slog.InfoContext(ctx, "personal_records_fetched", "count", len(prs))
+
+	return c.JSON(http.StatusOK, toPersonalRecordDTOs(prs))
+}
+
+func (h *personalRecordHandler) GetExercisePersonalRecords(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	exerciseID, err := strconv.ParseUint(c.Param("exerciseId"), 10, 32)
+	if err != nil || exerciseID == 0 {
+		return httpx.BadRequest("InvalidID", "種目IDが不正です", err)
+	}
+	prType := models.PersonalRecordType(c.QueryParam("type"))
+
+	prs, err := h.svc.GetExerciseHistory(userID, uint(exerciseID), prType)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPersonalRecordType) {
+			return httpx.BadRequest("InvalidQuery", "type が不正です", err)
+		}
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	slog.InfoContext(ctx, "exercise_personal_records_fetched",
+		"exercise_id", exerciseID,
+		"count", len(prs),
+	)
+
+	return c.JSON(http.StatusOK, toPersonalRecordDTOs(prs))
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPersonalRecordService struct {
+	GetCurrentRecordsFunc  func(userID uint) ([]models.PersonalRecord, error)
+	GetExerciseHistoryFunc func(userID uint, exerciseID uint, prType models.PersonalRecordType) ([]models.PersonalRecord, error)
+}
+
+func (m *mockPersonalRecordService) GetCurrentRecords(userID uint) ([]models.PersonalRecord, error) {
+	return m.GetCurrentRecordsFunc(userID)
+}
+func (m *mockPersonalRecordService) GetExerciseHistory(userID uint, exerciseID uint, prType models.PersonalRecordType) ([]models.PersonalRecord, error) {
+	return m.GetExerciseHistoryFunc(userID, exerciseID, prType)
+}
+
+func TestPersonalRecordHandler_GetPersonalRecords(t *testing.T) {
+	tests := []struct {
+		name         string
+		mock         *mockPersonalRecordService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】現在の自己ベスト一覧を返すこと",
+			mock: &mockPersonalRecordService{
+				GetCurrentRecordsFunc: func(userID uint) ([]models.PersonalRecord, error) {
+					require.Equal(t, uint(1), userID)
+					return []models.PersonalRecord{{
+						ID: 1, ExerciseID: 2, Type: models.PREstimated1RM, Value: 93.33, Weight: 80, Reps: 5,
+						WorkoutRecordID: 10, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+						Exercise: models.Exercise{Name: "ベンチプレス"},
+					}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `[{"id":1,"exercise_id":2,"exercise_name":"ベンチプレス","type":"estimated_1rm","value":93.33,"weight":80,"reps":5,"record_id":10,"trained_on":"2025-10-01"}]`,
+		},
+		{
+			name: "【正常系】0件の場合は空配列を返すこと",
+			mock: &mockPersonalRecordService{
+				GetCurrentRecordsFunc: func(uint) ([]models.PersonalRecord, error) { return nil, nil },
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `[]`,
+		},
+		{
+			name: "【異常系】システムエラーが発生した場合は InternalError を返すこと",
+			mock: &mockPersonalRecordService{
+				GetCurrentRecordsFunc: func(uint) ([]models.PersonalRecord, error) { return nil, errors.New("db down") },
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewPersonalRecordHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/records/prs", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			err := h.GetPersonalRecords(c)
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestPersonalRecordHandler_GetExercisePersonalRecords(t *testing.T) {
+	tests := []struct {
+		name         string
+		exerciseID   string
+		query        string
+		mock         *mockPersonalRecordService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:       "【正常系】種目IDと種類がサービスに渡されること",
+			exerciseID: "3",
+			query:      "?type=max_weight",
+			mock: &mockPersonalRecordService{
+				GetExerciseHistoryFunc: func(userID uint, exerciseID uint, prType models.PersonalRecordType) ([]models.PersonalRecord, error) {
+					require.Equal(t, uint(3), exerciseID)
+					require.Equal(t, models.PRMaxWeight, prType)
+					return []models.PersonalRecord{{ID: 5, ExerciseID: 3, Type: models.PRMaxWeight, Value: 100}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"id":5`,
+		},
+		{
+			name:         "【異常系】種目IDが不正な場合は InvalidID を返すこと",
+			exerciseID:   "abc",
+			mock:         &mockPersonalRecordService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidID"`,
+		},
+		{
+			name:       "【異常系】種類が不正な場合は InvalidQuery を返すこと",
+			exerciseID: "3",
+			query:      "?type=fastest",
+			mock: &mockPersonalRecordService{
+				GetExerciseHistoryFunc: func(uint, uint, models.PersonalRecordType) ([]models.PersonalRecord, error) {
+					return nil, service.ErrInvalidPersonalRecordType
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:       "【異常系】システムエラーが発生した場合は InternalError を返すこと",
+			exerciseID: "3",
+			mock: &mockPersonalRecordService{
+				GetExerciseHistoryFunc: func(uint, uint, models.PersonalRecordType) ([]models.PersonalRecord, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewPersonalRecordHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/records/prs/"+tt.exerciseID+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+			c.SetParamNames("exerciseId")
+			c.SetParamValues(tt.exerciseID)
+
+			err := h.GetExercisePersonalRecords(c)
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 )
@@ -24,15 +27,57 @@ func NewProfileHandler(svc service.ProfileService) ProfileHandler {
 	return &profileHandler{svc: svc}
 }
 
+// ProfileResponse の bmi / ffmi は身長と最新の体重・体脂肪率から求め、求められない場合は null とする。
 type ProfileResponse struct {
-	HeightCM     *float64 `json:"height_cm"`
-	GoalWeightKG *float64 `json:"goal_weight_kg"`
-	Email        string   `json:"email"`
+	HeightCM       *float64 `json:"height_cm"`
+	GoalWeightKG   *float64 `json:"goal_weight_kg"`
+	GoalDate       *string  `json:"goal_date"`
+	Sex            *string  `json:"sex"`
+	Email          string   `json:"email"`
+	IsPrivate      bool     `json:"is_private"`
+	BMI            *float64 `json:"bmi"`
+	FFMI           *float64 `json:"ffmi"`
+	NormalizedFFMI *float64 `json:"normalized_ffmi"`
 }
 
+// UpdateProfileRequest の goal_date は目標体重の達成目標日（YYYY-MM-DD）。
+// sex は male/female で、相対筋力ランキングの係数と体重階級に使う。
+// どちらもキーを省略すると変更せず、null または空文字で未設定に戻す。
 type UpdateProfileRequest struct {
-	HeightCM     *float64 `json:"height_cm"`
-	GoalWeightKG *float64 `json:"goal_weight_kg"`
+	HeightCM     *float64       `json:"height_cm"`
+	GoalWeightKG *float64       `json:"goal_weight_kg"`
+	GoalDate     optionalString `json:"goal_date"`
+	Sex          optionalString `json:"sex"`
+}
+
+// optionalString はキーの省略と null を区別する。キーがあれば Present が true になり、null なら Value は nil。
+type optionalString struct {
+	Present bool
+	Value   *string
+}
+
+func (o *optionalString) UnmarshalJSON(b []byte) error {
+	o.Present = true
+	return json.Unmarshal(b, &o.Value)
+}
+
+func toProfileResponse(user *models.User, bc models.BodyComposition) ProfileResponse {
+	var sex *string
+	if user.Sex != nil {
+		s := string(*user.Sex)
+		sex = &s
+	}
+	return ProfileResponse{
+		HeightCM:       user.Height,
+		GoalWeightKG:   user.GoalWeight,
+		GoalDate:       formatDatePtr(user.GoalDate),
+		Sex:            sex,
+		Email:          user.Email,
+		IsPrivate:      user.IsPrivate,
+		BMI:            bc.BMI,
+		FFMI:           bc.FFMI,
+		NormalizedFFMI: bc.NormalizedFFMI,
+	}
 }
 
 func (h *profileHandler) GetProfile(c echo.Context) error {
@@ -45,12 +90,12 @@ func (h *profileHandler) GetProfile(c echo.Context) error {
 		return httpx.Internal("システムエラーが発生しました", err)
 	}
 
-	res := ProfileResponse{
-		HeightCM:     user.Height,
-		GoalWeightKG: user.GoalWeight,
-		Email:        user.Email,
+	bc, err := h.svc.GetBodyComposition(userID, user.Height)
+	if err != nil {
+		return httpx.Internal("システムエラーが発生しました", err)
 	}
-	return c.JSON(http.StatusOK, res)
+
+	return c.JSON(http.StatusOK, toProfileResponse(user, bc))
 }
 
 func (h *profileHandler) UpdateProfile(c echo.Context) error {
@@ -62,24 +107,48 @@ func (h *profileHandler) UpdateProfile(c echo.Context) error {
 		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
 	}
 
-	user, err := h.svc.UpdateProfile(userID, req.HeightCM, req.GoalWeightKG)
+	data := service.ProfileData{
+		HeightCM:     req.HeightCM,
+		GoalWeightKG: req.GoalWeightKG,
+		GoalDateSet:  req.GoalDate.Present,
+		SexSet:       req.Sex.Present,
+	}
+	if v := req.GoalDate.Value; v != nil && *v != "" {
+		d, err := time.Parse("2006-01-02", *v)
+		if err != nil {
+			return httpx.BadRequest("InvalidDate", "goal_date の形式が不正です（YYYY-MM-DD）", err)
+		}
+		data.GoalDate = &d
+	}
+
+	if v := req.Sex.Value; v != nil && *v != "" {
+		s := models.Sex(*v)
+		data.Sex = &s
+	}
+
+	user, err := h.svc.UpdateProfile(userID, data)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidProfile) {
+			return httpx.BadRequest("ValidationError", "身長は50〜250cm、目標体重は20〜300kg、性別は male/female で入力してください", err)
+		}
 		if errors.Is(err, service.ErrUserNotFound) {
 			return httpx.NotFound("UserNotFound", "ユーザーが存在しません", err)
 		}
 		return httpx.Internal("システムエラーが発生しました", err)
 	}
 
+	bc, err := h.svc.GetBodyComposition(userID, user.Height)
+	if err != nil {
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
 	slog.InfoContext(ctx, "profile_updated",
 		"user_id", user.ID,
 		"height_cm", user.Height,
 		"goal_weight_kg", user.GoalWeight,
+		"goal_date", user.GoalDate,
+		"sex", user.Sex,
 	)
 
-	res := ProfileResponse{
-		HeightCM:     user.Height,
-		GoalWeightKG: user.GoalWeight,
-		Email:        user.Email,
-	}
-	return c.JSON(http.StatusOK, res)
+	return c.JSON(http.StatusOK, toProfileResponse(user, bc))
 }
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
@@ -20,14 +21,21 @@ import (
 
 type fakeProfileService struct {
 	getFunc    func(userID uint) (*models.User, error)
-	updateFunc func(userID uint, height *float64, goalWeight *float64) (*models.User, error)
+	updateFunc func(userID uint, data service.ProfileData) (*models.User, error)
+	bodyFunc   func(userID uint, height *float64) (models.BodyComposition, error)
 }
 
 func (f *fakeProfileService) GetProfile(userID uint) (*models.User, error) {
 	return f.getFunc(userID)
 }
-func (f *fakeProfileService) UpdateProfile(userID uint, h *float64, g *float64) (*models.User, error) {
-	return f.updateFunc(userID, h, g)
+func (f *fakeProfileService) UpdateProfile(userID uint, data service.ProfileData) (*models.User, error) {
+	return f.updateFunc(userID, data)
+}
+func (f *fakeProfileService) GetBodyComposition(userID uint, h *float64) (models.BodyComposition, error) {
+	if f.bodyFunc == nil {
+		return models.BodyComposition{}, nil
+	}
+	return f.bodyFunc(userID, h)
 }
 
 func newEchoWithErrHandler() *echo.Echo {
@@ -71,6 +79,20 @@ func TestProfileHandler_GetProfile(t *testing.T) {
 			wantStatus:  http.StatusOK,
 			wantBodyHas: `"email":"u@test.com"`,
 		},
+		{
+			name: "【正常系】BMI / FFMI を含めて返すこと",
+			mock: fakeProfileService{
+				getFunc: func(userID uint) (*models.User, error) {
+					return &models.User{Height: utils.Ptr(175.0)}, nil
+				},
+				bodyFunc: func(userID uint, h *float64) (models.BodyComposition, error) {
+					require.Equal(t, 175.0, *h)
+					return models.BodyComposition{BMI: utils.Ptr(22.9), FFMI: utils.Ptr(19.4), NormalizedFFMI: utils.Ptr(19.7)}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantBodyHas: `"bmi":22.9,"ffmi":19.4,"normalized_ffmi":19.7`,
+		},
 		{
 			name: "【異常系】ユーザーが存在しない場合は404(UserNotFound)を返すこと",
 			mock: fakeProfileService{
@@ -126,33 +148,103 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 			name: "【正常系】プロフィールを更新できること",
 			body: `{"height_cm":175.5,"goal_weight_kg":65.2}`,
 			mock: fakeProfileService{
-				updateFunc: func(userID uint, h *float64, g *float64) (*models.User, error) {
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
 					return &models.User{
 						Email:      "u@test.com",
-						Height:     h,
-						GoalWeight: g,
+						Height:     data.HeightCM,
+						GoalWeight: data.GoalWeightKG,
 					}, nil
 				},
 			},
 			wantStatus:  http.StatusOK,
 			wantBodyHas: `"email":"u@test.com"`,
 		},
+		{
+			name: "【正常系】目標日を指定して更新できること",
+			body: `{"height_cm":175.5,"goal_weight_kg":65.2,"goal_date":"2026-03-31"}`,
+			mock: fakeProfileService{
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
+					require.True(t, data.GoalDateSet)
+					require.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), *data.GoalDate)
+					return &models.User{Height: data.HeightCM, GoalWeight: data.GoalWeightKG, GoalDate: data.GoalDate}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantBodyHas: `"goal_date":"2026-03-31"`,
+		},
+		{
+			name: "【正常系】goal_date・sexを省略した場合は目標日・性別を変更対象にしないこと",
+			body: `{"height_cm":175.5,"goal_weight_kg":65.2}`,
+			mock: fakeProfileService{
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
+					require.False(t, data.GoalDateSet)
+					require.False(t, data.SexSet)
+					return &models.User{Height: data.HeightCM, GoalWeight: data.GoalWeightKG}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantBodyHas: `"height_cm":175.5`,
+		},
+		{
+			name: "【正常系】goal_dateにnullを指定した場合は目標日を未設定に戻すこと",
+			body: `{"height_cm":175.5,"goal_weight_kg":65.2,"goal_date":null}`,
+			mock: fakeProfileService{
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
+					require.True(t, data.GoalDateSet)
+					require.Nil(t, data.GoalDate)
+					return &models.User{Height: data.HeightCM, GoalWeight: data.GoalWeightKG}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantBodyHas: `"height_cm":175.5`,
+		},
+		{
+			name: "【正常系】性別を指定して更新できること",
+			body: `{"height_cm":175.5,"goal_weight_kg":65.2,"sex":"female"}`,
+			mock: fakeProfileService{
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
+					require.True(t, data.SexSet)
+					require.Equal(t, models.SexFemale, *data.Sex)
+					return &models.User{Height: data.HeightCM, GoalWeight: data.GoalWeightKG, Sex: data.Sex}, nil
+				},
+			},
+			wantStatus:  http.StatusOK,
+			wantBodyHas: `"sex":"female"`,
+		},
+		{
+			name:        "【異常系】目標日の形式が不正なら400(InvalidDate)を返すこと",
+			body:        `{"goal_date":"2026/03/31"}`,
+			mock:        fakeProfileService{},
+			wantStatus:  http.StatusBadRequest,
+			wantBodyHas: `"InvalidDate"`,
+		},
 		{
 			name: "【異常系】リクエストボディが不正なら400(InvalidBody)を返すこと",
 			body: `{"height_cm": 170.0`,
 			mock: fakeProfileService{
-				updateFunc: func(userID uint, h *float64, g *float64) (*models.User, error) {
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
 					return nil, nil
 				},
 			},
 			wantStatus:  http.StatusBadRequest,
 			wantBodyHas: `"InvalidBody"`,
 		},
+		{
+			name: "【異常系】身長・目標体重が範囲外なら400(ValidationError)を返すこと",
+			body: `{"height_cm":17.5,"goal_weight_kg":60}`,
+			mock: fakeProfileService{
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
+					return nil, service.ErrInvalidProfile
+				},
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantBodyHas: `"ValidationError"`,
+		},
 		{
 			name: "【異常系】ユーザーが存在しない場合は404(UserNotFound)を返すこと",
 			body: `{"height_cm":170,"goal_weight_kg":60}`,
 			mock: fakeProfileService{
-				updateFunc: func(userID uint, h *float64, g *float64) (*models.User, error) {
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
 					return nil, service.ErrUserNotFound
 				},
 			},
@@ -163,7 +255,7 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 			name: "【異常系】内部エラーは500を返すこと",
 			body: `{"height_cm":170,"goal_weight_kg":60}`,
 			mock: fakeProfileService{
-				updateFunc: func(userID uint, h *float64, g *float64) (*models.User, error) {
+				updateFunc: func(userID uint, data service.ProfileData) (*models.User, error) {
 					return nil, errors.New("update failed")
 				},
 			},
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ProgramHandler interface {
+	ListPrograms(c echo.Context) error
+	Enroll(c echo.Context) error
+	GetProgram(c echo.Context) error
+	GetToday(c echo.Context) error
+	Quit(c echo.Context) error
+}
+
+type programHandler struct {
+	svc service.ProgramService
+}
+
+type EnrollProgramRequest struct {
+	ProgramKey string               `json:"program_key"`
+	StartedOn  string               `json:"started_on"`
+	Lifts      []ProgramLiftRequest `json:"lifts"`
+}
+
+type ProgramLiftRequest struct {
+	Slot        string   `json:"slot"`
+	ExerciseID  uint     `json:"exercise_id"`
+	TrainingMax *float64 `json:"training_max"`
+}
+
+type programSlotDTO struct {
+	Key       string  `json:"key"`
+	Name      string  `json:"name"`
+	Increment float64 `json:"increment"`
+}
+
+type programDTO struct {
+	Key                 string           `json:"key"`
+	Name                string           `json:"name"`
+	Description         string           `json:"description"`
+	TrainingMaxPercent  float64          `json:"training_max_percent"`
+	Apply               string           `json:"apply"`
+	DeloadAfterFailures int              `json:"deload_after_failures"`
+	DeloadPercent       float64          `json:"deload_percent"`
+	Weeks               int              `json:"weeks"`
+	DaysPerWeek         int              `json:"days_per_week"`
+	Slots               []programSlotDTO `json:"slots"`
+}
+
+type userProgramLiftDTO struct {
+	Slot         string  `json:"slot"`
+	ExerciseID   uint    `json:"exercise_id"`
+	ExerciseName string  `json:"exercise_name"`
+	TrainingMax  float64 `json:"training_max"`
+	Failures     int     `json:"failures"`
+	Done         bool    `json:"done"`
+}
+
+// userProgramDTO の cycle / week / day は1始まりで返す。
+type userProgramDTO struct {
+	ProgramKey string               `json:"program_key"`
+	StartedOn  string               `json:"started_on"`
+	Cycle      int                  `json:"cycle"`
+	Week       int                  `json:"week"`
+	Day        int                  `json:"day"`
+	Lifts      []userProgramLiftDTO `json:"lifts"`
+}
+
+type prescribedSetDTO struct {
+	Set     int     `json:"set"`
+	Percent float64 `json:"percent"`
+	Weight  float64 `json:"weight"`
+	Reps    int     `json:"reps"`
+	AMRAP   bool    `json:"amrap"`
+}
+
+type prescribedLiftDTO struct {
+	Slot         string             `json:"slot"`
+	SlotName     string             `json:"slot_name"`
+	ExerciseID   uint               `json:"exercise_id"`
+	ExerciseName string             `json:"exercise_name"`
+	TrainingMax  float64            `json:"training_max"`
+	Done         bool               `json:"done"`
+	Sets         []prescribedSetDTO `json:"sets"`
+	LastTopSet   *topSetDTO         `json:"last_top_set"`
+}
+
+type ProgramTodayResponse struct {
+	ProgramKey  string              `json:"program_key"`
+	ProgramName string              `json:"program_name"`
+	Cycle       int                 `json:"cycle"`
+	Week        int                 `json:"week"`
+	Day         int                 `json:"day"`
+	Deload      bool                `json:"deload"`
+	Lifts       []prescribedLiftDTO `json:"lifts"`
+}
+
+func NewProgramHandler(svc service.ProgramService) ProgramHandler {
+	return &programHandler{svc: svc}
+}
+
+func programError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrProgramNotFound):
+		return httpx.NotFound("ProgramNotFound", "指定のプログラムが見つかりません", err)
+	case errors.Is(err, service.ErrNoActiveProgram):
+		return httpx.NotFound("NoActiveProgram", "参加中のプログラムがありません", err)
+	case errors.Is(err, service.ErrInvalidProgramLifts):
+		return httpx.BadRequest("ValidationError", "種目の割り当てが不正です", err)
+	case errors.Is(err, service.ErrTrainingMaxUnknown):
+		return httpx.BadRequest("TrainingMaxRequired", "記録が無い種目はトレーニングマックスを指定してください", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	case errors.Is(err, service.ErrExerciseArchived):
+		return httpx.BadRequest("ExerciseArchived", "アーカイブ済みの種目は使用できません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toProgramDTO(p models.TrainingProgram) programDTO {
+	slots := make([]programSlotDTO, 0, len(p.Slots))
+	for _, s := range p.Slots {
+		slots = append(slots, programSlotDTO{Key: s.Key, Name: s.Name, Increment: s.Increment})
+	}
+	return programDTO{
+		Key:                 p.Key,
+		Name:                p.Name,
+		Description:         p.Description,
+		TrainingMaxPercent:  p.TrainingMaxPercent,
+		Apply:               string(p.Progression.Apply),
+		DeloadAfterFailures: p.Progression.DeloadAfterFailures,
+		DeloadPercent:       p.Progression.DeloadPercent,
+		Weeks:               len(p.Weeks),
+		DaysPerWeek:         p.DaysPerWeek(),
+		Slots:               slots,
+	}
+}
+
+func toUserProgramDTO(up models.UserProgram) userProgramDTO {
+	lifts := make([]userProgramLiftDTO, 0, len(up.Lifts))
+	for _, l := range up.Lifts {
+		lifts = append(lifts, userProgramLiftDTO{
+			Slot:         l.Slot,
+			ExerciseID:   l.ExerciseID,
+			ExerciseName: l.Exercise.Name,
+			TrainingMax:  l.TrainingMax,
+			Failures:     l.Failures,
+			Done:         l.Done,
+		})
+	}
+	return userProgramDTO{
+		ProgramKey: up.ProgramKey,
+		StartedOn:  up.StartedOn.Format("2006-01-02"),
+		Cycle:      up.Cycle + 1,
+		Week:       up.Week + 1,
+		Day:        up.Day + 1,
+		Lifts:      lifts,
+	}
+}
+
+func (h *programHandler) ListPrograms(c echo.Context) error {
+	programs := h.svc.ListPrograms()
+	out := make([]programDTO, 0, len(programs))
+	for _, p := range programs {
+		out = append(out, toProgramDTO(p))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *programHandler) Enroll(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	var req EnrollProgramRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	startedOn := todayJST()
+	if req.StartedOn != "" {
+		d, err := time.Parse("2006-01-02", req.StartedOn)
+		if err != nil {
+			return httpx.BadRequest("InvalidDate", "started_on の形式が不正です（YYYY-MM-DD）", err)
+		}
+		startedOn = d
+	}
+
+	data := service.ProgramEnrollData{
+		ProgramKey: req.ProgramKey,
+		StartedOn:  startedOn,
+	}
+	for _, l := range req.Lifts {
+		data.Lifts = append(data.Lifts, service.ProgramLiftData{
+			Slot:        l.Slot,
+			ExerciseID:  l.ExerciseID,
+			TrainingMax: l.TrainingMax,
+		})
+	}
+
+	up, err := h.svc.Enroll(userID, data)
+	if err != nil {
+		return programError(err)
+	}
+
+	slog.InfoContext(ctx, "program_enrolled",
+		"program_key", up.ProgramKey,
+		"lift_count", len(up.Lifts),
+	)
+
+	return c.JSON(http.StatusCreated, toUserProgramDTO(*up))
+}
+
+func (h *programHandler) GetProgram(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	up, err := h.svc.GetProgram(userID)
+	if err != nil {
+		return programError(err)
+	}
+	return c.JSON(http.StatusOK, toUserProgramDTO(*up))
+}
+
+func (h *programHandler) GetToday(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	p, err := h.svc.GetTodayPrescription(userID, todayJST())
+	if err != nil {
+		return programError(err)
+	}
+
+	lifts := make([]prescribedLiftDTO, 0, len(p.Lifts))
+	for _, l := range p.Lifts {
+		sets := make([]prescribedSetDTO, 0, len(l.Sets))
+		for _, s := range l.Sets {
+			sets = append(sets, prescribedSetDTO{
+				Set:     s.SetNo,
+				Percent: s.Percent,
+				Weight:  s.Weight,
+				Reps:    s.Reps,
+				AMRAP:   s.AMRAP,
+			})
+		}
+		var last *topSetDTO
+		if l.LastTopSet != nil {
+			last = &topSetDTO{
+				Weight:    l.LastTopSet.Weight,
+				Reps:      l.LastTopSet.Reps,
+				TrainedOn: l.LastTopSet.TrainedOn.Format("2006-01-02"),
+			}
+		}
+		lifts = append(lifts, prescribedLiftDTO{
+			Slot:         l.Slot,
+			SlotName:     l.SlotName,
+			ExerciseID:   l.ExerciseID,
+			ExerciseName: l.ExerciseName,
+			TrainingMax:  l.TrainingMax,
+			Done:         l.Done,
+			Sets:         sets,
+			LastTopSet:   last,
+		})
+	}
+
+	slog.InfoContext(ctx, "program_today_fetched",
+		"program_key", p.Program.Key,
+		"lift_count", len(lifts),
+	)
+
+	return c.JSON(http.StatusOK, ProgramTodayResponse{
+		ProgramKey:  p.Program.Key,
+		ProgramName: p.Program.Name,
+		Cycle:       p.Cycle + 1,
+		Week:        p.Week + 1,
+		Day:         p.Day + 1,
+		Deload:      p.Deload,
+		Lifts:       lifts,
+	})
+}
+
+func (h *programHandler) Quit(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	if err := h.svc.QuitProgram(userID); err != nil {
+		return programError(err)
+	}
+
+	slog.InfoContext(ctx, "program_quit")
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Program quit successfully",
+	})
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProgramService struct {
+	ListProgramsFunc         func() []models.TrainingProgram
+	EnrollFunc               func(userID uint, data service.ProgramEnrollData) (*models.UserProgram, error)
+	GetProgramFunc           func(userID uint) (*models.UserProgram, error)
+	GetTodayPrescriptionFunc func(userID uint, today time.Time) (*service.ProgramPrescription, error)
+	QuitProgramFunc          func(userID uint) error
+}
+
+func (m *mockProgramService) ListPrograms() []models.TrainingProgram {
+	return m.ListProgramsFunc()
+}
+func (m *mockProgramService) Enroll(u uint, d service.ProgramEnrollData) (*models.UserProgram, error) {
+	return m.EnrollFunc(u, d)
+}
+func (m *mockProgramService) GetProgram(u uint) (*models.UserProgram, error) {
+	return m.GetProgramFunc(u)
+}
+func (m *mockProgramService) GetTodayPrescription(u uint, today time.Time) (*service.ProgramPrescription, error) {
+	return m.GetTodayPrescriptionFunc(u, today)
+}
+func (m *mockProgramService) QuitProgram(u uint) error {
+	return m.QuitProgramFunc(u)
+}
+
+const validEnrollBody = `{"program_key":"linear","started_on":"2025-10-01","lifts":[{"slot":"squat","exercise_id":1,"training_max":60},{"slot":"bench","exercise_id":2}]}`
+
+func TestProgramHandler_Enroll(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockProgramService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】プログラムに参加でき、進行状況を1始まりで返すこと",
+			body: validEnrollBody,
+			mock: &mockProgramService{
+				EnrollFunc: func(userID uint, d service.ProgramEnrollData) (*models.UserProgram, error) {
+					require.Equal(t, "linear", d.ProgramKey)
+					require.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), d.StartedOn)
+					require.Len(t, d.Lifts, 2)
+					require.Equal(t, 60.0, *d.Lifts[0].TrainingMax)
+					require.Nil(t, d.Lifts[1].TrainingMax)
+					return &models.UserProgram{
+						ProgramKey: "linear",
+						StartedOn:  d.StartedOn,
+						Lifts: []models.UserProgramLift{
+							{Slot: "squat", ExerciseID: 1, TrainingMax: 60, Exercise: models.Exercise{Name: "スクワット"}},
+						},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: []string{`"cycle":1`, `"week":1`, `"day":1`, `"exercise_name":"スクワット"`, `"started_on":"2025-10-01"`},
+		},
+		{
+			name:         "【異常系】開始日の形式が不正な場合は InvalidDate を返すこと",
+			body:         `{"program_key":"linear","started_on":"2025/10/01"}`,
+			mock:         &mockProgramService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidDate"`},
+		},
+		{
+			name: "【異常系】存在しないプログラムの場合は ProgramNotFound を返すこと",
+			body: validEnrollBody,
+			mock: &mockProgramService{
+				EnrollFunc: func(uint, service.ProgramEnrollData) (*models.UserProgram, error) {
+					return nil, service.ErrProgramNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"ProgramNotFound"`},
+		},
+		{
+			name: "【異常系】トレーニングマックスを決められない場合は TrainingMaxRequired を返すこと",
+			body: validEnrollBody,
+			mock: &mockProgramService{
+				EnrollFunc: func(uint, service.ProgramEnrollData) (*models.UserProgram, error) {
+					return nil, service.ErrTrainingMaxUnknown
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"TrainingMaxRequired"`},
+		},
+		{
+			name: "【異常系】システムエラーの場合は InternalError を返すこと",
+			body: validEnrollBody,
+			mock: &mockProgramService{
+				EnrollFunc: func(uint, service.ProgramEnrollData) (*models.UserProgram, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: []string{`"code":"InternalError"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewProgramHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPut, "/program", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.Enroll(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestProgramHandler_GetToday(t *testing.T) {
+	program, _ := models.FindTrainingProgram("531")
+
+	tests := []struct {
+		name         string
+		mock         *mockProgramService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】今日のメニューと直近の実績を返すこと",
+			mock: &mockProgramService{
+				GetTodayPrescriptionFunc: func(userID uint, today time.Time) (*service.ProgramPrescription, error) {
+					return &service.ProgramPrescription{
+						Program: program,
+						Week:    3,
+						Deload:  true,
+						Lifts: []service.PrescribedLift{{
+							Slot:         "ohp",
+							ExerciseName: "オーバーヘッドプレス",
+							TrainingMax:  40,
+							Sets:         []service.PrescribedSet{{SetNo: 1, Percent: 0.4, Weight: 15, Reps: 5}},
+							LastTopSet:   &service.TopSet{Weight: 35, Reps: 5, TrainedOn: time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+						}},
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"program_key":"531"`, `"week":4`, `"deload":true`, `"weight":15`,
+				`"last_top_set":{"weight":35,"reps":5,"trained_on":"2025-10-10"}`,
+			},
+		},
+		{
+			name: "【異常系】参加中のプログラムが無い場合は NoActiveProgram を返すこと",
+			mock: &mockProgramService{
+				GetTodayPrescriptionFunc: func(uint, time.Time) (*service.ProgramPrescription, error) {
+					return nil, service.ErrNoActiveProgram
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"NoActiveProgram"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewProgramHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/program/today", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetToday(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestProgramHandler_Quit(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "【正常系】参加中のプログラムをやめられること", wantCode: http.StatusOK},
+		{name: "【異常系】参加中のプログラムが無い場合は 404 を返すこと", err: service.ErrNoActiveProgram, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewProgramHandler(&mockProgramService{
+				QuitProgramFunc: func(uint) error { return tt.err },
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/program", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.Quit(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -3,17 +3,24 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 type RankingHandler interface {
-	MonthlyGymDays(c echo.Context) error
+	GymDays(c echo.Context) error
+	TotalVolume(c echo.Context) error
+	ExerciseBest(c echo.Context) error
+	RelativeStrength(c echo.Context) error
+	CacheStats(c echo.Context) error
 }
 
 type rankingHandler struct {
@@ -25,70 +32,190 @@ func NewRankingHandler(svc service.RankingService, cache *service.RankingCache)
 	return &rankingHandler{svc: svc, cache: cache}
 }
 
-func (h *rankingHandler) MonthlyGymDays(c echo.Context) error {
-	now := time.Now()
-	year := now.Year()
-	month := int(now.Month())
+// parseRankingPeriod は集計期間のクエリを解釈する。
+// from と to（YYYY-MM-DD、to を含む）があれば任意の期間、それ以外は period（week/month/year、省略時は month）の単位で
+// year・month、from（その日を含む期間）、どれも無ければ今日（日本時間）を含む期間とする。
+func parseRankingPeriod(c echo.Context, today time.Time) (service.RankingPeriod, error) {
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return service.RankingPeriod{}, err
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return service.RankingPeriod{}, err
+	}
+	if from != nil && to != nil {
+		return service.CustomRankingPeriod(*from, *to)
+	}
+	if to != nil {
+		return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+	}
+
+	granularity := models.RankingGranularity(c.QueryParam("period"))
+	if granularity == "" {
+		granularity = models.RankingMonth
+	}
+	if !granularity.Valid() || granularity == models.RankingCustom {
+		return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+	}
+
+	yearStr, monthStr := c.QueryParam("year"), c.QueryParam("month")
+	if yearStr == "" {
+		if monthStr != "" {
+			return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+		}
+		day := today
+		if from != nil {
+			day = *from
+		}
+		return service.RankingPeriodContaining(granularity, day)
+	}
 
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+	}
+	switch granularity {
+	case models.RankingYear:
+		if monthStr != "" {
+			return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+		}
+		return service.YearRankingPeriod(year)
+	case models.RankingMonth:
+		month, err := strconv.Atoi(monthStr)
+		if err != nil {
+			return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+		}
+		return service.MonthRankingPeriod(year, month)
+	}
+	// 週は from で指定する
+	return service.RankingPeriod{}, service.ErrInvalidRankingQuery
+}
+
+func rankingError(err error, msg string) error {
+	var appErr *httpx.AppError
+	switch {
+	case errors.As(err, &appErr):
+		return err
+	case errors.Is(err, service.ErrInvalidRankingQuery):
+		return httpx.BadRequest("InvalidQuery", "集計期間（period/year/month/from/to）または formula・class の指定が不正です", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	case errors.Is(err, service.ErrInvalidRankingExercise):
+		return httpx.BadRequest("InvalidExercise", "重量を記録する種目を指定してください", err)
+	}
+	return httpx.Internal(msg, err)
+}
+
+// serveRanking は種類 kind × 期間 p のランキングをキャッシュ経由で返す。name はログのイベント名に使う。
+func serveRanking[T any](
+	c echo.Context,
+	cache *service.RankingCache,
+	name string,
+	kind string,
+	p service.RankingPeriod,
+	fetch func(ctx context.Context) ([]T, error),
+) ([]T, error) {
 	ctx := c.Request().Context()
 
-	cached, lastUpdated := h.cache.GetGymDays()
+	result, hit, err := service.LoadRanking(ctx, cache, kind, p, fetch)
+	if err != nil {
+		return nil, err
+	}
 
-	isFresh := !lastUpdated.IsZero() &&
-		lastUpdated.Year() == year &&
-		int(lastUpdated.Month()) == month
+	slog.InfoContext(
+		ctx, name+"_ranking_served",
+		"granularity", p.Granularity,
+		"from", p.From.Format("2006-01-02"),
+		"to", p.To.Format("2006-01-02"),
+		"cache_hit", hit,
+		"count", len(result),
+	)
+	return result, nil
+}
 
-	var result []service.GymDaysDTO
-	var err error
+func (h *rankingHandler) GymDays(c echo.Context) error {
+	today := todayJST()
+	p, err := parseRankingPeriod(c, today)
+	if err != nil {
+		return rankingError(err, "ジム日数ランキングの取得に失敗しました")
+	}
 
-	if !isFresh || len(cached) == 0 {
-		result, err = h.svc.MonthlyGymDays(ctx, year, month)
-		if err != nil {
-			return httpx.Internal("ジム日数ランキングの取得に失敗しました", err)
-		}
+	result, err := serveRanking(c, h.cache, "gym_days", service.RankingKindGymDays, p,
+		func(ctx context.Context) ([]service.GymDaysDTO, error) { return h.svc.GymDays(ctx, p, today) })
+	if err != nil {
+		return rankingError(err, "ジム日数ランキングの取得に失敗しました")
+	}
+	return c.JSON(http.StatusOK, result)
+}
 
-		h.cache.SetGymDays(result)
+func (h *rankingHandler) TotalVolume(c echo.Context) error {
+	today := todayJST()
+	p, err := parseRankingPeriod(c, today)
+	if err != nil {
+		return rankingError(err, "総ボリュームランキングの取得に失敗しました")
+	}
 
-		slog.InfoContext(
-			ctx, "monthly_gym_days_ranking_fetched_from_db",
-			"year", year,
-			"month", month,
-			"count", len(result),
-		)
-	} else {
-		result = cached
+	result, err := serveRanking(c, h.cache, "total_volume", service.RankingKindTotalVolume, p,
+		func(ctx context.Context) ([]service.TotalVolumeDTO, error) { return h.svc.TotalVolume(ctx, p, today) })
+	if err != nil {
+		return rankingError(err, "総ボリュームランキングの取得に失敗しました")
+	}
+	return c.JSON(http.StatusOK, result)
+}
 
-		slog.InfoContext(
-			ctx, "monthly_gym_days_ranking_served_from_cache",
-			"year", year,
-			"month", month,
-			"count", len(result),
-		)
+// ExerciseBest は種目ごとの最高重量ランキングを返す（共通種目・共有された種目のみ）。
+func (h *rankingHandler) ExerciseBest(c echo.Context) error {
+	id64, err := strconv.ParseUint(c.Param("exercise_id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return httpx.BadRequest("InvalidID", "種目IDが不正です", err)
 	}
+	exerciseID := uint(id64)
 
-	go func(year, month int) {
-		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	today := todayJST()
+	p, err := parseRankingPeriod(c, today)
+	if err != nil {
+		return rankingError(err, "種目別ランキングの取得に失敗しました")
+	}
 
-		fresh, err := h.svc.MonthlyGymDays(bgCtx, year, month)
-		if err != nil {
-			slog.ErrorContext(bgCtx, "refresh_monthly_gym_days_failed",
-				"year", year,
-				"month", month,
-				"err", err,
-			)
-			return
-		}
+	result, err := serveRanking(c, h.cache, "exercise_best", service.RankingKindExerciseBest(exerciseID), p,
+		func(ctx context.Context) ([]service.ExerciseBestDTO, error) {
+			return h.svc.ExerciseBest(ctx, exerciseID, p, today)
+		})
+	if err != nil {
+		return rankingError(err, "種目別ランキングの取得に失敗しました")
+	}
+	return c.JSON(http.StatusOK, result)
+}
 
-		h.cache.SetGymDays(fresh)
+// RelativeStrength は BIG3 から求めた相対筋力スコアのランキングを返す。
+// formula は wilks/dots/ipf_gl（省略時は dots）、class は体重階級（"83" や "84+"、省略可）。
+func (h *rankingHandler) RelativeStrength(c echo.Context) error {
+	q := service.RelativeStrengthQuery{
+		Formula:     models.StrengthFormula(c.QueryParam("formula")),
+		WeightClass: c.QueryParam("class"),
+	}
+	if q.Formula == "" {
+		q.Formula = models.StrengthDOTS
+	}
 
-		slog.InfoContext(
-			bgCtx, "monthly_gym_days_ranking_refreshed",
-			"year", year,
-			"month", month,
-			"count", len(fresh),
-		)
-	}(year, month)
+	today := todayJST()
+	p, err := parseRankingPeriod(c, today)
+	if err != nil {
+		return rankingError(err, "相対筋力ランキングの取得に失敗しました")
+	}
 
+	result, err := serveRanking(c, h.cache, "relative_strength", service.RankingKindRelativeStrength(q), p,
+		func(ctx context.Context) ([]service.RelativeStrengthDTO, error) {
+			return h.svc.RelativeStrength(ctx, q, p, today)
+		})
+	if err != nil {
+		return rankingError(err, "相対筋力ランキングの取得に失敗しました")
+	}
 	return c.JSON(http.StatusOK, result)
 }
+
+// CacheStats はランキングキャッシュのヒット・ミスなどの累計を返す。
+func (h *rankingHandler) CacheStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.cache.Stats())
+}
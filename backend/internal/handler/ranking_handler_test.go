@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRankingService struct {
+	GymDaysFunc func(ctx context.Context, p service.RankingPeriod, today time.Time) ([]service.GymDaysDTO, error)
+}
+
+func (m *mockRankingService) GymDays(ctx context.Context, p service.RankingPeriod, today time.Time) ([]service.GymDaysDTO, error) {
+	return m.GymDaysFunc(ctx, p, today)
+}
+func (m *mockRankingService) TotalVolume(context.Context, service.RankingPeriod, time.Time) ([]service.TotalVolumeDTO, error) {
+	return nil, nil
+}
+func (m *mockRankingService) ExerciseBest(context.Context, uint, service.RankingPeriod, time.Time) ([]service.ExerciseBestDTO, error) {
+	return nil, nil
+}
+func (m *mockRankingService) RelativeStrength(context.Context, service.RelativeStrengthQuery, service.RankingPeriod, time.Time) ([]service.RelativeStrengthDTO, error) {
+	return nil, nil
+}
+
+func TestRankingHandler_GymDays_Period(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantPeriod   *service.RankingPeriod
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:  "【正常系】year と month で過去の月を指定できること",
+			query: "?year=2024&month=2",
+			wantPeriod: &service.RankingPeriod{
+				Granularity: models.RankingMonth,
+				From:        time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"total_training_days":8`,
+		},
+		{
+			name:  "【正常系】年単位のランキングを指定できること",
+			query: "?period=year&year=2024",
+			wantPeriod: &service.RankingPeriod{
+				Granularity: models.RankingYear,
+				From:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:  "【正常系】from を含む週を指定できること",
+			query: "?period=week&from=2024-05-09",
+			wantPeriod: &service.RankingPeriod{
+				Granularity: models.RankingWeek,
+				From:        time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC),
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:  "【正常系】from と to で任意の期間を指定できること",
+			query: "?from=2024-05-01&to=2024-05-10",
+			wantPeriod: &service.RankingPeriod{
+				Granularity: models.RankingCustom,
+				From:        time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				To:          time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC),
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:         "【異常系】不正な月は InvalidQuery を返すこと",
+			query:        "?year=2024&month=13",
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:         "【異常系】月単位で month が無い場合は InvalidQuery を返すこと",
+			query:        "?year=2024",
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:         "【異常系】未知の単位は InvalidQuery を返すこと",
+			query:        "?period=day",
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate を返すこと",
+			query:        "?from=2024/05/01&to=2024-05-10",
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidDate"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewRankingHandler(&mockRankingService{
+				GymDaysFunc: func(_ context.Context, p service.RankingPeriod, _ time.Time) ([]service.GymDaysDTO, error) {
+					require.Equal(t, *tt.wantPeriod, p)
+					return []service.GymDaysDTO{{UserID: 1, TotalTrainingDays: 8}}, nil
+				},
+			}, service.NewRankingCache())
+
+			req := httptest.NewRequest(http.MethodGet, "/ranking/gym_days"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.GymDays(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type RecapHandler interface {
+	ListRecaps(c echo.Context) error
+	GetRecap(c echo.Context) error
+	PublishRecap(c echo.Context) error
+	UnpublishRecap(c echo.Context) error
+}
+
+type recapHandler struct {
+	svc service.RecapService
+}
+
+type recapDTO struct {
+	Period           string               `json:"period"`
+	PeriodStart      string               `json:"period_start"`
+	Final            bool                 `json:"final"`
+	TotalSessions    int                  `json:"total_sessions"`
+	TrainingDays     int                  `json:"training_days"`
+	TotalTonnage     float64              `json:"total_tonnage"`
+	FavoriteExercise *favoriteExerciseDTO `json:"favorite_exercise"`
+	PRCount          int                  `json:"pr_count"`
+	LongestStreak    int                  `json:"longest_streak"`
+	BestMonth        *string              `json:"best_month"`
+	BestMonthDays    int                  `json:"best_month_days"`
+	LikesReceived    int                  `json:"likes_received"`
+	IsPublic         bool                 `json:"is_public"`
+	PublishedAt      *string              `json:"published_at"`
+}
+
+type favoriteExerciseDTO struct {
+	ExerciseID uint   `json:"exercise_id"`
+	Name       string `json:"name"`
+	Days       int    `json:"days"`
+}
+
+func NewRecapHandler(svc service.RecapService) RecapHandler {
+	return &recapHandler{svc: svc}
+}
+
+// parseRecapPath は :period（month/year）と :key（month は YYYY-MM、year は YYYY）を期間の初日に変換する。
+func parseRecapPath(c echo.Context) (models.RecapPeriod, time.Time, error) {
+	period := models.RecapPeriod(c.Param("period"))
+	layout := "2006-01"
+	switch period {
+	case models.RecapMonth:
+	case models.RecapYear:
+		layout = "2006"
+	default:
+		return "", time.Time{}, httpx.BadRequest("InvalidPeriod", "period は month/year を指定してください", nil)
+	}
+	start, err := time.Parse(layout, c.Param("key"))
+	if err != nil {
+		return "", time.Time{}, httpx.BadRequest("InvalidPeriod", "month は YYYY-MM、year は YYYY で指定してください", err)
+	}
+	return period, start, nil
+}
+
+func recapError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidRecapPeriod):
+		return httpx.BadRequest("InvalidPeriod", "まだ始まっていない期間です", err)
+	case errors.Is(err, service.ErrRecapNotFinal):
+		return httpx.Conflict("RecapNotFinal", "期間が終わるまでまとめは公開できません", err)
+	case errors.Is(err, service.ErrRecapNotFound):
+		return httpx.NotFound("RecapNotFound", "まとめが見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toRecapDTO(r models.Recap, final bool) recapDTO {
+	dto := recapDTO{
+		Period:        string(r.Period),
+		PeriodStart:   r.PeriodStart.Format("2006-01-02"),
+		Final:         final,
+		TotalSessions: r.TotalSessions,
+		TrainingDays:  r.TrainingDays,
+		TotalTonnage:  r.TotalTonnage,
+		PRCount:       r.PRCount,
+		LongestStreak: r.LongestStreak,
+		BestMonthDays: r.BestMonthDays,
+		LikesReceived: r.LikesReceived,
+		IsPublic:      r.IsPublic,
+	}
+	if r.FavoriteExerciseID != nil {
+		fav := &favoriteExerciseDTO{ExerciseID: *r.FavoriteExerciseID, Days: r.FavoriteExerciseDays}
+		if r.FavoriteExercise != nil {
+			fav.Name = r.FavoriteExercise.Name
+		}
+		dto.FavoriteExercise = fav
+	}
+	if r.BestMonth != nil {
+		m := r.BestMonth.Format("2006-01")
+		dto.BestMonth = &m
+	}
+	if r.PublishedAt != nil {
+		at := r.PublishedAt.Format(time.RFC3339)
+		dto.PublishedAt = &at
+	}
+	return dto
+}
+
+// ListRecaps は確定済み（保存済み）のまとめを新しい期間から順に返す。
+func (h *recapHandler) ListRecaps(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	recaps, err := h.svc.ListRecaps(userID)
+	if err != nil {
+		return recapError(err)
+	}
+
+	slog.InfoContext(ctx, "recaps_fetched",
+		"count", len(recaps),
+	)
+
+	out := make([]recapDTO, 0, len(recaps))
+	for _, r := range recaps {
+		out = append(out, toRecapDTO(r, true))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// GetRecap は月（/recaps/month/2025-09）または年（/recaps/year/2025）のまとめを返す。
+func (h *recapHandler) GetRecap(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	period, start, err := parseRecapPath(c)
+	if err != nil {
+		return err
+	}
+
+	pr, err := h.svc.GetRecap(userID, period, start, todayJST())
+	if err != nil {
+		return recapError(err)
+	}
+
+	slog.InfoContext(ctx, "recap_fetched",
+		"period", period,
+		"period_start", pr.Recap.PeriodStart.Format("2006-01-02"),
+		"final", pr.Final,
+	)
+
+	return c.JSON(http.StatusOK, toRecapDTO(pr.Recap, pr.Final))
+}
+
+func (h *recapHandler) PublishRecap(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	period, start, err := parseRecapPath(c)
+	if err != nil {
+		return err
+	}
+
+	recap, err := h.svc.PublishRecap(userID, period, start, todayJST())
+	if err != nil {
+		return recapError(err)
+	}
+
+	slog.InfoContext(ctx, "recap_published",
+		"recap_id", recap.ID,
+		"period", period,
+	)
+
+	return c.JSON(http.StatusOK, toRecapDTO(*recap, true))
+}
+
+func (h *recapHandler) UnpublishRecap(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	period, start, err := parseRecapPath(c)
+	if err != nil {
+		return err
+	}
+
+	recap, err := h.svc.UnpublishRecap(userID, period, start)
+	if err != nil {
+		return recapError(err)
+	}
+
+	slog.InfoContext(ctx, "recap_unpublished",
+		"recap_id", recap.ID,
+		"period", period,
+	)
+
+	return c.JSON(http.StatusOK, toRecapDTO(*recap, true))
+}
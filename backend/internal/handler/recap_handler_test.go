@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRecapService struct {
+	GetRecapFunc       func(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*service.PeriodRecap, error)
+	ListRecapsFunc     func(userID uint) ([]models.Recap, error)
+	PublishRecapFunc   func(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*models.Recap, error)
+	UnpublishRecapFunc func(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error)
+}
+
+func (m *mockRecapService) GetRecap(u uint, p models.RecapPeriod, start time.Time, today time.Time) (*service.PeriodRecap, error) {
+	return m.GetRecapFunc(u, p, start, today)
+}
+func (m *mockRecapService) ListRecaps(u uint) ([]models.Recap, error) {
+	return m.ListRecapsFunc(u)
+}
+func (m *mockRecapService) PublishRecap(u uint, p models.RecapPeriod, start time.Time, today time.Time) (*models.Recap, error) {
+	return m.PublishRecapFunc(u, p, start, today)
+}
+func (m *mockRecapService) UnpublishRecap(u uint, p models.RecapPeriod, start time.Time) (*models.Recap, error) {
+	return m.UnpublishRecapFunc(u, p, start)
+}
+
+func TestRecapHandler_GetRecap(t *testing.T) {
+	tests := []struct {
+		name         string
+		period       string
+		key          string
+		mock         *mockRecapService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:   "【正常系】年のまとめを返すこと",
+			period: "year",
+			key:    "2024",
+			mock: &mockRecapService{
+				GetRecapFunc: func(_ uint, p models.RecapPeriod, start time.Time, _ time.Time) (*service.PeriodRecap, error) {
+					require.Equal(t, models.RecapYear, p)
+					require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), start)
+					favorite := uint(2)
+					bestMonth := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+					return &service.PeriodRecap{
+						Recap: models.Recap{
+							Period: p, PeriodStart: start, TrainingDays: 150, TotalTonnage: 12345.5,
+							FavoriteExerciseID: &favorite, FavoriteExerciseDays: 80, FavoriteExercise: &models.Exercise{Name: "スクワット"},
+							BestMonth: &bestMonth, BestMonthDays: 18,
+						},
+						Final: true,
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"period":"year"`, `"period_start":"2024-01-01"`, `"final":true`, `"training_days":150`,
+				`"favorite_exercise":{"exercise_id":2,"name":"スクワット","days":80}`, `"best_month":"2024-03"`, `"published_at":null`,
+			},
+		},
+		{
+			name:         "【異常系】形式が期間と合わない場合は InvalidPeriod を返すこと",
+			period:       "month",
+			key:          "2024",
+			mock:         &mockRecapService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidPeriod"`},
+		},
+		{
+			name:         "【異常系】未知の期間は InvalidPeriod を返すこと",
+			period:       "week",
+			key:          "2024-10",
+			mock:         &mockRecapService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidPeriod"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewRecapHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/recaps/"+tt.period+"/"+tt.key, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("period", "key")
+			c.SetParamValues(tt.period, tt.key)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetRecap(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestRecapHandler_PublishRecap(t *testing.T) {
+	e := newEchoForTest()
+	h := NewRecapHandler(&mockRecapService{
+		PublishRecapFunc: func(uint, models.RecapPeriod, time.Time, time.Time) (*models.Recap, error) {
+			return nil, service.ErrRecapNotFinal
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/recaps/month/2025-10/publish", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("period", "key")
+	c.SetParamValues("month", "2025-10")
+	c.Set("user_id", uint(1))
+
+	if err := h.PublishRecap(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Contains(t, rec.Body.String(), `"code":"RecapNotFinal"`)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler interface {
+	GetVolumeReport(c echo.Context) error
+}
+
+type reportHandler struct {
+	svc service.ReportService
+}
+
+type VolumeReportResponse struct {
+	Bucket       string            `json:"bucket"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Periods      []volumePeriodDTO `json:"periods"`
+	Total        volumeSummaryDTO  `json:"total"`
+	PreviousFrom string            `json:"previous_from"`
+	PreviousTo   string            `json:"previous_to"`
+	Previous     volumeSummaryDTO  `json:"previous"`
+	Change       volumeChangeDTO   `json:"change"`
+}
+
+type volumeStatsDTO struct {
+	Tonnage  float64 `json:"tonnage"`
+	HardSets int     `json:"hard_sets"`
+	Reps     int     `json:"reps"`
+}
+
+type exerciseVolumeDTO struct {
+	ExerciseID  uint    `json:"exercise_id"`
+	Name        string  `json:"name"`
+	MuscleGroup *string `json:"muscle_group"`
+	volumeStatsDTO
+}
+
+type muscleVolumeDTO struct {
+	MuscleGroup *string `json:"muscle_group"`
+	volumeStatsDTO
+}
+
+type volumeSummaryDTO struct {
+	volumeStatsDTO
+	Push       volumeStatsDTO      `json:"push"`
+	Pull       volumeStatsDTO      `json:"pull"`
+	ByExercise []exerciseVolumeDTO `json:"by_exercise"`
+	ByMuscle   []muscleVolumeDTO   `json:"by_muscle"`
+}
+
+type volumePeriodDTO struct {
+	PeriodStart string `json:"period_start"`
+	volumeSummaryDTO
+	TonnageChangePercent *float64 `json:"tonnage_change_percent"`
+}
+
+type volumeChangeDTO struct {
+	TonnagePercent *float64 `json:"tonnage_percent"`
+	HardSets       int      `json:"hard_sets"`
+	Reps           int      `json:"reps"`
+}
+
+func NewReportHandler(svc service.ReportService) ReportHandler {
+	return &reportHandler{svc: svc}
+}
+
+func toVolumeStatsDTO(v service.VolumeStats) volumeStatsDTO {
+	return volumeStatsDTO{Tonnage: v.Tonnage, HardSets: v.HardSets, Reps: v.Reps}
+}
+
+// muscleGroupPtr は部位が未設定なら nil を返す。
+func muscleGroupPtr(g string) *string {
+	if g == "" {
+		return nil
+	}
+	return &g
+}
+
+func toVolumeSummaryDTO(s service.VolumeSummary) volumeSummaryDTO {
+	dto := volumeSummaryDTO{
+		volumeStatsDTO: toVolumeStatsDTO(s.VolumeStats),
+		Push:           toVolumeStatsDTO(s.Push),
+		Pull:           toVolumeStatsDTO(s.Pull),
+		ByExercise:     make([]exerciseVolumeDTO, 0, len(s.ByExercise)),
+		ByMuscle:       make([]muscleVolumeDTO, 0, len(s.ByMuscle)),
+	}
+	for _, e := range s.ByExercise {
+		dto.ByExercise = append(dto.ByExercise, exerciseVolumeDTO{
+			ExerciseID:     e.ExerciseID,
+			Name:           e.Name,
+			MuscleGroup:    muscleGroupPtr(string(e.MuscleGroup)),
+			volumeStatsDTO: toVolumeStatsDTO(e.VolumeStats),
+		})
+	}
+	for _, m := range s.ByMuscle {
+		dto.ByMuscle = append(dto.ByMuscle, muscleVolumeDTO{
+			MuscleGroup:    muscleGroupPtr(string(m.MuscleGroup)),
+			volumeStatsDTO: toVolumeStatsDTO(m.VolumeStats),
+		})
+	}
+	return dto
+}
+
+// GetVolumeReport は bucket（week/month）ごとのトン数・ハードセット数・回数を、種目・部位・プッシュ/プル別に返す。
+// from / to（YYYY-MM-DD）は省略可で、直前の同じ期間数との比較も含める。
+func (h *reportHandler) GetVolumeReport(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return err
+	}
+
+	query := service.VolumeReportQuery{
+		Bucket: service.ProgressionBucket(c.QueryParam("bucket")),
+		From:   from,
+		To:     to,
+	}
+	report, err := h.svc.GetVolumeReport(userID, query, todayJST())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidReportQuery) {
+			return httpx.BadRequest("InvalidQuery", "bucket は week/month、from は to 以前（最大104期間）を指定してください", err)
+		}
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	res := VolumeReportResponse{
+		Bucket:       string(report.Bucket),
+		From:         report.From.Format("2006-01-02"),
+		To:           report.To.Format("2006-01-02"),
+		Periods:      make([]volumePeriodDTO, 0, len(report.Periods)),
+		Total:        toVolumeSummaryDTO(report.Total),
+		PreviousFrom: report.PreviousFrom.Format("2006-01-02"),
+		PreviousTo:   report.PreviousTo.Format("2006-01-02"),
+		Previous:     toVolumeSummaryDTO(report.Previous),
+		Change: volumeChangeDTO{
+			TonnagePercent: report.Change.TonnagePercent,
+			HardSets:       report.Change.HardSets,
+			Reps:           report.Change.Reps,
+		},
+	}
+	for _, p := range report.Periods {
+		res.Periods = append(res.Periods, volumePeriodDTO{
+			PeriodStart:          p.PeriodStart.Format("2006-01-02"),
+			volumeSummaryDTO:     toVolumeSummaryDTO(p.VolumeSummary),
+			TonnageChangePercent: p.TonnageChangePercent,
+		})
+	}
+
+	slog.InfoContext(ctx, "volume_report_fetched",
+		"bucket", res.Bucket,
+		"from", res.From,
+		"to", res.To,
+		"tonnage", res.Total.Tonnage,
+	)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReportService struct {
+	GetVolumeReportFunc func(userID uint, query service.VolumeReportQuery, today time.Time) (*service.VolumeReport, error)
+}
+
+func (m *mockReportService) GetVolumeReport(u uint, q service.VolumeReportQuery, today time.Time) (*service.VolumeReport, error) {
+	return m.GetVolumeReportFunc(u, q, today)
+}
+
+func TestReportHandler_GetVolumeReport(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockReportService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:  "【正常系】期間ごとのボリュームと比較を返すこと",
+			query: "?bucket=month&from=2025-09-01&to=2025-10-31",
+			mock: &mockReportService{
+				GetVolumeReportFunc: func(userID uint, q service.VolumeReportQuery, today time.Time) (*service.VolumeReport, error) {
+					require.Equal(t, service.BucketMonth, q.Bucket)
+					require.Equal(t, day(9, 1), *q.From)
+					require.Equal(t, day(10, 31), *q.To)
+					summary := service.VolumeSummary{
+						VolumeStats: service.VolumeStats{Tonnage: 1500, HardSets: 6, Reps: 30},
+						Push:        service.VolumeStats{Tonnage: 1500, HardSets: 6, Reps: 30},
+						ByExercise: []service.ExerciseVolume{{
+							ExerciseID: 1, Name: "ベンチプレス", MuscleGroup: models.MuscleChest,
+							VolumeStats: service.VolumeStats{Tonnage: 1500, HardSets: 6, Reps: 30},
+						}},
+						ByMuscle: []service.MuscleVolume{{VolumeStats: service.VolumeStats{Tonnage: 1500}}},
+					}
+					return &service.VolumeReport{
+						Bucket: service.BucketMonth, From: day(9, 1), To: day(10, 31),
+						Periods: []service.VolumePeriod{
+							{PeriodStart: day(10, 1), VolumeSummary: summary, TonnageChangePercent: utils.Ptr(25.0)},
+						},
+						Total:        summary,
+						PreviousFrom: day(7, 1), PreviousTo: day(8, 31),
+						Change: service.VolumeChange{TonnagePercent: utils.Ptr(50.0), HardSets: 2, Reps: 5},
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"bucket":"month"`, `"previous_from":"2025-07-01"`,
+				`"period_start":"2025-10-01","tonnage":1500,"hard_sets":6,"reps":30`,
+				`"push":{"tonnage":1500,"hard_sets":6,"reps":30}`,
+				`{"exercise_id":1,"name":"ベンチプレス","muscle_group":"chest","tonnage":1500`,
+				`"by_muscle":[{"muscle_group":null,"tonnage":1500`,
+				`"tonnage_change_percent":25`,
+				`"change":{"tonnage_percent":50,"hard_sets":2,"reps":5}`,
+			},
+		},
+		{
+			name:  "【異常系】不正な条件は InvalidQuery を返すこと",
+			query: "?bucket=day",
+			mock: &mockReportService{
+				GetVolumeReportFunc: func(uint, service.VolumeReportQuery, time.Time) (*service.VolumeReport, error) {
+					return nil, service.ErrInvalidReportQuery
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidQuery"`},
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate を返すこと",
+			query:        "?from=2025/09/01",
+			mock:         &mockReportService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidDate"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewReportHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/reports/volume"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetVolumeReport(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type StatsHandler interface {
+	GetStats(c echo.Context) error
+	UpdateStreakRule(c echo.Context) error
+}
+
+type statsHandler struct {
+	svc service.StatsService
+}
+
+// StreakRuleRequest の weekly_target は weekly で1週に必要なトレーニング日数（省略時は3）。
+type StreakRuleRequest struct {
+	Rule         string `json:"rule"`
+	WeeklyTarget int    `json:"weekly_target"`
+}
+
+type trainingStatsDTO struct {
+	StreakRule      string             `json:"streak_rule"`
+	WeeklyTarget    int                `json:"weekly_target"`
+	CurrentStreak   int                `json:"current_streak"`
+	LongestStreak   int                `json:"longest_streak"`
+	WeeklyFrequency []weekFrequencyDTO `json:"weekly_frequency"`
+	AveragePerWeek  float64            `json:"average_per_week"`
+	Weekdays        []weekdayCountDTO  `json:"weekdays"`
+}
+
+type weekFrequencyDTO struct {
+	WeekStart string `json:"week_start"`
+	Days      int    `json:"days"`
+}
+
+type weekdayCountDTO struct {
+	Weekday string `json:"weekday"`
+	Days    int    `json:"days"`
+}
+
+const defaultStreakWeeklyTarget = 3
+
+var weekdayLabels = [7]string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+func NewStatsHandler(svc service.StatsService) StatsHandler {
+	return &statsHandler{svc: svc}
+}
+
+func toTrainingStatsDTO(s *service.TrainingStats) *trainingStatsDTO {
+	if s == nil {
+		return nil
+	}
+	dto := &trainingStatsDTO{
+		StreakRule:      string(s.StreakRule),
+		WeeklyTarget:    s.WeeklyTarget,
+		CurrentStreak:   s.CurrentStreak,
+		LongestStreak:   s.LongestStreak,
+		WeeklyFrequency: make([]weekFrequencyDTO, 0, len(s.WeeklyFrequency)),
+		AveragePerWeek:  s.AveragePerWeek,
+		Weekdays:        make([]weekdayCountDTO, 0, len(s.Weekdays)),
+	}
+	for _, w := range s.WeeklyFrequency {
+		dto.WeeklyFrequency = append(dto.WeeklyFrequency, weekFrequencyDTO{
+			WeekStart: w.WeekStart.Format("2006-01-02"),
+			Days:      w.Days,
+		})
+	}
+	for i, n := range s.Weekdays {
+		dto.Weekdays = append(dto.Weekdays, weekdayCountDTO{Weekday: weekdayLabels[i], Days: n})
+	}
+	return dto
+}
+
+func statsError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidStreakRule):
+		return httpx.BadRequest("ValidationError", "rule は daily/weekly、weekly_target は1〜7で指定してください", err)
+	case errors.Is(err, service.ErrUserNotFound):
+		return httpx.NotFound("UserNotFound", "ユーザーが存在しません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+// GetStats はストリーク・直近12週の週ごとの頻度・曜日ごとの日数を返す。
+func (h *statsHandler) GetStats(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	stats, err := h.svc.GetStats(userID, todayJST())
+	if err != nil {
+		return statsError(err)
+	}
+
+	slog.InfoContext(ctx, "training_stats_fetched",
+		"streak_rule", stats.StreakRule,
+		"current_streak", stats.CurrentStreak,
+		"longest_streak", stats.LongestStreak,
+	)
+
+	return c.JSON(http.StatusOK, toTrainingStatsDTO(stats))
+}
+
+func (h *statsHandler) UpdateStreakRule(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	var req StreakRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+	if req.WeeklyTarget == 0 {
+		req.WeeklyTarget = defaultStreakWeeklyTarget
+	}
+
+	stats, err := h.svc.UpdateStreakRule(userID, models.StreakRule(req.Rule), req.WeeklyTarget, todayJST())
+	if err != nil {
+		return statsError(err)
+	}
+
+	slog.InfoContext(ctx, "streak_rule_updated",
+		"streak_rule", stats.StreakRule,
+		"weekly_target", stats.WeeklyTarget,
+	)
+
+	return c.JSON(http.StatusOK, toTrainingStatsDTO(stats))
+}
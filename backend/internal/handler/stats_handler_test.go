@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStatsService struct {
+	GetStatsFunc         func(userID uint, today time.Time) (*service.TrainingStats, error)
+	UpdateStreakRuleFunc func(userID uint, rule models.StreakRule, weeklyTarget int, today time.Time) (*service.TrainingStats, error)
+}
+
+func (m *mockStatsService) GetStats(u uint, today time.Time) (*service.TrainingStats, error) {
+	return m.GetStatsFunc(u, today)
+}
+func (m *mockStatsService) UpdateStreakRule(u uint, r models.StreakRule, n int, today time.Time) (*service.TrainingStats, error) {
+	return m.UpdateStreakRuleFunc(u, r, n, today)
+}
+
+func TestStatsHandler_GetStats(t *testing.T) {
+	e := newEchoForTest()
+	h := NewStatsHandler(&mockStatsService{
+		GetStatsFunc: func(userID uint, today time.Time) (*service.TrainingStats, error) {
+			return &service.TrainingStats{
+				StreakRule:      models.StreakWeekly,
+				WeeklyTarget:    3,
+				CurrentStreak:   4,
+				LongestStreak:   6,
+				WeeklyFrequency: []service.WeekFrequency{{WeekStart: time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC), Days: 2}},
+				AveragePerWeek:  2.5,
+				Weekdays:        [7]int{5, 0, 3, 0, 4, 1, 0},
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uint(1))
+
+	require.NoError(t, h.GetStats(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	for _, s := range []string{
+		`"streak_rule":"weekly"`, `"current_streak":4`, `"longest_streak":6`,
+		`"weekly_frequency":[{"week_start":"2025-10-13","days":2}]`,
+		`{"weekday":"mon","days":5}`, `{"weekday":"sat","days":1}`,
+	} {
+		require.Contains(t, rec.Body.String(), s)
+	}
+}
+
+func TestStatsHandler_UpdateStreakRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockStatsService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】週の目標日数を省略すると3日で保存すること",
+			body: `{"rule":"weekly"}`,
+			mock: &mockStatsService{
+				UpdateStreakRuleFunc: func(userID uint, rule models.StreakRule, n int, today time.Time) (*service.TrainingStats, error) {
+					require.Equal(t, models.StreakWeekly, rule)
+					require.Equal(t, 3, n)
+					return &service.TrainingStats{StreakRule: rule, WeeklyTarget: n}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"weekly_target":3`,
+		},
+		{
+			name: "【異常系】不正な数え方は ValidationError を返すこと",
+			body: `{"rule":"monthly"}`,
+			mock: &mockStatsService{
+				UpdateStreakRuleFunc: func(uint, models.StreakRule, int, time.Time) (*service.TrainingStats, error) {
+					return nil, service.ErrInvalidStreakRule
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"ValidationError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewStatsHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPut, "/stats/streak_rule", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.UpdateStreakRule(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
@@ -18,12 +19,37 @@ type summaryHandler struct {
 	svc service.SummaryService
 }
 
+// SummaryResponse の latest_trained_on は latest_weight を測った日で、体組成ログの計測日の場合もある（互換性のためキー名は変えない）。
 type SummaryResponse struct {
-	TotalTrainingDays int      `json:"total_training_days"`
-	LatestWeight      *float64 `json:"latest_weight"`
-	LatestTrainedOn   string   `json:"latest_trained_on"`
-	GoalWeight        *float64 `json:"goal_weight"`
-	Height            *float64 `json:"height"`
+	TotalTrainingDays int               `json:"total_training_days"`
+	LatestWeight      *float64          `json:"latest_weight"`
+	LatestWeightOn    string            `json:"latest_trained_on"`
+	GoalWeight        *float64          `json:"goal_weight"`
+	Height            *float64          `json:"height"`
+	BMI               *float64          `json:"bmi"`
+	FFMI              *float64          `json:"ffmi"`
+	NormalizedFFMI    *float64          `json:"normalized_ffmi"`
+	GoalProgress      *goalProgressDTO  `json:"goal_progress"`
+	Stats             *trainingStatsDTO `json:"stats"`
+}
+
+type goalProgressDTO struct {
+	GoalWeight     float64  `json:"goal_weight"`
+	CurrentWeight  float64  `json:"current_weight"`
+	RemainingKG    float64  `json:"remaining_kg"`
+	Reached        bool     `json:"reached"`
+	TrendKGPerWeek *float64 `json:"trend_kg_per_week"`
+	EstimatedDate  *string  `json:"estimated_date"`
+	GoalDate       *string  `json:"goal_date"`
+	OnTrack        *bool    `json:"on_track"`
+}
+
+func formatDatePtr(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
 }
 
 func NewSummaryHandler(svc service.SummaryService) SummaryHandler {
@@ -34,29 +60,45 @@ func (h *summaryHandler) GetHomeSummary(c echo.Context) error {
 	ctx := c.Request().Context()
 	userID := middleware.GetUserID(c)
 
-	summary, err := h.svc.GetHomeSummary(userID)
+	summary, err := h.svc.GetHomeSummary(userID, todayJST())
 	if err != nil {
 		return httpx.Internal("サマリーの取得に失敗しました", err)
 	}
 
 	var latestOn string
-	if summary.LatestTrainedOn != nil {
-		latestOn = summary.LatestTrainedOn.Format("2006-01-02")
+	if summary.LatestWeightOn != nil {
+		latestOn = summary.LatestWeightOn.Format("2006-01-02")
 	}
 
 	res := SummaryResponse{
 		TotalTrainingDays: int(summary.TotalTrainingDays),
 		LatestWeight:      summary.LatestWeight,
-		LatestTrainedOn:   latestOn,
+		LatestWeightOn:    latestOn,
 		GoalWeight:        summary.GoalWeight,
 		Height:            summary.Height,
+		BMI:               summary.BMI,
+		FFMI:              summary.FFMI,
+		NormalizedFFMI:    summary.NormalizedFFMI,
+		Stats:             toTrainingStatsDTO(summary.Stats),
+	}
+	if gp := summary.GoalProgress; gp != nil {
+		res.GoalProgress = &goalProgressDTO{
+			GoalWeight:     gp.GoalWeight,
+			CurrentWeight:  gp.CurrentWeight,
+			RemainingKG:    gp.RemainingKG,
+			Reached:        gp.Reached,
+			TrendKGPerWeek: gp.TrendKGPerWeek,
+			EstimatedDate:  formatDatePtr(gp.EstimatedDate),
+			GoalDate:       formatDatePtr(gp.GoalDate),
+			OnTrack:        gp.OnTrack,
+		}
 	}
 
 	slog.InfoContext(ctx, "home_summary_fetched",
 		"user_id", userID,
 		"total_training_days", res.TotalTrainingDays,
 		"latest_weight", res.LatestWeight,
-		"latest_trained_on", res.LatestTrainedOn,
+		"latest_trained_on", res.LatestWeightOn,
 		"goal_weight", res.GoalWeight,
 		"height", res.Height,
 	)
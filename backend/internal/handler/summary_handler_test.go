@@ -18,11 +18,11 @@ import (
 )
 
 type mockSummaryService struct {
-	GetHomeSummaryFunc func(userID uint) (*service.HomeSummary, error)
+	GetHomeSummaryFunc func(userID uint, today time.Time) (*service.HomeSummary, error)
 }
 
-func (m *mockSummaryService) GetHomeSummary(userID uint) (*service.HomeSummary, error) {
-	return m.GetHomeSummaryFunc(userID)
+func (m *mockSummaryService) GetHomeSummary(userID uint, today time.Time) (*service.HomeSummary, error) {
+	return m.GetHomeSummaryFunc(userID, today)
 }
 
 func TestSummaryHandler_GetHomeSummary(t *testing.T) {
@@ -41,11 +41,11 @@ func TestSummaryHandler_GetHomeSummary(t *testing.T) {
 		{
 			name: "【正常系】サマリーを取得できること",
 			mockSvc: &mockSummaryService{
-				GetHomeSummaryFunc: func(userID uint) (*service.HomeSummary, error) {
+				GetHomeSummaryFunc: func(userID uint, today time.Time) (*service.HomeSummary, error) {
 					return &service.HomeSummary{
 						TotalTrainingDays: 10,
 						LatestWeight:      utils.Ptr(65.0),
-						LatestTrainedOn:   &now,
+						LatestWeightOn:    &now,
 						GoalWeight:        utils.Ptr(60.0),
 						Height:            utils.Ptr(170.0),
 					}, nil
@@ -54,10 +54,33 @@ func TestSummaryHandler_GetHomeSummary(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			wantBodyPart:   `"total_training_days":10`,
 		},
+		{
+			name: "【正常系】目標体重の進捗を日付付きで返すこと",
+			mockSvc: &mockSummaryService{
+				GetHomeSummaryFunc: func(userID uint, today time.Time) (*service.HomeSummary, error) {
+					eta := time.Date(2025, 11, 26, 0, 0, 0, 0, time.UTC)
+					return &service.HomeSummary{
+						TotalTrainingDays: 10,
+						LatestWeight:      utils.Ptr(65.0),
+						GoalWeight:        utils.Ptr(60.0),
+						GoalProgress: &service.GoalProgress{
+							GoalWeight:     60,
+							CurrentWeight:  65,
+							RemainingKG:    -5,
+							TrendKGPerWeek: utils.Ptr(-0.5),
+							EstimatedDate:  &eta,
+							OnTrack:        utils.Ptr(true),
+						},
+					}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBodyPart:   `"goal_progress":{"goal_weight":60,"current_weight":65,"remaining_kg":-5,"reached":false,"trend_kg_per_week":-0.5,"estimated_date":"2025-11-26","goal_date":null,"on_track":true}`,
+		},
 		{
 			name: "【異常系】サービス層でエラーが返された場合、500が返ること",
 			mockSvc: &mockSummaryService{
-				GetHomeSummaryFunc: func(userID uint) (*service.HomeSummary, error) {
+				GetHomeSummaryFunc: func(userID uint, today time.Time) (*service.HomeSummary, error) {
 					return nil, errors.New("test error")
 				},
 			},
@@ -85,9 +108,9 @@ func TestSummaryHandler_GetHomeSummary(t *testing.T) {
 			require.Contains(t, rec.Body.String(), tt.wantBodyPart)
 
 			if tt.wantStatusCode == http.StatusOK {
-				var res service.HomeSummary
+				var res SummaryResponse
 				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
-				require.Equal(t, int64(10), res.TotalTrainingDays)
+				require.Equal(t, 10, res.TotalTrainingDays)
 				require.InDelta(t, 65.0, *res.LatestWeight, 0.01)
 			}
 		})
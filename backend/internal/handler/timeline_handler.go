@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 )
@@ -23,44 +26,158 @@ func NewTimelineHandler(svc service.TimelineService) TimelineHandler {
 	return &timelineHandler{svc: svc}
 }
 
+// TimelineItemResponse の kind は session / recap。recap の場合は recap に内容が入り、trained_on は公開日。
 type TimelineItemResponse struct {
-	RecordID     uint    `json:"record_id"`
-	UserID       uint    `json:"user_id"`
-	UserEmail    string  `json:"user_email"`
-	ExerciseName string  `json:"exercise_name"`
-	BodyWeight   float64 `json:"body_weight"`
-	TrainedOn    string  `json:"trained_on"`
-	Comment      string  `json:"comment"`
-	LikedByMe    bool    `json:"liked_by_me"`
+	Kind          string               `json:"kind"`
+	SessionID     uint                 `json:"session_id"`
+	UserID        uint                 `json:"user_id"`
+	UserEmail     string               `json:"user_email"`
+	ExerciseNames []string             `json:"exercise_names"`
+	Exercises     []workoutExerciseDTO `json:"exercises"`
+	BodyWeight    float64              `json:"body_weight"`
+	TrainedOn     string               `json:"trained_on"`
+	Comment       string               `json:"comment"`
+	LikedByMe     bool                 `json:"liked_by_me"`
+	LikeCount     int                  `json:"like_count"`
+	CommentCount  int                  `json:"comment_count"`
+	Recap         *timelineRecapDTO    `json:"recap,omitempty"`
 }
 
+type timelineRecapDTO struct {
+	RecapID              uint    `json:"recap_id"`
+	Period               string  `json:"period"`
+	PeriodStart          string  `json:"period_start"`
+	TotalSessions        int     `json:"total_sessions"`
+	TrainingDays         int     `json:"training_days"`
+	TotalTonnage         float64 `json:"total_tonnage"`
+	FavoriteExerciseName *string `json:"favorite_exercise_name"`
+	PRCount              int     `json:"pr_count"`
+	LongestStreak        int     `json:"longest_streak"`
+}
+
+// TimelineResponse はタイムラインの1ページ。next_cursor を cursor に指定すると続きを取得できる（続きが無ければ null）。
+type TimelineResponse struct {
+	Items      []TimelineItemResponse `json:"items"`
+	NextCursor *string                `json:"next_cursor"`
+}
+
+// parseIDQuery は ID のクエリを解釈する。省略時は nil。
+func parseIDQuery(c echo.Context, name string) (*uint, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	id64, err := strconv.ParseUint(v, 10, 32)
+	if err != nil || id64 == 0 {
+		return nil, httpx.BadRequest("InvalidQuery", name+" が不正です", err)
+	}
+	id := uint(id64)
+	return &id, nil
+}
+
+func parseTimelineQuery(c echo.Context) (service.TimelineQuery, error) {
+	q := service.TimelineQuery{
+		Scope:  models.TimelineScope(c.QueryParam("scope")),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return q, httpx.BadRequest("InvalidQuery", "limit が不正です", err)
+		}
+		q.Limit = limit
+	}
+
+	var err error
+	if q.ExerciseID, err = parseIDQuery(c, "exercise_id"); err != nil {
+		return q, err
+	}
+	if q.AuthorID, err = parseIDQuery(c, "user_id"); err != nil {
+		return q, err
+	}
+	if q.From, err = parseDateQuery(c, "from"); err != nil {
+		return q, err
+	}
+	if q.To, err = parseDateQuery(c, "to"); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// GetTimeline はタイムラインを新しい順に1ページ分返す。
+// scope（global/following/me、省略時は global）、exercise_id・user_id・from・to（YYYY-MM-DD、to を含む）で絞り込み、
+// limit（1〜100、省略時は20）件ずつ cursor（前のページの next_cursor）から取得する。
 func (h *timelineHandler) GetTimeline(c echo.Context) error {
 	ctx := c.Request().Context()
 	userID := middleware.GetUserID(c)
 
-	items, err := h.svc.GetTimeline(userID)
+	q, err := parseTimelineQuery(c)
 	if err != nil {
+		return err
+	}
+
+	page, err := h.svc.GetTimeline(userID, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTimelineScope):
+			return httpx.BadRequest("InvalidQuery", "scope は global/following/me を指定してください", err)
+		case errors.Is(err, service.ErrInvalidTimelineQuery):
+			return httpx.BadRequest("InvalidQuery", "limit は1〜100、from は to 以前を指定してください", err)
+		case errors.Is(err, service.ErrInvalidTimelineCursor):
+			return httpx.BadRequest("InvalidCursor", "cursor が不正です", err)
+		}
 		return httpx.Internal("システムエラーが発生しました", err)
 	}
 
 	loc, _ := time.LoadLocation("Asia/Tokyo")
 
-	var res []TimelineItemResponse
-	for _, it := range items {
-		res = append(res, TimelineItemResponse{
-			RecordID:     it.RecordID,
-			UserID:       it.UserID,
-			UserEmail:    it.UserEmail,
-			ExerciseName: it.ExerciseName,
-			BodyWeight:   it.BodyWeight,
-			TrainedOn:    it.TrainedOn.In(loc).Format("2006-01-02"),
-			Comment:      it.Comment,
-			LikedByMe:    it.LikedByMe,
-		})
+	res := TimelineResponse{Items: make([]TimelineItemResponse, 0, len(page.Items))}
+	if page.NextCursor != "" {
+		res.NextCursor = &page.NextCursor
+	}
+	for _, it := range page.Items {
+		item := TimelineItemResponse{
+			Kind:          string(it.Kind),
+			SessionID:     it.SessionID,
+			UserID:        it.UserID,
+			UserEmail:     it.UserEmail,
+			ExerciseNames: it.ExerciseNames,
+			Exercises:     make([]workoutExerciseDTO, 0, len(it.Exercises)),
+			BodyWeight:    it.BodyWeight,
+			TrainedOn:     it.TrainedOn.In(loc).Format("2006-01-02"),
+			Comment:       it.Comment,
+			LikedByMe:     it.LikedByMe,
+			LikeCount:     it.LikeCount,
+			CommentCount:  it.CommentCount,
+		}
+		for _, ex := range it.Exercises {
+			item.Exercises = append(item.Exercises, workoutExerciseDTO{
+				RecordID:     ex.RecordID,
+				ExerciseID:   ex.ExerciseID,
+				ExerciseName: ex.ExerciseName,
+				Sets:         toWorkoutSetDTOs(ex.Sets),
+			})
+		}
+		if rc := it.Recap; rc != nil {
+			item.Recap = &timelineRecapDTO{
+				RecapID:              rc.RecapID,
+				Period:               string(rc.Period),
+				PeriodStart:          rc.PeriodStart.Format("2006-01-02"),
+				TotalSessions:        rc.TotalSessions,
+				TrainingDays:         rc.TrainingDays,
+				TotalTonnage:         rc.TotalTonnage,
+				FavoriteExerciseName: rc.FavoriteExerciseName,
+				PRCount:              rc.PRCount,
+				LongestStreak:        rc.LongestStreak,
+			}
+		}
+		res.Items = append(res.Items, item)
 	}
 
 	slog.InfoContext(ctx, "timeline_fetched",
-		"count", len(res),
+		"scope", q.Scope,
+		"count", len(res.Items),
+		"has_next", res.NextCursor != nil,
 	)
 
 	return c.JSON(http.StatusOK, res)
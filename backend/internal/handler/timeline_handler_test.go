@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
@@ -18,11 +19,11 @@ import (
 
 // TimelineService のモック
 type mockTimelineService struct {
-	GetTimelineFunc func(userID uint) ([]service.TimelineItem, error)
+	GetTimelineFunc func(userID uint, q service.TimelineQuery) (*service.TimelinePage, error)
 }
 
-func (m *mockTimelineService) GetTimeline(userID uint) ([]service.TimelineItem, error) {
-	return m.GetTimelineFunc(userID)
+func (m *mockTimelineService) GetTimeline(userID uint, q service.TimelineQuery) (*service.TimelinePage, error) {
+	return m.GetTimelineFunc(userID, q)
 }
 
 func TestTimelineHandler_GetTimeline(t *testing.T) {
@@ -37,32 +38,61 @@ func TestTimelineHandler_GetTimeline(t *testing.T) {
 		mockSvc        service.TimelineService
 		wantStatusCode int
 		wantBodyPart   string
+		wantLikeCount  int
 	}{
 		{
 			name: "【正常系】タイムラインを取得できること",
 			mockSvc: &mockTimelineService{
-				GetTimelineFunc: func(userID uint) ([]service.TimelineItem, error) {
-					return []service.TimelineItem{
+				GetTimelineFunc: func(userID uint, q service.TimelineQuery) (*service.TimelinePage, error) {
+					return &service.TimelinePage{Items: []service.TimelineItem{
 						{
-							RecordID:     1,
-							UserID:       10,
-							UserEmail:    "user@example.com",
-							ExerciseName: "ベンチプレス",
-							BodyWeight:   70.5,
-							TrainedOn:    now,
-							Comment:      "今日は自己ベスト！",
-							LikedByMe:    false,
+							SessionID:     1,
+							UserID:        10,
+							UserEmail:     "user@example.com",
+							ExerciseNames: []string{"ベンチプレス"},
+							BodyWeight:    70.5,
+							TrainedOn:     now,
+							Comment:       "今日は自己ベスト！",
+							LikedByMe:     false,
 						},
-					}, nil
+					}}, nil
 				},
 			},
 			wantStatusCode: http.StatusOK,
-			wantBodyPart:   `"exercise_name":"ベンチプレス"`,
+			wantBodyPart:   `"exercise_names":["ベンチプレス"]`,
+		},
+		{
+			name: "【正常系】いいね数と種目ごとのセットを返すこと",
+			mockSvc: &mockTimelineService{
+				GetTimelineFunc: func(userID uint, q service.TimelineQuery) (*service.TimelinePage, error) {
+					return &service.TimelinePage{Items: []service.TimelineItem{
+						{
+							Kind:          service.TimelineSession,
+							SessionID:     1,
+							UserID:        10,
+							UserEmail:     "user@example.com",
+							ExerciseNames: []string{"ベンチプレス"},
+							Exercises: []service.TimelineExercise{{
+								RecordID:     5,
+								ExerciseID:   2,
+								ExerciseName: "ベンチプレス",
+								Sets:         []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100, SetType: models.SetTypeWorking}},
+							}},
+							TrainedOn: now,
+							Comment:   "今日は自己ベスト！",
+							LikeCount: 3,
+						},
+					}}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBodyPart:   `"exercises":[{"record_id":5,"exercise_id":2,"exercise_name":"ベンチプレス","sets":[{"set":1,"reps":5,"exercise_weight":100,"set_type":"working"`,
+			wantLikeCount:  3,
 		},
 		{
 			name: "【異常系】サービス層でエラーが返された場合、500が返ること",
 			mockSvc: &mockTimelineService{
-				GetTimelineFunc: func(userID uint) ([]service.TimelineItem, error) {
+				GetTimelineFunc: func(userID uint, q service.TimelineQuery) (*service.TimelinePage, error) {
 					return nil, errors.New("db error")
 				},
 			},
@@ -89,17 +119,104 @@ func TestTimelineHandler_GetTimeline(t *testing.T) {
 			require.Contains(t, rec.Body.String(), tt.wantBodyPart)
 
 			if tt.wantStatusCode == http.StatusOK {
-				var res []TimelineItemResponse
-				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				var page TimelineResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+				require.Nil(t, page.NextCursor)
+				res := page.Items
 				require.Len(t, res, 1)
-				require.Equal(t, uint(1), res[0].RecordID)
+				require.Equal(t, uint(1), res[0].SessionID)
 				require.Equal(t, uint(10), res[0].UserID)
 				require.Equal(t, "user@example.com", res[0].UserEmail)
-				require.Equal(t, "ベンチプレス", res[0].ExerciseName)
+				require.Equal(t, []string{"ベンチプレス"}, res[0].ExerciseNames)
 				require.Equal(t, "今日は自己ベスト！", res[0].Comment)
+				require.Equal(t, tt.wantLikeCount, res[0].LikeCount)
 
 				require.NotEmpty(t, res[0].TrainedOn)
 			}
 		})
 	}
 }
+
+func TestTimelineHandler_GetTimeline_Query(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockTimelineService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:  "【正常系】絞り込みとページの指定をサービスに渡し、next_cursor を返すこと",
+			query: "?scope=following&limit=2&cursor=abc&exercise_id=3&user_id=4&from=2025-10-01&to=2025-10-31",
+			mock: &mockTimelineService{
+				GetTimelineFunc: func(userID uint, q service.TimelineQuery) (*service.TimelinePage, error) {
+					require.Equal(t, models.TimelineFollowing, q.Scope)
+					require.Equal(t, 2, q.Limit)
+					require.Equal(t, "abc", q.Cursor)
+					require.Equal(t, uint(3), *q.ExerciseID)
+					require.Equal(t, uint(4), *q.AuthorID)
+					require.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), *q.From)
+					require.Equal(t, time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), *q.To)
+					return &service.TimelinePage{NextCursor: "next"}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `{"items":[],"next_cursor":"next"}`,
+		},
+		{
+			name:         "【異常系】limit が数値でない場合は InvalidQuery を返すこと",
+			query:        "?limit=many",
+			mock:         &mockTimelineService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate を返すこと",
+			query:        "?from=2025/10/01",
+			mock:         &mockTimelineService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidDate"`,
+		},
+		{
+			name:  "【異常系】未知の scope は InvalidQuery を返すこと",
+			query: "?scope=friends",
+			mock: &mockTimelineService{
+				GetTimelineFunc: func(uint, service.TimelineQuery) (*service.TimelinePage, error) {
+					return nil, service.ErrInvalidTimelineScope
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:  "【異常系】カーソルが不正な場合は InvalidCursor を返すこと",
+			query: "?cursor=broken",
+			mock: &mockTimelineService{
+				GetTimelineFunc: func(uint, service.TimelineQuery) (*service.TimelinePage, error) {
+					return nil, service.ErrInvalidTimelineCursor
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidCursor"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewTimelineHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/timeline"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetTimeline(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
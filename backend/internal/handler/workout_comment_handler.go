@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type WorkoutCommentHandler interface {
+	ListComments(c echo.Context) error
+	CreateComment(c echo.Context) error
+	UpdateComment(c echo.Context) error
+	DeleteComment(c echo.Context) error
+}
+
+type workoutCommentHandler struct {
+	svc service.WorkoutCommentService
+}
+
+func NewWorkoutCommentHandler(svc service.WorkoutCommentService) WorkoutCommentHandler {
+	return &workoutCommentHandler{svc: svc}
+}
+
+// CommentRequest の parent_id を指定すると、そのコメントへの返信になる（返信への返信は不可）。
+type CommentRequest struct {
+	Body     string `json:"body"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type commentDTO struct {
+	ID        uint   `json:"id"`
+	SessionID uint   `json:"session_id"`
+	UserID    uint   `json:"user_id"`
+	UserEmail string `json:"user_email"`
+	ParentID  *uint  `json:"parent_id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type commentThreadDTO struct {
+	commentDTO
+	Replies []commentDTO `json:"replies"`
+}
+
+// CommentsResponse はコメント一覧の1ページ。next_cursor を cursor に指定すると続きを取得できる（続きが無ければ null）。
+type CommentsResponse struct {
+	Items      []commentThreadDTO `json:"items"`
+	NextCursor *string            `json:"next_cursor"`
+}
+
+func toCommentDTO(c service.Comment) commentDTO {
+	return commentDTO{
+		ID:        c.ID,
+		SessionID: c.SessionID,
+		UserID:    c.UserID,
+		UserEmail: c.UserEmail,
+		ParentID:  c.ParentID,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func parseCommentID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidCommentID", "comment_id が不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func commentError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidComment):
+		return httpx.BadRequest("ValidationError", "本文は1〜500文字で、返信先には返信ではないコメントを指定してください", err)
+	case errors.Is(err, service.ErrInvalidCommentQuery):
+		return httpx.BadRequest("InvalidQuery", "limit は1〜100、cursor は前のページの next_cursor を指定してください", err)
+	case errors.Is(err, service.ErrSessionNotFound):
+		return httpx.NotFound("SessionNotFound", "対象のトレーニングが存在しません", err)
+	case errors.Is(err, service.ErrCommentNotFound):
+		return httpx.NotFound("CommentNotFound", "指定のコメントが見つかりません", err)
+	case errors.Is(err, service.ErrForbiddenPrivateRecord):
+		return httpx.Forbidden("非公開の投稿にはコメントできません", err)
+	case errors.Is(err, service.ErrPrivateAccount):
+		return httpx.Forbidden("非公開アカウントの投稿のコメントは承認されたフォロワーのみ利用できます", err)
+	case errors.Is(err, service.ErrForbiddenComment):
+		return httpx.Forbidden("このコメントは操作できません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+// ListComments は公開セッションのコメントを古い順に、返信を付けて limit（1〜100、省略時は20）件ずつ返す。
+func (h *workoutCommentHandler) ListComments(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionID(c)
+	if err != nil {
+		return err
+	}
+
+	q := service.CommentQuery{Cursor: c.QueryParam("cursor")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return httpx.BadRequest("InvalidQuery", "limit が不正です", err)
+		}
+		q.Limit = limit
+	}
+
+	page, err := h.svc.ListComments(userID, sessionID, q)
+	if err != nil {
+		return commentError(err)
+	}
+
+	res := CommentsResponse{Items: make([]commentThreadDTO, 0, len(page.Items))}
+	if page.NextCursor != "" {
+		res.NextCursor = &page.NextCursor
+	}
+	for _, cm := range page.Items {
+		thread := commentThreadDTO{
+			commentDTO: toCommentDTO(cm),
+			Replies:    make([]commentDTO, 0, len(cm.Replies)),
+		}
+		for _, r := range cm.Replies {
+			thread.Replies = append(thread.Replies, toCommentDTO(r))
+		}
+		res.Items = append(res.Items, thread)
+	}
+
+	slog.InfoContext(ctx, "comments_fetched",
+		"session_id", sessionID,
+		"count", len(res.Items),
+		"has_next", res.NextCursor != nil,
+	)
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *workoutCommentHandler) CreateComment(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionID(c)
+	if err != nil {
+		return err
+	}
+
+	var req CommentRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	comment, err := h.svc.CreateComment(userID, sessionID, service.CommentData{
+		Body:     req.Body,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return commentError(err)
+	}
+
+	slog.InfoContext(ctx, "comment_created",
+		"session_id", sessionID,
+		"comment_id", comment.ID,
+		"is_reply", comment.ParentID != nil,
+	)
+
+	return c.JSON(http.StatusCreated, toCommentDTO(*comment))
+}
+
+// UpdateComment はコメントの本文を変更する。変更できるのはコメントした本人のみ。
+func (h *workoutCommentHandler) UpdateComment(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionID(c)
+	if err != nil {
+		return err
+	}
+	commentID, err := parseCommentID(c)
+	if err != nil {
+		return err
+	}
+
+	var req CommentRequest
+	if err := c.Bind(&req); err != nil {
+		return httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	comment, err := h.svc.UpdateComment(userID, sessionID, commentID, req.Body)
+	if err != nil {
+		return commentError(err)
+	}
+
+	slog.InfoContext(ctx, "comment_updated",
+		"session_id", sessionID,
+		"comment_id", commentID,
+	)
+
+	return c.JSON(http.StatusOK, toCommentDTO(*comment))
+}
+
+// DeleteComment はコメントを返信ごと削除する。削除できるのはコメントした本人とセッションの投稿者。
+func (h *workoutCommentHandler) DeleteComment(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionID(c)
+	if err != nil {
+		return err
+	}
+	commentID, err := parseCommentID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteComment(userID, sessionID, commentID); err != nil {
+		return commentError(err)
+	}
+
+	slog.InfoContext(ctx, "comment_deleted",
+		"session_id", sessionID,
+		"comment_id", commentID,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Comment deleted successfully",
+	})
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWorkoutCommentService struct {
+	ListCommentsFunc  func(userID uint, sessionID uint, q service.CommentQuery) (*service.CommentPage, error)
+	CreateCommentFunc func(userID uint, sessionID uint, data service.CommentData) (*service.Comment, error)
+	UpdateCommentFunc func(userID uint, sessionID uint, commentID uint, body string) (*service.Comment, error)
+	DeleteCommentFunc func(userID uint, sessionID uint, commentID uint) error
+}
+
+func (m *mockWorkoutCommentService) ListComments(u uint, s uint, q service.CommentQuery) (*service.CommentPage, error) {
+	return m.ListCommentsFunc(u, s, q)
+}
+func (m *mockWorkoutCommentService) CreateComment(u uint, s uint, d service.CommentData) (*service.Comment, error) {
+	return m.CreateCommentFunc(u, s, d)
+}
+func (m *mockWorkoutCommentService) UpdateComment(u uint, s uint, id uint, body string) (*service.Comment, error) {
+	return m.UpdateCommentFunc(u, s, id, body)
+}
+func (m *mockWorkoutCommentService) DeleteComment(u uint, s uint, id uint) error {
+	return m.DeleteCommentFunc(u, s, id)
+}
+
+var commentTime = time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestWorkoutCommentHandler_ListComments(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockWorkoutCommentService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:  "【正常系】返信付きのコメントと next_cursor を返すこと",
+			query: "?limit=1&cursor=5",
+			mock: &mockWorkoutCommentService{
+				ListCommentsFunc: func(userID uint, sessionID uint, q service.CommentQuery) (*service.CommentPage, error) {
+					require.Equal(t, uint(10), sessionID)
+					require.Equal(t, service.CommentQuery{Cursor: "5", Limit: 1}, q)
+					return &service.CommentPage{
+						Items: []service.Comment{{
+							ID: 6, SessionID: 10, UserID: 2, UserEmail: "fan@example.com", Body: "ナイス",
+							CreatedAt: commentTime, UpdatedAt: commentTime,
+							Replies: []service.Comment{{ID: 7, SessionID: 10, UserID: 1, ParentID: utils.Ptr(uint(6)), Body: "ありがとう", CreatedAt: commentTime, UpdatedAt: commentTime}},
+						}},
+						NextCursor: "6",
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"id":6`, `"user_email":"fan@example.com"`, `"parent_id":null`, `"created_at":"2025-10-01T09:00:00Z"`,
+				`"replies":[{"id":7`, `"parent_id":6`, `"next_cursor":"6"`,
+			},
+		},
+		{
+			name:  "【正常系】コメントが無い場合は空配列を返すこと",
+			query: "",
+			mock: &mockWorkoutCommentService{
+				ListCommentsFunc: func(uint, uint, service.CommentQuery) (*service.CommentPage, error) {
+					return &service.CommentPage{}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{`"items":[]`, `"next_cursor":null`},
+		},
+		{
+			name:         "【異常系】limit が数値でない場合は InvalidQuery を返すこと",
+			query:        "?limit=abc",
+			mock:         &mockWorkoutCommentService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidQuery"`},
+		},
+		{
+			name:  "【異常系】非公開のセッションは403を返すこと",
+			query: "",
+			mock: &mockWorkoutCommentService{
+				ListCommentsFunc: func(uint, uint, service.CommentQuery) (*service.CommentPage, error) {
+					return nil, service.ErrForbiddenPrivateRecord
+				},
+			},
+			wantCode:     http.StatusForbidden,
+			wantContains: []string{"非公開"},
+		},
+		{
+			name:  "【異常系】承認されていないフォロワーには非公開アカウントのコメントを見せず403を返すこと",
+			query: "",
+			mock: &mockWorkoutCommentService{
+				ListCommentsFunc: func(uint, uint, service.CommentQuery) (*service.CommentPage, error) {
+					return nil, service.ErrPrivateAccount
+				},
+			},
+			wantCode:     http.StatusForbidden,
+			wantContains: []string{"承認されたフォロワーのみ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutCommentHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/timeline/10/comments"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("sessionId")
+			c.SetParamValues("10")
+			c.Set("user_id", uint(1))
+
+			if err := h.ListComments(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestWorkoutCommentHandler_CreateComment(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockWorkoutCommentService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】返信を作成して201を返すこと",
+			body: `{"body":"同感","parent_id":6}`,
+			mock: &mockWorkoutCommentService{
+				CreateCommentFunc: func(userID uint, sessionID uint, d service.CommentData) (*service.Comment, error) {
+					require.Equal(t, uint(1), userID)
+					require.Equal(t, "同感", d.Body)
+					require.Equal(t, uint(6), *d.ParentID)
+					return &service.Comment{ID: 8, SessionID: sessionID, UserID: userID, ParentID: d.ParentID, Body: d.Body, CreatedAt: commentTime, UpdatedAt: commentTime}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: []string{`"id":8`, `"parent_id":6`, `"body":"同感"`},
+		},
+		{
+			name: "【異常系】本文が不正な場合は ValidationError を返すこと",
+			body: `{"body":""}`,
+			mock: &mockWorkoutCommentService{
+				CreateCommentFunc: func(uint, uint, service.CommentData) (*service.Comment, error) {
+					return nil, service.ErrInvalidComment
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"ValidationError"`},
+		},
+		{
+			name: "【異常系】存在しないセッションは SessionNotFound を返すこと",
+			body: `{"body":"こんにちは"}`,
+			mock: &mockWorkoutCommentService{
+				CreateCommentFunc: func(uint, uint, service.CommentData) (*service.Comment, error) {
+					return nil, service.ErrSessionNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"SessionNotFound"`},
+		},
+		{
+			name: "【異常系】フォローしていない非公開アカウントのセッションには403を返すこと",
+			body: `{"body":"こんにちは"}`,
+			mock: &mockWorkoutCommentService{
+				CreateCommentFunc: func(uint, uint, service.CommentData) (*service.Comment, error) {
+					return nil, service.ErrPrivateAccount
+				},
+			},
+			wantCode:     http.StatusForbidden,
+			wantContains: []string{"承認されたフォロワーのみ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutCommentHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/timeline/10/comments", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("sessionId")
+			c.SetParamValues("10")
+			c.Set("user_id", uint(1))
+
+			if err := h.CreateComment(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestWorkoutCommentHandler_UpdateAndDelete(t *testing.T) {
+	newContext := func(e *echo.Echo, method string, commentID string, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/timeline/10/comments/"+commentID, bytes.NewBufferString(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("sessionId", "commentId")
+		c.SetParamValues("10", commentID)
+		c.Set("user_id", uint(1))
+		return c, rec
+	}
+
+	t.Run("【正常系】本文を変更できること", func(t *testing.T) {
+		e := newEchoForTest()
+		h := NewWorkoutCommentHandler(&mockWorkoutCommentService{
+			UpdateCommentFunc: func(userID uint, sessionID uint, commentID uint, body string) (*service.Comment, error) {
+				require.Equal(t, uint(6), commentID)
+				return &service.Comment{ID: commentID, SessionID: sessionID, UserID: userID, Body: body, CreatedAt: commentTime, UpdatedAt: commentTime}, nil
+			},
+		})
+		c, rec := newContext(e, http.MethodPut, "6", `{"body":"修正しました"}`)
+		if err := h.UpdateComment(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"body":"修正しました"`)
+	})
+
+	t.Run("【異常系】他人のコメントの変更は403を返すこと", func(t *testing.T) {
+		e := newEchoForTest()
+		h := NewWorkoutCommentHandler(&mockWorkoutCommentService{
+			UpdateCommentFunc: func(uint, uint, uint, string) (*service.Comment, error) {
+				return nil, service.ErrForbiddenComment
+			},
+		})
+		c, rec := newContext(e, http.MethodPut, "6", `{"body":"書き換え"}`)
+		if err := h.UpdateComment(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("【異常系】コメントIDが不正な場合は InvalidCommentID を返すこと", func(t *testing.T) {
+		e := newEchoForTest()
+		h := NewWorkoutCommentHandler(&mockWorkoutCommentService{})
+		c, rec := newContext(e, http.MethodDelete, "0", "")
+		if err := h.DeleteComment(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), `"code":"InvalidCommentID"`)
+	})
+
+	t.Run("【異常系】存在しないコメントの削除は CommentNotFound を返すこと", func(t *testing.T) {
+		e := newEchoForTest()
+		h := NewWorkoutCommentHandler(&mockWorkoutCommentService{
+			DeleteCommentFunc: func(uint, uint, uint) error { return service.ErrCommentNotFound },
+		})
+		c, rec := newContext(e, http.MethodDelete, "6", "")
+		if err := h.DeleteComment(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Contains(t, rec.Body.String(), `"code":"CommentNotFound"`)
+	})
+}
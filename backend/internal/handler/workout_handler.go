@@ -9,6 +9,7 @@ import (
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
 	"github.com/labstack/echo/v4"
 )
@@ -20,6 +21,7 @@ type WorkoutHandler interface {
 	UpdateWorkoutRecord(c echo.Context) error
 	DeleteWorkoutRecord(c echo.Context) error
 	GetWorkoutRecordsByExercise(c echo.Context) error
+	GetExerciseProgression(c echo.Context) error
 }
 
 type workoutHandler struct {
@@ -36,19 +38,36 @@ type CreateWorkoutRecordRequest struct {
 }
 
 type WorkoutSetRequest struct {
-	Set            int     `json:"set"`
-	Reps           int     `json:"reps"`
-	ExerciseWeight float64 `json:"exercise_weight"`
+	Set             int      `json:"set"`
+	Reps            int      `json:"reps"`
+	ExerciseWeight  float64  `json:"exercise_weight"`
+	SetType         string   `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int     `json:"rir"`
+	RestSeconds     *int     `json:"rest_seconds"`
+	Tempo           string   `json:"tempo"`
+	Note            string   `json:"note"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
 }
 
 type workoutSetDTO struct {
-	Set            int     `json:"set"`
-	Reps           int     `json:"reps"`
-	ExerciseWeight float64 `json:"exercise_weight"`
+	Set             int      `json:"set"`
+	Reps            int      `json:"reps"`
+	ExerciseWeight  float64  `json:"exercise_weight"`
+	SetType         string   `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int     `json:"rir"`
+	RestSeconds     *int     `json:"rest_seconds"`
+	Tempo           string   `json:"tempo"`
+	Note            string   `json:"note"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
 }
 
 type workoutRecordDTO struct {
 	ID           uint            `json:"id"`
+	SessionID    *uint           `json:"session_id"`
 	ExerciseName string          `json:"exercise_name"`
 	BodyWeight   float64         `json:"body_weight"`
 	TrainedOn    string          `json:"trained_on"`
@@ -56,18 +75,90 @@ type workoutRecordDTO struct {
 }
 
 type ExerciseSingleSetResponse struct {
-	RecordID       uint    `json:"record_id"`
-	TrainedOn      string  `json:"trained_on"`
-	Set            int     `json:"set"`
-	Reps           int     `json:"reps"`
-	ExerciseWeight float64 `json:"exercise_weight"`
-	BodyWeight     float64 `json:"body_weight"`
+	RecordID        uint     `json:"record_id"`
+	TrainedOn       string   `json:"trained_on"`
+	Set             int      `json:"set"`
+	Reps            int      `json:"reps"`
+	ExerciseWeight  float64  `json:"exercise_weight"`
+	BodyWeight      float64  `json:"body_weight"`
+	SetType         string   `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int     `json:"rir"`
+	RestSeconds     *int     `json:"rest_seconds"`
+	Tempo           string   `json:"tempo"`
+	Note            string   `json:"note"`
+	MeasurementKind string   `json:"measurement_kind"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	Volume          float64  `json:"volume"`
+}
+
+type topSetDTO struct {
+	Weight    float64 `json:"weight"`
+	Reps      int     `json:"reps"`
+	TrainedOn string  `json:"trained_on"`
+}
+
+type progressionPointDTO struct {
+	PeriodStart  string     `json:"period_start"`
+	BestE1RM     float64    `json:"best_e1rm"`
+	TopSet       *topSetDTO `json:"top_set"`
+	TotalVolume  float64    `json:"total_volume"`
+	TotalReps    int        `json:"total_reps"`
+	TrainingDays int        `json:"training_days"`
+}
+
+type ExerciseProgressionResponse struct {
+	ExerciseID uint                  `json:"exercise_id"`
+	Formula    string                `json:"formula"`
+	Bucket     string                `json:"bucket"`
+	Points     []progressionPointDTO `json:"points"`
 }
 
 func NewWorkoutHandler(svc service.WorkoutService) WorkoutHandler {
 	return &workoutHandler{svc: svc}
 }
 
+func toWorkoutSetData(reqs []WorkoutSetRequest) []service.WorkoutSetData {
+	var sets []service.WorkoutSetData
+	for _, setReq := range reqs {
+		sets = append(sets, service.WorkoutSetData{
+			SetNo:           setReq.Set,
+			Reps:            setReq.Reps,
+			ExerciseWeight:  setReq.ExerciseWeight,
+			SetType:         models.SetType(setReq.SetType),
+			RPE:             setReq.RPE,
+			RIR:             setReq.RIR,
+			RestSeconds:     setReq.RestSeconds,
+			Tempo:           setReq.Tempo,
+			Note:            setReq.Note,
+			DurationSeconds: setReq.DurationSeconds,
+			DistanceMeters:  setReq.DistanceMeters,
+		})
+	}
+	return sets
+}
+
+func toWorkoutSetDTOs(sets []models.WorkoutSet) []workoutSetDTO {
+	out := make([]workoutSetDTO, 0, len(sets))
+	for _, s := range sets {
+		out = append(out, workoutSetDTO{
+			Set:             s.SetNo,
+			Reps:            s.Reps,
+			ExerciseWeight:  s.ExerciseWeight,
+			SetType:         string(s.SetType),
+			RPE:             s.RPE,
+			RIR:             s.RIR,
+			RestSeconds:     s.RestSeconds,
+			Tempo:           s.Tempo,
+			Note:            s.Note,
+			DurationSeconds: s.DurationSeconds,
+			DistanceMeters:  s.DistanceMeters,
+		})
+	}
+	return out
+}
+
 func (h *workoutHandler) CreateWorkoutRecord(c echo.Context) error {
 	ctx := c.Request().Context()
 	var req CreateWorkoutRecordRequest
@@ -84,14 +175,7 @@ func (h *workoutHandler) CreateWorkoutRecord(c echo.Context) error {
 	}
 	trainedOn = time.Date(trainedOn.Year(), trainedOn.Month(), trainedOn.Day(), 0, 0, 0, 0, time.UTC)
 
-	var sets []service.WorkoutSetData
-	for _, setReq := range req.Sets {
-		sets = append(sets, service.WorkoutSetData{
-			SetNo:          setReq.Set,
-			Reps:           setReq.Reps,
-			ExerciseWeight: setReq.ExerciseWeight,
-		})
-	}
+	sets := toWorkoutSetData(req.Sets)
 
 	record, err := h.svc.CreateWorkoutRecord(userID, req.BodyWeight, req.ExerciseID, trainedOn, sets, req.IsPublic, req.Comment)
 	if err != nil {
@@ -101,6 +185,8 @@ func (h *workoutHandler) CreateWorkoutRecord(c echo.Context) error {
 			return httpx.BadRequest("ValidationError", "セット内容が不正です", err)
 		case errors.Is(err, service.ErrExerciseNotFound):
 			return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+		case errors.Is(err, service.ErrExerciseArchived):
+			return httpx.BadRequest("ExerciseArchived", "アーカイブ済みの種目は記録できません", err)
 		default:
 			return httpx.Internal("システムエラーが発生しました", err)
 		}
@@ -109,11 +195,13 @@ func (h *workoutHandler) CreateWorkoutRecord(c echo.Context) error {
 	slog.InfoContext(ctx, "workout_created",
 		"record_id", record.ID,
 		"exercise_id", req.ExerciseID,
+		"personal_records", len(record.PersonalRecords),
 	)
 
 	return c.JSON(http.StatusCreated, map[string]interface{}{
-		"message":   "Workout record created successfully",
-		"record_id": record.ID,
+		"message":          "Workout record created successfully",
+		"record_id":        record.ID,
+		"personal_records": toPersonalRecordDTOs(record.PersonalRecords),
 	})
 }
 
@@ -149,16 +237,10 @@ func (h *workoutHandler) GetWorkoutRecordsByDate(c echo.Context) error {
 		if name == "" {
 			name = "Unknown"
 		}
-		sets := make([]workoutSetDTO, 0, len(r.Sets))
-		for _, s := range r.Sets {
-			sets = append(sets, workoutSetDTO{
-				Set:            s.SetNo,
-				Reps:           s.Reps,
-				ExerciseWeight: s.ExerciseWeight,
-			})
-		}
+		sets := toWorkoutSetDTOs(r.Sets)
 		out = append(out, workoutRecordDTO{
 			ID:           r.ID,
+			SessionID:    r.SessionID,
 			ExerciseName: name,
 			BodyWeight:   r.BodyWeight,
 			TrainedOn:    r.TrainedOn.Format("2006-01-02"),
@@ -229,14 +311,7 @@ func (h *workoutHandler) UpdateWorkoutRecord(c echo.Context) error {
 	}
 	trainedOn = time.Date(trainedOn.Year(), trainedOn.Month(), trainedOn.Day(), 0, 0, 0, 0, time.UTC)
 
-	var sets []service.WorkoutSetData
-	for _, setReq := range req.Sets {
-		sets = append(sets, service.WorkoutSetData{
-			SetNo:          setReq.Set,
-			Reps:           setReq.Reps,
-			ExerciseWeight: setReq.ExerciseWeight,
-		})
-	}
+	sets := toWorkoutSetData(req.Sets)
 
 	record, err := h.svc.UpdateWorkoutRecord(userID, uint(recordID), req.BodyWeight, req.ExerciseID, trainedOn, sets)
 	if err != nil {
@@ -246,6 +321,8 @@ func (h *workoutHandler) UpdateWorkoutRecord(c echo.Context) error {
 			return httpx.BadRequest("ValidationError", "セット内容が不正です", err)
 		case errors.Is(err, service.ErrExerciseNotFound):
 			return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+		case errors.Is(err, service.ErrExerciseArchived):
+			return httpx.BadRequest("ExerciseArchived", "アーカイブ済みの種目は記録できません", err)
 		case errors.Is(err, service.ErrRecordNotFound):
 			return httpx.NotFound("RecordNotFound", "指定の記録が見つかりません", err)
 		default:
@@ -315,12 +392,22 @@ func (h *workoutHandler) GetWorkoutRecordsByExercise(c echo.Context) error {
 	out := make([]ExerciseSingleSetResponse, 0, len(rows))
 	for _, r := range rows {
 		out = append(out, ExerciseSingleSetResponse{
-			RecordID:       r.RecordID,
-			TrainedOn:      r.TrainedOn.In(loc).Format("2006-01-02"),
-			Set:            r.SetNo,
-			Reps:           r.Reps,
-			ExerciseWeight: r.ExerciseWeight,
-			BodyWeight:     r.BodyWeight,
+			RecordID:        r.RecordID,
+			TrainedOn:       r.TrainedOn.In(loc).Format("2006-01-02"),
+			Set:             r.SetNo,
+			Reps:            r.Reps,
+			ExerciseWeight:  r.ExerciseWeight,
+			BodyWeight:      r.BodyWeight,
+			SetType:         string(r.SetType),
+			RPE:             r.RPE,
+			RIR:             r.RIR,
+			RestSeconds:     r.RestSeconds,
+			Tempo:           r.Tempo,
+			Note:            r.Note,
+			MeasurementKind: string(r.MeasurementKind),
+			DurationSeconds: r.DurationSeconds,
+			DistanceMeters:  r.DistanceMeters,
+			Volume:          r.Volume,
 		})
 	}
 
@@ -331,3 +418,90 @@ func (h *workoutHandler) GetWorkoutRecordsByExercise(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, out)
 }
+
+// parseDateQuery は YYYY-MM-DD のクエリパラメータを UTC の日付に変換する。未指定なら nil を返す。
+func parseDateQuery(c echo.Context, name string) (*time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	d, err := time.ParseInLocation("2006-01-02", v, loc)
+	if err != nil {
+		return nil, httpx.BadRequest("InvalidDate", name+" の形式が不正です（YYYY-MM-DD）", err)
+	}
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return &d, nil
+}
+
+// todayJST は日本時間の今日を UTC の日付として返す。
+func todayJST() time.Time {
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (h *workoutHandler) GetExerciseProgression(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	eid, err := strconv.ParseUint(c.Param("exerciseId"), 10, 32)
+	if err != nil || eid == 0 {
+		return httpx.BadRequest("InvalidExerciseID", "種目IDが不正です", err)
+	}
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return err
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return err
+	}
+
+	query := service.ProgressionQuery{
+		Formula: models.OneRepMaxFormula(c.QueryParam("formula")),
+		Bucket:  service.ProgressionBucket(c.QueryParam("bucket")),
+		From:    from,
+		To:      to,
+	}
+	progression, err := h.svc.GetExerciseProgression(userID, uint(eid), query)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidProgressionQuery) {
+			return httpx.BadRequest("InvalidQuery", "formula は epley/brzycki/lombardi、bucket は day/week/month、from は to 以前を指定してください", err)
+		}
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	res := ExerciseProgressionResponse{
+		ExerciseID: uint(eid),
+		Formula:    string(progression.Formula),
+		Bucket:     string(progression.Bucket),
+		Points:     make([]progressionPointDTO, 0, len(progression.Points)),
+	}
+	for _, p := range progression.Points {
+		dto := progressionPointDTO{
+			PeriodStart:  p.PeriodStart.Format("2006-01-02"),
+			BestE1RM:     p.BestE1RM,
+			TotalVolume:  p.TotalVolume,
+			TotalReps:    p.TotalReps,
+			TrainingDays: p.TrainingDays,
+		}
+		if p.TopSet != nil {
+			dto.TopSet = &topSetDTO{
+				Weight:    p.TopSet.Weight,
+				Reps:      p.TopSet.Reps,
+				TrainedOn: p.TopSet.TrainedOn.Format("2006-01-02"),
+			}
+		}
+		res.Points = append(res.Points, dto)
+	}
+
+	slog.InfoContext(ctx, "workout_exercise_progression_fetched",
+		"exercise_id", eid,
+		"formula", res.Formula,
+		"bucket", res.Bucket,
+		"count", len(res.Points),
+	)
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -32,6 +32,7 @@ type mockWorkoutService struct {
 	UpdateWorkoutRecordFunc       func(userID uint, recordID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []service.WorkoutSetData) (*models.WorkoutRecord, error)
 	DeleteWorkoutRecordFunc       func(userID uint, recordID uint) error
 	GetWorkoutRecordsByExerciseFn func(userID uint, exerciseID uint) ([]service.FlatSet, error)
+	GetExerciseProgressionFn      func(userID uint, exerciseID uint, query service.ProgressionQuery) (*service.ExerciseProgression, error)
 }
 
 func (m *mockWorkoutService) CreateWorkoutRecord(a uint, b float64, c uint, d time.Time, e []service.WorkoutSetData, f bool, g string) (*models.WorkoutRecord, error) {
@@ -52,6 +53,9 @@ func (m *mockWorkoutService) DeleteWorkoutRecord(a uint, id uint) error {
 func (m *mockWorkoutService) GetWorkoutRecordsByExercise(a uint, ex uint) ([]service.FlatSet, error) {
 	return m.GetWorkoutRecordsByExerciseFn(a, ex)
 }
+func (m *mockWorkoutService) GetExerciseProgression(a uint, ex uint, q service.ProgressionQuery) (*service.ExerciseProgression, error) {
+	return m.GetExerciseProgressionFn(a, ex, q)
+}
 
 func TestWorkoutHandler_CreateWorkoutRecord(t *testing.T) {
 	tests := []struct {
@@ -70,7 +74,40 @@ func TestWorkoutHandler_CreateWorkoutRecord(t *testing.T) {
 				},
 			},
 			wantCode:     http.StatusCreated,
-			wantContains: `"record_id":123`,
+			wantContains: `"personal_records":[],"record_id":123`,
+		},
+		{
+			name: "【正常系】セットのメタ情報がサービスに渡されること",
+			body: `{"body_weight":70.5,"exercise_id":2,"trained_on":"2025-10-01","sets":[{"set":1,"reps":5,"exercise_weight":80,"set_type":"amrap","rpe":9.5,"rest_seconds":120,"tempo":"2-0-1-0","note":"ラスト"}]}`,
+			mock: &mockWorkoutService{
+				CreateWorkoutRecordFunc: func(userID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []service.WorkoutSetData, isPublic bool, comment string) (*models.WorkoutRecord, error) {
+					if len(sets) != 1 || sets[0].SetType != models.SetTypeAMRAP || sets[0].RPE == nil || *sets[0].RPE != 9.5 ||
+						sets[0].RIR != nil || sets[0].RestSeconds == nil || *sets[0].RestSeconds != 120 ||
+						sets[0].Tempo != "2-0-1-0" || sets[0].Note != "ラスト" {
+						return nil, errors.New("unexpected sets")
+					}
+					return &models.WorkoutRecord{Model: gorm.Model{ID: 124}}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: `"record_id":124`,
+		},
+		{
+			name: "【正常系】更新した自己ベストがレスポンスに含まれること",
+			body: `{"body_weight":70.5,"exercise_id":2,"trained_on":"2025-10-01","sets":[{"set":1,"reps":1,"exercise_weight":100}]}`,
+			mock: &mockWorkoutService{
+				CreateWorkoutRecordFunc: func(userID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []service.WorkoutSetData, isPublic bool, comment string) (*models.WorkoutRecord, error) {
+					return &models.WorkoutRecord{
+						Model: gorm.Model{ID: 125},
+						PersonalRecords: []models.PersonalRecord{{
+							ID: 9, ExerciseID: 2, Type: models.PRMaxWeight, Value: 100, Weight: 100, Reps: 1,
+							WorkoutRecordID: 125, TrainedOn: trainedOn, Exercise: models.Exercise{Name: "ベンチプレス"},
+						}},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: `"personal_records":[{"id":9,"exercise_id":2,"exercise_name":"ベンチプレス","type":"max_weight","value":100,"weight":100,"reps":1,"record_id":125,"trained_on":"2025-10-01"}]`,
 		},
 		{
 			name: "【異常系】リクエストの形式が不正な場合は InvalidBody エラーを返すこと",
@@ -522,6 +559,34 @@ func TestWorkoutHandler_GetWorkoutRecordsByExercise(t *testing.T) {
 			wantCode:     http.StatusOK,
 			wantContains: `"record_id":1`,
 		},
+		{
+			name:    "【正常系】セットのメタ情報がレスポンスに含まれること",
+			pathVal: "2",
+			mock: &mockWorkoutService{
+				GetWorkoutRecordsByExerciseFn: func(uint, uint) ([]service.FlatSet, error) {
+					rir := 2
+					return []service.FlatSet{
+						{RecordID: 1, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), SetNo: 1, Reps: 8, ExerciseWeight: 60, BodyWeight: 70, SetType: models.SetTypeDrop, RIR: &rir, Tempo: "3-1-X-0"},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"set_type":"drop","rpe":null,"rir":2,"rest_seconds":null,"tempo":"3-1-X-0","note":""`,
+		},
+		{
+			name:    "【正常系】時間・距離種目の値とボリュームがレスポンスに含まれること",
+			pathVal: "4",
+			mock: &mockWorkoutService{
+				GetWorkoutRecordsByExerciseFn: func(uint, uint) ([]service.FlatSet, error) {
+					sec, dist := 1500, 5000.0
+					return []service.FlatSet{
+						{RecordID: 3, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), SetNo: 1, MeasurementKind: models.MeasurementDistanceDuration, DurationSeconds: &sec, DistanceMeters: &dist},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"measurement_kind":"distance_duration","duration_seconds":1500,"distance_meters":5000,"volume":0`,
+		},
 		{
 			name:         "【異常系】種目ID が指定されていない場合は MissingExerciseID エラーを返すこと",
 			pathVal:      "",
@@ -577,3 +642,120 @@ func TestWorkoutHandler_GetWorkoutRecordsByExercise(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutHandler_GetExerciseProgression(t *testing.T) {
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		exerciseID   string
+		query        string
+		mock         *mockWorkoutService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:       "【正常系】クエリがサービスに渡され、期間ごとの集計を返すこと",
+			exerciseID: "2",
+			query:      "?formula=brzycki&bucket=week&from=2025-10-01&to=2025-10-31",
+			mock: &mockWorkoutService{
+				GetExerciseProgressionFn: func(userID uint, exerciseID uint, q service.ProgressionQuery) (*service.ExerciseProgression, error) {
+					if exerciseID != 2 || q.Formula != models.OneRepMaxBrzycki || q.Bucket != service.BucketWeek ||
+						q.From == nil || !q.From.Equal(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)) ||
+						q.To == nil || !q.To.Equal(time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)) {
+						return nil, errors.New("unexpected query")
+					}
+					return &service.ExerciseProgression{
+						Formula: q.Formula,
+						Bucket:  q.Bucket,
+						Points: []service.ProgressionPoint{{
+							PeriodStart:  day,
+							BestE1RM:     90,
+							TopSet:       &service.TopSet{Weight: 80, Reps: 5, TrainedOn: day.AddDate(0, 0, 2)},
+							TotalVolume:  1200,
+							TotalReps:    15,
+							TrainingDays: 2,
+						}},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `{"exercise_id":2,"formula":"brzycki","bucket":"week","points":[{"period_start":"2025-10-06","best_e1rm":90,"top_set":{"weight":80,"reps":5,"trained_on":"2025-10-08"},"total_volume":1200,"total_reps":15,"training_days":2}]}`,
+		},
+		{
+			name:       "【正常系】トップセットが無い期間は top_set が null になること",
+			exerciseID: "2",
+			mock: &mockWorkoutService{
+				GetExerciseProgressionFn: func(uint, uint, service.ProgressionQuery) (*service.ExerciseProgression, error) {
+					return &service.ExerciseProgression{
+						Formula: models.OneRepMaxEpley,
+						Bucket:  service.BucketDay,
+						Points:  []service.ProgressionPoint{{PeriodStart: day}},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"top_set":null`,
+		},
+		{
+			name:         "【異常系】種目IDが不正な場合は InvalidExerciseID を返すこと",
+			exerciseID:   "abc",
+			mock:         &mockWorkoutService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidExerciseID"`,
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate を返すこと",
+			exerciseID:   "2",
+			query:        "?from=2025/10/01",
+			mock:         &mockWorkoutService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidDate"`,
+		},
+		{
+			name:       "【異常系】計算式・集計単位が不正な場合は InvalidQuery を返すこと",
+			exerciseID: "2",
+			query:      "?formula=unknown",
+			mock: &mockWorkoutService{
+				GetExerciseProgressionFn: func(uint, uint, service.ProgressionQuery) (*service.ExerciseProgression, error) {
+					return nil, service.ErrInvalidProgressionQuery
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:       "【異常系】システムエラーが発生した場合は InternalError を返すこと",
+			exerciseID: "2",
+			mock: &mockWorkoutService{
+				GetExerciseProgressionFn: func(uint, uint, service.ProgressionQuery) (*service.ExerciseProgression, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/training_records/exercises/"+tt.exerciseID+"/progression"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+			c.SetParamNames("exerciseId")
+			c.SetParamValues(tt.exerciseID)
+
+			err := h.GetExerciseProgression(c)
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
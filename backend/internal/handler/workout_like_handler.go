@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
@@ -15,6 +16,7 @@ import (
 type WorkoutLikeHandler interface {
 	Like(c echo.Context) error
 	Unlike(c echo.Context) error
+	ListLikers(c echo.Context) error
 }
 
 type workoutLikeHandler struct {
@@ -26,15 +28,27 @@ func NewWorkoutLikeHandler(svc service.WorkoutLikeService) WorkoutLikeHandler {
 }
 
 type LikeResponse struct {
-	RecordID uint `json:"record_id"`
-	Liked    bool `json:"liked"`
+	SessionID uint `json:"session_id"`
+	Liked     bool `json:"liked"`
 }
 
-func parseRecordID(c echo.Context) (uint, error) {
-	raw := c.Param("recordId")
+type likeUserDTO struct {
+	UserID  uint   `json:"user_id"`
+	Email   string `json:"email"`
+	LikedAt string `json:"liked_at"`
+}
+
+type LikersResponse struct {
+	SessionID uint          `json:"session_id"`
+	LikeCount int           `json:"like_count"`
+	Users     []likeUserDTO `json:"users"`
+}
+
+func parseSessionID(c echo.Context) (uint, error) {
+	raw := c.Param("sessionId")
 	id64, err := strconv.ParseUint(raw, 10, 32)
 	if err != nil || id64 == 0 {
-		return 0, httpx.BadRequest("InvalidRecordID", "record_id が不正です", err)
+		return 0, httpx.BadRequest("InvalidSessionID", "session_id が不正です", err)
 	}
 	return uint(id64), nil
 }
@@ -42,63 +56,112 @@ func parseRecordID(c echo.Context) (uint, error) {
 func (h *workoutLikeHandler) Like(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	recordID, err := parseRecordID(c)
+	sessionID, err := parseSessionID(c)
 	if err != nil {
 		return err
 	}
 
 	userID := middleware.GetUserID(c)
 
-	if err := h.svc.Like(userID, recordID); err != nil {
+	if err := h.svc.Like(userID, sessionID); err != nil {
 		switch {
-		case errors.Is(err, service.ErrRecordNotFound):
-			return httpx.NotFound("RecordNotFound", "対象レコードが存在しません", err)
+		case errors.Is(err, service.ErrSessionNotFound):
+			return httpx.NotFound("SessionNotFound", "対象のトレーニングが存在しません", err)
 		case errors.Is(err, service.ErrForbiddenPrivateRecord):
 			return httpx.Forbidden("非公開の投稿にはいいねできません", err)
+		case errors.Is(err, service.ErrPrivateAccount):
+			return httpx.Forbidden("非公開アカウントの投稿は承認されたフォロワーのみいいねできます", err)
 		default:
 			return httpx.Internal("システムエラーが発生しました", err)
 		}
 	}
 
 	slog.InfoContext(ctx, "like_created",
-		"record_id", recordID,
+		"session_id", sessionID,
 		"user_id", userID,
 	)
 
 	return c.JSON(http.StatusOK, LikeResponse{
-		RecordID: recordID,
-		Liked:    true,
+		SessionID: sessionID,
+		Liked:     true,
 	})
 }
 
 func (h *workoutLikeHandler) Unlike(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	recordID, err := parseRecordID(c)
+	sessionID, err := parseSessionID(c)
 	if err != nil {
 		return err
 	}
 
 	userID := middleware.GetUserID(c)
 
-	if err := h.svc.Unlike(userID, recordID); err != nil {
+	if err := h.svc.Unlike(userID, sessionID); err != nil {
 		switch {
-		case errors.Is(err, service.ErrRecordNotFound):
-			return httpx.NotFound("RecordNotFound", "対象レコードが存在しません", err)
+		case errors.Is(err, service.ErrSessionNotFound):
+			return httpx.NotFound("SessionNotFound", "対象のトレーニングが存在しません", err)
 		case errors.Is(err, service.ErrForbiddenPrivateRecord):
 			return httpx.Forbidden("非公開の投稿には解除できません", err)
+		case errors.Is(err, service.ErrPrivateAccount):
+			return httpx.Forbidden("非公開アカウントの投稿は承認されたフォロワーのみいいねできます", err)
 		default:
 			return httpx.Internal("システムエラーが発生しました", err)
 		}
 	}
 
 	slog.InfoContext(ctx, "like_deleted",
-		"record_id", recordID,
+		"session_id", sessionID,
 		"user_id", userID,
 	)
 
 	return c.JSON(http.StatusOK, LikeResponse{
-		RecordID: recordID,
-		Liked:    false,
+		SessionID: sessionID,
+		Liked:     false,
 	})
 }
+
+// ListLikers は公開セッションのいいね数と、いいねしたユーザーを新しい順に返す。
+func (h *workoutLikeHandler) ListLikers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sessionID, err := parseSessionID(c)
+	if err != nil {
+		return err
+	}
+
+	userID := middleware.GetUserID(c)
+
+	likes, err := h.svc.ListLikers(userID, sessionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound):
+			return httpx.NotFound("SessionNotFound", "対象のトレーニングが存在しません", err)
+		case errors.Is(err, service.ErrForbiddenPrivateRecord):
+			return httpx.Forbidden("非公開の投稿のいいねは表示できません", err)
+		case errors.Is(err, service.ErrPrivateAccount):
+			return httpx.Forbidden("非公開アカウントの投稿のいいねは承認されたフォロワーのみ閲覧できます", err)
+		default:
+			return httpx.Internal("システムエラーが発生しました", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "likers_fetched",
+		"session_id", sessionID,
+		"like_count", likes.LikeCount,
+	)
+
+	res := LikersResponse{
+		SessionID: likes.SessionID,
+		LikeCount: likes.LikeCount,
+		Users:     make([]likeUserDTO, 0, len(likes.Users)),
+	}
+	for _, u := range likes.Users {
+		res.Users = append(res.Users, likeUserDTO{
+			UserID:  u.UserID,
+			Email:   u.Email,
+			LikedAt: u.LikedAt.Format(time.RFC3339),
+		})
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
 	"github.com/RintaroNasu/muscle_diary_app/internal/service"
@@ -16,27 +17,32 @@ import (
 )
 
 type fakeWorkoutLikeService struct {
-	likeFunc   func(userID uint, recordID uint) error
-	unlikeFunc func(userID uint, recordID uint) error
+	likeFunc   func(userID uint, sessionID uint) error
+	unlikeFunc func(userID uint, sessionID uint) error
+	likersFunc func(userID uint, sessionID uint) (*service.SessionLikes, error)
 
 	likeCalled   int
 	unlikeCalled int
 }
 
-func (f *fakeWorkoutLikeService) Like(userID uint, recordID uint) error {
+func (f *fakeWorkoutLikeService) Like(userID uint, sessionID uint) error {
 	f.likeCalled++
 	if f.likeFunc == nil {
 		return nil
 	}
-	return f.likeFunc(userID, recordID)
+	return f.likeFunc(userID, sessionID)
 }
 
-func (f *fakeWorkoutLikeService) Unlike(userID uint, recordID uint) error {
+func (f *fakeWorkoutLikeService) Unlike(userID uint, sessionID uint) error {
 	f.unlikeCalled++
 	if f.unlikeFunc == nil {
 		return nil
 	}
-	return f.unlikeFunc(userID, recordID)
+	return f.unlikeFunc(userID, sessionID)
+}
+
+func (f *fakeWorkoutLikeService) ListLikers(userID uint, sessionID uint) (*service.SessionLikes, error) {
+	return f.likersFunc(userID, sessionID)
 }
 
 func TestNewWorkoutLikeHandler(t *testing.T) {
@@ -52,66 +58,66 @@ func TestWorkoutLikeHandler_Like(t *testing.T) {
 	e.HTTPErrorHandler = httpx.HTTPErrorHandler(logger)
 
 	tests := []struct {
-		name        string
-		recordParam string
-		mock        fakeWorkoutLikeService
-		wantStatus  int
-		wantBodyHas string
-		wantLiked   *bool
-		wantCalled  int
+		name         string
+		sessionParam string
+		mock         fakeWorkoutLikeService
+		wantStatus   int
+		wantBodyHas  string
+		wantLiked    *bool
+		wantCalled   int
 	}{
 		{
-			name:        "【正常系】いいねできること",
-			recordParam: "10",
+			name:         "【正常系】いいねできること",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error {
+				likeFunc: func(userID uint, sessionID uint) error {
 					require.Equal(t, uint(1), userID)
-					require.Equal(t, uint(10), recordID)
+					require.Equal(t, uint(10), sessionID)
 					return nil
 				},
 			},
 			wantStatus:  http.StatusOK,
-			wantBodyHas: `"record_id":10`,
+			wantBodyHas: `"session_id":10`,
 			wantLiked:   func() *bool { b := true; return &b }(),
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】record_id が数値でない場合は400(InvalidRecordID)",
-			recordParam: "abc",
+			name:         "【異常系】session_id が数値でない場合は400(InvalidSessionID)",
+			sessionParam: "abc",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error { return nil },
+				likeFunc: func(userID uint, sessionID uint) error { return nil },
 			},
 			wantStatus:  http.StatusBadRequest,
-			wantBodyHas: `"InvalidRecordID"`,
+			wantBodyHas: `"InvalidSessionID"`,
 			wantCalled:  0,
 		},
 		{
-			name:        "【異常系】record_id が 0 の場合は400(InvalidRecordID)",
-			recordParam: "0",
+			name:         "【異常系】session_id が 0 の場合は400(InvalidSessionID)",
+			sessionParam: "0",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error { return nil },
+				likeFunc: func(userID uint, sessionID uint) error { return nil },
 			},
 			wantStatus:  http.StatusBadRequest,
-			wantBodyHas: `"InvalidRecordID"`,
+			wantBodyHas: `"InvalidSessionID"`,
 			wantCalled:  0,
 		},
 		{
-			name:        "【異常系】存在しないレコードは404(RecordNotFound)",
-			recordParam: "999999",
+			name:         "【異常系】存在しないセッションは404(SessionNotFound)",
+			sessionParam: "999999",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error {
-					return service.ErrRecordNotFound
+				likeFunc: func(userID uint, sessionID uint) error {
+					return service.ErrSessionNotFound
 				},
 			},
 			wantStatus:  http.StatusNotFound,
-			wantBodyHas: `"RecordNotFound"`,
+			wantBodyHas: `"SessionNotFound"`,
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】非公開レコードは403",
-			recordParam: "10",
+			name:         "【異常系】非公開レコードは403",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error {
+				likeFunc: func(userID uint, sessionID uint) error {
 					return service.ErrForbiddenPrivateRecord
 				},
 			},
@@ -120,10 +126,10 @@ func TestWorkoutLikeHandler_Like(t *testing.T) {
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】想定外エラーは500(InternalError)",
-			recordParam: "10",
+			name:         "【異常系】想定外エラーは500(InternalError)",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				likeFunc: func(userID uint, recordID uint) error {
+				likeFunc: func(userID uint, sessionID uint) error {
 					return errors.New("db down")
 				},
 			},
@@ -136,12 +142,12 @@ func TestWorkoutLikeHandler_Like(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodPost, "/timeline/:recordId/like", nil)
+			req := httptest.NewRequest(http.MethodPost, "/timeline/:sessionId/like", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			c.SetParamNames("recordId")
-			c.SetParamValues(tt.recordParam)
+			c.SetParamNames("sessionId")
+			c.SetParamValues(tt.sessionParam)
 
 			c.Set("user_id", uint(1))
 
@@ -159,7 +165,7 @@ func TestWorkoutLikeHandler_Like(t *testing.T) {
 			if tt.wantStatus == http.StatusOK && tt.wantLiked != nil {
 				var res LikeResponse
 				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
-				require.Equal(t, uint(10), res.RecordID)
+				require.Equal(t, uint(10), res.SessionID)
 				require.Equal(t, *tt.wantLiked, res.Liked)
 			}
 		})
@@ -172,56 +178,56 @@ func TestWorkoutLikeHandler_Unlike(t *testing.T) {
 	e.HTTPErrorHandler = httpx.HTTPErrorHandler(logger)
 
 	tests := []struct {
-		name        string
-		recordParam string
-		mock        fakeWorkoutLikeService
-		wantStatus  int
-		wantBodyHas string
-		wantLiked   *bool
-		wantCalled  int
+		name         string
+		sessionParam string
+		mock         fakeWorkoutLikeService
+		wantStatus   int
+		wantBodyHas  string
+		wantLiked    *bool
+		wantCalled   int
 	}{
 		{
-			name:        "【正常系】いいね解除できること",
-			recordParam: "10",
+			name:         "【正常系】いいね解除できること",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				unlikeFunc: func(userID uint, recordID uint) error {
+				unlikeFunc: func(userID uint, sessionID uint) error {
 					require.Equal(t, uint(1), userID)
-					require.Equal(t, uint(10), recordID)
+					require.Equal(t, uint(10), sessionID)
 					return nil
 				},
 			},
 			wantStatus:  http.StatusOK,
-			wantBodyHas: `"record_id":10`,
+			wantBodyHas: `"session_id":10`,
 			wantLiked:   func() *bool { b := false; return &b }(),
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】record_id が不正なら400(InvalidRecordID)",
-			recordParam: "abc",
+			name:         "【異常系】session_id が不正なら400(InvalidSessionID)",
+			sessionParam: "abc",
 			mock: fakeWorkoutLikeService{
-				unlikeFunc: func(userID uint, recordID uint) error { return nil },
+				unlikeFunc: func(userID uint, sessionID uint) error { return nil },
 			},
 			wantStatus:  http.StatusBadRequest,
-			wantBodyHas: `"InvalidRecordID"`,
+			wantBodyHas: `"InvalidSessionID"`,
 			wantCalled:  0,
 		},
 		{
-			name:        "【異常系】存在しないレコードは404(RecordNotFound)",
-			recordParam: "999999",
+			name:         "【異常系】存在しないセッションは404(SessionNotFound)",
+			sessionParam: "999999",
 			mock: fakeWorkoutLikeService{
-				unlikeFunc: func(userID uint, recordID uint) error {
-					return service.ErrRecordNotFound
+				unlikeFunc: func(userID uint, sessionID uint) error {
+					return service.ErrSessionNotFound
 				},
 			},
 			wantStatus:  http.StatusNotFound,
-			wantBodyHas: `"RecordNotFound"`,
+			wantBodyHas: `"SessionNotFound"`,
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】非公開レコードは403",
-			recordParam: "10",
+			name:         "【異常系】非公開レコードは403",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				unlikeFunc: func(userID uint, recordID uint) error {
+				unlikeFunc: func(userID uint, sessionID uint) error {
 					return service.ErrForbiddenPrivateRecord
 				},
 			},
@@ -230,10 +236,10 @@ func TestWorkoutLikeHandler_Unlike(t *testing.T) {
 			wantCalled:  1,
 		},
 		{
-			name:        "【異常系】想定外エラーは500(InternalError)",
-			recordParam: "10",
+			name:         "【異常系】想定外エラーは500(InternalError)",
+			sessionParam: "10",
 			mock: fakeWorkoutLikeService{
-				unlikeFunc: func(userID uint, recordID uint) error {
+				unlikeFunc: func(userID uint, sessionID uint) error {
 					return errors.New("db down")
 				},
 			},
@@ -246,12 +252,12 @@ func TestWorkoutLikeHandler_Unlike(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodDelete, "/timeline/:recordId/like", nil)
+			req := httptest.NewRequest(http.MethodDelete, "/timeline/:sessionId/like", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			c.SetParamNames("recordId")
-			c.SetParamValues(tt.recordParam)
+			c.SetParamNames("sessionId")
+			c.SetParamValues(tt.sessionParam)
 
 			c.Set("user_id", uint(1))
 
@@ -269,9 +275,106 @@ func TestWorkoutLikeHandler_Unlike(t *testing.T) {
 			if tt.wantStatus == http.StatusOK && tt.wantLiked != nil {
 				var res LikeResponse
 				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
-				require.Equal(t, uint(10), res.RecordID)
+				require.Equal(t, uint(10), res.SessionID)
 				require.Equal(t, *tt.wantLiked, res.Liked)
 			}
 		})
 	}
 }
+
+func TestWorkoutLikeHandler_ListLikers(t *testing.T) {
+	tests := []struct {
+		name         string
+		sessionParam string
+		mock         fakeWorkoutLikeService
+		wantStatus   int
+		wantContains []string
+	}{
+		{
+			name:         "【正常系】いいね数といいねしたユーザーを返すこと",
+			sessionParam: "10",
+			mock: fakeWorkoutLikeService{
+				likersFunc: func(userID uint, sessionID uint) (*service.SessionLikes, error) {
+					require.Equal(t, uint(10), sessionID)
+					return &service.SessionLikes{
+						SessionID: sessionID,
+						LikeCount: 1,
+						Users:     []service.LikeUser{{UserID: 2, Email: "b@example.com", LikedAt: time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)}},
+					}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantContains: []string{
+				`"session_id":10`, `"like_count":1`,
+				`"users":[{"user_id":2,"email":"b@example.com","liked_at":"2025-10-01T09:00:00Z"}]`,
+			},
+		},
+		{
+			name:         "【正常系】いいねが無い場合は users を空配列で返すこと",
+			sessionParam: "10",
+			mock: fakeWorkoutLikeService{
+				likersFunc: func(userID uint, sessionID uint) (*service.SessionLikes, error) {
+					return &service.SessionLikes{SessionID: sessionID}, nil
+				},
+			},
+			wantStatus:   http.StatusOK,
+			wantContains: []string{`"like_count":0`, `"users":[]`},
+		},
+		{
+			name:         "【異常系】非公開のセッションは403",
+			sessionParam: "10",
+			mock: fakeWorkoutLikeService{
+				likersFunc: func(uint, uint) (*service.SessionLikes, error) {
+					return nil, service.ErrForbiddenPrivateRecord
+				},
+			},
+			wantStatus:   http.StatusForbidden,
+			wantContains: []string{"非公開"},
+		},
+		{
+			name:         "【異常系】非公開アカウントのセッションは承認されたフォロワー以外に403",
+			sessionParam: "10",
+			mock: fakeWorkoutLikeService{
+				likersFunc: func(uint, uint) (*service.SessionLikes, error) {
+					return nil, service.ErrPrivateAccount
+				},
+			},
+			wantStatus:   http.StatusForbidden,
+			wantContains: []string{"承認されたフォロワーのみ"},
+		},
+		{
+			name:         "【異常系】存在しないセッションは404(SessionNotFound)",
+			sessionParam: "999",
+			mock: fakeWorkoutLikeService{
+				likersFunc: func(uint, uint) (*service.SessionLikes, error) {
+					return nil, service.ErrSessionNotFound
+				},
+			},
+			wantStatus:   http.StatusNotFound,
+			wantContains: []string{`"SessionNotFound"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutLikeHandler(&tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/timeline/"+tt.sessionParam+"/likes", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("sessionId")
+			c.SetParamValues(tt.sessionParam)
+			c.Set("user_id", uint(1))
+
+			if err := h.ListLikers(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			for _, s := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
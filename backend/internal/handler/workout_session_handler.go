@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type WorkoutSessionHandler interface {
+	CreateSession(c echo.Context) error
+	GetSession(c echo.Context) error
+	GetSessionsByDate(c echo.Context) error
+	UpdateSession(c echo.Context) error
+	DeleteSession(c echo.Context) error
+}
+
+type workoutSessionHandler struct {
+	svc service.WorkoutSessionService
+}
+
+type WorkoutSessionRequest struct {
+	TrainedOn  string                   `json:"trained_on"`
+	StartedAt  *string                  `json:"started_at"`
+	EndedAt    *string                  `json:"ended_at"`
+	BodyWeight float64                  `json:"body_weight"`
+	IsPublic   bool                     `json:"is_public"`
+	Comment    string                   `json:"comment"`
+	TemplateID *uint                    `json:"template_id"`
+	Exercises  []WorkoutExerciseRequest `json:"exercises"`
+}
+
+type WorkoutExerciseRequest struct {
+	ExerciseID uint                `json:"exercise_id"`
+	Sets       []WorkoutSetRequest `json:"sets"`
+}
+
+type workoutExerciseDTO struct {
+	RecordID     uint            `json:"record_id"`
+	ExerciseID   uint            `json:"exercise_id"`
+	ExerciseName string          `json:"exercise_name"`
+	Sets         []workoutSetDTO `json:"sets"`
+}
+
+type workoutSessionDTO struct {
+	ID         uint                 `json:"id"`
+	TrainedOn  string               `json:"trained_on"`
+	StartedAt  *time.Time           `json:"started_at"`
+	EndedAt    *time.Time           `json:"ended_at"`
+	BodyWeight float64              `json:"body_weight"`
+	IsPublic   bool                 `json:"is_public"`
+	Comment    string               `json:"comment"`
+	TemplateID *uint                `json:"template_id"`
+	Exercises  []workoutExerciseDTO `json:"exercises"`
+}
+
+func NewWorkoutSessionHandler(svc service.WorkoutSessionService) WorkoutSessionHandler {
+	return &workoutSessionHandler{svc: svc}
+}
+
+func parseOptionalTime(raw *string) (*time.Time, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func bindSessionRequest(c echo.Context) (service.WorkoutSessionData, error) {
+	var req WorkoutSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return service.WorkoutSessionData{}, httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	trainedOn, err := time.ParseInLocation("2006-01-02", req.TrainedOn, loc)
+	if err != nil {
+		return service.WorkoutSessionData{}, httpx.BadRequest("InvalidDate", "日付の形式が不正です", err)
+	}
+	trainedOn = time.Date(trainedOn.Year(), trainedOn.Month(), trainedOn.Day(), 0, 0, 0, 0, time.UTC)
+
+	startedAt, err := parseOptionalTime(req.StartedAt)
+	if err != nil {
+		return service.WorkoutSessionData{}, httpx.BadRequest("InvalidTime", "started_at の形式が不正です（RFC3339）", err)
+	}
+	endedAt, err := parseOptionalTime(req.EndedAt)
+	if err != nil {
+		return service.WorkoutSessionData{}, httpx.BadRequest("InvalidTime", "ended_at の形式が不正です（RFC3339）", err)
+	}
+
+	data := service.WorkoutSessionData{
+		TrainedOn:  trainedOn,
+		StartedAt:  startedAt,
+		EndedAt:    endedAt,
+		BodyWeight: req.BodyWeight,
+		IsPublic:   req.IsPublic,
+		Comment:    req.Comment,
+		TemplateID: req.TemplateID,
+	}
+	for _, ex := range req.Exercises {
+		data.Exercises = append(data.Exercises, service.WorkoutExerciseData{
+			ExerciseID: ex.ExerciseID,
+			Sets:       toWorkoutSetData(ex.Sets),
+		})
+	}
+	return data, nil
+}
+
+func parseSessionPathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "セッションIDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func sessionWriteError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNoExercises),
+		errors.Is(err, service.ErrNoSets),
+		errors.Is(err, service.ErrInvalidSetValue),
+		errors.Is(err, service.ErrInvalidSessionTime):
+		return httpx.BadRequest("ValidationError", "トレーニング内容が不正です", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	case errors.Is(err, service.ErrExerciseArchived):
+		return httpx.BadRequest("ExerciseArchived", "アーカイブ済みの種目は記録できません", err)
+	case errors.Is(err, service.ErrSessionNotFound):
+		return httpx.NotFound("SessionNotFound", "指定のトレーニングが見つかりません", err)
+	case errors.Is(err, service.ErrTemplateNotFound):
+		return httpx.NotFound("TemplateNotFound", "指定のテンプレートが見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toWorkoutSessionDTO(s models.WorkoutSession) workoutSessionDTO {
+	exercises := make([]workoutExerciseDTO, 0, len(s.Records))
+	for _, r := range s.Records {
+		name := r.Exercise.Name
+		if name == "" {
+			name = "Unknown"
+		}
+		exercises = append(exercises, workoutExerciseDTO{
+			RecordID:     r.ID,
+			ExerciseID:   r.ExerciseID,
+			ExerciseName: name,
+			Sets:         toWorkoutSetDTOs(r.Sets),
+		})
+	}
+	return workoutSessionDTO{
+		ID:         s.ID,
+		TrainedOn:  s.TrainedOn.Format("2006-01-02"),
+		StartedAt:  s.StartedAt,
+		EndedAt:    s.EndedAt,
+		BodyWeight: s.BodyWeight,
+		IsPublic:   s.IsPublic,
+		Comment:    s.Comment,
+		TemplateID: s.TemplateID,
+		Exercises:  exercises,
+	}
+}
+
+func (h *workoutSessionHandler) CreateSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	data, err := bindSessionRequest(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.svc.CreateSession(userID, data)
+	if err != nil {
+		return sessionWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_session_created",
+		"session_id", session.ID,
+		"exercise_count", len(session.Records),
+	)
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":    "Workout session created successfully",
+		"session_id": session.ID,
+	})
+}
+
+func (h *workoutSessionHandler) GetSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionPathID(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.svc.GetSession(userID, sessionID)
+	if err != nil {
+		return sessionWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_session_fetched",
+		"session_id", session.ID,
+	)
+
+	return c.JSON(http.StatusOK, toWorkoutSessionDTO(*session))
+}
+
+func (h *workoutSessionHandler) GetSessionsByDate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	dateStr := c.QueryParam("date")
+	if dateStr == "" {
+		return httpx.BadRequest("InvalidQuery", "date は必須です（YYYY-MM-DD）", nil)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	day, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	if err != nil {
+		return httpx.BadRequest("InvalidDate", "date の形式が不正です（YYYY-MM-DD）", err)
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	sessions, err := h.svc.GetDailySessions(userID, day)
+	if err != nil {
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	slog.InfoContext(ctx, "workout_sessions_daily_fetched",
+		"date", day.Format("2006-01-02"),
+		"count", len(sessions),
+	)
+
+	out := make([]workoutSessionDTO, 0, len(sessions))
+	for _, s := range sessions {
+		out = append(out, toWorkoutSessionDTO(s))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *workoutSessionHandler) UpdateSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionPathID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := bindSessionRequest(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.svc.UpdateSession(userID, sessionID, data)
+	if err != nil {
+		return sessionWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_session_updated",
+		"session_id", session.ID,
+		"exercise_count", len(session.Records),
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":    "Workout session updated successfully",
+		"session_id": session.ID,
+	})
+}
+
+func (h *workoutSessionHandler) DeleteSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	sessionID, err := parseSessionPathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteSession(userID, sessionID); err != nil {
+		return sessionWriteError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_session_deleted",
+		"session_id", sessionID,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Workout session deleted successfully",
+	})
+}
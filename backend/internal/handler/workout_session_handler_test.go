@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mockWorkoutSessionService struct {
+	CreateSessionFunc    func(userID uint, data service.WorkoutSessionData) (*models.WorkoutSession, error)
+	GetSessionFunc       func(userID uint, sessionID uint) (*models.WorkoutSession, error)
+	GetDailySessionsFunc func(userID uint, day time.Time) ([]models.WorkoutSession, error)
+	UpdateSessionFunc    func(userID uint, sessionID uint, data service.WorkoutSessionData) (*models.WorkoutSession, error)
+	DeleteSessionFunc    func(userID uint, sessionID uint) error
+}
+
+func (m *mockWorkoutSessionService) CreateSession(u uint, d service.WorkoutSessionData) (*models.WorkoutSession, error) {
+	return m.CreateSessionFunc(u, d)
+}
+func (m *mockWorkoutSessionService) GetSession(u uint, id uint) (*models.WorkoutSession, error) {
+	return m.GetSessionFunc(u, id)
+}
+func (m *mockWorkoutSessionService) GetDailySessions(u uint, day time.Time) ([]models.WorkoutSession, error) {
+	return m.GetDailySessionsFunc(u, day)
+}
+func (m *mockWorkoutSessionService) UpdateSession(u uint, id uint, d service.WorkoutSessionData) (*models.WorkoutSession, error) {
+	return m.UpdateSessionFunc(u, id, d)
+}
+func (m *mockWorkoutSessionService) DeleteSession(u uint, id uint) error {
+	return m.DeleteSessionFunc(u, id)
+}
+
+const validSessionBody = `{"trained_on":"2025-10-01","started_at":"2025-10-01T19:00:00+09:00","ended_at":"2025-10-01T20:15:00+09:00","body_weight":70,"comment":"胸と脚","exercises":[{"exercise_id":1,"sets":[{"set":1,"reps":10,"exercise_weight":50}]},{"exercise_id":2,"sets":[{"set":1,"reps":5,"exercise_weight":100}]}]}`
+
+func TestWorkoutSessionHandler_CreateSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockWorkoutSessionService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】セッションを作成できること",
+			body: validSessionBody,
+			mock: &mockWorkoutSessionService{
+				CreateSessionFunc: func(userID uint, d service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					require.Len(t, d.Exercises, 2)
+					require.NotNil(t, d.StartedAt)
+					require.Equal(t, 75*time.Minute, d.EndedAt.Sub(*d.StartedAt))
+					return &models.WorkoutSession{Model: gorm.Model{ID: 7}}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: `"session_id":7`,
+		},
+		{
+			name:         "【異常系】日付の形式が不正な場合は InvalidDate エラーを返すこと",
+			body:         `{"trained_on":"2025/10/01","exercises":[]}`,
+			mock:         &mockWorkoutSessionService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidDate"`,
+		},
+		{
+			name:         "【異常系】開始時刻の形式が不正な場合は InvalidTime エラーを返すこと",
+			body:         `{"trained_on":"2025-10-01","started_at":"19:00","exercises":[]}`,
+			mock:         &mockWorkoutSessionService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidTime"`,
+		},
+		{
+			name: "【異常系】種目が空の場合は ValidationError を返すこと",
+			body: `{"trained_on":"2025-10-01","exercises":[]}`,
+			mock: &mockWorkoutSessionService{
+				CreateSessionFunc: func(uint, service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					return nil, service.ErrNoExercises
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"ValidationError"`,
+		},
+		{
+			name: "【異常系】種目が存在しない場合は ExerciseNotFound を返すこと",
+			body: validSessionBody,
+			mock: &mockWorkoutSessionService{
+				CreateSessionFunc: func(uint, service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					return nil, service.ErrExerciseNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: `"code":"ExerciseNotFound"`,
+		},
+		{
+			name: "【異常系】システムエラーの場合は InternalError を返すこと",
+			body: validSessionBody,
+			mock: &mockWorkoutSessionService{
+				CreateSessionFunc: func(uint, service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutSessionHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/training_sessions", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.CreateSession(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestWorkoutSessionHandler_GetSession(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		idParam      string
+		mock         *mockWorkoutSessionService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:    "【正常系】種目ブロック付きでセッションを取得できること",
+			idParam: "3",
+			mock: &mockWorkoutSessionService{
+				GetSessionFunc: func(userID, id uint) (*models.WorkoutSession, error) {
+					return &models.WorkoutSession{
+						Model:     gorm.Model{ID: id},
+						TrainedOn: day,
+						Records: []models.WorkoutRecord{
+							{Model: gorm.Model{ID: 11}, ExerciseID: 1, Exercise: models.Exercise{Name: "ベンチプレス"},
+								Sets: []models.WorkoutSet{{SetNo: 1, Reps: 10, ExerciseWeight: 50}}},
+						},
+					}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"exercise_name":"ベンチプレス"`,
+		},
+		{
+			name:         "【異常系】IDが不正な場合は InvalidID を返すこと",
+			idParam:      "abc",
+			mock:         &mockWorkoutSessionService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidID"`,
+		},
+		{
+			name:    "【異常系】存在しない場合は SessionNotFound を返すこと",
+			idParam: "3",
+			mock: &mockWorkoutSessionService{
+				GetSessionFunc: func(uint, uint) (*models.WorkoutSession, error) {
+					return nil, service.ErrSessionNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: `"code":"SessionNotFound"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutSessionHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/training_sessions/"+tt.idParam, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.idParam)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetSession(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestWorkoutSessionHandler_GetSessionsByDate(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		mock         *mockWorkoutSessionService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:  "【正常系】日別のセッションを取得できること",
+			query: "date=2025-10-01",
+			mock: &mockWorkoutSessionService{
+				GetDailySessionsFunc: func(uint, time.Time) ([]models.WorkoutSession, error) {
+					return []models.WorkoutSession{{Model: gorm.Model{ID: 4}, Comment: "朝トレ"}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"comment":"朝トレ"`,
+		},
+		{
+			name:         "【異常系】date が必須であること",
+			query:        "",
+			mock:         &mockWorkoutSessionService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidQuery"`,
+		},
+		{
+			name:  "【異常系】システムエラーの場合は InternalError を返すこと",
+			query: "date=2025-10-01",
+			mock: &mockWorkoutSessionService{
+				GetDailySessionsFunc: func(uint, time.Time) ([]models.WorkoutSession, error) {
+					return nil, errors.New("boom")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutSessionHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/training_sessions/date?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetSessionsByDate(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestWorkoutSessionHandler_UpdateAndDeleteSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		mock         *mockWorkoutSessionService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name:   "【正常系】セッションを更新できること",
+			method: http.MethodPut,
+			body:   validSessionBody,
+			mock: &mockWorkoutSessionService{
+				UpdateSessionFunc: func(userID, id uint, d service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					return &models.WorkoutSession{Model: gorm.Model{ID: id}}, nil
+				},
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"session_id":3`,
+		},
+		{
+			name:   "【異常系】更新対象が存在しない場合は SessionNotFound を返すこと",
+			method: http.MethodPut,
+			body:   validSessionBody,
+			mock: &mockWorkoutSessionService{
+				UpdateSessionFunc: func(uint, uint, service.WorkoutSessionData) (*models.WorkoutSession, error) {
+					return nil, service.ErrSessionNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: `"code":"SessionNotFound"`,
+		},
+		{
+			name:   "【正常系】セッションを削除できること",
+			method: http.MethodDelete,
+			mock: &mockWorkoutSessionService{
+				DeleteSessionFunc: func(uint, uint) error { return nil },
+			},
+			wantCode:     http.StatusOK,
+			wantContains: `"message":"Workout session deleted successfully"`,
+		},
+		{
+			name:   "【異常系】削除対象が存在しない場合は SessionNotFound を返すこと",
+			method: http.MethodDelete,
+			mock: &mockWorkoutSessionService{
+				DeleteSessionFunc: func(uint, uint) error { return service.ErrSessionNotFound },
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: `"code":"SessionNotFound"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutSessionHandler(tt.mock)
+
+			req := httptest.NewRequest(tt.method, "/training_sessions/3", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("3")
+			c.Set("user_id", uint(1))
+
+			var err error
+			if tt.method == http.MethodPut {
+				err = h.UpdateSession(c)
+			} else {
+				err = h.DeleteSession(c)
+			}
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
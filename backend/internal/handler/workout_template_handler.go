@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/middleware"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type WorkoutTemplateHandler interface {
+	CreateTemplate(c echo.Context) error
+	ListTemplates(c echo.Context) error
+	GetTemplate(c echo.Context) error
+	UpdateTemplate(c echo.Context) error
+	DeleteTemplate(c echo.Context) error
+	StartTemplate(c echo.Context) error
+}
+
+type workoutTemplateHandler struct {
+	svc service.WorkoutTemplateService
+}
+
+type WorkoutTemplateRequest struct {
+	Name      string                   `json:"name"`
+	Note      string                   `json:"note"`
+	Exercises []WorkoutExerciseRequest `json:"exercises"`
+}
+
+type templateSetDTO struct {
+	Set             int      `json:"set"`
+	Reps            int      `json:"reps"`
+	ExerciseWeight  float64  `json:"exercise_weight"`
+	SetType         string   `json:"set_type"`
+	RestSeconds     *int     `json:"rest_seconds"`
+	Tempo           string   `json:"tempo"`
+	Note            string   `json:"note"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	LastWeight      *float64 `json:"last_weight"`
+}
+
+type templateExerciseDTO struct {
+	ExerciseID   uint             `json:"exercise_id"`
+	ExerciseName string           `json:"exercise_name"`
+	Sets         []templateSetDTO `json:"sets"`
+}
+
+type workoutTemplateDTO struct {
+	ID              uint                  `json:"id"`
+	Name            string                `json:"name"`
+	Note            string                `json:"note"`
+	LastPerformedOn *string               `json:"last_performed_on"`
+	Exercises       []templateExerciseDTO `json:"exercises"`
+}
+
+func NewWorkoutTemplateHandler(svc service.WorkoutTemplateService) WorkoutTemplateHandler {
+	return &workoutTemplateHandler{svc: svc}
+}
+
+func bindTemplateRequest(c echo.Context) (service.WorkoutTemplateData, error) {
+	var req WorkoutTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return service.WorkoutTemplateData{}, httpx.BadRequest("InvalidBody", "リクエストの形式が不正です", err)
+	}
+
+	data := service.WorkoutTemplateData{
+		Name: req.Name,
+		Note: req.Note,
+	}
+	for _, ex := range req.Exercises {
+		data.Exercises = append(data.Exercises, service.WorkoutExerciseData{
+			ExerciseID: ex.ExerciseID,
+			Sets:       toWorkoutSetData(ex.Sets),
+		})
+	}
+	return data, nil
+}
+
+func parseTemplatePathID(c echo.Context) (uint, error) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		return 0, httpx.BadRequest("InvalidID", "テンプレートIDが不正です", err)
+	}
+	return uint(id64), nil
+}
+
+func templateError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTemplateName),
+		errors.Is(err, service.ErrNoExercises),
+		errors.Is(err, service.ErrNoSets),
+		errors.Is(err, service.ErrInvalidSetValue):
+		return httpx.BadRequest("ValidationError", "テンプレートの内容が不正です", err)
+	case errors.Is(err, service.ErrExerciseNotFound):
+		return httpx.NotFound("ExerciseNotFound", "指定の種目が見つかりません", err)
+	case errors.Is(err, service.ErrExerciseArchived):
+		return httpx.BadRequest("ExerciseArchived", "アーカイブ済みの種目は使用できません", err)
+	case errors.Is(err, service.ErrTemplateNotFound):
+		return httpx.NotFound("TemplateNotFound", "指定のテンプレートが見つかりません", err)
+	default:
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+}
+
+func toWorkoutTemplateDTO(t models.WorkoutTemplate) workoutTemplateDTO {
+	exercises := make([]templateExerciseDTO, 0, len(t.Exercises))
+	for _, ex := range t.Exercises {
+		name := ex.Exercise.Name
+		if name == "" {
+			name = "Unknown"
+		}
+		sets := make([]templateSetDTO, 0, len(ex.Sets))
+		for _, st := range ex.Sets {
+			sets = append(sets, templateSetDTO{
+				Set:             st.SetNo,
+				Reps:            st.Reps,
+				ExerciseWeight:  st.ExerciseWeight,
+				SetType:         string(st.SetType),
+				RestSeconds:     st.RestSeconds,
+				Tempo:           st.Tempo,
+				Note:            st.Note,
+				DurationSeconds: st.DurationSeconds,
+				DistanceMeters:  st.DistanceMeters,
+				LastWeight:      st.LastWeight,
+			})
+		}
+		exercises = append(exercises, templateExerciseDTO{
+			ExerciseID:   ex.ExerciseID,
+			ExerciseName: name,
+			Sets:         sets,
+		})
+	}
+
+	var lastPerformedOn *string
+	if t.LastPerformedOn != nil {
+		s := t.LastPerformedOn.Format("2006-01-02")
+		lastPerformedOn = &s
+	}
+	return workoutTemplateDTO{
+		ID:              t.ID,
+		Name:            t.Name,
+		Note:            t.Note,
+		LastPerformedOn: lastPerformedOn,
+		Exercises:       exercises,
+	}
+}
+
+func (h *workoutTemplateHandler) CreateTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	data, err := bindTemplateRequest(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.svc.CreateTemplate(userID, data)
+	if err != nil {
+		return templateError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_template_created",
+		"template_id", template.ID,
+		"exercise_count", len(template.Exercises),
+	)
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":     "Workout template created successfully",
+		"template_id": template.ID,
+	})
+}
+
+func (h *workoutTemplateHandler) ListTemplates(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	templates, err := h.svc.ListTemplates(userID)
+	if err != nil {
+		return httpx.Internal("システムエラーが発生しました", err)
+	}
+
+	slog.InfoContext(ctx, "workout_templates_fetched",
+		"count", len(templates),
+	)
+
+	out := make([]workoutTemplateDTO, 0, len(templates))
+	for _, t := range templates {
+		out = append(out, toWorkoutTemplateDTO(t))
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+func (h *workoutTemplateHandler) GetTemplate(c echo.Context) error {
+	userID := middleware.GetUserID(c)
+
+	templateID, err := parseTemplatePathID(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.svc.GetTemplate(userID, templateID)
+	if err != nil {
+		return templateError(err)
+	}
+	return c.JSON(http.StatusOK, toWorkoutTemplateDTO(*template))
+}
+
+func (h *workoutTemplateHandler) UpdateTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	templateID, err := parseTemplatePathID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := bindTemplateRequest(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.svc.UpdateTemplate(userID, templateID, data)
+	if err != nil {
+		return templateError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_template_updated",
+		"template_id", template.ID,
+		"exercise_count", len(template.Exercises),
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Workout template updated successfully",
+		"template_id": template.ID,
+	})
+}
+
+func (h *workoutTemplateHandler) DeleteTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	templateID, err := parseTemplatePathID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteTemplate(userID, templateID); err != nil {
+		return templateError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_template_deleted",
+		"template_id", templateID,
+	)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Workout template deleted successfully",
+	})
+}
+
+// StartTemplate はテンプレートから今日（日本時間）のトレーニングの入力内容を返す。保存はしない。
+// 実際の重量・回数を調整し、template_id を付けて POST /training_sessions で記録する。
+func (h *workoutTemplateHandler) StartTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := middleware.GetUserID(c)
+
+	templateID, err := parseTemplatePathID(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := h.svc.StartTemplate(userID, templateID, todayJST())
+	if err != nil {
+		return templateError(err)
+	}
+
+	slog.InfoContext(ctx, "workout_template_started",
+		"template_id", templateID,
+	)
+
+	return c.JSON(http.StatusOK, toWorkoutSessionDTO(*session))
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mockWorkoutTemplateService struct {
+	CreateTemplateFunc func(userID uint, data service.WorkoutTemplateData) (*models.WorkoutTemplate, error)
+	ListTemplatesFunc  func(userID uint) ([]models.WorkoutTemplate, error)
+	GetTemplateFunc    func(userID uint, templateID uint) (*models.WorkoutTemplate, error)
+	UpdateTemplateFunc func(userID uint, templateID uint, data service.WorkoutTemplateData) (*models.WorkoutTemplate, error)
+	DeleteTemplateFunc func(userID uint, templateID uint) error
+	StartTemplateFunc  func(userID uint, templateID uint, trainedOn time.Time) (*models.WorkoutSession, error)
+}
+
+func (m *mockWorkoutTemplateService) CreateTemplate(u uint, d service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+	return m.CreateTemplateFunc(u, d)
+}
+func (m *mockWorkoutTemplateService) ListTemplates(u uint) ([]models.WorkoutTemplate, error) {
+	return m.ListTemplatesFunc(u)
+}
+func (m *mockWorkoutTemplateService) GetTemplate(u uint, id uint) (*models.WorkoutTemplate, error) {
+	return m.GetTemplateFunc(u, id)
+}
+func (m *mockWorkoutTemplateService) UpdateTemplate(u uint, id uint, d service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+	return m.UpdateTemplateFunc(u, id, d)
+}
+func (m *mockWorkoutTemplateService) DeleteTemplate(u uint, id uint) error {
+	return m.DeleteTemplateFunc(u, id)
+}
+func (m *mockWorkoutTemplateService) StartTemplate(u uint, id uint, trainedOn time.Time) (*models.WorkoutSession, error) {
+	return m.StartTemplateFunc(u, id, trainedOn)
+}
+
+const validTemplateBody = `{"name":"Push day","note":"胸の日","exercises":[{"exercise_id":1,"sets":[{"set":1,"reps":8,"exercise_weight":60,"rest_seconds":120}]}]}`
+
+func TestWorkoutTemplateHandler_CreateTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		mock         *mockWorkoutTemplateService
+		wantCode     int
+		wantContains string
+	}{
+		{
+			name: "【正常系】テンプレートを作成できること",
+			body: validTemplateBody,
+			mock: &mockWorkoutTemplateService{
+				CreateTemplateFunc: func(userID uint, d service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+					require.Equal(t, "Push day", d.Name)
+					require.Len(t, d.Exercises, 1)
+					require.Equal(t, 60.0, d.Exercises[0].Sets[0].ExerciseWeight)
+					return &models.WorkoutTemplate{Model: gorm.Model{ID: 4}}, nil
+				},
+			},
+			wantCode:     http.StatusCreated,
+			wantContains: `"template_id":4`,
+		},
+		{
+			name:         "【異常系】不正なJSONの場合は InvalidBody を返すこと",
+			body:         `{"name":`,
+			mock:         &mockWorkoutTemplateService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"InvalidBody"`,
+		},
+		{
+			name: "【異常系】名前が不正な場合は ValidationError を返すこと",
+			body: validTemplateBody,
+			mock: &mockWorkoutTemplateService{
+				CreateTemplateFunc: func(uint, service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+					return nil, service.ErrInvalidTemplateName
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"ValidationError"`,
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目の場合は ExerciseArchived を返すこと",
+			body: validTemplateBody,
+			mock: &mockWorkoutTemplateService{
+				CreateTemplateFunc: func(uint, service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+					return nil, service.ErrExerciseArchived
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: `"code":"ExerciseArchived"`,
+		},
+		{
+			name: "【異常系】システムエラーの場合は InternalError を返すこと",
+			body: validTemplateBody,
+			mock: &mockWorkoutTemplateService{
+				CreateTemplateFunc: func(uint, service.WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantCode:     http.StatusInternalServerError,
+			wantContains: `"code":"InternalError"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutTemplateHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/templates", bytes.NewBufferString(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_id", uint(1))
+
+			if err := h.CreateTemplate(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestWorkoutTemplateHandler_GetTemplate(t *testing.T) {
+	last := 62.5
+	performed := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		idParam      string
+		mock         *mockWorkoutTemplateService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:    "【正常系】目標セットと前回の重量を含めて取得できること",
+			idParam: "4",
+			mock: &mockWorkoutTemplateService{
+				GetTemplateFunc: func(userID, id uint) (*models.WorkoutTemplate, error) {
+					return &models.WorkoutTemplate{
+						Model:           gorm.Model{ID: id},
+						Name:            "Push day",
+						LastPerformedOn: &performed,
+						Exercises: []models.WorkoutTemplateExercise{
+							{ExerciseID: 1, Exercise: models.Exercise{Name: "ベンチプレス"}, Sets: []models.WorkoutTemplateSet{
+								{SetNo: 1, SetType: models.SetTypeWorking, Reps: 8, ExerciseWeight: 60, LastWeight: &last},
+							}},
+						},
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"name":"Push day"`,
+				`"last_performed_on":"2025-10-06"`,
+				`"exercise_name":"ベンチプレス"`,
+				`"exercise_weight":60`,
+				`"last_weight":62.5`,
+			},
+		},
+		{
+			name:         "【異常系】IDが不正な場合は InvalidID を返すこと",
+			idParam:      "0",
+			mock:         &mockWorkoutTemplateService{},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"InvalidID"`},
+		},
+		{
+			name:    "【異常系】存在しない場合は TemplateNotFound を返すこと",
+			idParam: "4",
+			mock: &mockWorkoutTemplateService{
+				GetTemplateFunc: func(uint, uint) (*models.WorkoutTemplate, error) {
+					return nil, service.ErrTemplateNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"TemplateNotFound"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutTemplateHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodGet, "/templates/"+tt.idParam, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.idParam)
+			c.Set("user_id", uint(1))
+
+			if err := h.GetTemplate(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, want := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), want)
+			}
+		})
+	}
+}
+
+func TestWorkoutTemplateHandler_DeleteTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     int
+		wantContains string
+	}{
+		{name: "【正常系】テンプレートを削除できること", wantCode: http.StatusOK, wantContains: `"message"`},
+		{name: "【異常系】存在しない場合は TemplateNotFound を返すこと", err: service.ErrTemplateNotFound, wantCode: http.StatusNotFound, wantContains: `"code":"TemplateNotFound"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutTemplateHandler(&mockWorkoutTemplateService{
+				DeleteTemplateFunc: func(uint, uint) error { return tt.err },
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/templates/4", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("4")
+			c.Set("user_id", uint(1))
+
+			if err := h.DeleteTemplate(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantContains)
+		})
+	}
+}
+
+func TestWorkoutTemplateHandler_StartTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mock         *mockWorkoutTemplateService
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name: "【正常系】今日の日付で入力済みのトレーニングを保存せずに返すこと",
+			mock: &mockWorkoutTemplateService{
+				StartTemplateFunc: func(userID, id uint, trainedOn time.Time) (*models.WorkoutSession, error) {
+					require.Equal(t, uint(4), id)
+					require.Equal(t, time.UTC, trainedOn.Location())
+					require.Zero(t, trainedOn.Hour())
+					return &models.WorkoutSession{
+						TrainedOn:  trainedOn,
+						TemplateID: &id,
+						Records: []models.WorkoutRecord{
+							{ExerciseID: 1, Exercise: models.Exercise{Name: "ベンチプレス"},
+								Sets: []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 62.5}}},
+						},
+					}, nil
+				},
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				`"id":0`,
+				`"exercise_name":"ベンチプレス"`,
+				`"template_id":4`,
+				`"exercise_weight":62.5`,
+			},
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目を含む場合は ExerciseArchived を返すこと",
+			mock: &mockWorkoutTemplateService{
+				StartTemplateFunc: func(uint, uint, time.Time) (*models.WorkoutSession, error) {
+					return nil, service.ErrExerciseArchived
+				},
+			},
+			wantCode:     http.StatusBadRequest,
+			wantContains: []string{`"code":"ExerciseArchived"`},
+		},
+		{
+			name: "【異常系】存在しない場合は TemplateNotFound を返すこと",
+			mock: &mockWorkoutTemplateService{
+				StartTemplateFunc: func(uint, uint, time.Time) (*models.WorkoutSession, error) {
+					return nil, service.ErrTemplateNotFound
+				},
+			},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`"code":"TemplateNotFound"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEchoForTest()
+			h := NewWorkoutTemplateHandler(tt.mock)
+
+			req := httptest.NewRequest(http.MethodPost, "/templates/4/start", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("4")
+			c.Set("user_id", uint(1))
+
+			if err := h.StartTemplate(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			for _, want := range tt.wantContains {
+				require.Contains(t, rec.Body.String(), want)
+			}
+		})
+	}
+}
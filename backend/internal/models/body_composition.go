@@ -0,0 +1,38 @@
+package models
+
+import "math"
+
+// BodyComposition は身長・体重・体脂肪率から求める体格指数。求められない値は nil。
+// FFMI は除脂肪量(kg)÷身長(m)^2、NormalizedFFMI は身長 1.8m 相当に補正した値。
+type BodyComposition struct {
+	BMI            *float64
+	FFMI           *float64
+	NormalizedFFMI *float64
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// ComputeBodyComposition は体格指数を求める。BMI は身長と体重、FFMI はさらに体脂肪率が必要。
+func ComputeBodyComposition(heightCM, weightKG, bodyFatPercent *float64) BodyComposition {
+	var out BodyComposition
+	if heightCM == nil || weightKG == nil || *heightCM <= 0 || *weightKG <= 0 {
+		return out
+	}
+
+	h := *heightCM / 100
+	bmi := round1(*weightKG / (h * h))
+	out.BMI = &bmi
+
+	if bodyFatPercent == nil || *bodyFatPercent <= 0 || *bodyFatPercent >= 100 {
+		return out
+	}
+	lean := *weightKG * (1 - *bodyFatPercent/100)
+	ffmi := lean / (h * h)
+	normalized := round1(ffmi + 6.1*(1.8-h))
+	ffmi = round1(ffmi)
+	out.FFMI = &ffmi
+	out.NormalizedFFMI = &normalized
+	return out
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BodyMetric は体重・体組成・周囲径の記録。トレーニングとは独立して、ユーザーごとに1日1件記録する。
+// 未計測の項目は NULL とする。重量は kg、周囲径は cm。
+type BodyMetric struct {
+	ID             uint      `gorm:"primaryKey"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_body_metric_user_day"`
+	MeasuredOn     time.Time `gorm:"type:date;not null;uniqueIndex:idx_body_metric_user_day"`
+	Weight         *float64  `gorm:"type:numeric(5,2)"`
+	BodyFatPercent *float64  `gorm:"type:numeric(4,1)"`
+	MuscleMass     *float64  `gorm:"type:numeric(5,2)"`
+	Neck           *float64  `gorm:"type:numeric(5,1)"`
+	Chest          *float64  `gorm:"type:numeric(5,1)"`
+	Waist          *float64  `gorm:"type:numeric(5,1)"`
+	Hips           *float64  `gorm:"type:numeric(5,1)"`
+	Arm            *float64  `gorm:"type:numeric(5,1)"`
+	Thigh          *float64  `gorm:"type:numeric(5,1)"`
+	Note           string    `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TapeMeasurements は周囲径の項目を順に返す。
+func (m *BodyMetric) TapeMeasurements() []*float64 {
+	return []*float64{m.Neck, m.Chest, m.Waist, m.Hips, m.Arm, m.Thigh}
+}
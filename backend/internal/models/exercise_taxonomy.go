@@ -0,0 +1,97 @@
+package models
+
+// MuscleGroup は部位
+type MuscleGroup string
+
+const (
+	MuscleChest      MuscleGroup = "chest"
+	MuscleBack       MuscleGroup = "back"
+	MuscleShoulders  MuscleGroup = "shoulders"
+	MuscleBiceps     MuscleGroup = "biceps"
+	MuscleTriceps    MuscleGroup = "triceps"
+	MuscleForearms   MuscleGroup = "forearms"
+	MuscleCore       MuscleGroup = "core"
+	MuscleQuads      MuscleGroup = "quads"
+	MuscleHamstrings MuscleGroup = "hamstrings"
+	MuscleGlutes     MuscleGroup = "glutes"
+	MuscleCalves     MuscleGroup = "calves"
+	MuscleCardio     MuscleGroup = "cardio"
+)
+
+func (g MuscleGroup) Valid() bool {
+	switch g {
+	case MuscleChest, MuscleBack, MuscleShoulders, MuscleBiceps, MuscleTriceps, MuscleForearms,
+		MuscleCore, MuscleQuads, MuscleHamstrings, MuscleGlutes, MuscleCalves, MuscleCardio:
+		return true
+	}
+	return false
+}
+
+// Equipment は使用器具
+type Equipment string
+
+const (
+	EquipmentBarbell    Equipment = "barbell"
+	EquipmentDumbbell   Equipment = "dumbbell"
+	EquipmentMachine    Equipment = "machine"
+	EquipmentCable      Equipment = "cable"
+	EquipmentBodyweight Equipment = "bodyweight"
+)
+
+func (e Equipment) Valid() bool {
+	switch e {
+	case EquipmentBarbell, EquipmentDumbbell, EquipmentMachine, EquipmentCable, EquipmentBodyweight:
+		return true
+	}
+	return false
+}
+
+// MovementPattern は動作パターン
+type MovementPattern string
+
+const (
+	MovementHorizontalPush MovementPattern = "horizontal_push"
+	MovementVerticalPush   MovementPattern = "vertical_push"
+	MovementHorizontalPull MovementPattern = "horizontal_pull"
+	MovementVerticalPull   MovementPattern = "vertical_pull"
+	MovementSquat          MovementPattern = "squat"
+	MovementHinge          MovementPattern = "hinge"
+	MovementLunge          MovementPattern = "lunge"
+	MovementIsolation      MovementPattern = "isolation"
+	MovementCore           MovementPattern = "core"
+	MovementCardio         MovementPattern = "cardio"
+)
+
+func (p MovementPattern) Valid() bool {
+	switch p {
+	case MovementHorizontalPush, MovementVerticalPush, MovementHorizontalPull, MovementVerticalPull,
+		MovementSquat, MovementHinge, MovementLunge, MovementIsolation, MovementCore, MovementCardio:
+		return true
+	}
+	return false
+}
+
+// IsPush はプッシュ系（押す動作）かどうか
+func (p MovementPattern) IsPush() bool {
+	return p == MovementHorizontalPush || p == MovementVerticalPush
+}
+
+// IsPull はプル系（引く動作）かどうか
+func (p MovementPattern) IsPull() bool {
+	return p == MovementHorizontalPull || p == MovementVerticalPull
+}
+
+// ExerciseMuscle は種目の補助部位（主働筋は Exercise.PrimaryMuscle）
+type ExerciseMuscle struct {
+	ID          uint        `gorm:"primaryKey"`
+	ExerciseID  uint        `gorm:"not null;uniqueIndex:ux_exercise_muscle"`
+	MuscleGroup MuscleGroup `gorm:"type:varchar(24);not null;uniqueIndex:ux_exercise_muscle;index"`
+}
+
+// ExerciseAlias は種目の別名（多言語名・略称）
+type ExerciseAlias struct {
+	ID         uint   `gorm:"primaryKey"`
+	ExerciseID uint   `gorm:"not null;index"`
+	Locale     string `gorm:"type:varchar(8);not null"`
+	Name       string `gorm:"not null;index"`
+}
@@ -1,8 +1,50 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// MeasurementKind は種目ごとのセットの記録方法
+type MeasurementKind string
+
+const (
+	MeasurementRepsWeight       MeasurementKind = "reps_weight"       // 回数×重量
+	MeasurementRepsOnly         MeasurementKind = "reps_only"         // 回数のみ
+	MeasurementDuration         MeasurementKind = "duration"          // 時間（プランク等）
+	MeasurementDistanceDuration MeasurementKind = "distance_duration" // 距離＋時間（ランニング等）
+	MeasurementBodyweightLoad   MeasurementKind = "bodyweight_load"   // 体重＋加重（加重懸垂等）
+)
+
+func (k MeasurementKind) Valid() bool {
+	switch k {
+	case MeasurementRepsWeight, MeasurementRepsOnly, MeasurementDuration, MeasurementDistanceDuration, MeasurementBodyweightLoad:
+		return true
+	}
+	return false
+}
+
+// Exercise は種目。OwnerID が nil の種目は全ユーザー共通、それ以外はユーザー独自の種目。
 type Exercise struct {
 	gorm.Model
-	Name string `gorm:"unique;not null"`
+	OwnerID          *uint           `gorm:"uniqueIndex:ux_exercise_owner_name"`
+	Name             string          `gorm:"not null;uniqueIndex:ux_exercise_owner_name"`
+	MeasurementKind  MeasurementKind `gorm:"type:varchar(24);not null;default:reps_weight"`
+	IsShared         bool            `gorm:"not null;default:false"`
+	ArchivedAt       *time.Time
+	PrimaryMuscle    MuscleGroup      `gorm:"type:varchar(24);index"`
+	Equipment        Equipment        `gorm:"type:varchar(24);index"`
+	MovementPattern  MovementPattern  `gorm:"type:varchar(24);index"`
+	SecondaryMuscles []ExerciseMuscle `gorm:"constraint:OnDelete:CASCADE"`
+	Aliases          []ExerciseAlias  `gorm:"constraint:OnDelete:CASCADE"`
+	Owner            *User            `gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (e *Exercise) IsCustom() bool {
+	return e.OwnerID != nil
+}
+
+func (e *Exercise) IsArchived() bool {
+	return e.ArchivedAt != nil
 }
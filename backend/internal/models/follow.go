@@ -0,0 +1,41 @@
+package models
+
+import "gorm.io/gorm"
+
+// FollowStatus はフォローの状態。非公開アカウントへのフォローは承認されるまで pending。
+type FollowStatus string
+
+const (
+	FollowPending  FollowStatus = "pending"
+	FollowAccepted FollowStatus = "accepted"
+)
+
+// Follow は FollowerID のユーザーが FolloweeID のユーザーをフォローしていることを表す。
+type Follow struct {
+	gorm.Model
+	FollowerID uint         `gorm:"not null;index;uniqueIndex:ux_follower_followee"`
+	FolloweeID uint         `gorm:"not null;index;uniqueIndex:ux_follower_followee"`
+	Status     FollowStatus `gorm:"type:varchar(16);not null;default:accepted"`
+
+	Follower User `gorm:"foreignKey:FollowerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Followee User `gorm:"foreignKey:FolloweeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// TimelineScope はタイムラインに含める投稿者の範囲。
+// global は公開アカウントとフォロー中の非公開アカウント、following はフォロー中のユーザー、me は自分のみ。
+// いずれも自分の投稿を含む。
+type TimelineScope string
+
+const (
+	TimelineGlobal    TimelineScope = "global"
+	TimelineFollowing TimelineScope = "following"
+	TimelineMe        TimelineScope = "me"
+)
+
+func (s TimelineScope) Valid() bool {
+	switch s {
+	case TimelineGlobal, TimelineFollowing, TimelineMe:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// GoalType は目標の種類
+type GoalType string
+
+const (
+	GoalLiftWeight         GoalType = "lift_weight"         // 種目で目標重量(kg)を挙げる
+	GoalBodyweightMultiple GoalType = "bodyweight_multiple" // 種目で体重の目標倍率の重量を挙げる
+	GoalTrainingDays       GoalType = "training_days"       // 期間内に目標日数トレーニングする
+)
+
+func (t GoalType) Valid() bool {
+	switch t {
+	case GoalLiftWeight, GoalBodyweightMultiple, GoalTrainingDays:
+		return true
+	}
+	return false
+}
+
+// NeedsExercise は種目を指定する目標かどうか
+func (t GoalType) NeedsExercise() bool {
+	return t == GoalLiftWeight || t == GoalBodyweightMultiple
+}
+
+// GoalStatus は目標の状態。保存はせず AchievedAt と期限から求める。
+type GoalStatus string
+
+const (
+	GoalActive   GoalStatus = "active"
+	GoalAchieved GoalStatus = "achieved"
+	GoalExpired  GoalStatus = "expired"
+)
+
+func (s GoalStatus) Valid() bool {
+	switch s {
+	case GoalActive, GoalAchieved, GoalExpired:
+		return true
+	}
+	return false
+}
+
+// Goal はユーザーの目標。StartsOn〜Deadline の記録で達成を判定する（Deadline が nil なら期限なし）。
+// TargetValue は種類ごとの目標値（lift_weight: 重量, bodyweight_multiple: 体重の倍率, training_days: 日数）。
+// AchievedAt は条件を満たす記録が保存されたときに自動で設定する。
+type Goal struct {
+	ID          uint       `gorm:"primaryKey"`
+	UserID      uint       `gorm:"not null;index"`
+	Type        GoalType   `gorm:"type:varchar(24);not null"`
+	ExerciseID  *uint      `gorm:"index"`
+	TargetValue float64    `gorm:"not null"`
+	StartsOn    time.Time  `gorm:"type:date;not null"`
+	Deadline    *time.Time `gorm:"type:date"`
+	AchievedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	User        User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Exercise    *Exercise `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// Status は today 時点の目標の状態を返す。
+func (g Goal) Status(today time.Time) GoalStatus {
+	switch {
+	case g.AchievedAt != nil:
+		return GoalAchieved
+	case g.Deadline != nil && today.After(*g.Deadline):
+		return GoalExpired
+	default:
+		return GoalActive
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationType は通知の種類
+type NotificationType string
+
+const (
+	NotificationLike           NotificationType = "like"            // 自分のセッションへのいいね
+	NotificationComment        NotificationType = "comment"         // 自分のセッションへのコメント
+	NotificationReply          NotificationType = "reply"           // 自分のコメントへの返信
+	NotificationFollow         NotificationType = "follow"          // 新しいフォロワー
+	NotificationFollowRequest  NotificationType = "follow_request"  // 非公開アカウントへのフォロー申請
+	NotificationGoalAchieved   NotificationType = "goal_achieved"   // 記録の保存で目標を達成した
+	NotificationPersonalRecord NotificationType = "personal_record" // 記録の保存で自己ベストを更新した
+)
+
+// Notification はユーザー（UserID）への通知。ActorID は通知のきっかけになったユーザーで、自分の達成では nil。
+// GroupKey が同じ通知は一覧で「A さん他4人がいいねしました」のように1件にまとめる。
+type Notification struct {
+	gorm.Model
+	UserID     uint             `gorm:"not null;index:idx_notifications_user_group"`
+	Type       NotificationType `gorm:"type:varchar(32);not null"`
+	GroupKey   string           `gorm:"type:varchar(64);not null;index:idx_notifications_user_group"`
+	ActorID    *uint            `gorm:"index"`
+	SessionID  *uint            `gorm:"index"`
+	CommentID  *uint            `gorm:"index"`
+	GoalID     *uint
+	ExerciseID *uint
+	ReadAt     *time.Time
+}
@@ -0,0 +1,45 @@
+package models
+
+import "math"
+
+// OneRepMaxFormula は推定1RMの計算式
+type OneRepMaxFormula string
+
+const (
+	OneRepMaxEpley    OneRepMaxFormula = "epley"
+	OneRepMaxBrzycki  OneRepMaxFormula = "brzycki"
+	OneRepMaxLombardi OneRepMaxFormula = "lombardi"
+)
+
+// 推定1RMは回数が多いほど誤差が大きくなるため、この回数以下のセットのみを対象にする
+const MaxRepsForEstimated1RM = 12
+
+func (f OneRepMaxFormula) Valid() bool {
+	switch f {
+	case OneRepMaxEpley, OneRepMaxBrzycki, OneRepMaxLombardi:
+		return true
+	}
+	return false
+}
+
+// Estimate は重量と回数から推定1RMを求める。1回なら重量そのもの、推定できない場合は0を返す。
+func (f OneRepMaxFormula) Estimate(weight float64, reps int) float64 {
+	if reps <= 0 || weight <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+	switch f {
+	case OneRepMaxEpley:
+		return weight * (1 + float64(reps)/30)
+	case OneRepMaxBrzycki:
+		if reps >= 37 {
+			return 0
+		}
+		return weight * 36 / float64(37-reps)
+	case OneRepMaxLombardi:
+		return weight * math.Pow(float64(reps), 0.10)
+	}
+	return 0
+}
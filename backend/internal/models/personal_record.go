@@ -0,0 +1,169 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// PersonalRecordType は自己ベストの種類
+type PersonalRecordType string
+
+const (
+	PRMaxWeight       PersonalRecordType = "max_weight"
+	PRMaxRepsAtWeight PersonalRecordType = "max_reps_at_weight"
+	PREstimated1RM    PersonalRecordType = "estimated_1rm"
+	PRSessionVolume   PersonalRecordType = "session_volume"
+)
+
+func (t PersonalRecordType) Valid() bool {
+	switch t {
+	case PRMaxWeight, PRMaxRepsAtWeight, PREstimated1RM, PRSessionVolume:
+		return true
+	}
+	return false
+}
+
+// PersonalRecord は自己ベストの更新履歴。ユーザー・種目ごとに記録から再計算される。
+// Value は種類ごとの指標値（max_weight: 重量, max_reps_at_weight: 回数, estimated_1rm: 推定1RM, session_volume: ボリューム）。
+// Weight / Reps は達成したセットの値で、max_reps_at_weight では Weight が重量条件になる。
+type PersonalRecord struct {
+	ID              uint               `gorm:"primaryKey"`
+	UserID          uint               `gorm:"not null;index:idx_pr_user_exercise"`
+	ExerciseID      uint               `gorm:"not null;index:idx_pr_user_exercise"`
+	Type            PersonalRecordType `gorm:"type:varchar(24);not null"`
+	Value           float64            `gorm:"not null"`
+	Weight          float64            `gorm:"not null;default:0"`
+	Reps            int                `gorm:"not null;default:0"`
+	WorkoutRecordID uint               `gorm:"not null;index"`
+	TrainedOn       time.Time          `gorm:"type:date;not null"`
+	CreatedAt       time.Time
+	Exercise        Exercise `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func roundWeight(w float64) float64 {
+	return math.Round(w*100) / 100
+}
+
+// DetectPersonalRecords は同一ユーザー・同一種目の記録（trained_on, id の昇順）から自己ベストの更新履歴を求める。
+// 重量・回数は加重分（ExerciseWeight）で比較し、推定1RMとボリュームは自重種目なら体重を含めた負荷で比較する。
+// 時間・距離の種目とウォームアップセットは対象外。
+func DetectPersonalRecords(kind MeasurementKind, records []WorkoutRecord) []PersonalRecord {
+	var weighted bool
+	switch kind {
+	case MeasurementRepsWeight, MeasurementBodyweightLoad:
+		weighted = true
+	case MeasurementRepsOnly:
+	default:
+		return nil
+	}
+
+	var (
+		out        []PersonalRecord
+		bestWeight float64
+		best1RM    float64
+		bestVolume float64
+		bestReps   = map[float64]int{}
+	)
+	for _, rec := range records {
+		var (
+			top     *WorkoutSet
+			top1RM  *WorkoutSet
+			top1RMV float64
+			volume  float64
+			repsAt  = map[float64]int{}
+			weights []float64
+		)
+		for i := range rec.Sets {
+			st := &rec.Sets[i]
+			if st.Reps <= 0 || st.SetType == SetTypeWarmup {
+				continue
+			}
+			w := roundWeight(st.ExerciseWeight)
+			if _, ok := repsAt[w]; !ok {
+				weights = append(weights, w)
+			}
+			if st.Reps > repsAt[w] {
+				repsAt[w] = st.Reps
+			}
+			if !weighted {
+				continue
+			}
+
+			load := st.ExerciseWeight
+			if kind == MeasurementBodyweightLoad {
+				load += rec.BodyWeight
+			}
+			volume += load * float64(st.Reps)
+			if top == nil || st.ExerciseWeight > top.ExerciseWeight ||
+				(st.ExerciseWeight == top.ExerciseWeight && st.Reps > top.Reps) {
+				top = st
+			}
+			if st.Reps <= MaxRepsForEstimated1RM {
+				if e := OneRepMaxEpley.Estimate(load, st.Reps); e > top1RMV {
+					top1RM, top1RMV = st, e
+				}
+			}
+		}
+
+		if top != nil && top.ExerciseWeight > bestWeight {
+			bestWeight = top.ExerciseWeight
+			out = append(out, newPersonalRecord(rec, PRMaxWeight, top.ExerciseWeight, top.ExerciseWeight, top.Reps))
+		}
+		for _, w := range weights {
+			if prev, ok := bestReps[w]; !ok || repsAt[w] > prev {
+				bestReps[w] = repsAt[w]
+				out = append(out, newPersonalRecord(rec, PRMaxRepsAtWeight, float64(repsAt[w]), w, repsAt[w]))
+			}
+		}
+		if top1RM != nil && top1RMV > best1RM {
+			best1RM = top1RMV
+			out = append(out, newPersonalRecord(rec, PREstimated1RM, roundWeight(top1RMV), top1RM.ExerciseWeight, top1RM.Reps))
+		}
+		if volume > bestVolume {
+			bestVolume = volume
+			out = append(out, newPersonalRecord(rec, PRSessionVolume, roundWeight(volume), 0, 0))
+		}
+	}
+	return out
+}
+
+func newPersonalRecord(rec WorkoutRecord, t PersonalRecordType, value, weight float64, reps int) PersonalRecord {
+	return PersonalRecord{
+		UserID:          rec.UserID,
+		ExerciseID:      rec.ExerciseID,
+		Type:            t,
+		Value:           value,
+		Weight:          weight,
+		Reps:            reps,
+		WorkoutRecordID: rec.ID,
+		TrainedOn:       rec.TrainedOn,
+	}
+}
+
+// CurrentPersonalRecords は自己ベストの更新履歴（DetectPersonalRecords の結果を種目ごとに並べたもの）から、
+// 今も破られていないもの、つまり種目・種類ごと（max_reps_at_weight は重量ごと）の最後の更新だけを返す。
+func CurrentPersonalRecords(history []PersonalRecord) []PersonalRecord {
+	type key struct {
+		exerciseID uint
+		typ        PersonalRecordType
+		weight     float64
+	}
+	keyOf := func(pr PersonalRecord) key {
+		k := key{exerciseID: pr.ExerciseID, typ: pr.Type}
+		if pr.Type == PRMaxRepsAtWeight {
+			k.weight = pr.Weight
+		}
+		return k
+	}
+	last := map[key]int{}
+	for i, pr := range history {
+		last[keyOf(pr)] = i
+	}
+	out := make([]PersonalRecord, 0, len(last))
+	for i, pr := range history {
+		if last[keyOf(pr)] == i {
+			out = append(out, pr)
+		}
+	}
+	return out
+}
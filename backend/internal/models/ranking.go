@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// RankingGranularity はランキングの集計期間の単位。custom は任意の期間（from〜to）。
+type RankingGranularity string
+
+const (
+	RankingWeek   RankingGranularity = "week"
+	RankingMonth  RankingGranularity = "month"
+	RankingYear   RankingGranularity = "year"
+	RankingCustom RankingGranularity = "custom"
+)
+
+func (g RankingGranularity) Valid() bool {
+	switch g {
+	case RankingWeek, RankingMonth, RankingYear, RankingCustom:
+		return true
+	}
+	return false
+}
+
+// RankingSnapshot は締まった期間（週・月・年）のランキングの確定結果。
+// 後から記録が編集・削除されても過去の順位が変わらないように、初めて参照した時点の結果を保存する。
+// Kind はランキングの種類（種目別は種目ID、相対筋力は計算式と階級を含む）、Entries は順位の JSON。
+type RankingSnapshot struct {
+	ID          uint               `gorm:"primaryKey"`
+	Kind        string             `gorm:"type:varchar(64);not null;uniqueIndex:ux_ranking_snapshot"`
+	Granularity RankingGranularity `gorm:"type:varchar(8);not null;uniqueIndex:ux_ranking_snapshot"`
+	PeriodStart time.Time          `gorm:"type:date;not null;uniqueIndex:ux_ranking_snapshot"`
+	Entries     string             `gorm:"type:text;not null"`
+	CreatedAt   time.Time
+}
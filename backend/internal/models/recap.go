@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// RecapPeriod はまとめ（振り返り）の期間の単位
+type RecapPeriod string
+
+const (
+	RecapMonth RecapPeriod = "month"
+	RecapYear  RecapPeriod = "year"
+)
+
+func (p RecapPeriod) Valid() bool {
+	return p == RecapMonth || p == RecapYear
+}
+
+// Start は day を含む期間の初日を返す。
+func (p RecapPeriod) Start(day time.Time) time.Time {
+	if p == RecapYear {
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End は期間の翌日（期間に含まない最初の日）を返す。
+func (p RecapPeriod) End(start time.Time) time.Time {
+	if p == RecapYear {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// Recap は月・年ごとのトレーニングのまとめ。期間が終わった後に一度だけ集計して保存する。
+// LongestStreak は期間内の連続トレーニング日数の最長、BestMonth は年のまとめでトレーニング日数が最も多い月。
+// LikesReceived は期間内のセッションが受け取ったいいねの数（保存時点）。
+type Recap struct {
+	ID                   uint        `gorm:"primaryKey"`
+	UserID               uint        `gorm:"not null;uniqueIndex:ux_recap_user_period"`
+	Period               RecapPeriod `gorm:"type:varchar(8);not null;uniqueIndex:ux_recap_user_period"`
+	PeriodStart          time.Time   `gorm:"type:date;not null;uniqueIndex:ux_recap_user_period"`
+	TotalSessions        int         `gorm:"not null;default:0"`
+	TrainingDays         int         `gorm:"not null;default:0"`
+	TotalTonnage         float64     `gorm:"not null;default:0"`
+	FavoriteExerciseID   *uint       `gorm:"index"`
+	FavoriteExerciseDays int         `gorm:"not null;default:0"`
+	PRCount              int         `gorm:"not null;default:0"`
+	LongestStreak        int         `gorm:"not null;default:0"`
+	BestMonth            *time.Time  `gorm:"type:date"`
+	BestMonthDays        int         `gorm:"not null;default:0"`
+	LikesReceived        int         `gorm:"not null;default:0"`
+	IsPublic             bool        `gorm:"not null;default:false;index"`
+	PublishedAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	User                 User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FavoriteExercise     *Exercise `gorm:"foreignKey:FavoriteExerciseID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
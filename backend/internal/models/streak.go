@@ -0,0 +1,18 @@
+package models
+
+// StreakRule は連続記録（ストリーク）の数え方。
+// daily はトレーニングした日の連続日数、weekly は ISO 週（月曜始まり）に目標日数以上トレーニングした週の連続数。
+type StreakRule string
+
+const (
+	StreakDaily  StreakRule = "daily"
+	StreakWeekly StreakRule = "weekly"
+)
+
+func (r StreakRule) Valid() bool {
+	switch r {
+	case StreakDaily, StreakWeekly:
+		return true
+	}
+	return false
+}
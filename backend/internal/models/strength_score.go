@@ -0,0 +1,115 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+// Sex は相対筋力スコアの係数と階級を選ぶための性別区分
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+)
+
+func (s Sex) Valid() bool {
+	return s == SexMale || s == SexFemale
+}
+
+// StrengthFormula は体重で補正した相対筋力スコアの計算式
+type StrengthFormula string
+
+const (
+	StrengthWilks StrengthFormula = "wilks"
+	StrengthDOTS  StrengthFormula = "dots"
+	StrengthIPFGL StrengthFormula = "ipf_gl"
+)
+
+func (f StrengthFormula) Valid() bool {
+	switch f {
+	case StrengthWilks, StrengthDOTS, StrengthIPFGL:
+		return true
+	}
+	return false
+}
+
+// BigThreeLiftNames はスコアの対象にする共通種目（スクワット・ベンチプレス・デッドリフト）の名前
+var BigThreeLiftNames = []string{"スクワット", "ベンチプレス", "デッドリフト"}
+
+func polynomial(x float64, coef []float64) float64 {
+	var sum, p float64 = 0, 1
+	for _, c := range coef {
+		sum += c * p
+		p *= x
+	}
+	return sum
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+// Coefficient は体重 bodyWeight のときにトータルの挙上重量に掛けるスコアの係数を返す。
+func (f StrengthFormula) Coefficient(sex Sex, bodyWeight float64) float64 {
+	if bodyWeight <= 0 || !sex.Valid() {
+		return 0
+	}
+	male := sex == SexMale
+	switch f {
+	case StrengthWilks:
+		if male {
+			bw := clamp(bodyWeight, 40, 201.9)
+			return 500 / polynomial(bw, []float64{-216.0475144, 16.2606339, -0.002388645, -0.00113732, 7.01863e-06, -1.291e-08})
+		}
+		bw := clamp(bodyWeight, 26.51, 154.53)
+		return 500 / polynomial(bw, []float64{594.31747775582, -27.23842536447, 0.82112226871, -0.00930733913, 4.731582e-05, -9.054e-08})
+	case StrengthDOTS:
+		if male {
+			bw := clamp(bodyWeight, 40, 210)
+			return 500 / polynomial(bw, []float64{-307.75076, 24.0900756, -0.1918759221, 0.0007391293, -0.000001093})
+		}
+		bw := clamp(bodyWeight, 40, 150)
+		return 500 / polynomial(bw, []float64{-57.96288, 13.6175032, -0.1126655495, 0.0005158568, -0.0000010706})
+	case StrengthIPFGL:
+		// クラシック（ノーギア）のパワーリフティングの係数
+		if male {
+			return 100 / (1199.72839 - 1025.18162*math.Exp(-0.00921*bodyWeight))
+		}
+		return 100 / (610.32796 - 1045.59282*math.Exp(-0.03048*bodyWeight))
+	}
+	return 0
+}
+
+// IPF の体重階級の上限(kg)。最後の階級は上限なし（"120+" / "84+"）。
+var weightClassLimits = map[Sex][]float64{
+	SexMale:   {59, 66, 74, 83, 93, 105, 120},
+	SexFemale: {47, 52, 57, 63, 69, 76, 84},
+}
+
+// WeightClass は体重の属する階級（"83" や "120+"）を返す。
+func WeightClass(sex Sex, bodyWeight float64) string {
+	limits := weightClassLimits[sex]
+	if len(limits) == 0 {
+		return ""
+	}
+	for _, limit := range limits {
+		if bodyWeight <= limit {
+			return strconv.FormatFloat(limit, 'f', -1, 64)
+		}
+	}
+	return strconv.FormatFloat(limits[len(limits)-1], 'f', -1, 64) + "+"
+}
+
+// WeightClassSex は階級の表記が有効ならその性別を返す。男女で同じ表記の階級は無い。
+func WeightClassSex(class string) (Sex, bool) {
+	for sex, limits := range weightClassLimits {
+		for _, limit := range limits {
+			label := strconv.FormatFloat(limit, 'f', -1, 64)
+			if class == label || (limit == limits[len(limits)-1] && class == label+"+") {
+				return sex, true
+			}
+		}
+	}
+	return "", false
+}
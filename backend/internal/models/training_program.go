@@ -0,0 +1,331 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed data/programs.json
+var trainingProgramsJSON []byte
+
+// ProgressionApply は重量を更新するタイミング
+type ProgressionApply string
+
+const (
+	ProgressionPerSession ProgressionApply = "session" // 種目を行うたびに判定する（リニア・GZCLP）
+	ProgressionPerCycle   ProgressionApply = "cycle"   // サイクルの終わりにまとめて判定する（5/3/1）
+)
+
+// ProgramProgression は進行ルール。成功でスロットの Increment だけ重量を上げ、
+// DeloadAfterFailures 回続けて失敗したらトレーニングマックスを DeloadPercent 下げる。
+type ProgramProgression struct {
+	Apply               ProgressionApply `json:"apply"`
+	DeloadAfterFailures int              `json:"deload_after_failures"`
+	DeloadPercent       float64          `json:"deload_percent"`
+}
+
+// ProgramSlot はプログラム内の種目枠。ユーザーが参加時に自分の種目を割り当てる。
+type ProgramSlot struct {
+	Key       string  `json:"key"`
+	Name      string  `json:"name"`
+	Increment float64 `json:"increment"`
+}
+
+// ProgramSet はトレーニングマックスに対する割合で指定するセット。AMRAP は Reps 以上できるだけ行う。
+type ProgramSet struct {
+	Percent float64 `json:"percent"`
+	Reps    int     `json:"reps"`
+	AMRAP   bool    `json:"amrap"`
+}
+
+type ProgramLift struct {
+	Slot string       `json:"slot"`
+	Sets []ProgramSet `json:"sets"`
+}
+
+type ProgramDay struct {
+	Lifts []ProgramLift `json:"lifts"`
+}
+
+type ProgramWeek struct {
+	Deload bool         `json:"deload"`
+	Days   []ProgramDay `json:"days"`
+}
+
+// TrainingProgram は data/programs.json で定義する期分けプログラム。
+// TrainingMaxPercent は履歴の推定1RMからトレーニングマックスを求める割合。
+type TrainingProgram struct {
+	Key                string             `json:"key"`
+	Name               string             `json:"name"`
+	Description        string             `json:"description"`
+	TrainingMaxPercent float64            `json:"training_max_percent"`
+	Progression        ProgramProgression `json:"progression"`
+	Slots              []ProgramSlot      `json:"slots"`
+	Weeks              []ProgramWeek      `json:"weeks"`
+}
+
+var trainingPrograms = mustLoadTrainingPrograms()
+
+func mustLoadTrainingPrograms() []TrainingProgram {
+	programs, err := loadTrainingPrograms(trainingProgramsJSON)
+	if err != nil {
+		panic(err)
+	}
+	return programs
+}
+
+// loadTrainingPrograms はプログラム定義を読み込み、スロットと週・日の構成を検証する。
+func loadTrainingPrograms(raw []byte) ([]TrainingProgram, error) {
+	var programs []TrainingProgram
+	if err := json.Unmarshal(raw, &programs); err != nil {
+		return nil, fmt.Errorf("プログラム定義の読み込みに失敗しました: %w", err)
+	}
+
+	keys := make(map[string]bool, len(programs))
+	for _, p := range programs {
+		if p.Key == "" || keys[p.Key] {
+			return nil, fmt.Errorf("プログラムのキーが不正です: %q", p.Key)
+		}
+		keys[p.Key] = true
+
+		if p.TrainingMaxPercent <= 0 || p.TrainingMaxPercent > 1 ||
+			(p.Progression.Apply != ProgressionPerSession && p.Progression.Apply != ProgressionPerCycle) ||
+			p.Progression.DeloadPercent < 0 || p.Progression.DeloadPercent >= 1 {
+			return nil, fmt.Errorf("プログラムの進行ルールが不正です: %s", p.Key)
+		}
+
+		slots := make(map[string]bool, len(p.Slots))
+		for _, s := range p.Slots {
+			if s.Key == "" || slots[s.Key] || s.Increment < 0 {
+				return nil, fmt.Errorf("プログラムのスロットが不正です: %s/%s", p.Key, s.Key)
+			}
+			slots[s.Key] = true
+		}
+
+		if len(p.Weeks) == 0 {
+			return nil, fmt.Errorf("プログラムに週がありません: %s", p.Key)
+		}
+		for _, w := range p.Weeks {
+			if len(w.Days) == 0 {
+				return nil, fmt.Errorf("プログラムに日がありません: %s", p.Key)
+			}
+			for _, d := range w.Days {
+				seen := make(map[string]bool, len(d.Lifts))
+				for _, l := range d.Lifts {
+					if !slots[l.Slot] || seen[l.Slot] || len(l.Sets) == 0 {
+						return nil, fmt.Errorf("プログラムの種目指定が不正です: %s/%s", p.Key, l.Slot)
+					}
+					seen[l.Slot] = true
+					for _, st := range l.Sets {
+						if st.Percent <= 0 || st.Reps <= 0 {
+							return nil, fmt.Errorf("プログラムのセット指定が不正です: %s/%s", p.Key, l.Slot)
+						}
+					}
+				}
+			}
+		}
+	}
+	return programs, nil
+}
+
+// TrainingPrograms は定義済みのプログラムを返す。
+func TrainingPrograms() []TrainingProgram {
+	return trainingPrograms
+}
+
+func FindTrainingProgram(key string) (*TrainingProgram, bool) {
+	for i := range trainingPrograms {
+		if trainingPrograms[i].Key == key {
+			return &trainingPrograms[i], true
+		}
+	}
+	return nil, false
+}
+
+func (p *TrainingProgram) Slot(key string) (*ProgramSlot, bool) {
+	for i := range p.Slots {
+		if p.Slots[i].Key == key {
+			return &p.Slots[i], true
+		}
+	}
+	return nil, false
+}
+
+// DaysPerWeek は週ごとの日数の最大値
+func (p *TrainingProgram) DaysPerWeek() int {
+	n := 0
+	for _, w := range p.Weeks {
+		n = max(n, len(w.Days))
+	}
+	return n
+}
+
+// RoundToPlate は重量を 2.5kg 単位に丸める。
+func RoundToPlate(w float64) float64 {
+	return math.Round(w/2.5) * 2.5
+}
+
+// UserProgram はユーザーが参加中のプログラムと進行状況（ユーザーごとに1件）。
+// Cycle / Week / Day は0始まりで、次に行う日を指す。
+type UserProgram struct {
+	gorm.Model
+	UserID     uint              `gorm:"not null;uniqueIndex"`
+	ProgramKey string            `gorm:"type:varchar(32);not null"`
+	Cycle      int               `gorm:"not null;default:0"`
+	Week       int               `gorm:"not null;default:0"`
+	Day        int               `gorm:"not null;default:0"`
+	StartedOn  time.Time         `gorm:"type:date;not null"`
+	Lifts      []UserProgramLift `gorm:"foreignKey:UserProgramID;constraint:OnDelete:CASCADE"`
+}
+
+// UserProgramLift はスロットごとの割り当て種目とトレーニングマックス。
+// StartingTrainingMax は参加時のトレーニングマックスで、記録から進行状況を作り直すときの起点にする。
+// Done は現在の日にそのスロットを記録済みか、CycleFailed はサイクル内で失敗があったか。
+type UserProgramLift struct {
+	ID                  uint     `gorm:"primaryKey"`
+	UserProgramID       uint     `gorm:"not null;index"`
+	Slot                string   `gorm:"type:varchar(32);not null"`
+	ExerciseID          uint     `gorm:"not null;index"`
+	Exercise            Exercise `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TrainingMax         float64  `gorm:"not null"`
+	StartingTrainingMax float64  `gorm:"not null;default:0"`
+	Failures            int      `gorm:"not null;default:0"`
+	CycleFailed         bool     `gorm:"not null;default:false"`
+	Done                bool     `gorm:"not null;default:false"`
+}
+
+// CurrentDay は次に行う日の指定を返す。
+func (up *UserProgram) CurrentDay(p *TrainingProgram) ProgramDay {
+	return p.Weeks[up.Week].Days[up.Day]
+}
+
+func (up *UserProgram) lift(slot string) *UserProgramLift {
+	for i := range up.Lifts {
+		if up.Lifts[i].Slot == slot {
+			return &up.Lifts[i]
+		}
+	}
+	return nil
+}
+
+// ResetProgress は進行状況を参加時の状態に戻す。
+func (up *UserProgram) ResetProgress() {
+	up.Cycle, up.Week, up.Day = 0, 0, 0
+	for i := range up.Lifts {
+		l := &up.Lifts[i]
+		l.TrainingMax = l.StartingTrainingMax
+		l.Failures = 0
+		l.CycleFailed = false
+		l.Done = false
+	}
+}
+
+// LiftSucceeded は記録したセットが指定のセットをすべて満たしたかを返す。
+// ウォームアップを除くセットを重い順に並べ、指定のセットを重い順に1対1で割り当てて判定する。
+func LiftSucceeded(prescribed []ProgramSet, trainingMax float64, sets []WorkoutSet) bool {
+	var done []WorkoutSet
+	for _, st := range sets {
+		if st.SetType != SetTypeWarmup {
+			done = append(done, st)
+		}
+	}
+	used := make([]bool, len(done))
+	for _, ps := range prescribed {
+		target := RoundToPlate(trainingMax * ps.Percent)
+		matched := false
+		for i, st := range done {
+			if !used[i] && st.ExerciseWeight >= target && st.Reps >= ps.Reps {
+				used[i] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyRecord は記録を現在の日の未実施スロットに当てはめ、進行ルールに従って状態を進める。
+// 当てはまるスロットが無ければ false を返し、状態は変えない。
+func (up *UserProgram) ApplyRecord(p *TrainingProgram, rec WorkoutRecord) bool {
+	var (
+		target *UserProgramLift
+		spec   ProgramLift
+	)
+	for _, l := range up.CurrentDay(p).Lifts {
+		lift := up.lift(l.Slot)
+		if lift != nil && !lift.Done && lift.ExerciseID == rec.ExerciseID {
+			target, spec = lift, l
+			break
+		}
+	}
+	if target == nil {
+		return false
+	}
+
+	ok := LiftSucceeded(spec.Sets, target.TrainingMax, rec.Sets)
+	target.Done = true
+	switch p.Progression.Apply {
+	case ProgressionPerSession:
+		target.progress(p, ok)
+	case ProgressionPerCycle:
+		if !ok {
+			target.CycleFailed = true
+		}
+	}
+
+	for _, l := range up.CurrentDay(p).Lifts {
+		if lift := up.lift(l.Slot); lift != nil && !lift.Done {
+			return true
+		}
+	}
+	up.advance(p)
+	return true
+}
+
+// advance は次の日へ進める。週・サイクルの終わりでは繰り上げ、サイクルごとの進行ルールを適用する。
+func (up *UserProgram) advance(p *TrainingProgram) {
+	for i := range up.Lifts {
+		up.Lifts[i].Done = false
+	}
+	up.Day++
+	if up.Day < len(p.Weeks[up.Week].Days) {
+		return
+	}
+	up.Day = 0
+	up.Week++
+	if up.Week < len(p.Weeks) {
+		return
+	}
+	up.Week = 0
+	up.Cycle++
+	if p.Progression.Apply == ProgressionPerCycle {
+		for i := range up.Lifts {
+			l := &up.Lifts[i]
+			l.progress(p, !l.CycleFailed)
+			l.CycleFailed = false
+		}
+	}
+}
+
+func (l *UserProgramLift) progress(p *TrainingProgram, succeeded bool) {
+	if succeeded {
+		if slot, ok := p.Slot(l.Slot); ok {
+			l.TrainingMax += slot.Increment
+		}
+		l.Failures = 0
+		return
+	}
+	l.Failures++
+	if p.Progression.DeloadAfterFailures > 0 && l.Failures >= p.Progression.DeloadAfterFailures {
+		l.TrainingMax = RoundToPlate(l.TrainingMax * (1 - p.Progression.DeloadPercent))
+		l.Failures = 0
+	}
+}
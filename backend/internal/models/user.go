@@ -1,12 +1,25 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
 	Email      string `gorm:"unique"`
 	Password   string
-	Records    []WorkoutRecord `gorm:"constraint:OnDelete:CASCADE"`
-	Height     *float64        `gorm:"type:numeric(4,1)"`
-	GoalWeight *float64        `gorm:"type:numeric(4,1)"`
+	Records    []WorkoutRecord  `gorm:"constraint:OnDelete:CASCADE"`
+	Sessions   []WorkoutSession `gorm:"constraint:OnDelete:CASCADE"`
+	Height     *float64         `gorm:"type:numeric(4,1)"`
+	GoalWeight *float64         `gorm:"type:numeric(4,1)"`
+	GoalDate   *time.Time       `gorm:"type:date"`
+	// ストリークの数え方。StreakWeeklyTarget は weekly で1週に必要なトレーニング日数
+	StreakRule         StreakRule `gorm:"type:varchar(16);not null;default:daily"`
+	StreakWeeklyTarget int        `gorm:"not null;default:3"`
+	// 相対筋力スコア（Wilks/DOTS/IPF GL）の係数と体重階級に使う。未設定ならスコアのランキングに含めない
+	Sex *Sex `gorm:"type:varchar(8)"`
+	// 非公開アカウントの記録は、承認したフォロワーだけがタイムラインで見られる
+	IsPrivate bool `gorm:"not null;default:false"`
 }
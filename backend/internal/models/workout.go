@@ -8,14 +8,35 @@ import (
 
 type WorkoutRecord struct {
 	gorm.Model
-	UserID     uint
-	ExerciseID uint `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	BodyWeight float64
-	TrainedOn  time.Time    `gorm:"type:date;not null;index"`
-	Sets       []WorkoutSet `gorm:"constraint:OnDelete:CASCADE"`
-	Exercise   Exercise     `gorm:"foreignKey:ExerciseID"`
-	IsPublic   bool         `gorm:"default:false"`
-	Comment    string       `gorm:"type:text"`
+	UserID          uint
+	SessionID       *uint `gorm:"index"`
+	Position        int   `gorm:"not null;default:0"`
+	ExerciseID      uint  `gorm:"index"`
+	BodyWeight      float64
+	TrainedOn       time.Time        `gorm:"type:date;not null;index"`
+	Sets            []WorkoutSet     `gorm:"constraint:OnDelete:CASCADE"`
+	Exercise        Exercise         `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:NO ACTION;"`
+	IsPublic        bool             `gorm:"default:false"`
+	Comment         string           `gorm:"type:text"`
+	PersonalRecords []PersonalRecord `gorm:"foreignKey:WorkoutRecordID;constraint:OnDelete:CASCADE"`
+}
+
+type SetType string
+
+const (
+	SetTypeWarmup  SetType = "warmup"
+	SetTypeWorking SetType = "working"
+	SetTypeDrop    SetType = "drop"
+	SetTypeFailure SetType = "failure"
+	SetTypeAMRAP   SetType = "amrap"
+)
+
+func (t SetType) Valid() bool {
+	switch t {
+	case SetTypeWarmup, SetTypeWorking, SetTypeDrop, SetTypeFailure, SetTypeAMRAP:
+		return true
+	}
+	return false
 }
 
 type WorkoutSet struct {
@@ -24,4 +45,12 @@ type WorkoutSet struct {
 	SetNo           int
 	Reps            int
 	ExerciseWeight  float64
+	SetType         SetType  `gorm:"type:varchar(16);not null;default:working"`
+	RPE             *float64 `gorm:"type:numeric(3,1)"`
+	RIR             *int
+	RestSeconds     *int
+	Tempo           string `gorm:"type:varchar(16)"`
+	Note            string `gorm:"type:text"`
+	DurationSeconds *int
+	DistanceMeters  *float64
 }
@@ -0,0 +1,15 @@
+package models
+
+import "gorm.io/gorm"
+
+// WorkoutComment は公開セッションへのコメント。ParentID があれば返信で、返信への返信は持たない（1階層のみ）。
+type WorkoutComment struct {
+	gorm.Model
+	SessionID uint   `gorm:"not null;index:idx_workout_comments_session_parent"`
+	UserID    uint   `gorm:"not null;index"`
+	ParentID  *uint  `gorm:"index:idx_workout_comments_session_parent"`
+	Body      string `gorm:"type:text;not null"`
+
+	User    User           `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Session WorkoutSession `gorm:"foreignKey:SessionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -4,9 +4,9 @@ import "gorm.io/gorm"
 
 type WorkoutLike struct {
 	gorm.Model
-	UserID   uint `gorm:"not null;index;uniqueIndex:ux_user_record"`
-	RecordID uint `gorm:"not null;index;uniqueIndex:ux_user_record"`
+	UserID    uint `gorm:"not null;index;uniqueIndex:ux_user_session"`
+	SessionID uint `gorm:"not null;index;uniqueIndex:ux_user_session"`
 
-	User   User          `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Record WorkoutRecord `gorm:"foreignKey:RecordID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User    User           `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Session WorkoutSession `gorm:"foreignKey:SessionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkoutSession は1回のトレーニング（ジム1回分）を表し、種目ごとの WorkoutRecord を順序付きで保持する。
+// LikeCount・CommentCount は workout_likes・workout_comments の件数を非正規化したもので、
+// いいね・コメントの作成・削除と同じトランザクションで更新する。
+type WorkoutSession struct {
+	gorm.Model
+	UserID       uint            `gorm:"not null;index"`
+	TrainedOn    time.Time       `gorm:"type:date;not null;index"`
+	StartedAt    *time.Time      `gorm:"default:null"`
+	EndedAt      *time.Time      `gorm:"default:null"`
+	BodyWeight   float64         `gorm:"not null;default:0"`
+	IsPublic     bool            `gorm:"default:false"`
+	Comment      string          `gorm:"type:text"`
+	TemplateID   *uint           `gorm:"index"`
+	LikeCount    int             `gorm:"not null;default:0"`
+	CommentCount int             `gorm:"not null;default:0"`
+	Records      []WorkoutRecord `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE"`
+}
+
+// NewSessionFromRecord は単一種目の記録から、その記録だけを取り込んだセッションを作る。
+func NewSessionFromRecord(rec *WorkoutRecord) *WorkoutSession {
+	s := &WorkoutSession{
+		UserID:    rec.UserID,
+		TrainedOn: rec.TrainedOn,
+		IsPublic:  rec.IsPublic,
+	}
+	s.MergeRecord(rec)
+	return s
+}
+
+// MergeRecord は単一種目の記録が持つ体重・公開フラグ・コメントをセッションへ取り込む。
+// 非公開の記録を公開しないよう、セッションは取り込んだ記録がすべて公開の場合だけ公開のままにする。
+// 旧 API からの作成とマイグレーションで同じ規則を使う。
+func (s *WorkoutSession) MergeRecord(rec *WorkoutRecord) {
+	if rec.BodyWeight > 0 {
+		s.BodyWeight = rec.BodyWeight
+	}
+	if !rec.IsPublic {
+		s.IsPublic = false
+	}
+	if c := strings.TrimSpace(rec.Comment); c != "" {
+		if s.Comment == "" {
+			s.Comment = c
+		} else {
+			s.Comment = s.Comment + "\n" + c
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkoutTemplate は「Push day」のような繰り返し行うメニュー。種目と目標セットを順序付きで保持する。
+type WorkoutTemplate struct {
+	gorm.Model
+	UserID          uint                      `gorm:"not null;index"`
+	Name            string                    `gorm:"type:varchar(100);not null"`
+	Note            string                    `gorm:"type:text"`
+	LastPerformedOn *time.Time                `gorm:"type:date"`
+	Exercises       []WorkoutTemplateExercise `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+}
+
+type WorkoutTemplateExercise struct {
+	ID         uint                 `gorm:"primaryKey"`
+	TemplateID uint                 `gorm:"not null;index"`
+	Position   int                  `gorm:"not null;default:0"`
+	ExerciseID uint                 `gorm:"not null;index"`
+	Exercise   Exercise             `gorm:"foreignKey:ExerciseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Sets       []WorkoutTemplateSet `gorm:"foreignKey:TemplateExerciseID;constraint:OnDelete:CASCADE"`
+}
+
+// WorkoutTemplateSet は目標セット。LastWeight はこのテンプレートから記録した直近の使用重量。
+type WorkoutTemplateSet struct {
+	ID                 uint    `gorm:"primaryKey"`
+	TemplateExerciseID uint    `gorm:"not null;index"`
+	SetNo              int     `gorm:"not null"`
+	SetType            SetType `gorm:"type:varchar(16);not null;default:working"`
+	Reps               int
+	ExerciseWeight     float64
+	RestSeconds        *int
+	Tempo              string `gorm:"type:varchar(16)"`
+	Note               string `gorm:"type:text"`
+	DurationSeconds    *int
+	DistanceMeters     *float64
+	LastWeight         *float64
+}
+
+// PrefillWeight は次回の記録に使う重量。直近の使用重量があればそれを優先する。
+func (s WorkoutTemplateSet) PrefillWeight() float64 {
+	if s.LastWeight != nil {
+		return *s.LastWeight
+	}
+	return s.ExerciseWeight
+}
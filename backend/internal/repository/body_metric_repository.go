@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type BodyMetricRepository interface {
+	Create(metric *models.BodyMetric) error
+	FindByUserInRange(userID uint, from, to *time.Time) ([]models.BodyMetric, error)
+	FindByIDAndUserID(id uint, userID uint) (*models.BodyMetric, error)
+	Update(metric *models.BodyMetric) error
+	Delete(id uint, userID uint) error
+	GetHeight(userID uint) (*float64, error)
+}
+
+type bodyMetricRepository struct {
+	db *gorm.DB
+}
+
+func NewBodyMetricRepository(db *gorm.DB) BodyMetricRepository {
+	return &bodyMetricRepository{db: db}
+}
+
+// 同じ日の記録が既にある場合は ErrUniqueViolation に変換する
+func translateBodyMetricWriteError(err error) error {
+	if isUniqueViolation(err) {
+		return ErrUniqueViolation
+	}
+	return err
+}
+
+func (r *bodyMetricRepository) Create(metric *models.BodyMetric) error {
+	if err := r.db.Create(metric).Error; err != nil {
+		return translateBodyMetricWriteError(err)
+	}
+	return nil
+}
+
+// FindByUserInRange は計測日の昇順で返す（from / to は nil なら無制限）。
+func (r *bodyMetricRepository) FindByUserInRange(userID uint, from, to *time.Time) ([]models.BodyMetric, error) {
+	q := r.db.Where("user_id = ?", userID)
+	if from != nil {
+		q = q.Where("measured_on >= ?", *from)
+	}
+	if to != nil {
+		q = q.Where("measured_on <= ?", *to)
+	}
+
+	var metrics []models.BodyMetric
+	if err := q.Order("measured_on ASC").Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (r *bodyMetricRepository) FindByIDAndUserID(id uint, userID uint) (*models.BodyMetric, error) {
+	var metric models.BodyMetric
+	err := r.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&metric).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &metric, nil
+}
+
+// Update は計測日を含むすべての項目を上書きする（NULL への変更も反映する）。
+func (r *bodyMetricRepository) Update(metric *models.BodyMetric) error {
+	res := r.db.
+		Model(&models.BodyMetric{}).
+		Where("id = ? AND user_id = ?", metric.ID, metric.UserID).
+		Select("measured_on", "weight", "body_fat_percent", "muscle_mass",
+			"neck", "chest", "waist", "hips", "arm", "thigh", "note", "updated_at").
+		Updates(metric)
+	if res.Error != nil {
+		return translateBodyMetricWriteError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *bodyMetricRepository) Delete(id uint, userID uint) error {
+	res := r.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.BodyMetric{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *bodyMetricRepository) GetHeight(userID uint) (*float64, error) {
+	var u models.User
+	if err := r.db.
+		Select("id, height").
+		First(&u, userID).Error; err != nil {
+		return nil, err
+	}
+	return u.Height, nil
+}
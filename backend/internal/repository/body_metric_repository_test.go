@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newBodyMetricTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.BodyMetric{}))
+	return db
+}
+
+func TestBodyMetricRepository_CreateAndFind(t *testing.T) {
+	db := newBodyMetricTestDB(t)
+	repo := NewBodyMetricRepository(db)
+
+	user := models.User{Email: "metric@example.com"}
+	other := models.User{Email: "other@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	require.NoError(t, db.Create(&other).Error)
+
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	for _, m := range []*models.BodyMetric{
+		{UserID: user.ID, MeasuredOn: day(3), Weight: utils.Ptr(70.2)},
+		{UserID: user.ID, MeasuredOn: day(1), Weight: utils.Ptr(70.8), Waist: utils.Ptr(82.0)},
+		{UserID: user.ID, MeasuredOn: day(5), BodyFatPercent: utils.Ptr(17.5)},
+		{UserID: other.ID, MeasuredOn: day(3), Weight: utils.Ptr(55.0)},
+	} {
+		require.NoError(t, repo.Create(m))
+	}
+
+	t.Run("【異常系】同じ日の記録は ErrUniqueViolation を返すこと", func(t *testing.T) {
+		err := repo.Create(&models.BodyMetric{UserID: user.ID, MeasuredOn: day(3), Weight: utils.Ptr(71.0)})
+		require.ErrorIs(t, err, ErrUniqueViolation)
+	})
+
+	t.Run("【正常系】範囲内の自分の記録を計測日の昇順で返すこと", func(t *testing.T) {
+		from, to := day(1), day(4)
+		got, err := repo.FindByUserInRange(user.ID, &from, &to)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, 70.8, *got[0].Weight)
+		require.Equal(t, 82.0, *got[0].Waist)
+		require.Equal(t, 70.2, *got[1].Weight)
+
+		all, err := repo.FindByUserInRange(user.ID, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+	})
+
+	t.Run("【異常系】他人の記録は ErrNotFound を返すこと", func(t *testing.T) {
+		var theirs models.BodyMetric
+		require.NoError(t, db.Where("user_id = ?", other.ID).First(&theirs).Error)
+		_, err := repo.FindByIDAndUserID(theirs.ID, user.ID)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestBodyMetricRepository_UpdateDelete(t *testing.T) {
+	db := newBodyMetricTestDB(t)
+	repo := NewBodyMetricRepository(db)
+
+	user := models.User{Email: "metric@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+
+	first := &models.BodyMetric{UserID: user.ID, MeasuredOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), Weight: utils.Ptr(70.0), Waist: utils.Ptr(80.0)}
+	second := &models.BodyMetric{UserID: user.ID, MeasuredOn: time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC), Weight: utils.Ptr(69.5)}
+	require.NoError(t, repo.Create(first))
+	require.NoError(t, repo.Create(second))
+
+	t.Run("【正常系】未入力にした項目は NULL に更新されること", func(t *testing.T) {
+		require.NoError(t, repo.Update(&models.BodyMetric{
+			ID: first.ID, UserID: user.ID, MeasuredOn: first.MeasuredOn, Weight: utils.Ptr(70.4), Note: "朝",
+		}))
+		got, err := repo.FindByIDAndUserID(first.ID, user.ID)
+		require.NoError(t, err)
+		require.Equal(t, 70.4, *got.Weight)
+		require.Nil(t, got.Waist)
+		require.Equal(t, "朝", got.Note)
+	})
+
+	t.Run("【異常系】別の記録と同じ日に変更すると ErrUniqueViolation を返すこと", func(t *testing.T) {
+		err := repo.Update(&models.BodyMetric{ID: first.ID, UserID: user.ID, MeasuredOn: second.MeasuredOn, Weight: utils.Ptr(70.0)})
+		require.ErrorIs(t, err, ErrUniqueViolation)
+	})
+
+	t.Run("【異常系】他人の記録は更新・削除できないこと", func(t *testing.T) {
+		err := repo.Update(&models.BodyMetric{ID: first.ID, UserID: user.ID + 1, MeasuredOn: first.MeasuredOn, Weight: utils.Ptr(1.0)})
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, repo.Delete(first.ID, user.ID+1), ErrNotFound)
+	})
+
+	t.Run("【正常系】削除できること", func(t *testing.T) {
+		require.NoError(t, repo.Delete(first.ID, user.ID))
+		_, err := repo.FindByIDAndUserID(first.ID, user.ID)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, repo.Delete(first.ID, user.ID), ErrNotFound)
+	})
+}
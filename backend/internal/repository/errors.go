@@ -1,6 +1,11 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
 
 var ErrFKViolation = errors.New("foreign key violation")
 var ErrNotFound = errors.New("record not found")
@@ -13,3 +18,10 @@ type ConstraintError struct {
 func (e *ConstraintError) Error() string {
 	return "constraint violation: " + e.Constraint
 }
+
+// isUniqueViolation は一意制約の違反かどうかを返す。
+// Postgres のエラーは db.New の TranslateError で gorm.ErrDuplicatedKey に変換される。
+func isUniqueViolation(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey) ||
+		strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
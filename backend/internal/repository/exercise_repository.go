@@ -2,13 +2,32 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"gorm.io/gorm"
 )
 
 type ExerciseRepository interface {
-	List(ctx context.Context) ([]models.Exercise, error)
+	List(ctx context.Context, userID uint, filter ExerciseFilter) ([]models.Exercise, error)
+	FindOwnedByID(ctx context.Context, id uint, userID uint) (*models.Exercise, error)
+	NameExists(ctx context.Context, userID uint, name string, excludeID uint) (bool, error)
+	Create(ctx context.Context, ex *models.Exercise) error
+	Update(ctx context.Context, ex *models.Exercise) error
+	SetArchivedAt(ctx context.Context, id uint, userID uint, archivedAt *time.Time) error
+	Delete(ctx context.Context, id uint, userID uint) error
+	HasRecords(ctx context.Context, id uint) (bool, error)
+}
+
+// ExerciseFilter は種目一覧の検索条件。空の項目は条件に含めない。
+type ExerciseFilter struct {
+	Query           string
+	MuscleGroup     models.MuscleGroup
+	Equipment       models.Equipment
+	MovementPattern models.MovementPattern
+	IncludeArchived bool
 }
 
 type exerciseRepository struct {
@@ -19,13 +38,131 @@ func NewExerciseRepository(db *gorm.DB) ExerciseRepository {
 	return &exerciseRepository{db: db}
 }
 
-func (r *exerciseRepository) List(ctx context.Context) ([]models.Exercise, error) {
+// List は共通種目・userID の独自種目・他のユーザーが共有した種目を返す。
+// Query は種目名と別名の部分一致、MuscleGroup は主働筋・補助部位のどちらかに一致するものを対象にする。
+func (r *exerciseRepository) List(ctx context.Context, userID uint, filter ExerciseFilter) ([]models.Exercise, error) {
 	var xs []models.Exercise
-	if err := r.db.WithContext(ctx).
-		Select("id", "name").
+	q := r.db.WithContext(ctx).
+		Select("id", "name", "measurement_kind", "owner_id", "is_shared", "archived_at",
+			"primary_muscle", "equipment", "movement_pattern").
+		Where("owner_id IS NULL OR owner_id = ? OR is_shared = ?", userID, true)
+	if !filter.IncludeArchived {
+		q = q.Where("archived_at IS NULL")
+	}
+	if filter.Query != "" {
+		like := "%" + strings.ToLower(filter.Query) + "%"
+		q = q.Where(
+			"LOWER(name) LIKE ? OR EXISTS (SELECT 1 FROM exercise_aliases a WHERE a.exercise_id = exercises.id AND LOWER(a.name) LIKE ?)",
+			like, like,
+		)
+	}
+	if filter.MuscleGroup != "" {
+		q = q.Where(
+			"primary_muscle = ? OR EXISTS (SELECT 1 FROM exercise_muscles m WHERE m.exercise_id = exercises.id AND m.muscle_group = ?)",
+			filter.MuscleGroup, filter.MuscleGroup,
+		)
+	}
+	if filter.Equipment != "" {
+		q = q.Where("equipment = ?", filter.Equipment)
+	}
+	if filter.MovementPattern != "" {
+		q = q.Where("movement_pattern = ?", filter.MovementPattern)
+	}
+	if err := q.
+		Preload("SecondaryMuscles", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
 		Order("id ASC").
 		Find(&xs).Error; err != nil {
 		return nil, err
 	}
 	return xs, nil
 }
+
+func (r *exerciseRepository) FindOwnedByID(ctx context.Context, id uint, userID uint) (*models.Exercise, error) {
+	var ex models.Exercise
+	if err := r.db.WithContext(ctx).
+		Preload("SecondaryMuscles").
+		Preload("Aliases").
+		Where("id = ? AND owner_id = ?", id, userID).
+		First(&ex).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &ex, nil
+}
+
+// NameExists は共通種目または userID の独自種目に同名のものがあるかを返す（excludeID は除外）。
+func (r *exerciseRepository) NameExists(ctx context.Context, userID uint, name string, excludeID uint) (bool, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Exercise{}).
+		Where("name = ? AND (owner_id IS NULL OR owner_id = ?) AND id <> ?", name, userID, excludeID).
+		Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+func (r *exerciseRepository) Create(ctx context.Context, ex *models.Exercise) error {
+	if err := r.db.WithContext(ctx).Create(ex).Error; err != nil {
+		return translateExerciseWriteError(err)
+	}
+	return nil
+}
+
+func (r *exerciseRepository) Update(ctx context.Context, ex *models.Exercise) error {
+	if err := r.db.WithContext(ctx).
+		Model(&models.Exercise{}).
+		Where("id = ? AND owner_id = ?", ex.ID, ex.OwnerID).
+		Updates(map[string]any{
+			"name":      ex.Name,
+			"is_shared": ex.IsShared,
+		}).Error; err != nil {
+		return translateExerciseWriteError(err)
+	}
+	return nil
+}
+
+func (r *exerciseRepository) SetArchivedAt(ctx context.Context, id uint, userID uint, archivedAt *time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Exercise{}).
+		Where("id = ? AND owner_id = ?", id, userID).
+		Update("archived_at", archivedAt).Error
+}
+
+func (r *exerciseRepository) Delete(ctx context.Context, id uint, userID uint) error {
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND owner_id = ?", id, userID).
+		Delete(&models.Exercise{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) ||
+			strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return ErrFKViolation
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *exerciseRepository) HasRecords(ctx context.Context, id uint) (bool, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.WorkoutRecord{}).
+		Where("exercise_id = ?", id).
+		Limit(1).
+		Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+func translateExerciseWriteError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) ||
+		strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return ErrUniqueViolation
+	}
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/stretchr/testify/require"
@@ -16,7 +17,16 @@ func newExerciseTestDB(t *testing.T) *gorm.DB {
 	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
 	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.Exercise{}))
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.ExerciseMuscle{},
+		&models.ExerciseAlias{},
+		&models.WorkoutSession{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+		&models.PersonalRecord{},
+	))
 	return db
 }
 
@@ -68,7 +78,7 @@ func TestExerciseRepository_List(t *testing.T) {
 			tt.prepare(db)
 
 			repo := NewExerciseRepository(db)
-			got, err := repo.List(ctx)
+			got, err := repo.List(ctx, 1, ExerciseFilter{})
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -89,3 +99,268 @@ func TestExerciseRepository_List(t *testing.T) {
 		})
 	}
 }
+
+func TestExerciseRepository_ListVisibility(t *testing.T) {
+	ctx := context.Background()
+	db := newExerciseTestDB(t)
+	repo := NewExerciseRepository(db)
+
+	me := models.User{Email: "me@example.com"}
+	other := models.User{Email: "other@example.com"}
+	require.NoError(t, db.Create(&me).Error)
+	require.NoError(t, db.Create(&other).Error)
+
+	archivedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.Create(&[]models.Exercise{
+		{Name: "ベンチプレス"},
+		{Name: "マイ種目", OwnerID: &me.ID},
+		{Name: "旧マイ種目", OwnerID: &me.ID, ArchivedAt: &archivedAt},
+		{Name: "他人の種目", OwnerID: &other.ID},
+		{Name: "他人の共有種目", OwnerID: &other.ID, IsShared: true},
+		{Name: "他人の旧共有種目", OwnerID: &other.ID, IsShared: true, ArchivedAt: &archivedAt},
+	}).Error)
+
+	tests := []struct {
+		name            string
+		includeArchived bool
+		wantNames       []string
+	}{
+		{
+			name:      "【正常系】共通種目・自分の種目・他人の共有種目を返し、アーカイブ済みは除外すること",
+			wantNames: []string{"ベンチプレス", "マイ種目", "他人の共有種目"},
+		},
+		{
+			name:            "【正常系】include_archived の場合はアーカイブ済みも返すこと",
+			includeArchived: true,
+			wantNames:       []string{"ベンチプレス", "マイ種目", "旧マイ種目", "他人の共有種目", "他人の旧共有種目"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, me.ID, ExerciseFilter{IncludeArchived: tt.includeArchived})
+			require.NoError(t, err)
+
+			names := make([]string, len(got))
+			for i, e := range got {
+				names[i] = e.Name
+			}
+			require.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestExerciseRepository_ListFilter(t *testing.T) {
+	ctx := context.Background()
+	db := newExerciseTestDB(t)
+	repo := NewExerciseRepository(db)
+
+	me := models.User{Email: "me@example.com"}
+	require.NoError(t, db.Create(&me).Error)
+
+	require.NoError(t, db.Create(&[]models.Exercise{
+		{
+			Name:             "ベンチプレス",
+			PrimaryMuscle:    models.MuscleChest,
+			SecondaryMuscles: []models.ExerciseMuscle{{MuscleGroup: models.MuscleTriceps}, {MuscleGroup: models.MuscleShoulders}},
+			Equipment:        models.EquipmentBarbell,
+			MovementPattern:  models.MovementHorizontalPush,
+			Aliases:          []models.ExerciseAlias{{Locale: "en", Name: "Bench Press"}},
+		},
+		{
+			Name:            "ダンベルフライ",
+			PrimaryMuscle:   models.MuscleChest,
+			Equipment:       models.EquipmentDumbbell,
+			MovementPattern: models.MovementIsolation,
+			Aliases:         []models.ExerciseAlias{{Locale: "en", Name: "Dumbbell Fly"}},
+		},
+		{
+			Name:            "トライセプスプッシュダウン",
+			PrimaryMuscle:   models.MuscleTriceps,
+			Equipment:       models.EquipmentCable,
+			MovementPattern: models.MovementIsolation,
+		},
+		{
+			Name:          "マイベンチ",
+			OwnerID:       &me.ID,
+			PrimaryMuscle: models.MuscleChest,
+			Equipment:     models.EquipmentMachine,
+		},
+	}).Error)
+
+	tests := []struct {
+		name      string
+		filter    ExerciseFilter
+		wantNames []string
+	}{
+		{
+			name:      "【正常系】種目名の部分一致で検索できること",
+			filter:    ExerciseFilter{Query: "ベンチ"},
+			wantNames: []string{"ベンチプレス", "マイベンチ"},
+		},
+		{
+			name:      "【正常系】別名を大文字小文字を区別せずに検索できること",
+			filter:    ExerciseFilter{Query: "bench"},
+			wantNames: []string{"ベンチプレス"},
+		},
+		{
+			name:      "【正常系】部位は主働筋と補助部位のどちらにも一致すること",
+			filter:    ExerciseFilter{MuscleGroup: models.MuscleTriceps},
+			wantNames: []string{"ベンチプレス", "トライセプスプッシュダウン"},
+		},
+		{
+			name:      "【正常系】器具と動作パターンを組み合わせて絞り込めること",
+			filter:    ExerciseFilter{MuscleGroup: models.MuscleChest, MovementPattern: models.MovementIsolation, Equipment: models.EquipmentDumbbell},
+			wantNames: []string{"ダンベルフライ"},
+		},
+		{
+			name:      "【正常系】該当が無い場合は空スライスを返すこと",
+			filter:    ExerciseFilter{Query: "bench", Equipment: models.EquipmentCable},
+			wantNames: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, me.ID, tt.filter)
+			require.NoError(t, err)
+
+			names := make([]string, len(got))
+			for i, e := range got {
+				names[i] = e.Name
+			}
+			require.Equal(t, tt.wantNames, names)
+		})
+	}
+
+	t.Run("【正常系】補助部位と別名がプリロードされること", func(t *testing.T) {
+		got, err := repo.List(ctx, me.ID, ExerciseFilter{Query: "Bench Press"})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, models.EquipmentBarbell, got[0].Equipment)
+		require.Len(t, got[0].SecondaryMuscles, 2)
+		require.Equal(t, models.MuscleTriceps, got[0].SecondaryMuscles[0].MuscleGroup)
+		require.Equal(t, []models.ExerciseAlias{{ID: got[0].Aliases[0].ID, ExerciseID: got[0].ID, Locale: "en", Name: "Bench Press"}}, got[0].Aliases)
+	})
+}
+
+func TestExerciseRepository_OwnedOperations(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User)
+	}{
+		{
+			name: "【正常系】自分の種目のみ FindOwnedByID で取得でき、共通種目・他人の種目は ErrNotFound になること",
+			run: func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User) {
+				mine := models.Exercise{Name: "マイ種目", OwnerID: &me.ID}
+				global := models.Exercise{Name: "スクワット"}
+				theirs := models.Exercise{Name: "他人の種目", OwnerID: &other.ID}
+				require.NoError(t, db.Create(&[]*models.Exercise{&mine, &global, &theirs}).Error)
+
+				got, err := repo.FindOwnedByID(ctx, mine.ID, me.ID)
+				require.NoError(t, err)
+				require.Equal(t, "マイ種目", got.Name)
+
+				_, err = repo.FindOwnedByID(ctx, global.ID, me.ID)
+				require.ErrorIs(t, err, ErrNotFound)
+				_, err = repo.FindOwnedByID(ctx, theirs.ID, me.ID)
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name: "【正常系】NameExists は共通種目と自分の種目の名前のみを対象にすること",
+			run: func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User) {
+				mine := models.Exercise{Name: "マイ種目", OwnerID: &me.ID}
+				require.NoError(t, db.Create(&[]*models.Exercise{
+					{Name: "スクワット"},
+					&mine,
+					{Name: "他人の種目", OwnerID: &other.ID},
+				}).Error)
+
+				for name, want := range map[string]bool{"スクワット": true, "マイ種目": true, "他人の種目": false} {
+					got, err := repo.NameExists(ctx, me.ID, name, 0)
+					require.NoError(t, err)
+					require.Equal(t, want, got, name)
+				}
+				got, err := repo.NameExists(ctx, me.ID, "マイ種目", mine.ID)
+				require.NoError(t, err)
+				require.False(t, got)
+			},
+		},
+		{
+			name: "【異常系】同じユーザーで同名の種目を作成すると ErrUniqueViolation になること",
+			run: func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User) {
+				require.NoError(t, repo.Create(ctx, &models.Exercise{Name: "マイ種目", OwnerID: &me.ID}))
+				require.ErrorIs(t, repo.Create(ctx, &models.Exercise{Name: "マイ種目", OwnerID: &me.ID}), ErrUniqueViolation)
+				require.NoError(t, repo.Create(ctx, &models.Exercise{Name: "マイ種目", OwnerID: &other.ID}))
+			},
+		},
+		{
+			name: "【正常系】名前・共有設定の更新とアーカイブは所有者のみ反映されること",
+			run: func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User) {
+				mine := models.Exercise{Name: "マイ種目", OwnerID: &me.ID}
+				require.NoError(t, db.Create(&mine).Error)
+
+				mine.Name = "改名"
+				mine.IsShared = true
+				require.NoError(t, repo.Update(ctx, &mine))
+
+				at := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+				require.NoError(t, repo.SetArchivedAt(ctx, mine.ID, other.ID, &at))
+
+				var got models.Exercise
+				require.NoError(t, db.First(&got, mine.ID).Error)
+				require.Equal(t, "改名", got.Name)
+				require.True(t, got.IsShared)
+				require.Nil(t, got.ArchivedAt)
+
+				require.NoError(t, repo.SetArchivedAt(ctx, mine.ID, me.ID, &at))
+				require.NoError(t, db.First(&got, mine.ID).Error)
+				require.NotNil(t, got.ArchivedAt)
+			},
+		},
+		{
+			name: "【正常系】記録がある種目は HasRecords が true になり、削除しても記録は残ること",
+			run: func(t *testing.T, db *gorm.DB, repo ExerciseRepository, me, other models.User) {
+				used := models.Exercise{Name: "使用中", OwnerID: &me.ID}
+				unused := models.Exercise{Name: "未使用", OwnerID: &me.ID}
+				require.NoError(t, db.Create(&[]*models.Exercise{&used, &unused}).Error)
+				require.NoError(t, db.Create(&models.WorkoutRecord{
+					UserID: me.ID, ExerciseID: used.ID, TrainedOn: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				}).Error)
+
+				has, err := repo.HasRecords(ctx, used.ID)
+				require.NoError(t, err)
+				require.True(t, has)
+				has, err = repo.HasRecords(ctx, unused.ID)
+				require.NoError(t, err)
+				require.False(t, has)
+
+				require.ErrorIs(t, repo.Delete(ctx, used.ID, me.ID), ErrFKViolation)
+				require.NoError(t, repo.Delete(ctx, unused.ID, me.ID))
+
+				var cnt int64
+				require.NoError(t, db.Model(&models.WorkoutRecord{}).Where("exercise_id = ?", used.ID).Count(&cnt).Error)
+				require.EqualValues(t, 1, cnt)
+				require.NoError(t, db.Unscoped().Model(&models.Exercise{}).Where("id = ?", unused.ID).Count(&cnt).Error)
+				require.EqualValues(t, 0, cnt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newExerciseTestDB(t)
+			repo := NewExerciseRepository(db)
+
+			me := models.User{Email: "me@example.com"}
+			other := models.User{Email: "other@example.com"}
+			require.NoError(t, db.Create(&me).Error)
+			require.NoError(t, db.Create(&other).Error)
+
+			tt.run(t, db, repo, me, other)
+		})
+	}
+}
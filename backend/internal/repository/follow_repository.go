@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowUser はフォロー一覧の1件。FollowedAt はフォロー（申請）した日時。
+type FollowUser struct {
+	UserID     uint
+	Email      string
+	FollowedAt time.Time
+}
+
+type FollowRepository interface {
+	FindUser(userID uint) (*models.User, error)
+	FindFollow(followerID uint, followeeID uint) (*models.Follow, error)
+	CreateFollow(follow *models.Follow) error
+	DeleteFollow(followerID uint, followeeID uint) error
+	AcceptFollow(followerID uint, followeeID uint) error
+	FindFollowers(userID uint, status models.FollowStatus) ([]FollowUser, error)
+	FindFollowing(userID uint) ([]FollowUser, error)
+	CountFollows(userID uint) (followers int64, following int64, err error)
+	UpdatePrivacy(userID uint, isPrivate bool) error
+}
+
+type followRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{db: db}
+}
+
+func (r *followRepository) FindUser(userID uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.Select("id, email, is_private").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *followRepository) FindFollow(followerID uint, followeeID uint) (*models.Follow, error) {
+	var follow models.Follow
+	err := r.db.
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		First(&follow).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &follow, nil
+}
+
+// CreateFollow はフォロー（申請）を作成してフォローされたユーザーに通知する。既にフォロー（申請）している場合は ErrUniqueViolation を返す。
+func (r *followRepository) CreateFollow(follow *models.Follow) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(follow).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrUniqueViolation
+			}
+			return err
+		}
+		return notifyFollowed(tx, follow)
+	})
+}
+
+// DeleteFollow はフォロー（承認待ちの申請を含む）とその通知を取り消す。無い場合は ErrNotFound。
+func (r *followRepository) DeleteFollow(followerID uint, followeeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Unscoped().
+			Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+			Delete(&models.Follow{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return retractFollowed(tx, followerID, followeeID, models.NotificationFollow, models.NotificationFollowRequest)
+	})
+}
+
+// AcceptFollow は承認待ちの申請を承認し、対応済みの申請の通知を取り消す。承認待ちの申請が無い場合は ErrNotFound。
+func (r *followRepository) AcceptFollow(followerID uint, followeeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Model(&models.Follow{}).
+			Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, models.FollowPending).
+			Update("status", models.FollowAccepted)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return retractFollowed(tx, followerID, followeeID, models.NotificationFollowRequest)
+	})
+}
+
+// FindFollowers は userID をフォローしているユーザーのうち status のものを新しい順に返す。
+func (r *followRepository) FindFollowers(userID uint, status models.FollowStatus) ([]FollowUser, error) {
+	var rows []FollowUser
+	err := r.db.
+		Table("follows").
+		Select("users.id AS user_id, users.email AS email, follows.created_at AS followed_at").
+		Joins("JOIN users ON users.id = follows.follower_id").
+		Where("follows.followee_id = ? AND follows.status = ?", userID, status).
+		Where("follows.deleted_at IS NULL").
+		Order("follows.created_at DESC, follows.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindFollowing は userID が承認済みでフォローしているユーザーを新しい順に返す。
+func (r *followRepository) FindFollowing(userID uint) ([]FollowUser, error) {
+	var rows []FollowUser
+	err := r.db.
+		Table("follows").
+		Select("users.id AS user_id, users.email AS email, follows.created_at AS followed_at").
+		Joins("JOIN users ON users.id = follows.followee_id").
+		Where("follows.follower_id = ? AND follows.status = ?", userID, models.FollowAccepted).
+		Where("follows.deleted_at IS NULL").
+		Order("follows.created_at DESC, follows.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountFollows は承認済みのフォロワー数とフォロー数を返す。
+func (r *followRepository) CountFollows(userID uint) (int64, int64, error) {
+	var followers, following int64
+	if err := r.db.
+		Model(&models.Follow{}).
+		Where("followee_id = ? AND status = ?", userID, models.FollowAccepted).
+		Count(&followers).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := r.db.
+		Model(&models.Follow{}).
+		Where("follower_id = ? AND status = ?", userID, models.FollowAccepted).
+		Count(&following).Error; err != nil {
+		return 0, 0, err
+	}
+	return followers, following, nil
+}
+
+// UpdatePrivacy はアカウントの公開設定を変更する。公開に戻した場合は承認待ちの申請をすべて承認する。
+func (r *followRepository) UpdatePrivacy(userID uint, isPrivate bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.User{}).Where("id = ?", userID).Update("is_private", isPrivate)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if isPrivate {
+			return nil
+		}
+		if err := tx.
+			Model(&models.Follow{}).
+			Where("followee_id = ? AND status = ?", userID, models.FollowPending).
+			Update("status", models.FollowAccepted).Error; err != nil {
+			return err
+		}
+		// 自動で承認した申請の通知は不要になる
+		return tx.
+			Unscoped().
+			Where("user_id = ? AND type = ?", userID, models.NotificationFollowRequest).
+			Delete(&models.Notification{}).Error
+	})
+}
+
+// followeeIDs は viewerID が承認済みでフォローしているユーザーIDのサブクエリ。
+func followeeIDs(db *gorm.DB, viewerID uint) *gorm.DB {
+	return db.
+		Table("follows").
+		Select("followee_id").
+		Where("follower_id = ? AND status = ? AND deleted_at IS NULL", viewerID, models.FollowAccepted)
+}
+
+// SessionAccess は viewerID から見たセッションの公開状態。
+// AuthorVisible は投稿者が公開アカウントか、本人か、承認済みでフォローしている場合に true。
+type SessionAccess struct {
+	OwnerID       uint
+	IsPublic      bool
+	AuthorVisible bool
+}
+
+// findSessionAccess はタイムラインの scopeAuthors と同じ条件で、viewerID がセッションの投稿者を見られるかを返す。
+func findSessionAccess(db *gorm.DB, viewerID uint, sessionID uint) (*SessionAccess, error) {
+	var rows []SessionAccess
+	err := db.
+		Table("workout_sessions").
+		Select(`
+			workout_sessions.user_id   AS owner_id,
+			workout_sessions.is_public AS is_public,
+			(users.is_private = ? OR workout_sessions.user_id = ? OR workout_sessions.user_id IN (?)) AS author_visible
+		`, false, viewerID, followeeIDs(db, viewerID)).
+		Joins("JOIN users ON users.id = workout_sessions.user_id").
+		Where("workout_sessions.id = ? AND workout_sessions.deleted_at IS NULL", sessionID).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrNotFound
+	}
+	return &rows[0], nil
+}
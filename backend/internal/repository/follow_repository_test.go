@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func seedFollowUsers(t *testing.T, db *gorm.DB, emails ...string) []models.User {
+	t.Helper()
+	users := make([]models.User, 0, len(emails))
+	for _, email := range emails {
+		u := models.User{Email: email}
+		require.NoError(t, db.Create(&u).Error)
+		users = append(users, u)
+	}
+	return users
+}
+
+func TestFollowRepository(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Follow{}, &models.Notification{}))
+	users := seedFollowUsers(t, db, "a@example.com", "b@example.com", "c@example.com")
+	a, b, c := users[0], users[1], users[2]
+	repo := NewFollowRepository(db)
+
+	t.Run("【正常系】フォローを作成できること", func(t *testing.T) {
+		require.NoError(t, repo.CreateFollow(&models.Follow{FollowerID: a.ID, FolloweeID: b.ID, Status: models.FollowAccepted}))
+		require.NoError(t, repo.CreateFollow(&models.Follow{FollowerID: c.ID, FolloweeID: b.ID, Status: models.FollowPending}))
+
+		got, err := repo.FindFollow(c.ID, b.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.FollowPending, got.Status)
+	})
+
+	t.Run("【異常系】同じ相手を重ねてフォローすると ErrUniqueViolation を返すこと", func(t *testing.T) {
+		err := repo.CreateFollow(&models.Follow{FollowerID: a.ID, FolloweeID: b.ID, Status: models.FollowAccepted})
+		require.ErrorIs(t, err, ErrUniqueViolation)
+	})
+
+	t.Run("【正常系】フォロワーは状態ごと、フォロー数は承認済みだけを数えること", func(t *testing.T) {
+		accepted, err := repo.FindFollowers(b.ID, models.FollowAccepted)
+		require.NoError(t, err)
+		require.Len(t, accepted, 1)
+		require.Equal(t, "a@example.com", accepted[0].Email)
+		require.False(t, accepted[0].FollowedAt.IsZero())
+
+		pending, err := repo.FindFollowers(b.ID, models.FollowPending)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, c.ID, pending[0].UserID)
+
+		following, err := repo.FindFollowing(c.ID)
+		require.NoError(t, err)
+		require.Empty(t, following)
+
+		followers, followingCount, err := repo.CountFollows(b.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), followers)
+		require.Equal(t, int64(0), followingCount)
+	})
+
+	t.Run("【正常系】承認待ちの申請を承認できること", func(t *testing.T) {
+		require.NoError(t, repo.AcceptFollow(c.ID, b.ID))
+		require.ErrorIs(t, repo.AcceptFollow(c.ID, b.ID), ErrNotFound)
+
+		following, err := repo.FindFollowing(c.ID)
+		require.NoError(t, err)
+		require.Len(t, following, 1)
+		require.Equal(t, b.ID, following[0].UserID)
+	})
+
+	t.Run("【正常系】公開に戻すと承認待ちの申請がすべて承認されること", func(t *testing.T) {
+		require.NoError(t, repo.UpdatePrivacy(a.ID, true))
+		require.NoError(t, repo.CreateFollow(&models.Follow{FollowerID: b.ID, FolloweeID: a.ID, Status: models.FollowPending}))
+
+		user, err := repo.FindUser(a.ID)
+		require.NoError(t, err)
+		require.True(t, user.IsPrivate)
+
+		require.NoError(t, repo.UpdatePrivacy(a.ID, false))
+		got, err := repo.FindFollow(b.ID, a.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.FollowAccepted, got.Status)
+	})
+
+	t.Run("【正常系】フォローを取り消すと再びフォローできること", func(t *testing.T) {
+		require.NoError(t, repo.DeleteFollow(a.ID, b.ID))
+		require.ErrorIs(t, repo.DeleteFollow(a.ID, b.ID), ErrNotFound)
+		_, err := repo.FindFollow(a.ID, b.ID)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		require.NoError(t, repo.CreateFollow(&models.Follow{FollowerID: a.ID, FolloweeID: b.ID, Status: models.FollowAccepted}))
+	})
+
+	t.Run("【異常系】存在しないユーザーは ErrNotFound を返すこと", func(t *testing.T) {
+		_, err := repo.FindUser(999)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, repo.UpdatePrivacy(999, true), ErrNotFound)
+	})
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GoalRepository interface {
+	Create(goal *models.Goal) error
+	FindByUser(userID uint) ([]models.Goal, error)
+	FindByIDAndUserID(id uint, userID uint) (*models.Goal, error)
+	Update(goal *models.Goal) error
+	Delete(id uint, userID uint) error
+	CurrentValue(goal models.Goal) (float64, error)
+	FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error)
+}
+
+type goalRepository struct {
+	db *gorm.DB
+}
+
+func NewGoalRepository(db *gorm.DB) GoalRepository {
+	return &goalRepository{db: db}
+}
+
+func preloadGoalExercise(db *gorm.DB) *gorm.DB {
+	return db.Preload("Exercise", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "name")
+	})
+}
+
+// Create は目標を保存し、既存の記録で達成済みなら AchievedAt を設定する。
+func (r *goalRepository) Create(goal *models.Goal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(goal).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return err
+		}
+		return achieveGoal(tx, goal)
+	})
+}
+
+// FindByUser は期限の近い順（期限なしは最後）で返す。
+func (r *goalRepository) FindByUser(userID uint) ([]models.Goal, error) {
+	var goals []models.Goal
+	if err := preloadGoalExercise(r.db).
+		Where("user_id = ?", userID).
+		Order("deadline IS NULL, deadline ASC, id ASC").
+		Find(&goals).Error; err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *goalRepository) FindByIDAndUserID(id uint, userID uint) (*models.Goal, error) {
+	var goal models.Goal
+	err := preloadGoalExercise(r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&goal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// Update は目標の内容を上書きし、達成状況を新しい条件で判定し直す。
+func (r *goalRepository) Update(goal *models.Goal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Model(&models.Goal{}).
+			Where("id = ? AND user_id = ?", goal.ID, goal.UserID).
+			Select("type", "exercise_id", "target_value", "starts_on", "deadline", "achieved_at", "updated_at").
+			Updates(&models.Goal{
+				Type:        goal.Type,
+				ExerciseID:  goal.ExerciseID,
+				TargetValue: goal.TargetValue,
+				StartsOn:    goal.StartsOn,
+				Deadline:    goal.Deadline,
+			})
+		if res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		goal.AchievedAt = nil
+		return achieveGoal(tx, goal)
+	})
+}
+
+func (r *goalRepository) Delete(id uint, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Where("id = ? AND user_id = ?", id, userID).
+			Delete(&models.Goal{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return retractGoalAchieved(tx, id)
+	})
+}
+
+func (r *goalRepository) CurrentValue(goal models.Goal) (float64, error) {
+	return goalCurrentValue(r.db, goal)
+}
+
+func (r *goalRepository) FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error) {
+	exercises, err := findUsableExercises(r.db, userID, []uint{exerciseID})
+	if err != nil {
+		return nil, err
+	}
+	ex, ok := exercises[exerciseID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &ex, nil
+}
+
+// recordBodyWeightSQL は記録 r の日の体重。その日までに測った最新の体組成の体重を使い、無ければ記録の体重を使う。
+const recordBodyWeightSQL = `COALESCE((
+	SELECT m.weight FROM body_metrics m
+	WHERE m.user_id = r.user_id AND m.measured_on <= r.trained_on AND m.weight > 0
+	ORDER BY m.measured_on DESC
+	LIMIT 1
+), r.body_weight)`
+
+// goalCurrentValue は目標の期間内の記録から、TargetValue と比べる現在値を求める。
+// lift_weight: 最大の使用重量, bodyweight_multiple: 使用重量÷記録の日の体重の最大値, training_days: トレーニング日数。
+// ウォームアップセットは含めない。
+func goalCurrentValue(db *gorm.DB, goal models.Goal) (float64, error) {
+	q := db.
+		Table("workout_records AS r").
+		Where("r.user_id = ? AND r.trained_on >= ?", goal.UserID, goal.StartsOn)
+	if goal.Deadline != nil {
+		q = q.Where("r.trained_on <= ?", *goal.Deadline)
+	}
+
+	var value *float64
+	switch goal.Type {
+	case models.GoalLiftWeight, models.GoalBodyweightMultiple:
+		if goal.ExerciseID == nil {
+			return 0, nil
+		}
+		q = q.
+			Joins("JOIN workout_sets AS s ON s.workout_record_id = r.id").
+			Where("r.exercise_id = ? AND s.reps > 0 AND s.set_type <> ?", *goal.ExerciseID, models.SetTypeWarmup)
+		if goal.Type == models.GoalLiftWeight {
+			q = q.Select("MAX(s.exercise_weight)")
+		} else {
+			q = q.
+				Where(recordBodyWeightSQL + " > 0").
+				Select("MAX(s.exercise_weight / " + recordBodyWeightSQL + ")")
+		}
+	case models.GoalTrainingDays:
+		q = q.Select("COUNT(DISTINCT r.trained_on)")
+	default:
+		return 0, nil
+	}
+	if err := q.Scan(&value).Error; err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return 0, nil
+	}
+	return *value, nil
+}
+
+// achieveGoal は未達成の目標が期間内の記録で条件を満たしていれば、AchievedAt を設定する。
+func achieveGoal(tx *gorm.DB, goal *models.Goal) error {
+	if goal.AchievedAt != nil {
+		return nil
+	}
+	value, err := goalCurrentValue(tx, *goal)
+	if err != nil {
+		return err
+	}
+	if value < goal.TargetValue {
+		return nil
+	}
+
+	now := tx.NowFunc()
+	if err := tx.Model(&models.Goal{}).
+		Where("id = ?", goal.ID).
+		Update("achieved_at", now).Error; err != nil {
+		return err
+	}
+	goal.AchievedAt = &now
+	return nil
+}
+
+// achieveUserGoals は記録の保存と同じトランザクションで呼び、trainedOn の記録で達成できる目標に AchievedAt を設定して通知する。
+// 一度達成した目標は、後で記録を消しても達成のままとする。
+func achieveUserGoals(tx *gorm.DB, userID uint, trainedOn time.Time) error {
+	var goals []models.Goal
+	if err := tx.
+		Where("user_id = ? AND achieved_at IS NULL AND starts_on <= ?", userID, trainedOn).
+		Where("deadline IS NULL OR deadline >= ?", trainedOn).
+		Find(&goals).Error; err != nil {
+		return err
+	}
+	for i := range goals {
+		if err := achieveGoal(tx, &goals[i]); err != nil {
+			return err
+		}
+		if goals[i].AchievedAt == nil {
+			continue
+		}
+		if err := notifyGoalAchieved(tx, &goals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func seedGoalFixtures(t *testing.T, db *gorm.DB) (models.User, models.Exercise, models.Exercise) {
+	t.Helper()
+	user := models.User{Email: "goal@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+	return user, bench, squat
+}
+
+func goalDay(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+
+func logLift(t *testing.T, repo WorkoutRepository, userID, exerciseID uint, day time.Time, bodyWeight, weight float64) {
+	t.Helper()
+	require.NoError(t, repo.Create(&models.WorkoutRecord{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+		BodyWeight: bodyWeight,
+		TrainedOn:  day,
+		Sets:       []models.WorkoutSet{{SetNo: 1, Reps: 1, ExerciseWeight: weight}},
+	}))
+}
+
+func TestGoalRepository_CurrentValue(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	user, bench, squat := seedGoalFixtures(t, db)
+	workouts := NewWorkoutRepository(db)
+	repo := NewGoalRepository(db)
+
+	logLift(t, workouts, user.ID, bench.ID, goalDay(1), 70, 95) // 期間外
+	logLift(t, workouts, user.ID, bench.ID, goalDay(3), 70, 85)
+	logLift(t, workouts, user.ID, squat.ID, goalDay(3), 70, 120) // 同じ日
+	logLift(t, workouts, user.ID, squat.ID, goalDay(5), 0, 150)  // 体重未入力
+	logLift(t, workouts, user.ID, bench.ID, goalDay(7), 72, 90)
+	require.NoError(t, workouts.Create(&models.WorkoutRecord{
+		UserID:     user.ID,
+		ExerciseID: bench.ID,
+		BodyWeight: 72,
+		TrainedOn:  goalDay(7),
+		Sets:       []models.WorkoutSet{{SetNo: 1, Reps: 1, ExerciseWeight: 100, SetType: models.SetTypeWarmup}},
+	}))
+	require.NoError(t, db.Create(&models.BodyMetric{UserID: user.ID, MeasuredOn: goalDay(4), Weight: utils.Ptr(75.0)}).Error)
+
+	tests := []struct {
+		name string
+		goal models.Goal
+		want float64
+	}{
+		{
+			name: "【正常系】lift_weight は期間内の最大重量をウォームアップを除いて返すこと",
+			goal: models.Goal{Type: models.GoalLiftWeight, ExerciseID: &bench.ID, StartsOn: goalDay(2)},
+			want: 90,
+		},
+		{
+			name: "【正常系】bodyweight_multiple は体重のある記録だけで倍率を求めること",
+			goal: models.Goal{Type: models.GoalBodyweightMultiple, ExerciseID: &squat.ID, StartsOn: goalDay(1), Deadline: utils.Ptr(goalDay(4))},
+			want: 120.0 / 70.0,
+		},
+		{
+			name: "【正常系】bodyweight_multiple は記録の日までに測った最新の体重で倍率を求めること",
+			goal: models.Goal{Type: models.GoalBodyweightMultiple, ExerciseID: &squat.ID, StartsOn: goalDay(1)},
+			want: 150.0 / 75.0,
+		},
+		{
+			name: "【正常系】training_days は期間内のトレーニング日数を返すこと",
+			goal: models.Goal{Type: models.GoalTrainingDays, StartsOn: goalDay(2), Deadline: utils.Ptr(goalDay(6))},
+			want: 2,
+		},
+		{
+			name: "【正常系】期間内に記録が無い場合は0を返すこと",
+			goal: models.Goal{Type: models.GoalLiftWeight, ExerciseID: &bench.ID, StartsOn: goalDay(8)},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.goal.UserID = user.ID
+			got, err := repo.CurrentValue(tt.goal)
+			require.NoError(t, err)
+			require.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestGoalRepository_AchievedOnSave(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	user, bench, squat := seedGoalFixtures(t, db)
+	workouts := NewWorkoutRepository(db)
+	repo := NewGoalRepository(db)
+
+	benchGoal := &models.Goal{UserID: user.ID, Type: models.GoalLiftWeight, ExerciseID: &bench.ID, TargetValue: 100, StartsOn: goalDay(1), Deadline: utils.Ptr(goalDay(31))}
+	squatGoal := &models.Goal{UserID: user.ID, Type: models.GoalBodyweightMultiple, ExerciseID: &squat.ID, TargetValue: 2, StartsOn: goalDay(1)}
+	require.NoError(t, repo.Create(benchGoal))
+	require.NoError(t, repo.Create(squatGoal))
+	require.Nil(t, benchGoal.AchievedAt)
+
+	t.Run("【正常系】条件を満たさない記録では達成にならないこと", func(t *testing.T) {
+		logLift(t, workouts, user.ID, bench.ID, goalDay(2), 70, 97.5)
+		got, err := repo.FindByIDAndUserID(benchGoal.ID, user.ID)
+		require.NoError(t, err)
+		require.Nil(t, got.AchievedAt)
+		require.Equal(t, models.GoalActive, got.Status(goalDay(2)))
+	})
+
+	t.Run("【正常系】条件を満たす記録を保存すると達成日時が設定されること", func(t *testing.T) {
+		logLift(t, workouts, user.ID, bench.ID, goalDay(9), 70, 100)
+		logLift(t, workouts, user.ID, squat.ID, goalDay(9), 70, 140)
+
+		got, err := repo.FindByIDAndUserID(benchGoal.ID, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.AchievedAt)
+		require.Equal(t, "ベンチプレス", got.Exercise.Name)
+		require.Equal(t, models.GoalAchieved, got.Status(goalDay(31).AddDate(0, 0, 1)))
+
+		sq, err := repo.FindByIDAndUserID(squatGoal.ID, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, sq.AchievedAt)
+	})
+
+	t.Run("【正常系】既に達成している条件で作成した目標は作成時に達成となること", func(t *testing.T) {
+		g := &models.Goal{UserID: user.ID, Type: models.GoalTrainingDays, TargetValue: 2, StartsOn: goalDay(1), Deadline: utils.Ptr(goalDay(31))}
+		require.NoError(t, repo.Create(g))
+		require.NotNil(t, g.AchievedAt)
+	})
+
+	t.Run("【正常系】目標値を上げると達成が取り消されること", func(t *testing.T) {
+		benchGoal.TargetValue = 110
+		require.NoError(t, repo.Update(benchGoal))
+		got, err := repo.FindByIDAndUserID(benchGoal.ID, user.ID)
+		require.NoError(t, err)
+		require.Nil(t, got.AchievedAt)
+		require.Equal(t, models.GoalExpired, got.Status(goalDay(31).AddDate(0, 0, 1)))
+	})
+
+	t.Run("【異常系】他人の目標は更新・削除できないこと", func(t *testing.T) {
+		other := *benchGoal
+		other.UserID = user.ID + 1
+		require.ErrorIs(t, repo.Update(&other), ErrNotFound)
+		require.ErrorIs(t, repo.Delete(benchGoal.ID, user.ID+1), ErrNotFound)
+	})
+
+	t.Run("【正常系】期限の近い順に一覧できること", func(t *testing.T) {
+		goals, err := repo.FindByUser(user.ID)
+		require.NoError(t, err)
+		require.Len(t, goals, 3)
+		require.Equal(t, squatGoal.ID, goals[2].ID)
+	})
+}
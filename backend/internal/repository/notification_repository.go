@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationGroup は GroupKey でまとめた通知。内容は最新の通知のもので、ActorCount はまとめた通知のユーザー数。
+type NotificationGroup struct {
+	LatestID     uint
+	Type         models.NotificationType
+	ActorID      *uint
+	ActorEmail   *string
+	ActorCount   int
+	TotalCount   int
+	UnreadCount  int
+	SessionID    *uint
+	CommentID    *uint
+	GoalID       *uint
+	ExerciseName *string
+	CreatedAt    time.Time
+}
+
+type NotificationRepository interface {
+	FindGroups(userID uint, beforeID uint, limit int) ([]NotificationGroup, error)
+	CountUnreadGroups(userID uint) (int64, error)
+	MarkGroupRead(userID uint, id uint) error
+	MarkAllRead(userID uint) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// FindGroups は userID への通知を GroupKey ごとにまとめ、最新の通知が新しい順に返す。
+// beforeID が0でなければ、最新の通知の ID が beforeID より小さいまとまりだけを返す。
+func (r *notificationRepository) FindGroups(userID uint, beforeID uint, limit int) ([]NotificationGroup, error) {
+	groups := r.db.
+		Table("notifications").
+		Select(`
+			MAX(id)                                          AS latest_id,
+			COUNT(DISTINCT actor_id)                         AS actor_count,
+			COUNT(*)                                         AS total_count,
+			SUM(CASE WHEN read_at IS NULL THEN 1 ELSE 0 END) AS unread_count
+		`).
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Scopes(liveNotifications).
+		Group("group_key")
+	if beforeID > 0 {
+		groups = groups.Having("MAX(id) < ?", beforeID)
+	}
+
+	var rows []NotificationGroup
+	err := r.db.
+		Table("(?) AS g", groups).
+		Select(`
+			g.latest_id       AS latest_id,
+			g.actor_count     AS actor_count,
+			g.total_count     AS total_count,
+			g.unread_count    AS unread_count,
+			n.type            AS type,
+			n.actor_id        AS actor_id,
+			users.email       AS actor_email,
+			n.session_id      AS session_id,
+			n.comment_id      AS comment_id,
+			n.goal_id         AS goal_id,
+			exercises.name    AS exercise_name,
+			n.created_at      AS created_at
+		`).
+		Joins("JOIN notifications n ON n.id = g.latest_id").
+		Joins("LEFT JOIN users ON users.id = n.actor_id").
+		Joins("LEFT JOIN exercises ON exercises.id = n.exercise_id").
+		Order("g.latest_id DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountUnreadGroups は未読の通知を含むまとまりの数を返す。
+func (r *notificationRepository) CountUnreadGroups(userID uint) (int64, error) {
+	var count int64
+	err := r.db.
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Scopes(liveNotifications).
+		Distinct("group_key").
+		Count(&count).Error
+	return count, err
+}
+
+// liveNotifications は、取り消されたいいね・フォローの通知を除く。
+// 通知は取り消し時にも削除するが、まとめた件数は常に今あるいいね・フォローから数える。
+func liveNotifications(db *gorm.DB) *gorm.DB {
+	return db.
+		Where(`notifications.type <> ? OR EXISTS (
+			SELECT 1 FROM workout_likes l
+			WHERE l.session_id = notifications.session_id AND l.user_id = notifications.actor_id AND l.deleted_at IS NULL
+		)`, models.NotificationLike).
+		Where(`notifications.type NOT IN ? OR EXISTS (
+			SELECT 1 FROM follows f
+			WHERE f.follower_id = notifications.actor_id AND f.followee_id = notifications.user_id AND f.deleted_at IS NULL
+		)`, []models.NotificationType{models.NotificationFollow, models.NotificationFollowRequest})
+}
+
+// MarkGroupRead は id の通知と同じまとまりの通知をすべて既読にする。userID への通知でなければ ErrNotFound。
+func (r *notificationRepository) MarkGroupRead(userID uint, id uint) error {
+	var n models.Notification
+	if err := r.db.Select("id, group_key").Where("id = ? AND user_id = ?", id, userID).First(&n).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return r.db.
+		Model(&models.Notification{}).
+		Where("user_id = ? AND group_key = ? AND read_at IS NULL", userID, n.GroupKey).
+		Update("read_at", r.db.NowFunc()).Error
+}
+
+func (r *notificationRepository) MarkAllRead(userID uint) error {
+	return r.db.
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", r.db.NowFunc()).Error
+}
+
+// 以下は、きっかけになった書き込みと同じトランザクションで通知を作成・取り消すための関数。
+
+// createNotification は通知を作成する。自分の行動による自分への通知は作らない。
+func createNotification(tx *gorm.DB, n *models.Notification) error {
+	if n.ActorID != nil && *n.ActorID == n.UserID {
+		return nil
+	}
+	return tx.Create(n).Error
+}
+
+// sessionTarget はセッションの投稿者と、通知の文面に使う最初の種目を返す。
+func sessionTarget(tx *gorm.DB, sessionID uint) (ownerID uint, exerciseID *uint, err error) {
+	var session models.WorkoutSession
+	if err := tx.Select("id, user_id").First(&session, sessionID).Error; err != nil {
+		return 0, nil, err
+	}
+	var record models.WorkoutRecord
+	err = tx.
+		Select("exercise_id").
+		Where("session_id = ?", sessionID).
+		Order("position ASC, id ASC").
+		Limit(1).
+		Find(&record).Error
+	if err != nil {
+		return 0, nil, err
+	}
+	if record.ExerciseID != 0 {
+		exerciseID = &record.ExerciseID
+	}
+	return session.UserID, exerciseID, nil
+}
+
+func notifySessionLiked(tx *gorm.DB, actorID uint, sessionID uint) error {
+	ownerID, exerciseID, err := sessionTarget(tx, sessionID)
+	if err != nil {
+		return err
+	}
+	return createNotification(tx, &models.Notification{
+		UserID:     ownerID,
+		Type:       models.NotificationLike,
+		GroupKey:   fmt.Sprintf("like:session:%d", sessionID),
+		ActorID:    &actorID,
+		SessionID:  &sessionID,
+		ExerciseID: exerciseID,
+	})
+}
+
+func retractSessionLiked(tx *gorm.DB, actorID uint, sessionID uint) error {
+	return tx.
+		Unscoped().
+		Where("type = ? AND actor_id = ? AND session_id = ?", models.NotificationLike, actorID, sessionID).
+		Delete(&models.Notification{}).Error
+}
+
+// notifyCommentPosted はコメントをセッションの投稿者へ、返信を返信先のコメントの投稿者へ通知する。
+// 返信は、返信先と異なるセッションの投稿者にもコメントとして通知する。
+func notifyCommentPosted(tx *gorm.DB, comment *models.WorkoutComment) error {
+	ownerID, exerciseID, err := sessionTarget(tx, comment.SessionID)
+	if err != nil {
+		return err
+	}
+
+	notifyOwner := true
+	if comment.ParentID != nil {
+		var parent models.WorkoutComment
+		if err := tx.Select("id, user_id").First(&parent, *comment.ParentID).Error; err != nil {
+			return err
+		}
+		if err := createNotification(tx, &models.Notification{
+			UserID:     parent.UserID,
+			Type:       models.NotificationReply,
+			GroupKey:   fmt.Sprintf("reply:comment:%d", parent.ID),
+			ActorID:    &comment.UserID,
+			SessionID:  &comment.SessionID,
+			CommentID:  &comment.ID,
+			ExerciseID: exerciseID,
+		}); err != nil {
+			return err
+		}
+		notifyOwner = parent.UserID != ownerID
+	}
+	if !notifyOwner {
+		return nil
+	}
+	return createNotification(tx, &models.Notification{
+		UserID:     ownerID,
+		Type:       models.NotificationComment,
+		GroupKey:   fmt.Sprintf("comment:session:%d", comment.SessionID),
+		ActorID:    &comment.UserID,
+		SessionID:  &comment.SessionID,
+		CommentID:  &comment.ID,
+		ExerciseID: exerciseID,
+	})
+}
+
+// retractComments は削除するコメント（commentIDs のサブクエリ）への通知を取り消す。
+func retractComments(tx *gorm.DB, commentIDs *gorm.DB) error {
+	return tx.
+		Unscoped().
+		Where("comment_id IN (?)", commentIDs).
+		Delete(&models.Notification{}).Error
+}
+
+func notifyFollowed(tx *gorm.DB, follow *models.Follow) error {
+	typ := models.NotificationFollow
+	if follow.Status == models.FollowPending {
+		typ = models.NotificationFollowRequest
+	}
+	return createNotification(tx, &models.Notification{
+		UserID:   follow.FolloweeID,
+		Type:     typ,
+		GroupKey: string(typ),
+		ActorID:  &follow.FollowerID,
+	})
+}
+
+// retractFollowed は followerID からのフォロー・フォロー申請の通知を取り消す。
+func retractFollowed(tx *gorm.DB, followerID uint, followeeID uint, types ...models.NotificationType) error {
+	return tx.
+		Unscoped().
+		Where("user_id = ? AND actor_id = ? AND type IN ?", followeeID, followerID, types).
+		Delete(&models.Notification{}).Error
+}
+
+// retractSessionNotifications は削除するセッションへのいいね・コメント・返信の通知を取り消す。
+func retractSessionNotifications(tx *gorm.DB, sessionID uint) error {
+	return tx.
+		Unscoped().
+		Where("session_id = ?", sessionID).
+		Delete(&models.Notification{}).Error
+}
+
+func notifyGoalAchieved(tx *gorm.DB, goal *models.Goal) error {
+	return createNotification(tx, &models.Notification{
+		UserID:     goal.UserID,
+		Type:       models.NotificationGoalAchieved,
+		GroupKey:   fmt.Sprintf("goal:%d", goal.ID),
+		GoalID:     &goal.ID,
+		ExerciseID: goal.ExerciseID,
+	})
+}
+
+func retractGoalAchieved(tx *gorm.DB, goalID uint) error {
+	return tx.
+		Unscoped().
+		Where("type = ? AND goal_id = ?", models.NotificationGoalAchieved, goalID).
+		Delete(&models.Notification{}).Error
+}
+
+func personalRecordGroupKey(recordID uint) string {
+	return fmt.Sprintf("pr:record:%d", recordID)
+}
+
+// notifyPersonalRecords は1つの記録で更新した自己ベストのうち、今も破られていないものを記録ごとにまとまる通知にする。
+// current は CurrentPersonalRecords で絞った自己ベストで、過去の日付の記録で過去の自己ベストを更新しても通知しない。
+func notifyPersonalRecords(tx *gorm.DB, current []models.PersonalRecord, recordID uint) error {
+	for _, pr := range personalRecordsOf(current, recordID) {
+		exerciseID := pr.ExerciseID
+		if err := createNotification(tx, &models.Notification{
+			UserID:     pr.UserID,
+			Type:       models.NotificationPersonalRecord,
+			GroupKey:   personalRecordGroupKey(pr.WorkoutRecordID),
+			ExerciseID: &exerciseID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retractPersonalRecords は削除する記録の自己ベスト更新の通知を取り消す。
+func retractPersonalRecords(tx *gorm.DB, recordIDs []uint) error {
+	if len(recordIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(recordIDs))
+	for i, id := range recordIDs {
+		keys[i] = personalRecordGroupKey(id)
+	}
+	return tx.
+		Unscoped().
+		Where("type = ? AND group_key IN ?", models.NotificationPersonalRecord, keys).
+		Delete(&models.Notification{}).Error
+}
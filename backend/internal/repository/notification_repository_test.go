@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func findGroupByType(t *testing.T, groups []NotificationGroup, typ models.NotificationType) NotificationGroup {
+	t.Helper()
+	for _, g := range groups {
+		if g.Type == typ {
+			return g
+		}
+	}
+	require.FailNow(t, "notification group not found", typ)
+	return NotificationGroup{}
+}
+
+func countNotifications(t *testing.T, db *gorm.DB, userID uint) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", userID).Count(&n).Error)
+	return n
+}
+
+func TestNotificationRepository(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.WorkoutLike{}, &models.WorkoutComment{}, &models.Follow{}))
+	owner, bench, _ := seedGoalFixtures(t, db)
+	fans := seedFollowUsers(t, db, "a@example.com", "b@example.com", "c@example.com")
+
+	workouts := NewWorkoutRepository(db)
+	goals := NewGoalRepository(db)
+	likes := NewWorkoutLikeRepository(db)
+	comments := NewWorkoutCommentRepository(db)
+	follows := NewFollowRepository(db)
+	repo := NewNotificationRepository(db)
+
+	require.NoError(t, goals.Create(&models.Goal{UserID: owner.ID, Type: models.GoalLiftWeight, ExerciseID: &bench.ID, TargetValue: 100, StartsOn: goalDay(1)}))
+	logLift(t, workouts, owner.ID, bench.ID, goalDay(3), 70, 100)
+	var record models.WorkoutRecord
+	require.NoError(t, db.Where("user_id = ?", owner.ID).First(&record).Error)
+	sessionID := *record.SessionID
+	require.NoError(t, db.Model(&models.WorkoutSession{}).Where("id = ?", sessionID).Update("is_public", true).Error)
+
+	t.Run("【正常系】目標の達成と自己ベストの更新が本人に通知されること", func(t *testing.T) {
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		goal := findGroupByType(t, groups, models.NotificationGoalAchieved)
+		require.NotNil(t, goal.GoalID)
+		require.Nil(t, goal.ActorID)
+		require.Equal(t, "ベンチプレス", *goal.ExerciseName)
+
+		pr := findGroupByType(t, groups, models.NotificationPersonalRecord)
+		require.Greater(t, pr.TotalCount, 1, "1つの記録で更新した自己ベストは1件にまとまる")
+	})
+
+	t.Run("【正常系】同じセッションへのいいねは1件にまとまり、自分のいいねは通知しないこと", func(t *testing.T) {
+		before := countNotifications(t, db, owner.ID)
+		require.NoError(t, likes.CreateLike(owner.ID, sessionID))
+		require.Equal(t, before, countNotifications(t, db, owner.ID))
+
+		for _, u := range fans {
+			require.NoError(t, likes.CreateLike(u.ID, sessionID))
+		}
+
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		require.Equal(t, models.NotificationLike, groups[0].Type)
+		require.Equal(t, 3, groups[0].ActorCount)
+		require.Equal(t, 3, groups[0].UnreadCount)
+		require.Equal(t, "c@example.com", *groups[0].ActorEmail)
+		require.Equal(t, "ベンチプレス", *groups[0].ExerciseName)
+		require.Equal(t, sessionID, *groups[0].SessionID)
+	})
+
+	t.Run("【正常系】いいねを取り消すと通知も取り消されること", func(t *testing.T) {
+		require.NoError(t, likes.DeleteLike(fans[2].ID, sessionID))
+
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		like := findGroupByType(t, groups, models.NotificationLike)
+		require.Equal(t, 2, like.ActorCount)
+		require.Equal(t, "b@example.com", *like.ActorEmail)
+	})
+
+	t.Run("【正常系】取り消されたいいねの通知が残っていても件数に含めないこと", func(t *testing.T) {
+		require.NoError(t, db.Create(&models.Notification{
+			UserID:    owner.ID,
+			Type:      models.NotificationLike,
+			GroupKey:  fmt.Sprintf("like:session:%d", sessionID),
+			ActorID:   &fans[2].ID,
+			SessionID: &sessionID,
+		}).Error)
+
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		like := findGroupByType(t, groups, models.NotificationLike)
+		require.Equal(t, 2, like.ActorCount)
+		require.Equal(t, 2, like.TotalCount)
+		require.Equal(t, "b@example.com", *like.ActorEmail)
+	})
+
+	t.Run("【正常系】コメントは投稿者へ、返信はコメントした人へ通知されること", func(t *testing.T) {
+		comment := &models.WorkoutComment{SessionID: sessionID, UserID: fans[0].ID, Body: "ナイス"}
+		require.NoError(t, comments.Create(comment))
+		reply := &models.WorkoutComment{SessionID: sessionID, UserID: owner.ID, ParentID: utils.Ptr(comment.ID), Body: "ありがとう"}
+		require.NoError(t, comments.Create(reply))
+
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, models.NotificationComment, groups[0].Type)
+		require.Equal(t, comment.ID, *groups[0].CommentID)
+
+		fanGroups, err := repo.FindGroups(fans[0].ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, fanGroups, 1)
+		require.Equal(t, models.NotificationReply, fanGroups[0].Type)
+		require.Equal(t, reply.ID, *fanGroups[0].CommentID)
+
+		require.NoError(t, comments.Delete(comment.ID, sessionID))
+		require.Zero(t, countNotifications(t, db, fans[0].ID))
+	})
+
+	t.Run("【正常系】フォローとフォロー申請が通知され、取り消すと通知も消えること", func(t *testing.T) {
+		require.NoError(t, follows.CreateFollow(&models.Follow{FollowerID: fans[0].ID, FolloweeID: owner.ID, Status: models.FollowAccepted}))
+		require.NoError(t, follows.CreateFollow(&models.Follow{FollowerID: fans[1].ID, FolloweeID: owner.ID, Status: models.FollowPending}))
+
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, models.NotificationFollowRequest, groups[0].Type)
+		require.Equal(t, models.NotificationFollow, groups[1].Type)
+
+		require.NoError(t, follows.DeleteFollow(fans[1].ID, owner.ID))
+		groups, err = repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, models.NotificationFollow, groups[0].Type)
+	})
+
+	t.Run("【正常系】最新の通知の ID より前のまとまりを返すこと", func(t *testing.T) {
+		all, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+
+		rest, err := repo.FindGroups(owner.ID, all[1].LatestID, 10)
+		require.NoError(t, err)
+		require.Equal(t, all[2:], rest)
+	})
+
+	t.Run("【正常系】まとまりごと・すべての通知を既読にできること", func(t *testing.T) {
+		groups, err := repo.FindGroups(owner.ID, 0, 10)
+		require.NoError(t, err)
+		unread, err := repo.CountUnreadGroups(owner.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(groups)), unread)
+
+		like := findGroupByType(t, groups, models.NotificationLike)
+		require.NoError(t, repo.MarkGroupRead(owner.ID, like.LatestID))
+		unread, err = repo.CountUnreadGroups(owner.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(groups)-1), unread)
+
+		require.NoError(t, repo.MarkAllRead(owner.ID))
+		unread, err = repo.CountUnreadGroups(owner.ID)
+		require.NoError(t, err)
+		require.Zero(t, unread)
+	})
+
+	t.Run("【異常系】他人の通知は既読にできないこと", func(t *testing.T) {
+		groups, err := repo.FindGroups(owner.ID, 0, 1)
+		require.NoError(t, err)
+		require.ErrorIs(t, repo.MarkGroupRead(fans[0].ID, groups[0].LatestID), ErrNotFound)
+	})
+}
+
+func TestNotificationRepository_PersonalRecords(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.WorkoutLike{}, &models.Follow{}))
+	user, bench, _ := seedGoalFixtures(t, db)
+	workouts := NewWorkoutRepository(db)
+	repo := NewNotificationRepository(db)
+
+	logSets := func(day time.Time, weight float64, reps int) *models.WorkoutRecord {
+		rec := &models.WorkoutRecord{
+			UserID:     user.ID,
+			ExerciseID: bench.ID,
+			BodyWeight: 70,
+			TrainedOn:  day,
+			Sets:       []models.WorkoutSet{{SetNo: 1, Reps: reps, ExerciseWeight: weight}},
+		}
+		require.NoError(t, workouts.Create(rec))
+		return rec
+	}
+	logSets(goalDay(3), 100, 1)
+	logSets(goalDay(4), 90, 2)
+	before := countNotifications(t, db, user.ID)
+
+	t.Run("【正常系】過去の日付の記録で過去の自己ベストを更新しても通知しないこと", func(t *testing.T) {
+		rec := logSets(goalDay(2), 90, 1)
+		require.NotEmpty(t, rec.PersonalRecords, "更新履歴には残る")
+		require.Equal(t, before, countNotifications(t, db, user.ID))
+	})
+
+	t.Run("【正常系】記録を削除すると自己ベストの通知も取り消されること", func(t *testing.T) {
+		rec := logSets(goalDay(5), 110, 1)
+		require.Greater(t, countNotifications(t, db, user.ID), before)
+
+		require.NoError(t, workouts.Delete(rec.ID, user.ID))
+		require.Equal(t, before, countNotifications(t, db, user.ID))
+
+		groups, err := repo.FindGroups(user.ID, 0, 10)
+		require.NoError(t, err)
+		for _, g := range groups {
+			require.Equal(t, models.NotificationPersonalRecord, g.Type)
+		}
+	})
+}
+
+func TestNotificationRepository_RetractOnDelete(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.WorkoutLike{}, &models.WorkoutComment{}, &models.Follow{}))
+	owner, bench, _ := seedGoalFixtures(t, db)
+	fans := seedFollowUsers(t, db, "a@example.com")
+
+	workouts := NewWorkoutRepository(db)
+	sessions := NewWorkoutSessionRepository(db)
+	goals := NewGoalRepository(db)
+	likes := NewWorkoutLikeRepository(db)
+	comments := NewWorkoutCommentRepository(db)
+
+	goal := &models.Goal{UserID: owner.ID, Type: models.GoalLiftWeight, ExerciseID: &bench.ID, TargetValue: 100, StartsOn: goalDay(1)}
+	require.NoError(t, goals.Create(goal))
+	logLift(t, workouts, owner.ID, bench.ID, goalDay(3), 70, 100)
+	var record models.WorkoutRecord
+	require.NoError(t, db.Where("user_id = ?", owner.ID).First(&record).Error)
+	sessionID := *record.SessionID
+	require.NoError(t, db.Model(&models.WorkoutSession{}).Where("id = ?", sessionID).Update("is_public", true).Error)
+	require.NoError(t, likes.CreateLike(fans[0].ID, sessionID))
+	require.NoError(t, comments.Create(&models.WorkoutComment{SessionID: sessionID, UserID: fans[0].ID, Body: "ナイス"}))
+
+	t.Run("【正常系】セッションを削除するといいね・コメント・自己ベストの通知が取り消されること", func(t *testing.T) {
+		require.NoError(t, sessions.Delete(sessionID, owner.ID))
+
+		var remaining []models.Notification
+		require.NoError(t, db.Where("user_id = ?", owner.ID).Find(&remaining).Error)
+		require.Len(t, remaining, 1)
+		require.Equal(t, models.NotificationGoalAchieved, remaining[0].Type)
+	})
+
+	t.Run("【正常系】目標を削除すると達成の通知が取り消されること", func(t *testing.T) {
+		require.NoError(t, goals.Delete(goal.ID, owner.ID))
+		require.Zero(t, countNotifications(t, db, owner.ID))
+	})
+}
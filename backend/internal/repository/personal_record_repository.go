@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PersonalRecordRepository interface {
+	FindByUser(userID uint) ([]models.PersonalRecord, error)
+	FindByUserAndExercise(userID uint, exerciseID uint) ([]models.PersonalRecord, error)
+}
+
+type personalRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalRecordRepository(db *gorm.DB) PersonalRecordRepository {
+	return &personalRecordRepository{db: db}
+}
+
+func preloadPersonalRecordExercise(db *gorm.DB) *gorm.DB {
+	return db.Preload("Exercise", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "name", "measurement_kind")
+	})
+}
+
+// FindByUser はユーザーの自己ベスト更新履歴を全種目分、達成日の昇順で返す。
+func (r *personalRecordRepository) FindByUser(userID uint) ([]models.PersonalRecord, error) {
+	var prs []models.PersonalRecord
+	if err := preloadPersonalRecordExercise(r.db).
+		Where("user_id = ?", userID).
+		Order("trained_on ASC, id ASC").
+		Find(&prs).Error; err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func (r *personalRecordRepository) FindByUserAndExercise(userID uint, exerciseID uint) ([]models.PersonalRecord, error) {
+	var prs []models.PersonalRecord
+	if err := preloadPersonalRecordExercise(r.db).
+		Where("user_id = ? AND exercise_id = ?", userID, exerciseID).
+		Order("trained_on ASC, id ASC").
+		Find(&prs).Error; err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+// rebuildPersonalRecords は userID の指定種目について、残っている記録から自己ベストの履歴を作り直す。
+// 記録の作成・更新・削除と同じトランザクションで呼び、古いベストが残らないようにする。
+func rebuildPersonalRecords(tx *gorm.DB, userID uint, exerciseIDs []uint) ([]models.PersonalRecord, error) {
+	var out []models.PersonalRecord
+	seen := make(map[uint]bool, len(exerciseIDs))
+	for _, exerciseID := range exerciseIDs {
+		if seen[exerciseID] {
+			continue
+		}
+		seen[exerciseID] = true
+
+		if err := tx.
+			Where("user_id = ? AND exercise_id = ?", userID, exerciseID).
+			Delete(&models.PersonalRecord{}).Error; err != nil {
+			return nil, err
+		}
+
+		var ex models.Exercise
+		err := tx.Unscoped().Select("id", "name", "measurement_kind").First(&ex, exerciseID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var records []models.WorkoutRecord
+		if err := tx.
+			Preload("Sets", func(db *gorm.DB) *gorm.DB {
+				return db.Order("set_no ASC, id ASC")
+			}).
+			Where("user_id = ? AND exercise_id = ?", userID, exerciseID).
+			Order("trained_on ASC, id ASC").
+			Find(&records).Error; err != nil {
+			return nil, err
+		}
+
+		prs := models.DetectPersonalRecords(ex.MeasurementKind, records)
+		if len(prs) == 0 {
+			continue
+		}
+		if err := tx.Omit(clause.Associations).Create(&prs).Error; err != nil {
+			return nil, err
+		}
+		for i := range prs {
+			prs[i].Exercise = ex
+		}
+		out = append(out, prs...)
+	}
+	return out, nil
+}
+
+// personalRecordsOf は prs のうち recordID の記録で更新したものを返す。
+func personalRecordsOf(prs []models.PersonalRecord, recordID uint) []models.PersonalRecord {
+	out := make([]models.PersonalRecord, 0)
+	for _, pr := range prs {
+		if pr.WorkoutRecordID == recordID {
+			out = append(out, pr)
+		}
+	}
+	return out
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type prFixture struct {
+	user    models.User
+	bench   models.Exercise
+	pullUp  models.Exercise
+	pushUp  models.Exercise
+	plank   models.Exercise
+	firstOn time.Time
+}
+
+func seedPersonalRecordFixtures(t *testing.T, db *gorm.DB) prFixture {
+	t.Helper()
+	f := prFixture{
+		user:    models.User{Email: "pr@example.com"},
+		bench:   models.Exercise{Name: "ベンチプレス", MeasurementKind: models.MeasurementRepsWeight},
+		pullUp:  models.Exercise{Name: "懸垂", MeasurementKind: models.MeasurementBodyweightLoad},
+		pushUp:  models.Exercise{Name: "腕立て伏せ", MeasurementKind: models.MeasurementRepsOnly},
+		plank:   models.Exercise{Name: "プランク", MeasurementKind: models.MeasurementDuration},
+		firstOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, db.Create(&f.user).Error)
+	for _, ex := range []*models.Exercise{&f.bench, &f.pullUp, &f.pushUp, &f.plank} {
+		require.NoError(t, db.Create(ex).Error)
+	}
+	return f
+}
+
+func prTypes(prs []models.PersonalRecord) []models.PersonalRecordType {
+	out := make([]models.PersonalRecordType, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, pr.Type)
+	}
+	return out
+}
+
+func TestWorkoutRepository_PersonalRecordsOnCreate(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, repo WorkoutRepository, f prFixture)
+		// 最後に作成した記録
+		record    func(f prFixture) models.WorkoutRecord
+		wantTypes []models.PersonalRecordType
+		check     func(t *testing.T, prs []models.PersonalRecord)
+	}{
+		{
+			name:  "【正常系】初回の記録は重量・重量ごとの回数・推定1RM・ボリュームの全てが自己ベストになること",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {},
+			record: func(f prFixture) models.WorkoutRecord {
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn,
+					Sets: []models.WorkoutSet{
+						{SetNo: 1, Reps: 10, ExerciseWeight: 60},
+						{SetNo: 2, Reps: 5, ExerciseWeight: 80},
+					},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{
+				models.PRMaxWeight, models.PRMaxRepsAtWeight, models.PRMaxRepsAtWeight,
+				models.PREstimated1RM, models.PRSessionVolume,
+			},
+			check: func(t *testing.T, prs []models.PersonalRecord) {
+				require.InDelta(t, 80, prs[0].Value, 1e-6)
+				require.Equal(t, 5, prs[0].Reps)
+				require.InDelta(t, 60, prs[1].Weight, 1e-6)
+				require.InDelta(t, 10, prs[1].Value, 1e-6)
+				// 60kg×10 = 80kg、80kg×5 ≒ 93.33kg
+				require.InDelta(t, 93.33, prs[3].Value, 1e-6)
+				require.InDelta(t, 80, prs[3].Weight, 1e-6)
+				require.InDelta(t, 1000, prs[4].Value, 1e-6)
+			},
+		},
+		{
+			name: "【正常系】同じ重量で回数が増えた場合は重量ごとの回数のみ更新されること",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {
+				require.NoError(t, repo.Create(&models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn,
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 3, ExerciseWeight: 100}, {SetNo: 2, Reps: 8, ExerciseWeight: 60}},
+				}))
+			},
+			record: func(f prFixture) models.WorkoutRecord {
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn.AddDate(0, 0, 7),
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 9, ExerciseWeight: 60}},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{models.PRMaxRepsAtWeight},
+		},
+		{
+			name: "【正常系】ベストと同じ値の場合は自己ベストにならないこと",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {
+				require.NoError(t, repo.Create(&models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn,
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 80}},
+				}))
+			},
+			record: func(f prFixture) models.WorkoutRecord {
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn.AddDate(0, 0, 7),
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 80}},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{},
+		},
+		{
+			name:  "【正常系】自重種目は推定1RMとボリュームに体重を含め、重量は加重分で判定すること",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {},
+			record: func(f prFixture) models.WorkoutRecord {
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.pullUp.ID, TrainedOn: f.firstOn, BodyWeight: 70,
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 1, ExerciseWeight: 10}},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{
+				models.PRMaxWeight, models.PRMaxRepsAtWeight, models.PREstimated1RM, models.PRSessionVolume,
+			},
+			check: func(t *testing.T, prs []models.PersonalRecord) {
+				require.InDelta(t, 10, prs[0].Value, 1e-6)
+				require.InDelta(t, 80, prs[2].Value, 1e-6)
+				require.InDelta(t, 80, prs[3].Value, 1e-6)
+			},
+		},
+		{
+			name:  "【正常系】回数のみの種目は回数の自己ベストのみ記録すること",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {},
+			record: func(f prFixture) models.WorkoutRecord {
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.pushUp.ID, TrainedOn: f.firstOn,
+					Sets: []models.WorkoutSet{{SetNo: 1, Reps: 30}, {SetNo: 2, Reps: 25}},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{models.PRMaxRepsAtWeight},
+		},
+		{
+			name:  "【正常系】時間の種目は自己ベストの対象外であること",
+			setup: func(t *testing.T, repo WorkoutRepository, f prFixture) {},
+			record: func(f prFixture) models.WorkoutRecord {
+				d := 60
+				return models.WorkoutRecord{
+					UserID: f.user.ID, ExerciseID: f.plank.ID, TrainedOn: f.firstOn,
+					Sets: []models.WorkoutSet{{SetNo: 1, DurationSeconds: &d}},
+				}
+			},
+			wantTypes: []models.PersonalRecordType{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			repo := NewWorkoutRepository(db)
+			f := seedPersonalRecordFixtures(t, db)
+			tt.setup(t, repo, f)
+
+			rec := tt.record(f)
+			require.NoError(t, repo.Create(&rec))
+
+			require.Equal(t, tt.wantTypes, prTypes(rec.PersonalRecords))
+			for _, pr := range rec.PersonalRecords {
+				require.NotZero(t, pr.ID)
+				require.Equal(t, rec.ID, pr.WorkoutRecordID)
+				require.NotEmpty(t, pr.Exercise.Name)
+			}
+			if tt.check != nil {
+				tt.check(t, rec.PersonalRecords)
+			}
+		})
+	}
+}
+
+func TestWorkoutRepository_PersonalRecordsRecomputed(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, db *gorm.DB, repo WorkoutRepository, f prFixture, first, second *models.WorkoutRecord)
+	}{
+		{
+			name: "【正常系】ベストを出した記録を削除すると、残りの記録からベストが再計算されること",
+			run: func(t *testing.T, db *gorm.DB, repo WorkoutRepository, f prFixture, first, second *models.WorkoutRecord) {
+				require.NoError(t, repo.Delete(second.ID, f.user.ID))
+
+				var prs []models.PersonalRecord
+				require.NoError(t, db.Where("user_id = ? AND type = ?", f.user.ID, models.PRMaxWeight).Find(&prs).Error)
+				require.Len(t, prs, 1)
+				require.Equal(t, first.ID, prs[0].WorkoutRecordID)
+				require.InDelta(t, 80, prs[0].Value, 1e-6)
+			},
+		},
+		{
+			name: "【正常系】ベストを出した記録の重量を下げると、古いベストが残らないこと",
+			run: func(t *testing.T, db *gorm.DB, repo WorkoutRepository, f prFixture, first, second *models.WorkoutRecord) {
+				rec, err := repo.FindByIDAndUserID(second.ID, f.user.ID)
+				require.NoError(t, err)
+				rec.Sets = []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 70}}
+				require.NoError(t, repo.Update(rec))
+				// 70kg は初めての重量なので回数のみ自己ベストになる
+				require.Equal(t, []models.PersonalRecordType{models.PRMaxRepsAtWeight}, prTypes(rec.PersonalRecords))
+
+				var maxWeight []float64
+				require.NoError(t, db.Model(&models.PersonalRecord{}).
+					Where("user_id = ? AND type = ?", f.user.ID, models.PRMaxWeight).
+					Pluck("value", &maxWeight).Error)
+				require.Equal(t, []float64{80}, maxWeight)
+			},
+		},
+		{
+			name: "【正常系】記録の種目を変更すると、変更前と変更後の両方の種目が再計算されること",
+			run: func(t *testing.T, db *gorm.DB, repo WorkoutRepository, f prFixture, first, second *models.WorkoutRecord) {
+				rec, err := repo.FindByIDAndUserID(second.ID, f.user.ID)
+				require.NoError(t, err)
+				rec.ExerciseID = f.pullUp.ID
+				require.NoError(t, repo.Update(rec))
+				require.Contains(t, prTypes(rec.PersonalRecords), models.PRMaxWeight)
+
+				var benchCnt, pullUpCnt int64
+				require.NoError(t, db.Model(&models.PersonalRecord{}).
+					Where("exercise_id = ? AND workout_record_id = ?", f.bench.ID, second.ID).
+					Count(&benchCnt).Error)
+				require.NoError(t, db.Model(&models.PersonalRecord{}).
+					Where("exercise_id = ? AND workout_record_id = ?", f.pullUp.ID, second.ID).
+					Count(&pullUpCnt).Error)
+				require.Zero(t, benchCnt)
+				require.NotZero(t, pullUpCnt)
+			},
+		},
+		{
+			name: "【正常系】前の日付へ移動した記録がベストとなり、後の記録のベストが外れること",
+			run: func(t *testing.T, db *gorm.DB, repo WorkoutRepository, f prFixture, first, second *models.WorkoutRecord) {
+				rec, err := repo.FindByIDAndUserID(first.ID, f.user.ID)
+				require.NoError(t, err)
+				rec.TrainedOn = f.firstOn.AddDate(0, 0, 14)
+				require.NoError(t, repo.Update(rec))
+
+				var prs []models.PersonalRecord
+				require.NoError(t, db.Where("user_id = ? AND type = ?", f.user.ID, models.PRMaxWeight).Find(&prs).Error)
+				require.Len(t, prs, 1)
+				require.Equal(t, second.ID, prs[0].WorkoutRecordID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			repo := NewWorkoutRepository(db)
+			f := seedPersonalRecordFixtures(t, db)
+
+			first := models.WorkoutRecord{
+				UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn,
+				Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 80}},
+			}
+			second := models.WorkoutRecord{
+				UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: f.firstOn.AddDate(0, 0, 7),
+				Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 90}},
+			}
+			require.NoError(t, repo.Create(&first))
+			require.NoError(t, repo.Create(&second))
+
+			tt.run(t, db, repo, f, &first, &second)
+		})
+	}
+}
+
+func TestWorkoutSessionRepository_PersonalRecords(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	sessionRepo := NewWorkoutSessionRepository(db)
+	f := seedPersonalRecordFixtures(t, db)
+
+	session := models.WorkoutSession{
+		UserID: f.user.ID, TrainedOn: f.firstOn,
+		Records: []models.WorkoutRecord{
+			{ExerciseID: f.bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 80}}},
+			{ExerciseID: f.pushUp.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 20}}},
+		},
+	}
+	require.NoError(t, sessionRepo.Create(&session))
+	require.Contains(t, prTypes(session.Records[0].PersonalRecords), models.PRMaxWeight)
+	require.Equal(t, []models.PersonalRecordType{models.PRMaxRepsAtWeight}, prTypes(session.Records[1].PersonalRecords))
+
+	// 種目を入れ替えて更新すると、外れた種目の自己ベストは消えること
+	loaded, err := sessionRepo.FindByIDAndUserID(session.ID, f.user.ID)
+	require.NoError(t, err)
+	loaded.Records = []models.WorkoutRecord{
+		{ExerciseID: f.bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 85}}},
+	}
+	require.NoError(t, sessionRepo.Update(loaded))
+
+	var pushUpCnt int64
+	require.NoError(t, db.Model(&models.PersonalRecord{}).Where("exercise_id = ?", f.pushUp.ID).Count(&pushUpCnt).Error)
+	require.Zero(t, pushUpCnt)
+
+	prRepo := NewPersonalRecordRepository(db)
+	prs, err := prRepo.FindByUserAndExercise(f.user.ID, f.bench.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, prs)
+	require.Equal(t, "ベンチプレス", prs[0].Exercise.Name)
+	require.Equal(t, loaded.Records[0].ID, prs[0].WorkoutRecordID)
+
+	require.NoError(t, sessionRepo.Delete(session.ID, f.user.ID))
+	all, err := prRepo.FindByUser(f.user.ID)
+	require.NoError(t, err)
+	require.Empty(t, all)
+}
@@ -9,7 +9,9 @@ import (
 
 type ProfileRepository interface {
 	GetProfile(userID uint) (*models.User, error)
-	UpdateProfile(userID uint, height *float64, goalWeight *float64) error
+	UpdateProfile(userID uint, profile models.User, columns []string) error
+	GetLatestWeight(userID uint) (*float64, error)
+	GetLatestBodyFat(userID uint) (*float64, error)
 }
 
 type profileRepository struct {
@@ -31,12 +33,9 @@ func (r *profileRepository) GetProfile(userID uint) (*models.User, error) {
 	return &user, nil
 }
 
-func (r *profileRepository) UpdateProfile(userID uint, height *float64, goalWeight *float64) error {
-	updates := map[string]interface{}{
-		"height":      height,
-		"goal_weight": goalWeight,
-	}
-	result := r.db.Model(&models.User{}).Where("id = ?", userID).Updates(updates)
+// UpdateProfile は columns に指定した列だけを profile の値で更新する。値が nil の列は未設定に戻す。
+func (r *profileRepository) UpdateProfile(userID uint, profile models.User, columns []string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Select(columns).Updates(&profile)
 
 	if result.Error != nil {
 		return result.Error
@@ -48,3 +47,12 @@ func (r *profileRepository) UpdateProfile(userID uint, height *float64, goalWeig
 
 	return nil
 }
+
+func (r *profileRepository) GetLatestWeight(userID uint) (*float64, error) {
+	w, _, err := latestWeight(r.db, userID)
+	return w, err
+}
+
+func (r *profileRepository) GetLatestBodyFat(userID uint) (*float64, error) {
+	return latestBodyFat(r.db, userID)
+}
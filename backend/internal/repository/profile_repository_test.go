@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/utils"
@@ -100,6 +101,9 @@ func TestProfileRepository_UpdateProfile(t *testing.T) {
 		userID         uint
 		newHeight      float64
 		newGoal        float64
+		newGoalDate    *time.Time
+		columns        []string
+		wantGoalDate   *time.Time
 		expectError    bool
 		expectNotFound bool
 	}{
@@ -116,6 +120,43 @@ func TestProfileRepository_UpdateProfile(t *testing.T) {
 			userID:         1,
 			newHeight:      170.0,
 			newGoal:        60.0,
+			newGoalDate:    utils.Ptr(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)),
+			columns:        []string{"height", "goal_weight", "goal_date"},
+			wantGoalDate:   utils.Ptr(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)),
+			expectError:    false,
+			expectNotFound: false,
+		},
+		{
+			name: "【正常系】columnsに含まれない目標日は変更しないこと",
+			prepare: func(db *gorm.DB) {
+				user := models.User{
+					Email:    "test@example.com",
+					GoalDate: utils.Ptr(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)),
+				}
+				require.NoError(t, db.Create(&user).Error)
+			},
+			userID:         1,
+			newHeight:      170.0,
+			newGoal:        60.0,
+			columns:        []string{"height", "goal_weight"},
+			wantGoalDate:   utils.Ptr(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)),
+			expectError:    false,
+			expectNotFound: false,
+		},
+		{
+			name: "【正常系】columnsに含まれる目標日がnilの場合は未設定に戻すこと",
+			prepare: func(db *gorm.DB) {
+				user := models.User{
+					Email:    "test@example.com",
+					GoalDate: utils.Ptr(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)),
+				}
+				require.NoError(t, db.Create(&user).Error)
+			},
+			userID:         1,
+			newHeight:      170.0,
+			newGoal:        60.0,
+			columns:        []string{"height", "goal_weight", "goal_date"},
+			wantGoalDate:   nil,
 			expectError:    false,
 			expectNotFound: false,
 		},
@@ -152,7 +193,12 @@ func TestProfileRepository_UpdateProfile(t *testing.T) {
 			tt.prepare(db)
 
 			repo := NewProfileRepository(db)
-			err := repo.UpdateProfile(tt.userID, &tt.newHeight, &tt.newGoal)
+			columns := tt.columns
+			if columns == nil {
+				columns = []string{"height", "goal_weight", "goal_date"}
+			}
+			profile := models.User{Height: &tt.newHeight, GoalWeight: &tt.newGoal, GoalDate: tt.newGoalDate}
+			err := repo.UpdateProfile(tt.userID, profile, columns)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -169,6 +215,12 @@ func TestProfileRepository_UpdateProfile(t *testing.T) {
 			require.NoError(t, db.First(&user, tt.userID).Error)
 			require.Equal(t, tt.newHeight, *user.Height)
 			require.Equal(t, tt.newGoal, *user.GoalWeight)
+			if tt.wantGoalDate == nil {
+				require.Nil(t, user.GoalDate)
+				return
+			}
+			require.NotNil(t, user.GoalDate)
+			require.Equal(t, tt.wantGoalDate.Format("2006-01-02"), user.GoalDate.Format("2006-01-02"))
 		})
 	}
 }
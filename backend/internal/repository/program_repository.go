@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type ProgramRepository interface {
+	FindByUser(userID uint) (*models.UserProgram, error)
+	Replace(program *models.UserProgram) error
+	Delete(userID uint) error
+	FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+	FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]FlatWorkoutSet, error)
+}
+
+type programRepository struct {
+	db *gorm.DB
+}
+
+func NewProgramRepository(db *gorm.DB) ProgramRepository {
+	return &programRepository{db: db}
+}
+
+func (r *programRepository) FindByUser(userID uint) (*models.UserProgram, error) {
+	var up models.UserProgram
+	err := r.db.
+		Preload("Lifts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Lifts.Exercise", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select("id", "name", "measurement_kind")
+		}).
+		Where("user_id = ?", userID).
+		First(&up).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &up, nil
+}
+
+// Replace は参加中のプログラムを破棄して program に置き換える（ユーザーごとに1件）。
+// 開始日以降の記録が既にあれば、進行状況に反映する。
+func (r *programRepository) Replace(program *models.UserProgram) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteUserProgram(tx, program.UserID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Lifts.Exercise").Create(program).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return err
+		}
+		return recomputeUserProgram(tx, program.UserID)
+	})
+}
+
+func (r *programRepository) Delete(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		if err := tx.Model(&models.UserProgram{}).Where("user_id = ?", userID).Count(&cnt).Error; err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return ErrNotFound
+		}
+		return deleteUserProgram(tx, userID)
+	})
+}
+
+func deleteUserProgram(tx *gorm.DB, userID uint) error {
+	ids := tx.Model(&models.UserProgram{}).Select("id").Where("user_id = ?", userID)
+	if err := tx.
+		Where("user_program_id IN (?)", ids).
+		Delete(&models.UserProgramLift{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&models.UserProgram{}).Error
+}
+
+func (r *programRepository) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	return findUsableExercises(r.db, userID, exerciseIDs)
+}
+
+func (r *programRepository) FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]FlatWorkoutSet, error) {
+	return findFlatSets(r.db, userID, exerciseID, from, to)
+}
+
+// recomputeUserProgram は参加中のプログラムの進行状況を参加時の状態に戻し、開始日以降の記録を日付順に当てはめ直す。
+// 記録の作成・更新・削除と同じトランザクションで呼ぶ。
+func recomputeUserProgram(tx *gorm.DB, userID uint) error {
+	var up models.UserProgram
+	err := tx.
+		Preload("Lifts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Where("user_id = ?", userID).
+		First(&up).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	program, ok := models.FindTrainingProgram(up.ProgramKey)
+	if !ok {
+		return nil
+	}
+
+	var records []models.WorkoutRecord
+	if err := tx.
+		Preload("Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("set_no ASC, id ASC")
+		}).
+		Where("user_id = ? AND trained_on >= ?", userID, up.StartedOn).
+		Order("trained_on ASC, session_id ASC, position ASC, id ASC").
+		Find(&records).Error; err != nil {
+		return err
+	}
+
+	up.ResetProgress()
+	for _, rec := range records {
+		up.ApplyRecord(program, rec)
+	}
+
+	if err := tx.Model(&models.UserProgram{}).
+		Where("id = ?", up.ID).
+		Updates(map[string]any{
+			"cycle": up.Cycle,
+			"week":  up.Week,
+			"day":   up.Day,
+		}).Error; err != nil {
+		return err
+	}
+	for _, l := range up.Lifts {
+		if err := tx.Model(&models.UserProgramLift{}).
+			Where("id = ?", l.ID).
+			Updates(map[string]any{
+				"training_max": l.TrainingMax,
+				"failures":     l.Failures,
+				"cycle_failed": l.CycleFailed,
+				"done":         l.Done,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
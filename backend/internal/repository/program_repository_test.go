@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type programFixtures struct {
+	user                             models.User
+	squat, bench, row, ohp, deadlift models.Exercise
+}
+
+func seedProgramFixtures(t *testing.T, db *gorm.DB) programFixtures {
+	t.Helper()
+	f := programFixtures{
+		user:     models.User{Email: "program@example.com"},
+		squat:    models.Exercise{Name: "スクワット"},
+		bench:    models.Exercise{Name: "ベンチプレス"},
+		row:      models.Exercise{Name: "ベントオーバーロウ"},
+		ohp:      models.Exercise{Name: "オーバーヘッドプレス"},
+		deadlift: models.Exercise{Name: "デッドリフト"},
+	}
+	require.NoError(t, db.Create(&f.user).Error)
+	for _, ex := range []*models.Exercise{&f.squat, &f.bench, &f.row, &f.ohp, &f.deadlift} {
+		require.NoError(t, db.Create(ex).Error)
+	}
+	return f
+}
+
+func enrollProgram(t *testing.T, db *gorm.DB, f programFixtures, key string, tm float64) {
+	t.Helper()
+	program, ok := models.FindTrainingProgram(key)
+	require.True(t, ok)
+
+	byName := map[string]uint{
+		"squat": f.squat.ID, "bench": f.bench.ID, "row": f.row.ID, "ohp": f.ohp.ID, "deadlift": f.deadlift.ID,
+	}
+	up := &models.UserProgram{UserID: f.user.ID, ProgramKey: key, StartedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)}
+	for _, s := range program.Slots {
+		up.Lifts = append(up.Lifts, models.UserProgramLift{Slot: s.Key, ExerciseID: byName[s.Key], TrainingMax: tm, StartingTrainingMax: tm})
+	}
+	require.NoError(t, NewProgramRepository(db).Replace(up))
+}
+
+// logSets は同じ重量・回数のセットを n 本記録する。
+func logSets(t *testing.T, db *gorm.DB, userID, exerciseID uint, day int, weight float64, reps, n int) {
+	t.Helper()
+	rec := &models.WorkoutRecord{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+		TrainedOn:  time.Date(2025, 10, day, 0, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < n; i++ {
+		rec.Sets = append(rec.Sets, models.WorkoutSet{SetNo: i + 1, Reps: reps, ExerciseWeight: weight, SetType: models.SetTypeWorking})
+	}
+	require.NoError(t, NewWorkoutRepository(db).Create(rec))
+}
+
+func liftOf(up *models.UserProgram, slot string) models.UserProgramLift {
+	for _, l := range up.Lifts {
+		if l.Slot == slot {
+			return l
+		}
+	}
+	return models.UserProgramLift{}
+}
+
+func TestProgramRepository_ReplaceFindDelete(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	f := seedProgramFixtures(t, db)
+	repo := NewProgramRepository(db)
+
+	_, err := repo.FindByUser(f.user.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	enrollProgram(t, db, f, "531", 100)
+	enrollProgram(t, db, f, "linear", 60)
+
+	up, err := repo.FindByUser(f.user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "linear", up.ProgramKey)
+	require.Len(t, up.Lifts, 5)
+	require.Equal(t, "スクワット", up.Lifts[0].Exercise.Name)
+
+	var liftCnt int64
+	require.NoError(t, db.Model(&models.UserProgramLift{}).Count(&liftCnt).Error)
+	require.Equal(t, int64(5), liftCnt)
+
+	require.NoError(t, repo.Delete(f.user.ID))
+	require.ErrorIs(t, repo.Delete(f.user.ID), ErrNotFound)
+	require.NoError(t, db.Model(&models.UserProgramLift{}).Count(&liftCnt).Error)
+	require.Zero(t, liftCnt)
+}
+
+func TestRecomputeUserProgram_PerSession(t *testing.T) {
+	tests := []struct {
+		name  string
+		log   func(t *testing.T, db *gorm.DB, f programFixtures)
+		check func(t *testing.T, up *models.UserProgram)
+	}{
+		{
+			name: "【正常系】指定を満たすと重量が上がり、その日の種目がそろうと次の日へ進むこと",
+			log: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				logSets(t, db, f.user.ID, f.squat.ID, 1, 60, 5, 3)
+				logSets(t, db, f.user.ID, f.bench.ID, 1, 60, 5, 3)
+				logSets(t, db, f.user.ID, f.row.ID, 1, 60, 5, 3)
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 1, up.Day)
+				require.Equal(t, 62.5, liftOf(up, "squat").TrainingMax)
+				require.Equal(t, 62.5, liftOf(up, "bench").TrainingMax)
+				require.False(t, liftOf(up, "squat").Done)
+				// B の日の種目は変わらない
+				require.Equal(t, 60.0, liftOf(up, "deadlift").TrainingMax)
+			},
+		},
+		{
+			name: "【正常系】途中までなら日を進めず、実施済みとして記録すること",
+			log: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				logSets(t, db, f.user.ID, f.squat.ID, 1, 60, 5, 3)
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 0, up.Day)
+				require.True(t, liftOf(up, "squat").Done)
+			},
+		},
+		{
+			name: "【正常系】現在の日に無い種目や実施済みの種目は進行に影響しないこと",
+			log: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				logSets(t, db, f.user.ID, f.deadlift.ID, 1, 100, 5, 1)
+				logSets(t, db, f.user.ID, f.squat.ID, 1, 60, 5, 3)
+				logSets(t, db, f.user.ID, f.squat.ID, 1, 60, 5, 3)
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 0, up.Day)
+				require.Equal(t, 62.5, liftOf(up, "squat").TrainingMax)
+				require.Equal(t, 60.0, liftOf(up, "deadlift").TrainingMax)
+			},
+		},
+		{
+			name: "【正常系】3回続けて失敗すると10%下げること",
+			log: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				for i, day := 0, 1; i < 3; i++ {
+					// A の日: スクワットのみ回数が足りない。他の種目は余裕のある重量で成功させる
+					logSets(t, db, f.user.ID, f.squat.ID, day, 60, 4, 3)
+					logSets(t, db, f.user.ID, f.bench.ID, day, 100, 5, 3)
+					logSets(t, db, f.user.ID, f.row.ID, day, 100, 5, 3)
+					day++
+					// B の日
+					logSets(t, db, f.user.ID, f.squat.ID, day, 60, 4, 3)
+					logSets(t, db, f.user.ID, f.ohp.ID, day, 100, 5, 3)
+					logSets(t, db, f.user.ID, f.deadlift.ID, day, 100, 5, 1)
+					day++
+				}
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 3, up.Cycle)
+				require.Equal(t, 0, up.Day)
+				squat := liftOf(up, "squat")
+				// 6回失敗: 3回目で 54kg→55kg に下げ、さらに3回失敗で 49.5→50kg
+				require.Equal(t, 50.0, squat.TrainingMax)
+				require.Equal(t, 0, squat.Failures)
+				require.Equal(t, 67.5, liftOf(up, "bench").TrainingMax)
+				require.Equal(t, 75.0, liftOf(up, "deadlift").TrainingMax)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			f := seedProgramFixtures(t, db)
+			enrollProgram(t, db, f, "linear", 60)
+
+			tt.log(t, db, f)
+
+			up, err := NewProgramRepository(db).FindByUser(f.user.ID)
+			require.NoError(t, err)
+			tt.check(t, up)
+		})
+	}
+}
+
+func TestRecomputeUserProgram_PerCycle(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	f := seedProgramFixtures(t, db)
+	enrollProgram(t, db, f, "531", 100)
+
+	// 4週×4日を行い、スクワットだけ1週目の最終セットで失敗する
+	program, _ := models.FindTrainingProgram("531")
+	day := 1
+	for w, week := range program.Weeks {
+		for _, d := range week.Days {
+			lift := d.Lifts[0]
+			exerciseID := map[string]uint{"ohp": f.ohp.ID, "deadlift": f.deadlift.ID, "bench": f.bench.ID, "squat": f.squat.ID}[lift.Slot]
+			rec := &models.WorkoutRecord{UserID: f.user.ID, ExerciseID: exerciseID, TrainedOn: time.Date(2025, 10, day, 0, 0, 0, 0, time.UTC)}
+			for i, st := range lift.Sets {
+				reps := st.Reps
+				if lift.Slot == "squat" && w == 0 && i == len(lift.Sets)-1 {
+					reps--
+				}
+				rec.Sets = append(rec.Sets, models.WorkoutSet{SetNo: i + 1, Reps: reps, ExerciseWeight: models.RoundToPlate(100 * st.Percent)})
+			}
+			require.NoError(t, NewWorkoutRepository(db).Create(rec))
+			day++
+
+			if w == 0 && lift.Slot == "squat" {
+				// サイクルの途中ではトレーニングマックスを変えない
+				up, err := NewProgramRepository(db).FindByUser(f.user.ID)
+				require.NoError(t, err)
+				require.Equal(t, 100.0, liftOf(up, "squat").TrainingMax)
+				require.True(t, liftOf(up, "squat").CycleFailed)
+				require.Equal(t, 1, up.Week)
+			}
+		}
+	}
+
+	up, err := NewProgramRepository(db).FindByUser(f.user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, up.Cycle)
+	require.Equal(t, 0, up.Week)
+	require.Equal(t, 102.5, liftOf(up, "ohp").TrainingMax)
+	require.Equal(t, 105.0, liftOf(up, "deadlift").TrainingMax)
+	squat := liftOf(up, "squat")
+	require.Equal(t, 100.0, squat.TrainingMax)
+	require.Equal(t, 1, squat.Failures)
+	require.False(t, squat.CycleFailed)
+}
+
+func TestRecomputeUserProgram_OnWrites(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	sets := func(reps int) []models.WorkoutSet {
+		var out []models.WorkoutSet
+		for i := 0; i < 3; i++ {
+			out = append(out, models.WorkoutSet{SetNo: i + 1, Reps: reps, ExerciseWeight: 60, SetType: models.SetTypeWorking})
+		}
+		return out
+	}
+	dayA := func(f programFixtures, squatReps int) *models.WorkoutSession {
+		return &models.WorkoutSession{
+			UserID:    f.user.ID,
+			TrainedOn: day,
+			Records: []models.WorkoutRecord{
+				{ExerciseID: f.squat.ID, Sets: sets(squatReps)},
+				{ExerciseID: f.bench.ID, Sets: sets(5)},
+				{ExerciseID: f.row.ID, Sets: sets(5)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		write func(t *testing.T, db *gorm.DB, f programFixtures)
+		check func(t *testing.T, up *models.UserProgram)
+	}{
+		{
+			name: "【正常系】セッションの作成で進行状況が進むこと",
+			write: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				require.NoError(t, NewWorkoutSessionRepository(db).Create(dayA(f, 5)))
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 1, up.Day)
+				require.Equal(t, 62.5, liftOf(up, "squat").TrainingMax)
+			},
+		},
+		{
+			name: "【正常系】セッションの更新で進行状況を作り直すこと",
+			write: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				repo := NewWorkoutSessionRepository(db)
+				session := dayA(f, 5)
+				require.NoError(t, repo.Create(session))
+
+				session.Records = dayA(f, 4).Records
+				require.NoError(t, repo.Update(session))
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 1, up.Day)
+				squat := liftOf(up, "squat")
+				require.Equal(t, 60.0, squat.TrainingMax)
+				require.Equal(t, 1, squat.Failures)
+				require.Equal(t, 62.5, liftOf(up, "bench").TrainingMax)
+			},
+		},
+		{
+			name: "【正常系】セッションの削除で参加時の状態に戻ること",
+			write: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				repo := NewWorkoutSessionRepository(db)
+				session := dayA(f, 5)
+				require.NoError(t, repo.Create(session))
+				require.NoError(t, repo.Delete(session.ID, f.user.ID))
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 0, up.Day)
+				require.Equal(t, 60.0, liftOf(up, "squat").TrainingMax)
+				require.False(t, liftOf(up, "squat").Done)
+			},
+		},
+		{
+			name: "【正常系】記録の更新・削除で進行状況を作り直すこと",
+			write: func(t *testing.T, db *gorm.DB, f programFixtures) {
+				repo := NewWorkoutRepository(db)
+				squat := &models.WorkoutRecord{UserID: f.user.ID, ExerciseID: f.squat.ID, TrainedOn: day, Sets: sets(5)}
+				require.NoError(t, repo.Create(squat))
+				bench := &models.WorkoutRecord{UserID: f.user.ID, ExerciseID: f.bench.ID, TrainedOn: day, Sets: sets(5)}
+				require.NoError(t, repo.Create(bench))
+
+				squat.Sets = sets(4)
+				require.NoError(t, repo.Update(squat))
+				require.NoError(t, repo.Delete(bench.ID, f.user.ID))
+			},
+			check: func(t *testing.T, up *models.UserProgram) {
+				require.Equal(t, 0, up.Day)
+				squat := liftOf(up, "squat")
+				require.True(t, squat.Done)
+				require.Equal(t, 1, squat.Failures)
+				bench := liftOf(up, "bench")
+				require.False(t, bench.Done)
+				require.Equal(t, 60.0, bench.TrainingMax)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			f := seedProgramFixtures(t, db)
+			enrollProgram(t, db, f, "linear", 60)
+
+			tt.write(t, db, f)
+
+			up, err := NewProgramRepository(db).FindByUser(f.user.ID)
+			require.NoError(t, err)
+			tt.check(t, up)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,12 @@ import (
 
 type RankingRepository interface {
 	MonthlyGymDays(ctx context.Context, from, to time.Time) ([]GymDaysRow, error)
+	MonthlyTotalVolume(ctx context.Context, from, to time.Time) ([]TotalVolumeRow, error)
+	FindRankableExercise(ctx context.Context, exerciseID uint) (*models.Exercise, error)
+	MonthlyExerciseBest(ctx context.Context, exerciseID uint, from, to time.Time) ([]ExerciseBestRow, error)
+	MonthlyBigThreeBests(ctx context.Context, from, to time.Time) ([]BigThreeLiftRow, error)
+	FindSnapshot(ctx context.Context, kind string, granularity models.RankingGranularity, start time.Time) (*models.RankingSnapshot, error)
+	CreateSnapshot(ctx context.Context, snapshot *models.RankingSnapshot) error
 }
 
 type rankingRepository struct {
@@ -33,6 +40,22 @@ type TotalVolumeRow struct {
 	TotalVolume float64
 }
 
+type ExerciseBestRow struct {
+	UserID     uint
+	Email      string
+	BestWeight float64
+}
+
+// BigThreeLiftRow はユーザーごとの BIG3 の1種目の最高重量と、その記録時の体重。Lift は種目名。
+type BigThreeLiftRow struct {
+	UserID     uint
+	Email      string
+	Sex        models.Sex
+	Lift       string
+	Weight     float64
+	BodyWeight float64
+}
+
 func (r *rankingRepository) MonthlyGymDays(ctx context.Context, from, to time.Time) ([]GymDaysRow, error) {
 	var rows []GymDaysRow
 	err := r.db.WithContext(ctx).
@@ -49,3 +72,129 @@ func (r *rankingRepository) MonthlyGymDays(ctx context.Context, from, to time.Ti
 	fmt.Println("rows", rows)
 	return rows, nil
 }
+
+// MonthlyTotalVolume はウォームアップを除いたボリュームの合計が多い順に返す。ボリュームが0のユーザーは含まない。
+func (r *rankingRepository) MonthlyTotalVolume(ctx context.Context, from, to time.Time) ([]TotalVolumeRow, error) {
+	var rows []TotalVolumeRow
+	err := r.db.WithContext(ctx).
+		Table("workout_sets AS s").
+		Select("r.user_id AS user_id, users.email AS email, SUM("+setVolumeExpr+") AS total_volume").
+		Joins("INNER JOIN workout_records r ON r.id = s.workout_record_id").
+		Joins("INNER JOIN exercises e ON e.id = r.exercise_id").
+		Joins("INNER JOIN users ON users.id = r.user_id").
+		Where("r.trained_on >= ? AND r.trained_on < ?", from, to).
+		Where("s.set_type <> ?", models.SetTypeWarmup).
+		Where("s.deleted_at IS NULL AND r.deleted_at IS NULL").
+		Group("r.user_id, users.email").
+		Having("SUM(" + setVolumeExpr + ") > 0").
+		Order("total_volume DESC, r.user_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindRankableExercise はランキングの対象にできる種目（共通種目・共有された種目）を返す。
+func (r *rankingRepository) FindRankableExercise(ctx context.Context, exerciseID uint) (*models.Exercise, error) {
+	var ex models.Exercise
+	err := r.db.WithContext(ctx).
+		Select("id", "name", "measurement_kind").
+		Where("id = ?", exerciseID).
+		Where("owner_id IS NULL OR is_shared = ?", true).
+		First(&ex).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &ex, nil
+}
+
+// MonthlyExerciseBest は種目の最高重量（加重分、1回以上のセット）が重い順に返す。
+func (r *rankingRepository) MonthlyExerciseBest(ctx context.Context, exerciseID uint, from, to time.Time) ([]ExerciseBestRow, error) {
+	var rows []ExerciseBestRow
+	err := r.db.WithContext(ctx).
+		Table("workout_sets AS s").
+		Select("r.user_id AS user_id, users.email AS email, MAX(s.exercise_weight) AS best_weight").
+		Joins("INNER JOIN workout_records r ON r.id = s.workout_record_id").
+		Joins("INNER JOIN users ON users.id = r.user_id").
+		Where("r.exercise_id = ?", exerciseID).
+		Where("r.trained_on >= ? AND r.trained_on < ?", from, to).
+		Where("s.reps > 0 AND s.exercise_weight > 0").
+		Where("s.deleted_at IS NULL AND r.deleted_at IS NULL").
+		Group("r.user_id, users.email").
+		Order("best_weight DESC, r.user_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// MonthlyBigThreeBests は性別を設定しているユーザーについて、共通種目の BIG3 ごとの最高重量と記録時の体重を返す。
+// 同じ重量の記録が複数あれば体重の軽い方を使う。体重を入力していない記録は対象外。
+func (r *rankingRepository) MonthlyBigThreeBests(ctx context.Context, from, to time.Time) ([]BigThreeLiftRow, error) {
+	var rows []BigThreeLiftRow
+	err := r.db.WithContext(ctx).
+		Table("workout_sets AS s").
+		Select(`
+			r.user_id AS user_id,
+			users.email AS email,
+			users.sex AS sex,
+			e.name AS lift,
+			r.body_weight AS body_weight,
+			MAX(s.exercise_weight) AS weight
+		`).
+		Joins("INNER JOIN workout_records r ON r.id = s.workout_record_id").
+		Joins("INNER JOIN exercises e ON e.id = r.exercise_id").
+		Joins("INNER JOIN users ON users.id = r.user_id").
+		Where("e.owner_id IS NULL AND e.name IN ? AND e.measurement_kind = ?", models.BigThreeLiftNames, models.MeasurementRepsWeight).
+		Where("users.sex IS NOT NULL").
+		Where("r.trained_on >= ? AND r.trained_on < ?", from, to).
+		Where("r.body_weight > 0 AND s.reps > 0 AND s.exercise_weight > 0").
+		Where("s.set_type <> ?", models.SetTypeWarmup).
+		Where("s.deleted_at IS NULL AND r.deleted_at IS NULL AND users.deleted_at IS NULL").
+		Group("r.user_id, users.email, users.sex, e.name, r.body_weight").
+		Order("r.user_id ASC, e.name ASC, weight DESC, r.body_weight ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]BigThreeLiftRow, 0, len(rows))
+	for _, row := range rows {
+		n := len(out)
+		if n > 0 && out[n-1].UserID == row.UserID && out[n-1].Lift == row.Lift {
+			continue
+		}
+		out = append(out, row)
+	}
+	return out, nil
+}
+
+func (r *rankingRepository) FindSnapshot(ctx context.Context, kind string, granularity models.RankingGranularity, start time.Time) (*models.RankingSnapshot, error) {
+	var snap models.RankingSnapshot
+	err := r.db.WithContext(ctx).
+		Where("kind = ? AND granularity = ? AND period_start = ?", kind, granularity, start).
+		First(&snap).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// CreateSnapshot は同じ期間のスナップショットが既にある場合 ErrUniqueViolation を返す。
+func (r *rankingRepository) CreateSnapshot(ctx context.Context, snapshot *models.RankingSnapshot) error {
+	if err := r.db.WithContext(ctx).Create(snapshot).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrUniqueViolation
+		}
+		return err
+	}
+	return nil
+}
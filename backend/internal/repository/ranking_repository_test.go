@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankingRepository_MonthlyTotalVolumeAndExerciseBest(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	alice := models.User{Email: "alice@example.com"}
+	bob := models.User{Email: "bob@example.com"}
+	require.NoError(t, db.Create(&alice).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	plank := models.Exercise{Name: "プランク", MeasurementKind: models.MeasurementDuration}
+	private := models.Exercise{Name: "自分用ベンチ", OwnerID: &alice.ID}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&plank).Error)
+	require.NoError(t, db.Create(&private).Error)
+
+	workouts := NewWorkoutRepository(db)
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	save := func(userID, exerciseID uint, trainedOn time.Time, sets ...models.WorkoutSet) {
+		require.NoError(t, workouts.Create(&models.WorkoutRecord{UserID: userID, ExerciseID: exerciseID, BodyWeight: 70, TrainedOn: trainedOn, Sets: sets}))
+	}
+	save(alice.ID, bench.ID, day(9, 30), models.WorkoutSet{SetNo: 1, Reps: 1, ExerciseWeight: 150}) // 先月
+	save(alice.ID, bench.ID, day(10, 2),
+		models.WorkoutSet{SetNo: 1, Reps: 10, ExerciseWeight: 60, SetType: models.SetTypeWarmup},
+		models.WorkoutSet{SetNo: 2, Reps: 5, ExerciseWeight: 100},
+	)
+	save(bob.ID, bench.ID, day(10, 5), models.WorkoutSet{SetNo: 1, Reps: 3, ExerciseWeight: 110})
+	save(bob.ID, bench.ID, day(10, 6), models.WorkoutSet{SetNo: 1, Reps: 2, ExerciseWeight: 105})
+	save(bob.ID, plank.ID, day(10, 6), models.WorkoutSet{SetNo: 1, DurationSeconds: utils.Ptr(60)})
+
+	repo := NewRankingRepository(db)
+	ctx := context.Background()
+	from, to := day(10, 1), day(11, 1)
+
+	t.Run("【正常系】ウォームアップを除いた総ボリュームの多い順に返すこと", func(t *testing.T) {
+		rows, err := repo.MonthlyTotalVolume(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, bob.ID, rows[0].UserID)
+		require.Equal(t, 540.0, rows[0].TotalVolume)
+		require.Equal(t, "alice@example.com", rows[1].Email)
+		require.Equal(t, 500.0, rows[1].TotalVolume)
+	})
+
+	t.Run("【正常系】種目の最高重量の重い順に返すこと", func(t *testing.T) {
+		rows, err := repo.MonthlyExerciseBest(ctx, bench.ID, from, to)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, bob.ID, rows[0].UserID)
+		require.Equal(t, 110.0, rows[0].BestWeight)
+		require.Equal(t, 100.0, rows[1].BestWeight)
+	})
+
+	t.Run("【異常系】独自種目はランキングの対象にならないこと", func(t *testing.T) {
+		_, err := repo.FindRankableExercise(ctx, private.ID)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		ex, err := repo.FindRankableExercise(ctx, bench.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.MeasurementRepsWeight, ex.MeasurementKind)
+	})
+}
+
+func TestRankingRepository_MonthlyBigThreeBests(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	male := models.SexMale
+	alice := models.User{Email: "alice@example.com", Sex: &male}
+	bob := models.User{Email: "bob@example.com"} // 性別未設定
+	require.NoError(t, db.Create(&alice).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	squat := models.Exercise{Name: "スクワット"}
+	bench := models.Exercise{Name: "ベンチプレス"}
+	custom := models.Exercise{Name: "デッドリフト", OwnerID: &alice.ID}
+	require.NoError(t, db.Create(&squat).Error)
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&custom).Error)
+
+	workouts := NewWorkoutRepository(db)
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	save := func(userID, exerciseID uint, d int, bodyWeight, weight float64) {
+		require.NoError(t, workouts.Create(&models.WorkoutRecord{
+			UserID: userID, ExerciseID: exerciseID, BodyWeight: bodyWeight, TrainedOn: day(d),
+			Sets: []models.WorkoutSet{{SetNo: 1, Reps: 1, ExerciseWeight: weight}},
+		}))
+	}
+	save(alice.ID, squat.ID, 2, 80, 150)
+	save(alice.ID, squat.ID, 9, 78, 150) // 同じ重量なら体重の軽い記録
+	save(alice.ID, bench.ID, 3, 0, 120)  // 体重未入力
+	save(alice.ID, bench.ID, 4, 79, 100)
+	save(alice.ID, custom.ID, 5, 79, 200) // 独自種目は対象外
+	save(bob.ID, squat.ID, 2, 70, 180)
+
+	repo := NewRankingRepository(db)
+	rows, err := repo.MonthlyBigThreeBests(context.Background(), day(1), day(31))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	byLift := map[string]BigThreeLiftRow{}
+	for _, r := range rows {
+		require.Equal(t, alice.ID, r.UserID)
+		require.Equal(t, models.SexMale, r.Sex)
+		byLift[r.Lift] = r
+	}
+	require.Equal(t, 150.0, byLift["スクワット"].Weight)
+	require.Equal(t, 78.0, byLift["スクワット"].BodyWeight)
+	require.Equal(t, 100.0, byLift["ベンチプレス"].Weight)
+}
+
+func TestRankingRepository_Snapshot(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.RankingSnapshot{}))
+	repo := NewRankingRepository(db)
+	ctx := context.Background()
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := repo.FindSnapshot(ctx, "gym_days", models.RankingMonth, start)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, repo.CreateSnapshot(ctx, &models.RankingSnapshot{Kind: "gym_days", Granularity: models.RankingMonth, PeriodStart: start, Entries: `[{"user_id":1}]`}))
+	require.NoError(t, repo.CreateSnapshot(ctx, &models.RankingSnapshot{Kind: "gym_days", Granularity: models.RankingYear, PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Entries: `[]`}))
+
+	got, err := repo.FindSnapshot(ctx, "gym_days", models.RankingMonth, start)
+	require.NoError(t, err)
+	require.Equal(t, `[{"user_id":1}]`, got.Entries)
+
+	err = repo.CreateSnapshot(ctx, &models.RankingSnapshot{Kind: "gym_days", Granularity: models.RankingMonth, PeriodStart: start, Entries: `[]`})
+	require.ErrorIs(t, err, ErrUniqueViolation)
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecapRepository interface {
+	FindByPeriod(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error)
+	FindByUser(userID uint) ([]models.Recap, error)
+	Create(recap *models.Recap) error
+	UpdatePublication(recap *models.Recap) error
+	FindSource(userID uint, from, to time.Time) (*RecapSource, error)
+}
+
+// RecapSource はまとめの集計元。期間は from 以上 to 未満。
+type RecapSource struct {
+	Sessions      int
+	TrainingDates []time.Time
+	Exercises     []RecapExercise
+	PRCount       int
+	LikesReceived int
+}
+
+// RecapExercise は種目ごとのトレーニング日数と、ウォームアップを除いたボリュームの合計。
+type RecapExercise struct {
+	ExerciseID uint
+	Name       string
+	Days       int
+	Tonnage    float64
+}
+
+type recapRepository struct {
+	db *gorm.DB
+}
+
+func NewRecapRepository(db *gorm.DB) RecapRepository {
+	return &recapRepository{db: db}
+}
+
+func preloadRecapExercise(db *gorm.DB) *gorm.DB {
+	return db.Preload("FavoriteExercise", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "name")
+	})
+}
+
+func (r *recapRepository) FindByPeriod(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error) {
+	var recap models.Recap
+	err := preloadRecapExercise(r.db).
+		Where("user_id = ? AND period = ? AND period_start = ?", userID, period, start).
+		First(&recap).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &recap, nil
+}
+
+// FindByUser は保存済みのまとめを新しい期間から順に返す。
+func (r *recapRepository) FindByUser(userID uint) ([]models.Recap, error) {
+	var recaps []models.Recap
+	if err := preloadRecapExercise(r.db).
+		Where("user_id = ?", userID).
+		Order("period_start DESC, period ASC").
+		Find(&recaps).Error; err != nil {
+		return nil, err
+	}
+	return recaps, nil
+}
+
+// Create は同じ期間のまとめが既にある場合 ErrUniqueViolation を返す。
+func (r *recapRepository) Create(recap *models.Recap) error {
+	if err := r.db.Omit(clause.Associations).Create(recap).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrUniqueViolation
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *recapRepository) UpdatePublication(recap *models.Recap) error {
+	res := r.db.
+		Model(&models.Recap{}).
+		Where("id = ? AND user_id = ?", recap.ID, recap.UserID).
+		Updates(map[string]interface{}{
+			"is_public":    recap.IsPublic,
+			"published_at": recap.PublishedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *recapRepository) FindSource(userID uint, from, to time.Time) (*RecapSource, error) {
+	var src RecapSource
+
+	var sessions int64
+	if err := r.db.
+		Model(&models.WorkoutSession{}).
+		Where("user_id = ? AND trained_on >= ? AND trained_on < ?", userID, from, to).
+		Count(&sessions).Error; err != nil {
+		return nil, err
+	}
+	src.Sessions = int(sessions)
+
+	if err := r.db.
+		Model(&models.WorkoutRecord{}).
+		Where("user_id = ? AND trained_on >= ? AND trained_on < ?", userID, from, to).
+		Distinct("trained_on").
+		Order("trained_on ASC").
+		Pluck("trained_on", &src.TrainingDates).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.
+		Table("workout_records AS r").
+		Select(`
+			e.id AS exercise_id,
+			e.name AS name,
+			COUNT(DISTINCT r.trained_on) AS days,
+			COALESCE(SUM(CASE WHEN s.set_type = 'warmup' THEN 0 ELSE `+setVolumeExpr+` END), 0) AS tonnage
+		`).
+		Joins("INNER JOIN exercises e ON e.id = r.exercise_id").
+		Joins("LEFT JOIN workout_sets s ON s.workout_record_id = r.id AND s.deleted_at IS NULL").
+		Where("r.user_id = ? AND r.trained_on >= ? AND r.trained_on < ?", userID, from, to).
+		Where("r.deleted_at IS NULL").
+		Group("e.id, e.name").
+		Order("e.id ASC").
+		Scan(&src.Exercises).Error; err != nil {
+		return nil, err
+	}
+
+	var prs int64
+	if err := r.db.
+		Model(&models.PersonalRecord{}).
+		Where("user_id = ? AND trained_on >= ? AND trained_on < ?", userID, from, to).
+		Count(&prs).Error; err != nil {
+		return nil, err
+	}
+	src.PRCount = int(prs)
+
+	// 自分のいいねは数えない
+	var likes int64
+	if err := r.db.
+		Table("workout_likes AS l").
+		Joins("INNER JOIN workout_sessions ws ON ws.id = l.session_id").
+		Where("ws.user_id = ? AND ws.trained_on >= ? AND ws.trained_on < ?", userID, from, to).
+		Where("l.user_id <> ?", userID).
+		Where("ws.deleted_at IS NULL AND l.deleted_at IS NULL").
+		Count(&likes).Error; err != nil {
+		return nil, err
+	}
+	src.LikesReceived = int(likes)
+
+	return &src, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecapRepository_FindSource(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.WorkoutLike{}, &models.Recap{}))
+
+	user := models.User{Email: "recap@example.com"}
+	friend := models.User{Email: "friend@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	require.NoError(t, db.Create(&friend).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+
+	workouts := NewWorkoutRepository(db)
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	save := func(exerciseID uint, trainedOn time.Time, sets ...models.WorkoutSet) *models.WorkoutRecord {
+		r := &models.WorkoutRecord{UserID: user.ID, ExerciseID: exerciseID, BodyWeight: 70, TrainedOn: trainedOn, Sets: sets}
+		require.NoError(t, workouts.Create(r))
+		return r
+	}
+	save(bench.ID, day(9, 30), models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 100}) // 期間外
+	first := save(bench.ID, day(10, 1),
+		models.WorkoutSet{SetNo: 1, Reps: 10, ExerciseWeight: 40, SetType: models.SetTypeWarmup},
+		models.WorkoutSet{SetNo: 2, Reps: 5, ExerciseWeight: 100},
+	)
+	save(squat.ID, day(10, 1), models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 120})
+	save(bench.ID, day(10, 3), models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 80})
+
+	require.NoError(t, db.Create(&models.WorkoutLike{UserID: friend.ID, SessionID: *first.SessionID}).Error)
+	require.NoError(t, db.Create(&models.WorkoutLike{UserID: user.ID, SessionID: *first.SessionID}).Error) // 自分のいいね
+
+	repo := NewRecapRepository(db)
+	got, err := repo.FindSource(user.ID, day(10, 1), day(11, 1))
+	require.NoError(t, err)
+
+	require.Equal(t, 2, got.Sessions)
+	require.Len(t, got.TrainingDates, 2)
+	require.Len(t, got.Exercises, 2)
+	require.Equal(t, "ベンチプレス", got.Exercises[0].Name)
+	require.Equal(t, 2, got.Exercises[0].Days)
+	require.Equal(t, 900.0, got.Exercises[0].Tonnage) // ウォームアップを除く
+	require.Equal(t, 600.0, got.Exercises[1].Tonnage)
+	require.Equal(t, 1, got.LikesReceived)
+	require.Positive(t, got.PRCount)
+}
+
+func TestRecapRepository_CreateAndPublish(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Recap{}))
+
+	user := models.User{Email: "recap@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	require.NoError(t, db.Create(&bench).Error)
+
+	repo := NewRecapRepository(db)
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	recap := &models.Recap{UserID: user.ID, Period: models.RecapMonth, PeriodStart: start, TrainingDays: 12, FavoriteExerciseID: &bench.ID}
+	require.NoError(t, repo.Create(recap))
+
+	t.Run("【異常系】同じ期間のまとめは ErrUniqueViolation を返すこと", func(t *testing.T) {
+		dup := &models.Recap{UserID: user.ID, Period: models.RecapMonth, PeriodStart: start}
+		require.ErrorIs(t, repo.Create(dup), ErrUniqueViolation)
+	})
+
+	t.Run("【正常系】公開状態を更新して種目名付きで取得できること", func(t *testing.T) {
+		now := time.Now()
+		recap.IsPublic = true
+		recap.PublishedAt = &now
+		require.NoError(t, repo.UpdatePublication(recap))
+
+		got, err := repo.FindByPeriod(user.ID, models.RecapMonth, start)
+		require.NoError(t, err)
+		require.True(t, got.IsPublic)
+		require.NotNil(t, got.PublishedAt)
+		require.Equal(t, "ベンチプレス", got.FavoriteExercise.Name)
+	})
+
+	t.Run("【異常系】他人のまとめは更新できないこと", func(t *testing.T) {
+		other := *recap
+		other.UserID = user.ID + 1
+		require.ErrorIs(t, repo.UpdatePublication(&other), ErrNotFound)
+	})
+
+	t.Run("【異常系】保存されていない期間は ErrNotFound を返すこと", func(t *testing.T) {
+		_, err := repo.FindByPeriod(user.ID, models.RecapYear, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReportRepository interface {
+	FindVolumeSets(userID uint, from, to time.Time) ([]VolumeSet, error)
+}
+
+// VolumeSet はボリューム集計用のセット。Volume は setVolumeExpr で求めた値(kg)。
+type VolumeSet struct {
+	TrainedOn       time.Time
+	ExerciseID      uint
+	ExerciseName    string
+	PrimaryMuscle   models.MuscleGroup
+	MovementPattern models.MovementPattern
+	SetType         models.SetType
+	Reps            int
+	RPE             *float64
+	RIR             *int
+	Volume          float64
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+// FindVolumeSets はトレーニング日が from〜to（両端含む）のセットを記録日の昇順で返す。
+func (r *reportRepository) FindVolumeSets(userID uint, from, to time.Time) ([]VolumeSet, error) {
+	var rows []VolumeSet
+	err := r.db.
+		Table("workout_sets AS s").
+		Select(`
+			r.trained_on AS trained_on,
+			e.id AS exercise_id,
+			e.name AS exercise_name,
+			e.primary_muscle AS primary_muscle,
+			e.movement_pattern AS movement_pattern,
+			s.set_type AS set_type,
+			s.reps AS reps,
+			s.rpe AS rpe,
+			s.rir AS rir,
+			`+setVolumeExpr+` AS volume
+		`).
+		Joins("INNER JOIN workout_records r ON r.id = s.workout_record_id").
+		Joins("INNER JOIN exercises e ON e.id = r.exercise_id").
+		Where("r.user_id = ? AND r.trained_on >= ? AND r.trained_on <= ?", userID, from, to).
+		Where("r.deleted_at IS NULL AND s.deleted_at IS NULL").
+		Order("r.trained_on ASC, r.id ASC, s.set_no ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRepository_FindVolumeSets(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	user := models.User{Email: "report@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	bench := models.Exercise{Name: "ベンチプレス", PrimaryMuscle: models.MuscleChest, MovementPattern: models.MovementHorizontalPush}
+	chin := models.Exercise{Name: "加重懸垂", MeasurementKind: models.MeasurementBodyweightLoad, PrimaryMuscle: models.MuscleBack, MovementPattern: models.MovementVerticalPull}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&chin).Error)
+
+	workouts := NewWorkoutRepository(db)
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	save := func(exerciseID uint, d int, sets ...models.WorkoutSet) *models.WorkoutRecord {
+		r := &models.WorkoutRecord{UserID: user.ID, ExerciseID: exerciseID, BodyWeight: 70, TrainedOn: day(d), Sets: sets}
+		require.NoError(t, workouts.Create(r))
+		return r
+	}
+	save(bench.ID, 1, models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 100}) // 範囲外
+	save(bench.ID, 3,
+		models.WorkoutSet{SetNo: 1, Reps: 10, ExerciseWeight: 40, SetType: models.SetTypeWarmup},
+		models.WorkoutSet{SetNo: 2, Reps: 5, ExerciseWeight: 100, RIR: utils.Ptr(2)},
+	)
+	save(chin.ID, 4, models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 10})
+	deleted := save(bench.ID, 5, models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 100})
+	require.NoError(t, workouts.Delete(deleted.ID, user.ID))
+
+	repo := NewReportRepository(db)
+	got, err := repo.FindVolumeSets(user.ID, day(2), day(5))
+	require.NoError(t, err)
+
+	require.Len(t, got, 3)
+	require.Equal(t, models.SetTypeWarmup, got[0].SetType)
+	require.Equal(t, "ベンチプレス", got[1].ExerciseName)
+	require.Equal(t, models.MuscleChest, got[1].PrimaryMuscle)
+	require.Equal(t, 500.0, got[1].Volume)
+	require.Equal(t, 2, *got[1].RIR)
+	require.Equal(t, models.MovementVerticalPull, got[2].MovementPattern)
+	require.Equal(t, 400.0, got[2].Volume) // (体重70 + 加重10) × 5回
+}
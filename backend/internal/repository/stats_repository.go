@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type StatsRepository interface {
+	FindTrainingDates(userID uint) ([]time.Time, error)
+	GetStreakRule(userID uint) (models.StreakRule, int, error)
+	UpdateStreakRule(userID uint, rule models.StreakRule, weeklyTarget int) error
+}
+
+type statsRepository struct {
+	db *gorm.DB
+}
+
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+func (r *statsRepository) FindTrainingDates(userID uint) ([]time.Time, error) {
+	return trainingDates(r.db, userID)
+}
+
+func (r *statsRepository) GetStreakRule(userID uint) (models.StreakRule, int, error) {
+	return streakRule(r.db, userID)
+}
+
+func (r *statsRepository) UpdateStreakRule(userID uint, rule models.StreakRule, weeklyTarget int) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"streak_rule":          rule,
+			"streak_weekly_target": weeklyTarget,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// trainingDates はトレーニングした日を重複なしで昇順に返す。
+func trainingDates(db *gorm.DB, userID uint) ([]time.Time, error) {
+	var dates []time.Time
+	err := db.
+		Model(&models.WorkoutRecord{}).
+		Where("user_id = ?", userID).
+		Distinct("trained_on").
+		Order("trained_on ASC").
+		Pluck("trained_on", &dates).Error
+	if err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+func streakRule(db *gorm.DB, userID uint) (models.StreakRule, int, error) {
+	var u models.User
+	if err := db.
+		Select("id, streak_rule, streak_weekly_target").
+		First(&u, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, ErrNotFound
+		}
+		return "", 0, err
+	}
+	return u.StreakRule, u.StreakWeeklyTarget, nil
+}
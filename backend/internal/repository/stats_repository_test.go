@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRepository_FindTrainingDates(t *testing.T) {
+	db := newSummaryTestDB(t)
+	user := models.User{Email: "stats@example.com"}
+	other := models.User{Email: "other@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	require.NoError(t, db.Create(&other).Error)
+	exercise := models.Exercise{Name: "ベンチプレス"}
+	require.NoError(t, db.Create(&exercise).Error)
+
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	records := []models.WorkoutRecord{
+		{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day(5)},
+		{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day(2)},
+		{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day(5)},
+		{UserID: other.ID, ExerciseID: exercise.ID, TrainedOn: day(3)},
+	}
+	require.NoError(t, db.Create(&records).Error)
+
+	repo := NewStatsRepository(db)
+
+	t.Run("【正常系】自分のトレーニング日を重複なしで昇順に返すこと", func(t *testing.T) {
+		got, err := repo.FindTrainingDates(user.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.True(t, got[0].Equal(day(2)))
+		require.True(t, got[1].Equal(day(5)))
+	})
+}
+
+func TestStatsRepository_StreakRule(t *testing.T) {
+	db := newSummaryTestDB(t)
+	user := models.User{Email: "streak@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	repo := NewStatsRepository(db)
+
+	t.Run("【正常系】未設定の場合は daily と週3日を返すこと", func(t *testing.T) {
+		rule, target, err := repo.GetStreakRule(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.StreakDaily, rule)
+		require.Equal(t, 3, target)
+	})
+
+	t.Run("【正常系】数え方を更新できること", func(t *testing.T) {
+		require.NoError(t, repo.UpdateStreakRule(user.ID, models.StreakWeekly, 2))
+		rule, target, err := repo.GetStreakRule(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.StreakWeekly, rule)
+		require.Equal(t, 2, target)
+	})
+
+	t.Run("【異常系】存在しないユーザーは ErrNotFound を返すこと", func(t *testing.T) {
+		require.ErrorIs(t, repo.UpdateStreakRule(user.ID+1, models.StreakDaily, 3), ErrNotFound)
+		_, _, err := repo.GetStreakRule(user.ID + 1)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"sort"
 	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
@@ -10,7 +11,18 @@ import (
 type SummaryRepository interface {
 	CountTrainingDays(userID uint) (int64, error)
 	GetLatestWeight(userID uint) (*float64, *time.Time, error)
+	GetLatestBodyFat(userID uint) (*float64, error)
 	GetProfileBasics(userID uint) (*float64, *float64, error)
+	GetGoalDate(userID uint) (*time.Time, error)
+	GetWeightSeries(userID uint, from time.Time) ([]WeightPoint, error)
+	GetTrainingDates(userID uint) ([]time.Time, error)
+	GetStreakRule(userID uint) (models.StreakRule, int, error)
+}
+
+// WeightPoint は1日分の体重。
+type WeightPoint struct {
+	Date   time.Time
+	Weight float64
 }
 
 type summaryRepository struct {
@@ -31,16 +43,45 @@ func (r *summaryRepository) CountTrainingDays(userID uint) (int64, error) {
 	return cnt, err
 }
 
+func (r *summaryRepository) GetTrainingDates(userID uint) ([]time.Time, error) {
+	return trainingDates(r.db, userID)
+}
+
+func (r *summaryRepository) GetStreakRule(userID uint) (models.StreakRule, int, error) {
+	return streakRule(r.db, userID)
+}
+
 func (r *summaryRepository) GetLatestWeight(userID uint) (*float64, *time.Time, error) {
+	return latestWeight(r.db, userID)
+}
+
+func (r *summaryRepository) GetLatestBodyFat(userID uint) (*float64, error) {
+	return latestBodyFat(r.db, userID)
+}
+
+// latestWeight は体組成ログの最新の体重とトレーニング記録の最新の体重のうち、日付の新しい方とその日付を返す。
+// 同じ日なら体組成ログの体重を使う。
+func latestWeight(db *gorm.DB, userID uint) (*float64, *time.Time, error) {
+	var metric models.BodyMetric
+	tx := db.
+		Where("user_id = ? AND weight IS NOT NULL", userID).
+		Order("measured_on DESC").
+		Limit(1).
+		Find(&metric)
+	if tx.Error != nil {
+		return nil, nil, tx.Error
+	}
+	hasMetric := tx.RowsAffected > 0
+
 	type row struct {
 		BodyWeight float64
 		TrainedOn  time.Time
 	}
 
 	var out row
-	tx := r.db.
+	tx = db.
 		Model(&models.WorkoutRecord{}).
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND body_weight > 0", userID).
 		Select("body_weight, trained_on").
 		Order("trained_on DESC, id DESC").
 		Limit(1).
@@ -48,10 +89,29 @@ func (r *summaryRepository) GetLatestWeight(userID uint) (*float64, *time.Time,
 	if tx.Error != nil {
 		return nil, nil, tx.Error
 	}
-	if tx.RowsAffected == 0 {
-		return nil, nil, nil
+	hasRecord := tx.RowsAffected > 0
+
+	switch {
+	case hasRecord && (!hasMetric || out.TrainedOn.After(metric.MeasuredOn)):
+		return &out.BodyWeight, &out.TrainedOn, nil
+	case hasMetric:
+		return metric.Weight, &metric.MeasuredOn, nil
+	}
+	return nil, nil, nil
+}
+
+// latestBodyFat は体組成ログの最新の体脂肪率を返す（記録が無ければ nil）。
+func latestBodyFat(db *gorm.DB, userID uint) (*float64, error) {
+	var metric models.BodyMetric
+	tx := db.
+		Where("user_id = ? AND body_fat_percent IS NOT NULL", userID).
+		Order("measured_on DESC").
+		Limit(1).
+		Find(&metric)
+	if tx.Error != nil {
+		return nil, tx.Error
 	}
-	return &out.BodyWeight, &out.TrainedOn, nil
+	return metric.BodyFatPercent, nil
 }
 
 func (r *summaryRepository) GetProfileBasics(userID uint) (*float64, *float64, error) {
@@ -63,3 +123,52 @@ func (r *summaryRepository) GetProfileBasics(userID uint) (*float64, *float64, e
 	}
 	return u.Height, u.GoalWeight, nil
 }
+
+func (r *summaryRepository) GetGoalDate(userID uint) (*time.Time, error) {
+	var u models.User
+	if err := r.db.
+		Select("id, goal_date").
+		First(&u, userID).Error; err != nil {
+		return nil, err
+	}
+	return u.GoalDate, nil
+}
+
+// GetWeightSeries は from 以降の体重を日付の昇順で1日1件返す。
+// 体組成ログがある日はログを、無い日はその日の最後のトレーニング記録の体重を使う。
+func (r *summaryRepository) GetWeightSeries(userID uint, from time.Time) ([]WeightPoint, error) {
+	byDay := map[time.Time]float64{}
+	dayOf := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	var records []models.WorkoutRecord
+	if err := r.db.
+		Select("body_weight, trained_on").
+		Where("user_id = ? AND body_weight > 0 AND trained_on >= ?", userID, from).
+		Order("trained_on ASC, id ASC").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		byDay[dayOf(rec.TrainedOn)] = rec.BodyWeight
+	}
+
+	var metrics []models.BodyMetric
+	if err := r.db.
+		Select("weight, measured_on").
+		Where("user_id = ? AND weight IS NOT NULL AND measured_on >= ?", userID, from).
+		Find(&metrics).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range metrics {
+		byDay[dayOf(m.MeasuredOn)] = *m.Weight
+	}
+
+	points := make([]WeightPoint, 0, len(byDay))
+	for d, w := range byDay {
+		points = append(points, WeightPoint{Date: d, Weight: w})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Date.Before(points[j].Date) })
+	return points, nil
+}
@@ -17,7 +17,7 @@ func newSummaryTestDB(t *testing.T) *gorm.DB {
 	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
 	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}))
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}, &models.BodyMetric{}))
 	return db
 }
 
@@ -36,10 +36,11 @@ func TestSummaryRepository_CountTrainingDays(t *testing.T) {
 				require.NoError(t, db.Create(&user).Error)
 				exercise := models.Exercise{Name: "ベンチプレス"}
 				require.NoError(t, db.Create(&exercise).Error)
+				day := time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)
 				records := []models.WorkoutRecord{
-					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: time.Now()},
-					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: time.Now()},
-					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: time.Now().AddDate(0, 0, -1)},
+					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day},
+					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day},
+					{UserID: user.ID, ExerciseID: exercise.ID, TrainedOn: day.AddDate(0, 0, -1)},
 				}
 				require.NoError(t, db.Create(&records).Error)
 			},
@@ -90,6 +91,7 @@ func TestSummaryRepository_GetLatestWeight(t *testing.T) {
 		prepare     func(db *gorm.DB)
 		userID      uint
 		wantWeight  *float64
+		wantDate    *time.Time
 		expectNil   bool
 		expectError bool
 	}{
@@ -112,6 +114,68 @@ func TestSummaryRepository_GetLatestWeight(t *testing.T) {
 			expectNil:   false,
 			expectError: false,
 		},
+		{
+			name: "【正常系】体組成ログの体重の方が新しい場合はそちらを返すこと",
+			prepare: func(db *gorm.DB) {
+				user := models.User{Email: "test@example.com"}
+				require.NoError(t, db.Create(&user).Error)
+				exercise := models.Exercise{Name: "ベンチプレス"}
+				require.NoError(t, db.Create(&exercise).Error)
+				require.NoError(t, db.Create(&models.WorkoutRecord{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 62.0, TrainedOn: now.AddDate(0, 0, -3)}).Error)
+				metrics := []models.BodyMetric{
+					{UserID: user.ID, MeasuredOn: now.AddDate(0, 0, -2), Weight: utils.Ptr(59.0)},
+					{UserID: user.ID, MeasuredOn: now.AddDate(0, 0, -1), Weight: utils.Ptr(60.0)},
+					// 体重を測っていない日は対象外
+					{UserID: user.ID, MeasuredOn: now, BodyFatPercent: utils.Ptr(18.0)},
+				}
+				require.NoError(t, db.Create(&metrics).Error)
+			},
+			userID:     1,
+			wantWeight: utils.Ptr(60.0),
+			wantDate:   utils.Ptr(now.AddDate(0, 0, -1)),
+		},
+		{
+			name: "【正常系】トレーニング記録の体重の方が新しい場合はそちらを返すこと",
+			prepare: func(db *gorm.DB) {
+				user := models.User{Email: "test@example.com"}
+				require.NoError(t, db.Create(&user).Error)
+				exercise := models.Exercise{Name: "ベンチプレス"}
+				require.NoError(t, db.Create(&exercise).Error)
+				require.NoError(t, db.Create(&models.WorkoutRecord{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 62.0, TrainedOn: now}).Error)
+				require.NoError(t, db.Create(&models.BodyMetric{UserID: user.ID, MeasuredOn: now.AddDate(0, 0, -1), Weight: utils.Ptr(60.0)}).Error)
+			},
+			userID:     1,
+			wantWeight: utils.Ptr(62.0),
+		},
+		{
+			name: "【正常系】同じ日なら体組成ログの体重を返すこと",
+			prepare: func(db *gorm.DB) {
+				user := models.User{Email: "test@example.com"}
+				require.NoError(t, db.Create(&user).Error)
+				exercise := models.Exercise{Name: "ベンチプレス"}
+				require.NoError(t, db.Create(&exercise).Error)
+				require.NoError(t, db.Create(&models.WorkoutRecord{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 62.0, TrainedOn: now}).Error)
+				require.NoError(t, db.Create(&models.BodyMetric{UserID: user.ID, MeasuredOn: now, Weight: utils.Ptr(61.5)}).Error)
+			},
+			userID:     1,
+			wantWeight: utils.Ptr(61.5),
+		},
+		{
+			name: "【正常系】体重が未入力(0)の記録は対象外とすること",
+			prepare: func(db *gorm.DB) {
+				user := models.User{Email: "test@example.com"}
+				require.NoError(t, db.Create(&user).Error)
+				exercise := models.Exercise{Name: "ベンチプレス"}
+				require.NoError(t, db.Create(&exercise).Error)
+				records := []models.WorkoutRecord{
+					{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 61.2, TrainedOn: now},
+					{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 0, TrainedOn: now.AddDate(0, 0, 1)},
+				}
+				require.NoError(t, db.Create(&records).Error)
+			},
+			userID:     1,
+			wantWeight: utils.Ptr(61.2),
+		},
 		{
 			name:        "【正常系】該当ユーザーのレコードが存在しない場合はnilを返すこと",
 			prepare:     func(db *gorm.DB) {},
@@ -154,7 +218,11 @@ func TestSummaryRepository_GetLatestWeight(t *testing.T) {
 
 			require.NotNil(t, weight)
 			require.InDelta(t, *tt.wantWeight, *weight, 0.001)
-			require.WithinDuration(t, now, *date, time.Second)
+			wantDate := now
+			if tt.wantDate != nil {
+				wantDate = *tt.wantDate
+			}
+			require.WithinDuration(t, wantDate, *date, time.Second)
 		})
 	}
 }
@@ -222,3 +290,37 @@ func TestSummaryRepository_GetProfileBasics(t *testing.T) {
 		})
 	}
 }
+
+func TestSummaryRepository_GetWeightSeries(t *testing.T) {
+	db := newSummaryTestDB(t)
+	repo := NewSummaryRepository(db)
+
+	user := models.User{Email: "series@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	exercise := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&exercise).Error)
+
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	records := []models.WorkoutRecord{
+		{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 72.0, TrainedOn: day(1)}, // 範囲外
+		{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 71.0, TrainedOn: day(3)}, // ログが優先される
+		{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 70.6, TrainedOn: day(5)}, // 同日の前の記録
+		{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 70.4, TrainedOn: day(5)}, // 同日の最後の記録
+		{UserID: user.ID, ExerciseID: exercise.ID, BodyWeight: 0, TrainedOn: day(6)},    // 体重未入力
+	}
+	require.NoError(t, db.Create(&records).Error)
+	metrics := []models.BodyMetric{
+		{UserID: user.ID, MeasuredOn: day(3), Weight: utils.Ptr(70.8)},
+		{UserID: user.ID, MeasuredOn: day(4), BodyFatPercent: utils.Ptr(18.0)}, // 体重なし
+		{UserID: user.ID, MeasuredOn: day(7), Weight: utils.Ptr(70.1)},
+	}
+	require.NoError(t, db.Create(&metrics).Error)
+
+	got, err := repo.GetWeightSeries(user.ID, day(2))
+	require.NoError(t, err)
+	require.Equal(t, []WeightPoint{
+		{Date: day(3), Weight: 70.8},
+		{Date: day(5), Weight: 70.4},
+		{Date: day(7), Weight: 70.1},
+	}, got)
+}
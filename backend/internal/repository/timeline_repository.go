@@ -3,22 +3,72 @@ package repository
 import (
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"gorm.io/gorm"
 )
 
 type TimelineItem struct {
+	SessionID     uint
+	UserID        uint
+	UserEmail     string
+	ExerciseNames []string           `gorm:"-"`
+	Exercises     []TimelineExercise `gorm:"-"`
+	BodyWeight    float64
+	TrainedOn     time.Time
+	Comment       string
+	LikedByMe     bool
+	LikeCount     int
+	CommentCount  int
+}
+
+// TimelineExercise はセッション内の1種目の記録とセット。
+type TimelineExercise struct {
 	RecordID     uint
-	UserID       uint
-	UserEmail    string
+	ExerciseID   uint
 	ExerciseName string
-	BodyWeight   float64
-	TrainedOn    time.Time
-	Comment      string
-	LikedByMe    bool
+	Sets         []models.WorkoutSet
+}
+
+// TimelineRecap は公開されたまとめ。
+type TimelineRecap struct {
+	RecapID              uint
+	UserID               uint
+	UserEmail            string
+	Period               models.RecapPeriod
+	PeriodStart          time.Time
+	TotalSessions        int
+	TrainingDays         int
+	TotalTonnage         float64
+	FavoriteExerciseName *string
+	PRCount              int
+	LongestStreak        int
+	PublishedAt          time.Time
+}
+
+// TimelineQuery はタイムラインの絞り込みと取得位置。From・To は日付（To を含む）、Limit が0なら件数を制限しない。
+type TimelineQuery struct {
+	Scope      models.TimelineScope
+	ExerciseID *uint
+	AuthorID   *uint
+	From       *time.Time
+	To         *time.Time
+	Cursor     *TimelineCursor
+	Limit      int
+}
+
+// TimelineCursor は前のページの最後の投稿の位置。
+// タイムラインは日付の新しい順で、同じ日はまとめ（公開日時・ID の新しい順）→セッション（ID の大きい順）と並ぶ。
+// Day はセッションなら実施日、まとめなら公開日（日本時間）。
+type TimelineCursor struct {
+	Day         time.Time
+	IsRecap     bool
+	PublishedAt time.Time
+	ID          uint
 }
 
 type TimelineRepository interface {
-	FindPublicRecords(userID uint) ([]TimelineItem, error)
+	FindPublicSessions(userID uint, q TimelineQuery) ([]TimelineItem, error)
+	FindPublicRecaps(userID uint, q TimelineQuery) ([]TimelineRecap, error)
 }
 
 type timelineRepository struct {
@@ -29,35 +79,194 @@ func NewTimelineRepository(db *gorm.DB) TimelineRepository {
 	return &timelineRepository{db: db}
 }
 
-func (r *timelineRepository) FindPublicRecords(userID uint) ([]TimelineItem, error) {
+// FindPublicSessions は userID から見た q の公開セッションを、カーソルより後ろから新しい順に返す。
+func (r *timelineRepository) FindPublicSessions(userID uint, q TimelineQuery) ([]TimelineItem, error) {
 	var rows []TimelineItem
 
-	err := r.db.
-		Table("workout_records").
+	query := r.db.
+		Table("workout_sessions").
 		Select(`
-			workout_records.id          AS record_id,
-			workout_records.user_id     AS user_id,
-			users.email                 AS user_email,
-			exercises.name              AS exercise_name,
-			workout_records.body_weight AS body_weight,
-			workout_records.trained_on  AS trained_on,
-			workout_records.comment     AS comment,
+			workout_sessions.id            AS session_id,
+			workout_sessions.user_id       AS user_id,
+			users.email                    AS user_email,
+			workout_sessions.body_weight   AS body_weight,
+			workout_sessions.trained_on    AS trained_on,
+			workout_sessions.comment       AS comment,
+			workout_sessions.like_count    AS like_count,
+			workout_sessions.comment_count AS comment_count,
 			EXISTS (
 					SELECT 1
 					FROM workout_likes wl
-					WHERE wl.record_id = workout_records.id
+					WHERE wl.session_id = workout_sessions.id
 						AND wl.user_id = ?
 				) AS liked_by_me
 			`, userID).
-		Joins("JOIN users ON users.id = workout_records.user_id").
-		Joins("JOIN exercises ON exercises.id = workout_records.exercise_id").
-		Where("workout_records.is_public = ?", true).
-		Order("workout_records.trained_on DESC, workout_records.id DESC").
+		Joins("JOIN users ON users.id = workout_sessions.user_id").
+		Where("workout_sessions.is_public = ?", true).
+		Where("workout_sessions.deleted_at IS NULL")
+	query = r.scopeAuthors(query, "workout_sessions.user_id", userID, q.Scope)
+
+	if q.AuthorID != nil {
+		query = query.Where("workout_sessions.user_id = ?", *q.AuthorID)
+	}
+	if q.ExerciseID != nil {
+		query = query.Where(`EXISTS (
+			SELECT 1
+			FROM workout_records wr
+			WHERE wr.session_id = workout_sessions.id
+				AND wr.exercise_id = ?
+				AND wr.deleted_at IS NULL
+		)`, *q.ExerciseID)
+	}
+	if q.From != nil {
+		query = query.Where("workout_sessions.trained_on >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("workout_sessions.trained_on <= ?", *q.To)
+	}
+	if c := q.Cursor; c != nil {
+		if c.IsRecap {
+			// 同じ日のセッションはまとめより後ろに並ぶ
+			query = query.Where("workout_sessions.trained_on <= ?", c.Day)
+		} else {
+			query = query.Where("(workout_sessions.trained_on < ? OR (workout_sessions.trained_on = ? AND workout_sessions.id < ?))", c.Day, c.Day, c.ID)
+		}
+	}
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	err := query.
+		Order("workout_sessions.trained_on DESC, workout_sessions.id DESC").
 		Scan(&rows).Error
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.fillExercises(rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// FindPublicRecaps は userID から見た q の公開されたまとめを、カーソルより後ろから公開日時の新しい順に返す。
+// 日付の絞り込みは公開日（日本時間）で行う。まとめは種目を持たないため、種目で絞り込む場合は返さない。
+func (r *timelineRepository) FindPublicRecaps(userID uint, q TimelineQuery) ([]TimelineRecap, error) {
+	var rows []TimelineRecap
+	if q.ExerciseID != nil {
+		return rows, nil
+	}
+
+	query := r.db.
+		Table("recaps").
+		Select(`
+			recaps.id              AS recap_id,
+			recaps.user_id         AS user_id,
+			users.email            AS user_email,
+			recaps.period          AS period,
+			recaps.period_start    AS period_start,
+			recaps.total_sessions  AS total_sessions,
+			recaps.training_days   AS training_days,
+			recaps.total_tonnage   AS total_tonnage,
+			exercises.name         AS favorite_exercise_name,
+			recaps.pr_count        AS pr_count,
+			recaps.longest_streak  AS longest_streak,
+			recaps.published_at    AS published_at
+		`).
+		Joins("JOIN users ON users.id = recaps.user_id").
+		Joins("LEFT JOIN exercises ON exercises.id = recaps.favorite_exercise_id").
+		Where("recaps.is_public = ?", true)
+	query = r.scopeAuthors(query, "recaps.user_id", userID, q.Scope)
+
+	if q.AuthorID != nil {
+		query = query.Where("recaps.user_id = ?", *q.AuthorID)
+	}
+	if q.From != nil {
+		query = query.Where("recaps.published_at >= ?", jstDayStart(*q.From))
+	}
+	if q.To != nil {
+		query = query.Where("recaps.published_at < ?", jstDayStart(q.To.AddDate(0, 0, 1)))
+	}
+	if c := q.Cursor; c != nil {
+		if c.IsRecap {
+			query = query.Where("(recaps.published_at < ? OR (recaps.published_at = ? AND recaps.id < ?))", c.PublishedAt, c.PublishedAt, c.ID)
+		} else {
+			// 同じ日のまとめはセッションより前に並ぶため、前の日以前だけが残る
+			query = query.Where("recaps.published_at < ?", jstDayStart(c.Day))
+		}
+	}
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	err := query.
+		Order("recaps.published_at DESC, recaps.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
 	return rows, nil
 }
+
+// jstDayStart は日付 day（UTC の0時で表した日本時間の日付）が始まる時刻を返す。
+func jstDayStart(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Add(-9 * time.Hour)
+}
+
+// scopeAuthors は投稿者（column）を viewerID から見た scope の範囲に絞り込む。
+// 非公開アカウントの投稿は本人と承認済みのフォロワーにだけ見せる。q は users を JOIN している前提。
+func (r *timelineRepository) scopeAuthors(q *gorm.DB, column string, viewerID uint, scope models.TimelineScope) *gorm.DB {
+	switch scope {
+	case models.TimelineMe:
+		return q.Where(column+" = ?", viewerID)
+	case models.TimelineFollowing:
+		return q.Where("("+column+" = ? OR "+column+" IN (?))", viewerID, followeeIDs(r.db, viewerID))
+	default:
+		return q.Where("(users.is_private = ? OR "+column+" = ? OR "+column+" IN (?))", false, viewerID, followeeIDs(r.db, viewerID))
+	}
+}
+
+// fillExercises は各セッションの種目の記録とセットを並び順どおりに詰める。
+func (r *timelineRepository) fillExercises(items []TimelineItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.SessionID)
+	}
+
+	var records []models.WorkoutRecord
+	err := r.db.
+		Preload("Exercise").
+		Preload("Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("workout_sets.set_no ASC, workout_sets.id ASC")
+		}).
+		Where("session_id IN ?", ids).
+		Order("session_id ASC, position ASC, id ASC").
+		Find(&records).Error
+	if err != nil {
+		return err
+	}
+
+	bySession := make(map[uint][]TimelineExercise, len(items))
+	for _, rec := range records {
+		sid := *rec.SessionID
+		bySession[sid] = append(bySession[sid], TimelineExercise{
+			RecordID:     rec.ID,
+			ExerciseID:   rec.ExerciseID,
+			ExerciseName: rec.Exercise.Name,
+			Sets:         rec.Sets,
+		})
+	}
+	for i := range items {
+		items[i].Exercises = bySession[items[i].SessionID]
+		for _, ex := range items[i].Exercises {
+			items[i].ExerciseNames = append(items[i].ExerciseNames, ex.ExerciseName)
+		}
+	}
+	return nil
+}
@@ -18,12 +18,12 @@ func newTimelineTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	require.NoError(t, err)
 
-	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}, &models.WorkoutLike{}))
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutSession{}, &models.WorkoutRecord{}, &models.WorkoutSet{}, &models.WorkoutLike{}, &models.WorkoutComment{}, &models.Follow{}, &models.Notification{}))
 
 	return db
 }
 
-func TestTimelineRepository_FindPublicRecords(t *testing.T) {
+func TestTimelineRepository_FindPublicSessions(t *testing.T) {
 	now := time.Now()
 
 	tests := []struct {
@@ -33,7 +33,7 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "【正常系】公開フラグが true の記録のみ取得できること",
+			name: "【正常系】公開フラグが true のセッションのみ取得できること",
 			prepare: func(db *gorm.DB) {
 				// ユーザー
 				user := models.User{Email: "user@example.com"}
@@ -43,40 +43,50 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 				ex := models.Exercise{Name: "ベンチプレス"}
 				require.NoError(t, db.Create(&ex).Error)
 
-				// レコード（公開と非公開混在）
-				records := []models.WorkoutRecord{
+				ex2 := models.Exercise{Name: "スクワット"}
+				require.NoError(t, db.Create(&ex2).Error)
+
+				// セッション（公開と非公開混在）
+				sessions := []models.WorkoutSession{
 					{
 						UserID:     user.ID,
-						ExerciseID: ex.ID,
 						BodyWeight: 70.5,
 						TrainedOn:  now.Add(-2 * time.Hour),
 						IsPublic:   true,
 						Comment:    "公開1件目",
+						Records: []models.WorkoutRecord{
+							{UserID: user.ID, ExerciseID: ex.ID, TrainedOn: now, Position: 0},
+							{UserID: user.ID, ExerciseID: ex2.ID, TrainedOn: now, Position: 1},
+						},
 					},
 					{
 						UserID:     user.ID,
-						ExerciseID: ex.ID,
 						BodyWeight: 71.0,
 						TrainedOn:  now.Add(-1 * time.Hour),
 						IsPublic:   false,
 						Comment:    "非公開",
+						Records: []models.WorkoutRecord{
+							{UserID: user.ID, ExerciseID: ex.ID, TrainedOn: now},
+						},
 					},
 					{
 						UserID:     user.ID,
-						ExerciseID: ex.ID,
 						BodyWeight: 69.8,
 						TrainedOn:  now,
 						IsPublic:   true,
 						Comment:    "公開2件目",
+						Records: []models.WorkoutRecord{
+							{UserID: user.ID, ExerciseID: ex.ID, TrainedOn: now},
+						},
 					},
 				}
-				require.NoError(t, db.Create(&records).Error)
+				require.NoError(t, db.Create(&sessions).Error)
 			},
 			wantLen:     2,
 			expectError: false,
 		},
 		{
-			name: "【正常系】公開レコードが存在しない場合は空スライスを返すこと",
+			name: "【正常系】公開セッションが存在しない場合は空スライスを返すこと",
 			prepare: func(db *gorm.DB) {
 				user := models.User{Email: "user2@example.com"}
 				require.NoError(t, db.Create(&user).Error)
@@ -84,17 +94,19 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 				ex := models.Exercise{Name: "スクワット"}
 				require.NoError(t, db.Create(&ex).Error)
 
-				records := []models.WorkoutRecord{
+				sessions := []models.WorkoutSession{
 					{
 						UserID:     user.ID,
-						ExerciseID: ex.ID,
 						BodyWeight: 60.0,
 						TrainedOn:  now,
 						IsPublic:   false,
 						Comment:    "非公開のみ",
+						Records: []models.WorkoutRecord{
+							{UserID: user.ID, ExerciseID: ex.ID, TrainedOn: now},
+						},
 					},
 				}
-				require.NoError(t, db.Create(&records).Error)
+				require.NoError(t, db.Create(&sessions).Error)
 			},
 			wantLen:     0,
 			expectError: false,
@@ -102,7 +114,7 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 		{
 			name: "【異常系】テーブルが存在しない場合はエラーを返すこと",
 			prepare: func(db *gorm.DB) {
-				require.NoError(t, db.Migrator().DropTable(&models.WorkoutRecord{}))
+				require.NoError(t, db.Migrator().DropTable(&models.WorkoutSession{}))
 			},
 			wantLen:     0,
 			expectError: true,
@@ -116,7 +128,7 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 			tt.prepare(db)
 
 			repo := NewTimelineRepository(db)
-			rows, err := repo.FindPublicRecords(1)
+			rows, err := repo.FindPublicSessions(1, TimelineQuery{Scope: models.TimelineGlobal})
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -131,10 +143,10 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 			}
 
 			for _, r := range rows {
-				require.NotZero(t, r.RecordID)
+				require.NotZero(t, r.SessionID)
 				require.NotZero(t, r.UserID)
 				require.NotEmpty(t, r.UserEmail)
-				require.NotEmpty(t, r.ExerciseName)
+				require.NotEmpty(t, r.ExerciseNames)
 				require.InDelta(t, 0.0, r.BodyWeight, 100.0)
 
 				require.False(t, r.TrainedOn.IsZero())
@@ -149,7 +161,129 @@ func TestTimelineRepository_FindPublicRecords(t *testing.T) {
 						first.TrainedOn.Equal(second.TrainedOn),
 					"rows should be ordered by trained_on DESC",
 				)
+				require.Equal(t, []string{"ベンチプレス", "スクワット"}, second.ExerciseNames)
 			}
 		})
 	}
 }
+
+func TestTimelineRepository_FindPublicSessions_Scope(t *testing.T) {
+	db := newTimelineTestDB(t)
+
+	ex := models.Exercise{Name: "ベンチプレス"}
+	require.NoError(t, db.Create(&ex).Error)
+
+	newUser := func(email string, private bool) models.User {
+		u := models.User{Email: email}
+		require.NoError(t, db.Create(&u).Error)
+		require.NoError(t, db.Model(&u).Update("is_private", private).Error)
+		session := models.WorkoutSession{
+			UserID:    u.ID,
+			TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			IsPublic:  true,
+			Records:   []models.WorkoutRecord{{UserID: u.ID, ExerciseID: ex.ID, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)}},
+		}
+		require.NoError(t, db.Create(&session).Error)
+		return u
+	}
+	me := newUser("me@example.com", false)
+	followed := newUser("followed@example.com", false)
+	stranger := newUser("stranger@example.com", false)
+	privateFollowed := newUser("private-followed@example.com", true)
+	privatePending := newUser("private-pending@example.com", true)
+
+	follows := []models.Follow{
+		{FollowerID: me.ID, FolloweeID: followed.ID, Status: models.FollowAccepted},
+		{FollowerID: me.ID, FolloweeID: privateFollowed.ID, Status: models.FollowAccepted},
+		{FollowerID: me.ID, FolloweeID: privatePending.ID, Status: models.FollowPending},
+		{FollowerID: stranger.ID, FolloweeID: me.ID, Status: models.FollowAccepted},
+	}
+	require.NoError(t, db.Create(&follows).Error)
+
+	tests := []struct {
+		name  string
+		scope models.TimelineScope
+		want  []uint
+	}{
+		{
+			name:  "【正常系】global は公開アカウントと承認済みでフォロー中の非公開アカウントを返すこと",
+			scope: models.TimelineGlobal,
+			want:  []uint{me.ID, followed.ID, stranger.ID, privateFollowed.ID},
+		},
+		{
+			name:  "【正常系】following は自分と承認済みでフォロー中のユーザーを返すこと",
+			scope: models.TimelineFollowing,
+			want:  []uint{me.ID, followed.ID, privateFollowed.ID},
+		},
+		{
+			name:  "【正常系】me は自分の投稿のみ返すこと",
+			scope: models.TimelineMe,
+			want:  []uint{me.ID},
+		},
+	}
+
+	repo := NewTimelineRepository(db)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := repo.FindPublicSessions(me.ID, TimelineQuery{Scope: tt.scope})
+			require.NoError(t, err)
+
+			got := make([]uint, 0, len(rows))
+			for _, r := range rows {
+				got = append(got, r.UserID)
+			}
+			require.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestTimelineRepository_FindPublicSessions_ExercisesAndReactions(t *testing.T) {
+	db := newTimelineTestDB(t)
+
+	user := models.User{Email: "lifter@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	fan := models.User{Email: "fan@example.com"}
+	require.NoError(t, db.Create(&fan).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	session := models.WorkoutSession{
+		UserID:    user.ID,
+		TrainedOn: day,
+		IsPublic:  true,
+		Records: []models.WorkoutRecord{
+			{UserID: user.ID, ExerciseID: squat.ID, TrainedOn: day, Position: 1, Sets: []models.WorkoutSet{
+				{SetNo: 1, Reps: 5, ExerciseWeight: 140},
+			}},
+			{UserID: user.ID, ExerciseID: bench.ID, TrainedOn: day, Position: 0, Sets: []models.WorkoutSet{
+				{SetNo: 2, Reps: 8, ExerciseWeight: 90},
+				{SetNo: 1, Reps: 5, ExerciseWeight: 100},
+			}},
+		},
+	}
+	require.NoError(t, db.Create(&session).Error)
+
+	likes := NewWorkoutLikeRepository(db)
+	require.NoError(t, likes.CreateLike(user.ID, session.ID))
+	require.NoError(t, likes.CreateLike(fan.ID, session.ID))
+	require.NoError(t, NewWorkoutCommentRepository(db).Create(&models.WorkoutComment{SessionID: session.ID, UserID: fan.ID, Body: "ナイス"}))
+
+	rows, err := NewTimelineRepository(db).FindPublicSessions(fan.ID, TimelineQuery{Scope: models.TimelineGlobal})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	got := rows[0]
+	require.Equal(t, 2, got.LikeCount)
+	require.Equal(t, 1, got.CommentCount)
+	require.True(t, got.LikedByMe)
+	require.Equal(t, []string{"ベンチプレス", "スクワット"}, got.ExerciseNames)
+	require.Len(t, got.Exercises, 2)
+	require.Equal(t, bench.ID, got.Exercises[0].ExerciseID)
+	require.Len(t, got.Exercises[0].Sets, 2)
+	require.Equal(t, 1, got.Exercises[0].Sets[0].SetNo)
+	require.Equal(t, 100.0, got.Exercises[0].Sets[0].ExerciseWeight)
+	require.Equal(t, 140.0, got.Exercises[1].Sets[0].ExerciseWeight)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentRow はコメント一覧の1件。
+type CommentRow struct {
+	ID        uint
+	SessionID uint
+	UserID    uint
+	UserEmail string
+	ParentID  *uint
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WorkoutCommentRepository interface {
+	FindSessionAccess(viewerID uint, sessionID uint) (*SessionAccess, error)
+	FindByID(id uint, sessionID uint) (*CommentRow, error)
+	Create(comment *models.WorkoutComment) error
+	UpdateBody(id uint, body string) error
+	Delete(id uint, sessionID uint) error
+	FindTopLevel(sessionID uint, afterID uint, limit int) ([]CommentRow, error)
+	FindReplies(parentIDs []uint) ([]CommentRow, error)
+}
+
+type workoutCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutCommentRepository(db *gorm.DB) WorkoutCommentRepository {
+	return &workoutCommentRepository{db: db}
+}
+
+// FindSessionAccess はコメントの可否の判定に使う、viewerID から見たセッションの投稿者と公開状態を返す。
+func (r *workoutCommentRepository) FindSessionAccess(viewerID uint, sessionID uint) (*SessionAccess, error) {
+	return findSessionAccess(r.db, viewerID, sessionID)
+}
+
+func (r *workoutCommentRepository) FindByID(id uint, sessionID uint) (*CommentRow, error) {
+	var rows []CommentRow
+	err := r.selectRows().
+		Where("workout_comments.id = ? AND workout_comments.session_id = ?", id, sessionID).
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrNotFound
+	}
+	return &rows[0], nil
+}
+
+// Create はコメントを作成し、workout_sessions.comment_count を1増やして投稿者（返信なら返信先）に通知する。
+func (r *workoutCommentRepository) Create(comment *models.WorkoutComment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WorkoutSession{}).
+			Where("id = ?", comment.SessionID).
+			UpdateColumn("comment_count", gorm.Expr("comment_count + 1")).Error; err != nil {
+			return err
+		}
+		return notifyCommentPosted(tx, comment)
+	})
+}
+
+func (r *workoutCommentRepository) UpdateBody(id uint, body string) error {
+	res := r.db.
+		Model(&models.WorkoutComment{}).
+		Where("id = ?", id).
+		Update("body", body)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete はコメントとその返信を削除し、workout_sessions.comment_count を削除した件数だけ減らす。
+// 削除したコメントの通知も取り消す。
+func (r *workoutCommentRepository) Delete(id uint, sessionID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		target := func() *gorm.DB {
+			return tx.Model(&models.WorkoutComment{}).
+				Where("session_id = ? AND (id = ? OR parent_id = ?)", sessionID, id, id)
+		}
+		if err := retractComments(tx, target().Select("id")); err != nil {
+			return err
+		}
+
+		res := target().Delete(&models.WorkoutComment{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return tx.Model(&models.WorkoutSession{}).
+			Where("id = ?", sessionID).
+			UpdateColumn("comment_count", gorm.Expr("CASE WHEN comment_count > ? THEN comment_count - ? ELSE 0 END", res.RowsAffected, res.RowsAffected)).Error
+	})
+}
+
+func (r *workoutCommentRepository) selectRows() *gorm.DB {
+	return r.db.
+		Table("workout_comments").
+		Select(`
+			workout_comments.id         AS id,
+			workout_comments.session_id AS session_id,
+			workout_comments.user_id    AS user_id,
+			users.email                 AS user_email,
+			workout_comments.parent_id  AS parent_id,
+			workout_comments.body       AS body,
+			workout_comments.created_at AS created_at,
+			workout_comments.updated_at AS updated_at
+		`).
+		Joins("JOIN users ON users.id = workout_comments.user_id").
+		Where("workout_comments.deleted_at IS NULL")
+}
+
+// FindTopLevel は返信ではないコメントを afterID より後ろから古い順に最大 limit 件返す。
+func (r *workoutCommentRepository) FindTopLevel(sessionID uint, afterID uint, limit int) ([]CommentRow, error) {
+	var rows []CommentRow
+	err := r.selectRows().
+		Where("workout_comments.session_id = ?", sessionID).
+		Where("workout_comments.parent_id IS NULL").
+		Where("workout_comments.id > ?", afterID).
+		Order("workout_comments.id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindReplies は parentIDs への返信を古い順に返す。
+func (r *workoutCommentRepository) FindReplies(parentIDs []uint) ([]CommentRow, error) {
+	var rows []CommentRow
+	if len(parentIDs) == 0 {
+		return rows, nil
+	}
+	err := r.selectRows().
+		Where("workout_comments.parent_id IN ?", parentIDs).
+		Order("workout_comments.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newWorkoutCommentTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutSession{},
+		&models.WorkoutRecord{},
+		&models.WorkoutComment{},
+		&models.Notification{},
+	))
+
+	return db
+}
+
+func commentCount(t *testing.T, db *gorm.DB, sessionID uint) int {
+	t.Helper()
+	var session models.WorkoutSession
+	require.NoError(t, db.Select("comment_count").First(&session, sessionID).Error)
+	return session.CommentCount
+}
+
+func TestWorkoutCommentRepository(t *testing.T) {
+	db := newWorkoutCommentTestDB(t)
+	owner := models.User{Email: "owner@example.com"}
+	fan := models.User{Email: "fan@example.com"}
+	require.NoError(t, db.Create(&owner).Error)
+	require.NoError(t, db.Create(&fan).Error)
+	session := models.WorkoutSession{UserID: owner.ID, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), IsPublic: true}
+	require.NoError(t, db.Create(&session).Error)
+
+	repo := NewWorkoutCommentRepository(db)
+
+	first := &models.WorkoutComment{SessionID: session.ID, UserID: fan.ID, Body: "ナイスです"}
+	second := &models.WorkoutComment{SessionID: session.ID, UserID: fan.ID, Body: "重量すごい"}
+	require.NoError(t, repo.Create(first))
+	require.NoError(t, repo.Create(second))
+	reply := &models.WorkoutComment{SessionID: session.ID, UserID: owner.ID, ParentID: utils.Ptr(first.ID), Body: "ありがとう"}
+	require.NoError(t, repo.Create(reply))
+
+	t.Run("【正常系】作成した件数だけ comment_count が増えること", func(t *testing.T) {
+		require.Equal(t, 3, commentCount(t, db, session.ID))
+	})
+
+	t.Run("【正常系】返信ではないコメントを古い順にカーソルの後ろから返すこと", func(t *testing.T) {
+		rows, err := repo.FindTopLevel(session.ID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, first.ID, rows[0].ID)
+		require.Equal(t, "fan@example.com", rows[0].UserEmail)
+
+		rows, err = repo.FindTopLevel(session.ID, first.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Equal(t, second.ID, rows[0].ID)
+	})
+
+	t.Run("【正常系】返信を親コメントごとに返すこと", func(t *testing.T) {
+		rows, err := repo.FindReplies([]uint{first.ID, second.ID})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		require.Equal(t, reply.ID, rows[0].ID)
+		require.Equal(t, first.ID, *rows[0].ParentID)
+	})
+
+	t.Run("【正常系】本文を変更できること", func(t *testing.T) {
+		require.NoError(t, repo.UpdateBody(second.ID, "重量すごい！"))
+		got, err := repo.FindByID(second.ID, session.ID)
+		require.NoError(t, err)
+		require.Equal(t, "重量すごい！", got.Body)
+	})
+
+	t.Run("【異常系】別のセッションのコメントは ErrNotFound を返すこと", func(t *testing.T) {
+		_, err := repo.FindByID(first.ID, session.ID+1)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, repo.Delete(first.ID, session.ID+1), ErrNotFound)
+	})
+
+	t.Run("【正常系】コメントを削除すると返信も削除され、comment_count が減ること", func(t *testing.T) {
+		require.NoError(t, repo.Delete(first.ID, session.ID))
+		require.Equal(t, 1, commentCount(t, db, session.ID))
+
+		_, err := repo.FindByID(reply.ID, session.ID)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorIs(t, repo.Delete(first.ID, session.ID), ErrNotFound)
+	})
+}
@@ -2,16 +2,27 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// LikeUser はいいねしたユーザーの1件。LikedAt はいいねした日時。
+type LikeUser struct {
+	UserID  uint
+	Email   string
+	LikedAt time.Time
+}
+
 type WorkoutLikeRepository interface {
-	CreateLike(userID uint, recordID uint) error
-	DeleteLike(userID uint, recordID uint) error
-	IsRecordPublic(recordID uint) (bool, error)
-	IsLikedByMe(userID uint, recordID uint) (bool, error)
+	CreateLike(userID uint, sessionID uint) error
+	DeleteLike(userID uint, sessionID uint) error
+	FindSessionAccess(viewerID uint, sessionID uint) (*SessionAccess, error)
+	IsLikedByMe(userID uint, sessionID uint) (bool, error)
+	CountLikes(sessionID uint) (int, error)
+	FindLikers(sessionID uint) ([]LikeUser, error)
 }
 
 type workoutLikeRepository struct {
@@ -22,52 +33,68 @@ func NewWorkoutLikeRepository(db *gorm.DB) WorkoutLikeRepository {
 	return &workoutLikeRepository{db: db}
 }
 
-func (r *workoutLikeRepository) CreateLike(userID uint, recordID uint) error {
-	like := models.WorkoutLike{
-		UserID:   userID,
-		RecordID: recordID,
-	}
+// CreateLike はいいねを作成し、workout_sessions.like_count を1増やしてセッションの投稿者に通知する。
+// 既にいいね済みなら何もしない。
+func (r *workoutLikeRepository) CreateLike(userID uint, sessionID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		like := models.WorkoutLike{
+			UserID:    userID,
+			SessionID: sessionID,
+		}
 
-	if err := r.db.Create(&like).Error; err != nil {
-		liked, e := r.IsLikedByMe(userID, recordID)
-		if e == nil && liked {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&like)
+		if res.Error != nil {
+			return res.Error
+		}
+		// 既にいいね済み（rows=0）の場合は件数を変えない
+		if res.RowsAffected == 0 {
 			return nil
 		}
-		return err
-	}
 
-	return nil
+		if err := tx.Model(&models.WorkoutSession{}).
+			Where("id = ?", sessionID).
+			UpdateColumn("like_count", gorm.Expr("like_count + 1")).Error; err != nil {
+			return err
+		}
+		return notifySessionLiked(tx, userID, sessionID)
+	})
 }
 
-func (r *workoutLikeRepository) DeleteLike(userID uint, recordID uint) error {
-	res := r.db.
-		Unscoped().
-		Where("user_id = ? AND record_id = ?", userID, recordID).
-		Delete(&models.WorkoutLike{})
+// DeleteLike はいいねを削除し、workout_sessions.like_count を1減らしていいねの通知を取り消す。
+func (r *workoutLikeRepository) DeleteLike(userID uint, sessionID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Unscoped().
+			Where("user_id = ? AND session_id = ?", userID, sessionID).
+			Delete(&models.WorkoutLike{})
 
-	if res.Error != nil {
-		return res.Error
-	}
+		if res.Error != nil {
+			return res.Error
+		}
 
-	// rows=0 でも冪等で成功扱い
-	return nil
-}
+		// rows=0 でも冪等で成功扱い
+		if res.RowsAffected == 0 {
+			return nil
+		}
 
-func (r *workoutLikeRepository) IsRecordPublic(recordID uint) (bool, error) {
-	var rec models.WorkoutRecord
-	if err := r.db.Select("id, is_public").First(&rec, recordID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, ErrNotFound
+		if err := tx.Model(&models.WorkoutSession{}).
+			Where("id = ? AND like_count > 0", sessionID).
+			UpdateColumn("like_count", gorm.Expr("like_count - 1")).Error; err != nil {
+			return err
 		}
-		return false, err
-	}
-	return rec.IsPublic, nil
+		return retractSessionLiked(tx, userID, sessionID)
+	})
+}
+
+// FindSessionAccess はいいねの可否の判定に使う、viewerID から見たセッションの公開状態を返す。
+func (r *workoutLikeRepository) FindSessionAccess(viewerID uint, sessionID uint) (*SessionAccess, error) {
+	return findSessionAccess(r.db, viewerID, sessionID)
 }
 
-func (r *workoutLikeRepository) IsLikedByMe(userID uint, recordID uint) (bool, error) {
+func (r *workoutLikeRepository) IsLikedByMe(userID uint, sessionID uint) (bool, error) {
 	var like models.WorkoutLike
 	err := r.db.
-		Where("user_id = ? AND record_id = ?", userID, recordID).
+		Where("user_id = ? AND session_id = ?", userID, sessionID).
 		First(&like).Error
 
 	if err != nil {
@@ -79,3 +106,32 @@ func (r *workoutLikeRepository) IsLikedByMe(userID uint, recordID uint) (bool, e
 
 	return true, nil
 }
+
+// CountLikes はセッションのいいね数を返す。
+func (r *workoutLikeRepository) CountLikes(sessionID uint) (int, error) {
+	var session models.WorkoutSession
+	if err := r.db.Select("id, like_count").First(&session, sessionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrNotFound
+		}
+		return 0, err
+	}
+	return session.LikeCount, nil
+}
+
+// FindLikers はセッションにいいねしたユーザーを新しい順に返す。
+func (r *workoutLikeRepository) FindLikers(sessionID uint) ([]LikeUser, error) {
+	var rows []LikeUser
+	err := r.db.
+		Table("workout_likes").
+		Select("users.id AS user_id, users.email AS email, workout_likes.created_at AS liked_at").
+		Joins("JOIN users ON users.id = workout_likes.user_id").
+		Where("workout_likes.session_id = ?", sessionID).
+		Where("workout_likes.deleted_at IS NULL").
+		Order("workout_likes.created_at DESC, workout_likes.id DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
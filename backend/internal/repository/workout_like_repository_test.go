@@ -21,14 +21,17 @@ func newWorkoutLikeTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, db.AutoMigrate(
 		&models.User{},
 		&models.Exercise{},
+		&models.WorkoutSession{},
 		&models.WorkoutRecord{},
 		&models.WorkoutLike{},
+		&models.Follow{},
+		&models.Notification{},
 	))
 
 	return db
 }
 
-func seedUserExerciseRecord(t *testing.T, db *gorm.DB, isPublic bool) (user models.User, ex models.Exercise, rec models.WorkoutRecord) {
+func seedUserExerciseSession(t *testing.T, db *gorm.DB, isPublic bool) (user models.User, ex models.Exercise, session models.WorkoutSession) {
 	t.Helper()
 
 	user = models.User{Email: "user@example.com", Password: "hashed"}
@@ -37,52 +40,54 @@ func seedUserExerciseRecord(t *testing.T, db *gorm.DB, isPublic bool) (user mode
 	ex = models.Exercise{Name: "ベンチプレス"}
 	require.NoError(t, db.Create(&ex).Error)
 
-	rec = models.WorkoutRecord{
+	session = models.WorkoutSession{
 		UserID:     user.ID,
-		ExerciseID: ex.ID,
 		BodyWeight: 70.0,
 		TrainedOn:  time.Now(),
 		IsPublic:   isPublic,
 		Comment:    "test",
+		Records: []models.WorkoutRecord{
+			{UserID: user.ID, ExerciseID: ex.ID, TrainedOn: time.Now()},
+		},
 	}
-	require.NoError(t, db.Create(&rec).Error)
+	require.NoError(t, db.Create(&session).Error)
 
-	return user, ex, rec
+	return user, ex, session
 }
 
 func TestWorkoutLikeRepository_CreateLike(t *testing.T) {
 	tests := []struct {
 		name        string
-		prepare     func(db *gorm.DB) (userID uint, recordID uint)
+		prepare     func(db *gorm.DB) (userID uint, sessionID uint)
 		expectError bool
 	}{
 		{
 			name: "【正常系】初回いいねが作成できること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
-				return user.ID, rec.ID
+				user, _, session := seedUserExerciseSession(t, db, true)
+				return user.ID, session.ID
 			},
 			expectError: false,
 		},
 		{
 			name: "【正常系】同じ投稿に2回いいねしても冪等で成功扱いになること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				// 1回目
 				require.NoError(t, db.Create(&models.WorkoutLike{
-					UserID:   user.ID,
-					RecordID: rec.ID,
+					UserID:    user.ID,
+					SessionID: session.ID,
 				}).Error)
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			expectError: false,
 		},
 		{
 			name: "【異常系】workout_likes テーブルが存在しない場合はエラーになること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				require.NoError(t, db.Migrator().DropTable(&models.WorkoutLike{}))
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			expectError: true,
 		},
@@ -92,10 +97,10 @@ func TestWorkoutLikeRepository_CreateLike(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			db := newWorkoutLikeTestDB(t)
-			userID, recordID := tt.prepare(db)
+			userID, sessionID := tt.prepare(db)
 
 			repo := NewWorkoutLikeRepository(db)
-			err := repo.CreateLike(userID, recordID)
+			err := repo.CreateLike(userID, sessionID)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -105,7 +110,7 @@ func TestWorkoutLikeRepository_CreateLike(t *testing.T) {
 
 			var cnt int64
 			require.NoError(t, db.Model(&models.WorkoutLike{}).
-				Where("user_id = ? AND record_id = ?", userID, recordID).
+				Where("user_id = ? AND session_id = ?", userID, sessionID).
 				Count(&cnt).Error)
 			require.Equal(t, int64(1), cnt)
 		})
@@ -115,19 +120,19 @@ func TestWorkoutLikeRepository_CreateLike(t *testing.T) {
 func TestWorkoutLikeRepository_DeleteLike(t *testing.T) {
 	tests := []struct {
 		name        string
-		prepare     func(db *gorm.DB) (userID uint, recordID uint)
+		prepare     func(db *gorm.DB) (userID uint, sessionID uint)
 		expectError bool
 		afterCount  int64
 	}{
 		{
 			name: "【正常系】存在するいいねを物理削除できること（Unscoped）",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				require.NoError(t, db.Create(&models.WorkoutLike{
-					UserID:   user.ID,
-					RecordID: rec.ID,
+					UserID:    user.ID,
+					SessionID: session.ID,
 				}).Error)
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			expectError: false,
 			afterCount:  0,
@@ -135,8 +140,8 @@ func TestWorkoutLikeRepository_DeleteLike(t *testing.T) {
 		{
 			name: "【正常系】存在しないいいねを削除しても冪等で成功扱いになること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
-				return user.ID, rec.ID
+				user, _, session := seedUserExerciseSession(t, db, true)
+				return user.ID, session.ID
 			},
 			expectError: false,
 			afterCount:  0,
@@ -144,9 +149,9 @@ func TestWorkoutLikeRepository_DeleteLike(t *testing.T) {
 		{
 			name: "【異常系】workout_likes テーブルが存在しない場合はエラーになること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				require.NoError(t, db.Migrator().DropTable(&models.WorkoutLike{}))
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			expectError: true,
 		},
@@ -156,10 +161,10 @@ func TestWorkoutLikeRepository_DeleteLike(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			db := newWorkoutLikeTestDB(t)
-			userID, recordID := tt.prepare(db)
+			userID, sessionID := tt.prepare(db)
 
 			repo := NewWorkoutLikeRepository(db)
-			err := repo.DeleteLike(userID, recordID)
+			err := repo.DeleteLike(userID, sessionID)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -169,53 +174,83 @@ func TestWorkoutLikeRepository_DeleteLike(t *testing.T) {
 
 			var cnt int64
 			require.NoError(t, db.Unscoped().Model(&models.WorkoutLike{}).
-				Where("user_id = ? AND record_id = ?", userID, recordID).
+				Where("user_id = ? AND session_id = ?", userID, sessionID).
 				Count(&cnt).Error)
 			require.Equal(t, tt.afterCount, cnt)
 		})
 	}
 }
 
-func TestWorkoutLikeRepository_IsRecordPublic(t *testing.T) {
+func TestWorkoutLikeRepository_FindSessionAccess(t *testing.T) {
+	// 投稿者を非公開アカウントにし、viewer を作成して follow の状態を設定する
+	privateOwner := func(db *gorm.DB, status models.FollowStatus) (uint, uint) {
+		owner, _, session := seedUserExerciseSession(t, db, true)
+		require.NoError(t, db.Model(&owner).Update("is_private", true).Error)
+		viewer := models.User{Email: "viewer@example.com", Password: "hashed"}
+		require.NoError(t, db.Create(&viewer).Error)
+		if status != "" {
+			require.NoError(t, db.Create(&models.Follow{FollowerID: viewer.ID, FolloweeID: owner.ID, Status: status}).Error)
+		}
+		return viewer.ID, session.ID
+	}
+
 	tests := []struct {
 		name        string
-		prepare     func(db *gorm.DB) (recordID uint)
-		wantPublic  bool
+		prepare     func(db *gorm.DB) (viewerID uint, sessionID uint)
+		wantAccess  SessionAccess
 		wantErr     error
 		expectError bool
 	}{
 		{
-			name: "【正常系】公開レコードは true を返すこと",
-			prepare: func(db *gorm.DB) uint {
-				_, _, rec := seedUserExerciseRecord(t, db, true)
-				return rec.ID
+			name: "【正常系】公開アカウントの公開セッションは誰からも見えること",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				_, _, session := seedUserExerciseSession(t, db, true)
+				return session.UserID + 100, session.ID
 			},
-			wantPublic:  true,
-			expectError: false,
+			wantAccess: SessionAccess{IsPublic: true, AuthorVisible: true},
 		},
 		{
-			name: "【正常系】非公開レコードは false を返すこと",
-			prepare: func(db *gorm.DB) uint {
-				_, _, rec := seedUserExerciseRecord(t, db, false)
-				return rec.ID
+			name: "【正常系】非公開セッションは IsPublic が false になること",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				_, _, session := seedUserExerciseSession(t, db, false)
+				return session.UserID, session.ID
 			},
-			wantPublic:  false,
-			expectError: false,
+			wantAccess: SessionAccess{IsPublic: false, AuthorVisible: true},
 		},
 		{
-			name: "【正常系】存在しない recordID は ErrNotFound を返すこと",
-			prepare: func(db *gorm.DB) uint {
-				return 999999
+			name: "【正常系】非公開アカウントのセッションはフォローしていない人から見えないこと",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				return privateOwner(db, "")
+			},
+			wantAccess: SessionAccess{IsPublic: true, AuthorVisible: false},
+		},
+		{
+			name: "【正常系】非公開アカウントのセッションは承認待ちのフォロワーから見えないこと",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				return privateOwner(db, models.FollowPending)
+			},
+			wantAccess: SessionAccess{IsPublic: true, AuthorVisible: false},
+		},
+		{
+			name: "【正常系】非公開アカウントのセッションは承認済みのフォロワーから見えること",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				return privateOwner(db, models.FollowAccepted)
+			},
+			wantAccess: SessionAccess{IsPublic: true, AuthorVisible: true},
+		},
+		{
+			name: "【正常系】存在しない sessionID は ErrNotFound を返すこと",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				return 1, 999999
 			},
-			wantPublic:  false,
 			wantErr:     ErrNotFound,
 			expectError: true,
 		},
 		{
-			name: "【異常系】workout_records テーブルが存在しない場合はエラーになること",
-			prepare: func(db *gorm.DB) uint {
-				require.NoError(t, db.Migrator().DropTable(&models.WorkoutRecord{}))
-				return 1
+			name: "【異常系】workout_sessions テーブルが存在しない場合はエラーになること",
+			prepare: func(db *gorm.DB) (uint, uint) {
+				require.NoError(t, db.Migrator().DropTable(&models.WorkoutSession{}))
+				return 1, 1
 			},
 			expectError: true,
 		},
@@ -225,10 +260,10 @@ func TestWorkoutLikeRepository_IsRecordPublic(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			db := newWorkoutLikeTestDB(t)
-			recordID := tt.prepare(db)
+			viewerID, sessionID := tt.prepare(db)
 
 			repo := NewWorkoutLikeRepository(db)
-			pub, err := repo.IsRecordPublic(recordID)
+			got, err := repo.FindSessionAccess(viewerID, sessionID)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -239,7 +274,9 @@ func TestWorkoutLikeRepository_IsRecordPublic(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, tt.wantPublic, pub)
+			require.NotZero(t, got.OwnerID)
+			require.Equal(t, tt.wantAccess.IsPublic, got.IsPublic)
+			require.Equal(t, tt.wantAccess.AuthorVisible, got.AuthorVisible)
 		})
 	}
 }
@@ -247,15 +284,15 @@ func TestWorkoutLikeRepository_IsRecordPublic(t *testing.T) {
 func TestWorkoutLikeRepository_IsLikedByMe(t *testing.T) {
 	tests := []struct {
 		name        string
-		prepare     func(db *gorm.DB) (userID uint, recordID uint)
+		prepare     func(db *gorm.DB) (userID uint, sessionID uint)
 		wantLiked   bool
 		expectError bool
 	}{
 		{
 			name: "【正常系】いいねしていない場合は false を返すこと",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
-				return user.ID, rec.ID
+				user, _, session := seedUserExerciseSession(t, db, true)
+				return user.ID, session.ID
 			},
 			wantLiked:   false,
 			expectError: false,
@@ -263,12 +300,12 @@ func TestWorkoutLikeRepository_IsLikedByMe(t *testing.T) {
 		{
 			name: "【正常系】いいね済みの場合は true を返すこと",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				require.NoError(t, db.Create(&models.WorkoutLike{
-					UserID:   user.ID,
-					RecordID: rec.ID,
+					UserID:    user.ID,
+					SessionID: session.ID,
 				}).Error)
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			wantLiked:   true,
 			expectError: false,
@@ -276,9 +313,9 @@ func TestWorkoutLikeRepository_IsLikedByMe(t *testing.T) {
 		{
 			name: "【異常系】workout_likes テーブルが存在しない場合はエラーになること",
 			prepare: func(db *gorm.DB) (uint, uint) {
-				user, _, rec := seedUserExerciseRecord(t, db, true)
+				user, _, session := seedUserExerciseSession(t, db, true)
 				require.NoError(t, db.Migrator().DropTable(&models.WorkoutLike{}))
-				return user.ID, rec.ID
+				return user.ID, session.ID
 			},
 			expectError: true,
 		},
@@ -288,10 +325,10 @@ func TestWorkoutLikeRepository_IsLikedByMe(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			db := newWorkoutLikeTestDB(t)
-			userID, recordID := tt.prepare(db)
+			userID, sessionID := tt.prepare(db)
 
 			repo := NewWorkoutLikeRepository(db)
-			liked, err := repo.IsLikedByMe(userID, recordID)
+			liked, err := repo.IsLikedByMe(userID, sessionID)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -303,3 +340,44 @@ func TestWorkoutLikeRepository_IsLikedByMe(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutLikeRepository_LikeCount(t *testing.T) {
+	db := newWorkoutLikeTestDB(t)
+	owner, _, session := seedUserExerciseSession(t, db, true)
+	fan := models.User{Email: "fan@example.com", Password: "hashed"}
+	require.NoError(t, db.Create(&fan).Error)
+	repo := NewWorkoutLikeRepository(db)
+
+	t.Run("【正常系】いいねの作成で件数が増え、重複したいいねでは増えないこと", func(t *testing.T) {
+		require.NoError(t, repo.CreateLike(owner.ID, session.ID))
+		require.NoError(t, repo.CreateLike(fan.ID, session.ID))
+		require.NoError(t, repo.CreateLike(fan.ID, session.ID))
+
+		count, err := repo.CountLikes(session.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("【正常系】いいねしたユーザーを新しい順に返すこと", func(t *testing.T) {
+		users, err := repo.FindLikers(session.ID)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, fan.ID, users[0].UserID)
+		require.Equal(t, "fan@example.com", users[0].Email)
+		require.Equal(t, owner.ID, users[1].UserID)
+	})
+
+	t.Run("【正常系】いいねの削除で件数が減り、いいねしていない解除では減らないこと", func(t *testing.T) {
+		require.NoError(t, repo.DeleteLike(fan.ID, session.ID))
+		require.NoError(t, repo.DeleteLike(fan.ID, session.ID))
+
+		count, err := repo.CountLikes(session.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("【異常系】存在しないセッションは ErrNotFound を返すこと", func(t *testing.T) {
+		_, err := repo.CountLikes(session.ID + 100)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -16,6 +16,8 @@ type WorkoutRepository interface {
 	Update(record *models.WorkoutRecord) error
 	Delete(id uint, userID uint) error
 	FindSetsByUserAndExercise(userID uint, exerciseID uint) ([]FlatWorkoutSet, error)
+	FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]FlatWorkoutSet, error)
+	FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error)
 }
 
 type workoutRepository struct {
@@ -23,33 +25,127 @@ type workoutRepository struct {
 }
 
 type FlatWorkoutSet struct {
-	RecordID       uint      `json:"record_id"`
-	TrainedOn      time.Time `json:"trained_on"`
-	SetNo          int       `json:"set"`
-	Reps           int       `json:"reps"`
-	ExerciseWeight float64   `json:"exercise_weight"`
-	BodyWeight     float64   `json:"body_weight"`
+	RecordID        uint                   `json:"record_id"`
+	TrainedOn       time.Time              `json:"trained_on"`
+	SetNo           int                    `json:"set"`
+	Reps            int                    `json:"reps"`
+	ExerciseWeight  float64                `json:"exercise_weight"`
+	BodyWeight      float64                `json:"body_weight"`
+	SetType         models.SetType         `json:"set_type"`
+	RPE             *float64               `json:"rpe"`
+	RIR             *int                   `json:"rir"`
+	RestSeconds     *int                   `json:"rest_seconds"`
+	Tempo           string                 `json:"tempo"`
+	Note            string                 `json:"note"`
+	MeasurementKind models.MeasurementKind `json:"measurement_kind"`
+	DurationSeconds *int                   `json:"duration_seconds"`
+	DistanceMeters  *float64               `json:"distance_meters"`
+	Volume          float64                `json:"volume"`
 }
 
+// setVolumeExpr はセットのボリューム(kg)を計測方法に応じて算出する（s: workout_sets, r: workout_records, e: exercises）。
+// 自重種目は記録時の体重に加重を足した値を負荷とし、回数のみ・時間・距離の種目は0とする。
+const setVolumeExpr = `CASE e.measurement_kind
+	WHEN 'reps_weight' THEN s.reps * s.exercise_weight
+	WHEN 'bodyweight_load' THEN s.reps * (r.body_weight + s.exercise_weight)
+	ELSE 0 END`
+
 func NewWorkoutRepository(db *gorm.DB) WorkoutRepository {
 	return &workoutRepository{db: db}
 }
 
 func (r *workoutRepository) Create(record *models.WorkoutRecord) error {
-	if err := r.db.Create(record).Error; err != nil {
-		if errors.Is(err, gorm.ErrForeignKeyViolated) {
-			return &ConstraintError{Constraint: "foreign_key"}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := attachToDaySession(tx, record); err != nil {
+			return err
+		}
+		if err := tx.Create(record).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return err
 		}
+
+		prs, err := rebuildPersonalRecords(tx, record.UserID, []uint{record.ExerciseID})
+		if err != nil {
+			return err
+		}
+		record.PersonalRecords = personalRecordsOf(prs, record.ID)
+		if err := notifyPersonalRecords(tx, models.CurrentPersonalRecords(prs), record.ID); err != nil {
+			return err
+		}
+		if err := recomputeUserProgram(tx, record.UserID); err != nil {
+			return err
+		}
+		return achieveUserGoals(tx, record.UserID, record.TrainedOn)
+	})
+}
+
+// attachToDaySession は同じユーザー・同じ日のセッションへ記録を紐付ける（無ければ作成する）。
+func attachToDaySession(tx *gorm.DB, record *models.WorkoutRecord) error {
+	var session models.WorkoutSession
+	err := tx.
+		Where("user_id = ? AND trained_on = ?", record.UserID, record.TrainedOn).
+		Order("id DESC").
+		First(&session).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		session = *models.NewSessionFromRecord(record)
+		if err := tx.Create(&session).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return err
+		}
+	case err != nil:
 		return err
+	default:
+		session.MergeRecord(record)
+		if err := tx.Model(&session).Updates(map[string]any{
+			"body_weight": session.BodyWeight,
+			"is_public":   session.IsPublic,
+			"comment":     session.Comment,
+		}).Error; err != nil {
+			return err
+		}
 	}
+
+	var maxPos int
+	if err := tx.Model(&models.WorkoutRecord{}).
+		Where("session_id = ?", session.ID).
+		Select("COALESCE(MAX(position), -1)").
+		Scan(&maxPos).Error; err != nil {
+		return err
+	}
+
+	record.SessionID = &session.ID
+	record.Position = maxPos + 1
 	return nil
 }
 
+// deleteSessionIfEmpty は記録が1件も残っていないセッションを削除する。
+func deleteSessionIfEmpty(tx *gorm.DB, sessionID *uint) error {
+	if sessionID == nil {
+		return nil
+	}
+	var cnt int64
+	if err := tx.Model(&models.WorkoutRecord{}).Where("session_id = ?", *sessionID).Count(&cnt).Error; err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return nil
+	}
+	if err := retractSessionNotifications(tx, *sessionID); err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id = ?", *sessionID).Delete(&models.WorkoutSession{}).Error
+}
+
 func (r *workoutRepository) FindByUserAndDay(userID uint, day time.Time) ([]models.WorkoutRecord, error) {
 	var records []models.WorkoutRecord
 	err := r.db.
 		Where("user_id = ? AND trained_on = ?", userID, day).Preload("Exercise").Preload("Sets").
-		Order("id ASC").
+		Order("session_id ASC, position ASC, id ASC").
 		Find(&records).Error
 	if err != nil {
 		return nil, err
@@ -91,6 +187,19 @@ func (r *workoutRepository) FindByIDAndUserID(id uint, userID uint) (*models.Wor
 
 func (r *workoutRepository) Update(record *models.WorkoutRecord) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		var prevExerciseID uint
+		if err := tx.Model(&models.WorkoutRecord{}).
+			Select("exercise_id").
+			Where("id = ?", record.ID).
+			Scan(&prevExerciseID).Error; err != nil {
+			return err
+		}
+
+		prevSessionID, err := moveSessionIfDayChanged(tx, record)
+		if err != nil {
+			return err
+		}
+
 		if err := tx.Unscoped().
 			Where("workout_record_id = ?", record.ID).
 			Delete(&models.WorkoutSet{}).Error; err != nil {
@@ -105,10 +214,16 @@ func (r *workoutRepository) Update(record *models.WorkoutRecord) error {
 				"body_weight": record.BodyWeight,
 				"exercise_id": record.ExerciseID,
 				"trained_on":  record.TrainedOn,
+				"session_id":  record.SessionID,
+				"position":    record.Position,
 			}).Error; err != nil {
 			return err
 		}
 
+		if err := deleteSessionIfEmpty(tx, prevSessionID); err != nil {
+			return err
+		}
+
 		for i := range record.Sets {
 			record.Sets[i].ID = 0
 			record.Sets[i].WorkoutRecordID = record.ID
@@ -121,17 +236,78 @@ func (r *workoutRepository) Update(record *models.WorkoutRecord) error {
 				return err
 			}
 		}
-		return nil
+
+		prs, err := rebuildPersonalRecords(tx, record.UserID, []uint{prevExerciseID, record.ExerciseID})
+		if err != nil {
+			return err
+		}
+		record.PersonalRecords = personalRecordsOf(prs, record.ID)
+		if err := recomputeUserProgram(tx, record.UserID); err != nil {
+			return err
+		}
+		return achieveUserGoals(tx, record.UserID, record.TrainedOn)
 	})
 }
 
+// moveSessionIfDayChanged は日付が変わった記録を移動先の日のセッションへ付け替え、移動元のセッションIDを返す。
+// 移動が不要な場合は nil を返す。
+func moveSessionIfDayChanged(tx *gorm.DB, record *models.WorkoutRecord) (*uint, error) {
+	prev := record.SessionID
+	if prev != nil {
+		var session models.WorkoutSession
+		err := tx.Select("id", "trained_on").First(&session, *prev).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil && session.TrainedOn.Equal(record.TrainedOn) {
+			return nil, nil
+		}
+	}
+	if err := attachToDaySession(tx, record); err != nil {
+		return nil, err
+	}
+	return prev, nil
+}
+
 func (r *workoutRepository) Delete(id uint, userID uint) error {
-	return r.db.Where("id = ? AND user_id = ?", id, userID).Unscoped().Delete(&models.WorkoutRecord{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var record models.WorkoutRecord
+		err := tx.Select("id", "session_id", "exercise_id").Where("id = ? AND user_id = ?", id, userID).First(&record).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.WorkoutRecord{}, record.ID).Error; err != nil {
+			return err
+		}
+		if err := retractPersonalRecords(tx, []uint{record.ID}); err != nil {
+			return err
+		}
+		if err := deleteSessionIfEmpty(tx, record.SessionID); err != nil {
+			return err
+		}
+		if _, err := rebuildPersonalRecords(tx, userID, []uint{record.ExerciseID}); err != nil {
+			return err
+		}
+		return recomputeUserProgram(tx, userID)
+	})
 }
 
 func (r *workoutRepository) FindSetsByUserAndExercise(userID uint, exerciseID uint) ([]FlatWorkoutSet, error) {
+	return r.FindSetsByUserAndExerciseInRange(userID, exerciseID, nil, nil)
+}
+
+// FindSetsByUserAndExerciseInRange はトレーニング日が from〜to（両端含む、nil は制限なし）のセットを返す。
+func (r *workoutRepository) FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]FlatWorkoutSet, error) {
+	return findFlatSets(r.db, userID, exerciseID, from, to)
+}
+
+// findFlatSets はユーザー・種目のセットを記録日とセット番号の順に平坦化して返す（from / to は nil なら無制限）。
+func findFlatSets(db *gorm.DB, userID uint, exerciseID uint, from, to *time.Time) ([]FlatWorkoutSet, error) {
 	var rows []FlatWorkoutSet
-	err := r.db.
+	q := db.
 		Table("workout_sets AS s").
 		Select(`
 			r.id AS record_id,
@@ -139,10 +315,28 @@ func (r *workoutRepository) FindSetsByUserAndExercise(userID uint, exerciseID ui
 			s.set_no AS set_no,
 			s.reps AS reps,
 			s.exercise_weight AS exercise_weight,
-			r.body_weight AS body_weight
+			r.body_weight AS body_weight,
+			s.set_type AS set_type,
+			s.rpe AS rpe,
+			s.rir AS rir,
+			s.rest_seconds AS rest_seconds,
+			s.tempo AS tempo,
+			s.note AS note,
+			e.measurement_kind AS measurement_kind,
+			s.duration_seconds AS duration_seconds,
+			s.distance_meters AS distance_meters,
+			`+setVolumeExpr+` AS volume
 		`).
 		Joins("INNER JOIN workout_records r ON r.id = s.workout_record_id").
-		Where("r.user_id = ? AND r.exercise_id = ?", userID, exerciseID).
+		Joins("INNER JOIN exercises e ON e.id = r.exercise_id").
+		Where("r.user_id = ? AND r.exercise_id = ?", userID, exerciseID)
+	if from != nil {
+		q = q.Where("r.trained_on >= ?", *from)
+	}
+	if to != nil {
+		q = q.Where("r.trained_on <= ?", *to)
+	}
+	err := q.
 		Order("r.trained_on ASC, s.set_no ASC").
 		Scan(&rows).Error
 	if err != nil {
@@ -150,3 +344,33 @@ func (r *workoutRepository) FindSetsByUserAndExercise(userID uint, exerciseID ui
 	}
 	return rows, nil
 }
+
+func (r *workoutRepository) FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error) {
+	exercises, err := findUsableExercises(r.db, userID, []uint{exerciseID})
+	if err != nil {
+		return nil, err
+	}
+	ex, ok := exercises[exerciseID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &ex, nil
+}
+
+// findUsableExercises は userID が記録に使える種目（共通・自分の独自種目・共有された種目）を種目IDごとに返す。
+// 使えない種目は含まれない。
+func findUsableExercises(db *gorm.DB, userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	var exercises []models.Exercise
+	if err := db.
+		Select("id", "owner_id", "measurement_kind", "archived_at").
+		Where("id IN ?", exerciseIDs).
+		Where("owner_id IS NULL OR owner_id = ? OR is_shared = ?", userID, true).
+		Find(&exercises).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[uint]models.Exercise, len(exercises))
+	for _, ex := range exercises {
+		out[ex.ID] = ex
+	}
+	return out, nil
+}
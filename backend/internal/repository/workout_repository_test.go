@@ -14,14 +14,21 @@ import (
 func newWorkoutTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
 	require.NoError(t, err)
 
 	require.NoError(t, db.AutoMigrate(
 		&models.User{},
 		&models.Exercise{},
+		&models.WorkoutSession{},
 		&models.WorkoutRecord{},
 		&models.WorkoutSet{},
+		&models.PersonalRecord{},
+		&models.UserProgram{},
+		&models.UserProgramLift{},
+		&models.Goal{},
+		&models.Notification{},
+		&models.BodyMetric{},
 	))
 	return db
 }
@@ -428,6 +435,7 @@ func TestWorkoutRepository_FindSetsByUserAndExercise(t *testing.T) {
 
 				day1 := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
 				day2 := time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC)
+				rpe := 8.0
 
 				r1 := models.WorkoutRecord{UserID: u.ID, ExerciseID: ex1.ID, BodyWeight: 70, TrainedOn: day1}
 				require.NoError(t, db.Create(&r1).Error)
@@ -438,8 +446,8 @@ func TestWorkoutRepository_FindSetsByUserAndExercise(t *testing.T) {
 
 				require.NoError(t, db.Create(&[]models.WorkoutSet{
 					{WorkoutRecordID: r1.ID, SetNo: 2, Reps: 8, ExerciseWeight: 100},
-					{WorkoutRecordID: r1.ID, SetNo: 1, Reps: 10, ExerciseWeight: 90},
-					{WorkoutRecordID: r2.ID, SetNo: 1, Reps: 6, ExerciseWeight: 110},
+					{WorkoutRecordID: r1.ID, SetNo: 1, Reps: 10, ExerciseWeight: 90, SetType: models.SetTypeWarmup},
+					{WorkoutRecordID: r2.ID, SetNo: 1, Reps: 6, ExerciseWeight: 110, RPE: &rpe, Tempo: "3-1-X-0", Note: "重め"},
 					{WorkoutRecordID: r3.ID, SetNo: 1, Reps: 5, ExerciseWeight: 120},
 				}).Error)
 
@@ -505,7 +513,209 @@ func TestWorkoutRepository_FindSetsByUserAndExercise(t *testing.T) {
 				require.Equal(t, 2, got[1].SetNo)
 				require.Equal(t, 1, got[2].SetNo)
 				require.True(t, got[0].TrainedOn.Before(got[2].TrainedOn) || got[0].TrainedOn.Equal(got[2].TrainedOn))
+				require.Equal(t, models.SetTypeWarmup, got[0].SetType)
+				require.Equal(t, models.SetTypeWorking, got[1].SetType)
+				require.NotNil(t, got[2].RPE)
+				require.Equal(t, 8.0, *got[2].RPE)
+				require.Equal(t, "3-1-X-0", got[2].Tempo)
+				require.Equal(t, "重め", got[2].Note)
+				require.Equal(t, models.MeasurementRepsWeight, got[0].MeasurementKind)
+				require.Equal(t, 900.0, got[0].Volume)
 			}
 		})
 	}
 }
+
+func TestWorkoutRepository_SessionGrouping(t *testing.T) {
+	day := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		action func(t *testing.T, repo WorkoutRepository, userID, exID uint)
+		verify func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "【正常系】同じ日の記録は1つのセッションに順番にまとめられること",
+			action: func(t *testing.T, repo WorkoutRepository, userID, exID uint) {
+				require.NoError(t, repo.Create(&models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day, BodyWeight: 70, Comment: "1種目目"}))
+				require.NoError(t, repo.Create(&models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day, BodyWeight: 70.3, IsPublic: true}))
+				require.NoError(t, repo.Create(&models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day.AddDate(0, 0, 1)}))
+			},
+			verify: func(t *testing.T, db *gorm.DB) {
+				var sessions []models.WorkoutSession
+				require.NoError(t, db.Preload("Records").Order("id ASC").Find(&sessions).Error)
+				require.Len(t, sessions, 2)
+				require.Len(t, sessions[0].Records, 2)
+				require.Equal(t, 0, sessions[0].Records[0].Position)
+				require.Equal(t, 1, sessions[0].Records[1].Position)
+				require.InDelta(t, 70.3, sessions[0].BodyWeight, 1e-6)
+				require.False(t, sessions[0].IsPublic, "非公開の記録を含むセッションは公開しない")
+				require.Equal(t, "1種目目", sessions[0].Comment)
+			},
+		},
+		{
+			name: "【正常系】日付を変更すると移動先のセッションへ付け替え、空になったセッションは削除されること",
+			action: func(t *testing.T, repo WorkoutRepository, userID, exID uint) {
+				rec := models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day}
+				require.NoError(t, repo.Create(&rec))
+				require.NoError(t, repo.Create(&models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day.AddDate(0, 0, 2)}))
+
+				rec.TrainedOn = day.AddDate(0, 0, 2)
+				rec.Sets = []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}
+				require.NoError(t, repo.Update(&rec))
+			},
+			verify: func(t *testing.T, db *gorm.DB) {
+				var sessions []models.WorkoutSession
+				require.NoError(t, db.Preload("Records").Find(&sessions).Error)
+				require.Len(t, sessions, 1)
+				require.Len(t, sessions[0].Records, 2)
+				require.Equal(t, day.AddDate(0, 0, 2), sessions[0].TrainedOn)
+			},
+		},
+		{
+			name: "【正常系】最後の記録を削除するとセッションも削除されること",
+			action: func(t *testing.T, repo WorkoutRepository, userID, exID uint) {
+				rec := models.WorkoutRecord{UserID: userID, ExerciseID: exID, TrainedOn: day}
+				require.NoError(t, repo.Create(&rec))
+				require.NoError(t, repo.Delete(rec.ID, userID))
+			},
+			verify: func(t *testing.T, db *gorm.DB) {
+				var cnt int64
+				require.NoError(t, db.Model(&models.WorkoutSession{}).Count(&cnt).Error)
+				require.EqualValues(t, 0, cnt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			repo := NewWorkoutRepository(db)
+
+			u := models.User{Email: "session@example.com"}
+			ex := models.Exercise{Name: "ベンチプレス"}
+			require.NoError(t, db.Create(&u).Error)
+			require.NoError(t, db.Create(&ex).Error)
+
+			tt.action(t, repo, u.ID, ex.ID)
+			tt.verify(t, db)
+		})
+	}
+}
+
+func TestWorkoutRepository_FindSetsByUserAndExercise_Volume(t *testing.T) {
+	day := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	sec := 60
+	dist := 5000.0
+
+	tests := []struct {
+		name       string
+		kind       models.MeasurementKind
+		bodyWeight float64
+		set        models.WorkoutSet
+		wantVolume float64
+	}{
+		{
+			name:       "【正常系】重量種目は回数×重量がボリュームになること",
+			kind:       models.MeasurementRepsWeight,
+			bodyWeight: 70,
+			set:        models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 100},
+			wantVolume: 500,
+		},
+		{
+			name:       "【正常系】自重種目は回数×(体重＋加重)がボリュームになること",
+			kind:       models.MeasurementBodyweightLoad,
+			bodyWeight: 70,
+			set:        models.WorkoutSet{SetNo: 1, Reps: 5, ExerciseWeight: 10},
+			wantVolume: 400,
+		},
+		{
+			name:       "【正常系】回数のみの種目のボリュームは0になること",
+			kind:       models.MeasurementRepsOnly,
+			bodyWeight: 70,
+			set:        models.WorkoutSet{SetNo: 1, Reps: 20},
+			wantVolume: 0,
+		},
+		{
+			name:       "【正常系】時間種目は秒数が返りボリュームは0になること",
+			kind:       models.MeasurementDuration,
+			bodyWeight: 70,
+			set:        models.WorkoutSet{SetNo: 1, DurationSeconds: &sec},
+			wantVolume: 0,
+		},
+		{
+			name:       "【正常系】距離＋時間種目は距離と秒数が返りボリュームは0になること",
+			kind:       models.MeasurementDistanceDuration,
+			bodyWeight: 70,
+			set:        models.WorkoutSet{SetNo: 1, DurationSeconds: &sec, DistanceMeters: &dist},
+			wantVolume: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTestDB(t)
+			repo := NewWorkoutRepository(db)
+
+			u := models.User{Email: "volume@example.com"}
+			ex := models.Exercise{Name: "種目", MeasurementKind: tt.kind}
+			require.NoError(t, db.Create(&u).Error)
+			require.NoError(t, db.Create(&ex).Error)
+			require.NoError(t, repo.Create(&models.WorkoutRecord{
+				UserID: u.ID, ExerciseID: ex.ID, BodyWeight: tt.bodyWeight, TrainedOn: day,
+				Sets: []models.WorkoutSet{tt.set},
+			}))
+
+			got, err := repo.FindSetsByUserAndExercise(u.ID, ex.ID)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.Equal(t, tt.kind, got[0].MeasurementKind)
+			require.InDelta(t, tt.wantVolume, got[0].Volume, 1e-6)
+			require.Equal(t, tt.set.DurationSeconds, got[0].DurationSeconds)
+			require.Equal(t, tt.set.DistanceMeters, got[0].DistanceMeters)
+		})
+	}
+}
+
+func TestWorkoutRepository_FindUsableExercise(t *testing.T) {
+	db := newWorkoutTestDB(t)
+	repo := NewWorkoutRepository(db)
+
+	me := models.User{Email: "me@example.com"}
+	other := models.User{Email: "other@example.com"}
+	require.NoError(t, db.Create(&me).Error)
+	require.NoError(t, db.Create(&other).Error)
+
+	global := models.Exercise{Name: "プランク", MeasurementKind: models.MeasurementDuration}
+	mine := models.Exercise{Name: "マイ種目", OwnerID: &me.ID}
+	shared := models.Exercise{Name: "共有種目", OwnerID: &other.ID, IsShared: true}
+	private := models.Exercise{Name: "非公開種目", OwnerID: &other.ID}
+	require.NoError(t, db.Create(&[]*models.Exercise{&global, &mine, &shared, &private}).Error)
+
+	tests := []struct {
+		name       string
+		exerciseID uint
+		wantKind   models.MeasurementKind
+		wantErr    error
+	}{
+		{name: "【正常系】共通種目は計測方法付きで取得できること", exerciseID: global.ID, wantKind: models.MeasurementDuration},
+		{name: "【正常系】自分の独自種目を取得できること", exerciseID: mine.ID, wantKind: models.MeasurementRepsWeight},
+		{name: "【正常系】他人の共有種目を取得できること", exerciseID: shared.ID, wantKind: models.MeasurementRepsWeight},
+		{name: "【異常系】他人の非公開種目は ErrNotFound になること", exerciseID: private.ID, wantErr: ErrNotFound},
+		{name: "【異常系】存在しない種目は ErrNotFound になること", exerciseID: private.ID + 100, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.FindUsableExercise(me.ID, tt.exerciseID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantKind, got.MeasurementKind)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkoutSessionRepository interface {
+	Create(session *models.WorkoutSession) error
+	FindByIDAndUserID(id uint, userID uint) (*models.WorkoutSession, error)
+	FindByUserAndDay(userID uint, day time.Time) ([]models.WorkoutSession, error)
+	Update(session *models.WorkoutSession) error
+	Delete(id uint, userID uint) error
+	FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+}
+
+type workoutSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutSessionRepository(db *gorm.DB) WorkoutSessionRepository {
+	return &workoutSessionRepository{db: db}
+}
+
+func preloadSessionRecords(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Records", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).
+		Preload("Records.Exercise").
+		Preload("Records.Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("set_no ASC, id ASC")
+		})
+}
+
+// syncRecords はセッション単位の項目（日付・体重・公開フラグ）を各記録へ反映し、並び順を採番する。
+func syncRecords(session *models.WorkoutSession) {
+	for i := range session.Records {
+		rec := &session.Records[i]
+		rec.SessionID = &session.ID
+		rec.Position = i
+		rec.UserID = session.UserID
+		rec.TrainedOn = session.TrainedOn
+		rec.BodyWeight = session.BodyWeight
+		rec.IsPublic = session.IsPublic
+	}
+}
+
+// saveSessionRecords はセッションの記録を並び順で既存の記録と対応付けて上書きし、増えた分だけ作成、減った分だけ削除する。
+// 記録・セットの ID は編集しても変わらない。削除した記録と種目を変えた記録の ID を返す。
+func saveSessionRecords(tx *gorm.DB, session *models.WorkoutSession) ([]uint, error) {
+	var existing []models.WorkoutRecord
+	if session.ID != 0 {
+		if err := tx.
+			Preload("Sets", func(db *gorm.DB) *gorm.DB {
+				return db.Order("set_no ASC, id ASC")
+			}).
+			Where("session_id = ?", session.ID).
+			Order("position ASC, id ASC").
+			Find(&existing).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	syncRecords(session)
+	var staleIDs []uint
+	for i := range session.Records {
+		rec := &session.Records[i]
+		if i >= len(existing) {
+			rec.ID = 0
+			for j := range rec.Sets {
+				rec.Sets[j].ID = 0
+				rec.Sets[j].WorkoutRecordID = 0
+			}
+			if err := tx.Create(rec).Error; err != nil {
+				if errors.Is(err, gorm.ErrForeignKeyViolated) {
+					return nil, &ConstraintError{Constraint: "foreign_key"}
+				}
+				return nil, err
+			}
+			continue
+		}
+
+		prev := existing[i]
+		rec.ID = prev.ID
+		rec.CreatedAt = prev.CreatedAt
+		rec.Comment = prev.Comment
+		if rec.ExerciseID != prev.ExerciseID {
+			staleIDs = append(staleIDs, prev.ID)
+		}
+		if err := tx.
+			Model(&models.WorkoutRecord{}).
+			Where("id = ?", rec.ID).
+			Updates(map[string]any{
+				"exercise_id": rec.ExerciseID,
+				"position":    rec.Position,
+				"trained_on":  rec.TrainedOn,
+				"body_weight": rec.BodyWeight,
+				"is_public":   rec.IsPublic,
+			}).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return nil, &ConstraintError{Constraint: "foreign_key"}
+			}
+			return nil, err
+		}
+		if err := saveRecordSets(tx, rec, prev.Sets); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(existing) > len(session.Records) {
+		var removedIDs []uint
+		for _, rec := range existing[len(session.Records):] {
+			removedIDs = append(removedIDs, rec.ID)
+		}
+		if err := tx.Unscoped().
+			Where("workout_record_id IN ?", removedIDs).
+			Delete(&models.WorkoutSet{}).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Unscoped().
+			Where("id IN ?", removedIDs).
+			Delete(&models.WorkoutRecord{}).Error; err != nil {
+			return nil, err
+		}
+		staleIDs = append(staleIDs, removedIDs...)
+	}
+	return staleIDs, nil
+}
+
+// saveRecordSets は記録のセットを並び順で既存のセット（existing）と対応付けて上書きし、増えた分だけ作成、減った分だけ削除する。
+func saveRecordSets(tx *gorm.DB, rec *models.WorkoutRecord, existing []models.WorkoutSet) error {
+	for j := range rec.Sets {
+		st := &rec.Sets[j]
+		st.WorkoutRecordID = rec.ID
+		if j >= len(existing) {
+			st.ID = 0
+			if err := tx.Create(st).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		st.ID = existing[j].ID
+		st.CreatedAt = existing[j].CreatedAt
+		if err := tx.Save(st).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(existing) > len(rec.Sets) {
+		var removedIDs []uint
+		for _, st := range existing[len(rec.Sets):] {
+			removedIDs = append(removedIDs, st.ID)
+		}
+		return tx.Unscoped().
+			Where("id IN ?", removedIDs).
+			Delete(&models.WorkoutSet{}).Error
+	}
+	return nil
+}
+
+// Create はセッションを記録とともに作成する。作成元のテンプレートが本人のものでなければ ErrNotFound を返す。
+func (r *workoutSessionRepository) Create(session *models.WorkoutSession) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSessionTemplate(tx, session); err != nil {
+			return err
+		}
+		records := session.Records
+		session.Records = nil
+		if err := tx.Create(session).Error; err != nil {
+			if errors.Is(err, gorm.ErrForeignKeyViolated) {
+				return &ConstraintError{Constraint: "foreign_key"}
+			}
+			return err
+		}
+
+		session.Records = records
+		if _, err := saveSessionRecords(tx, session); err != nil {
+			return err
+		}
+		if err := rememberTemplateWeights(tx, session); err != nil {
+			return err
+		}
+		prs, err := rebuildSessionPersonalRecords(tx, session, nil)
+		if err != nil {
+			return err
+		}
+		// 自己ベストの通知は新しい記録の作成時だけ行い、編集で作り直した場合は通知しない
+		current := models.CurrentPersonalRecords(prs)
+		for _, rec := range session.Records {
+			if err := notifyPersonalRecords(tx, current, rec.ID); err != nil {
+				return err
+			}
+		}
+		if err := recomputeUserProgram(tx, session.UserID); err != nil {
+			return err
+		}
+		return achieveUserGoals(tx, session.UserID, session.TrainedOn)
+	})
+}
+
+// rebuildSessionPersonalRecords はセッションの記録に含まれる種目と prevExerciseIDs の自己ベストを作り直し、
+// 各記録が更新した自己ベストを PersonalRecords に設定する。作り直した自己ベストの更新履歴を返す。
+func rebuildSessionPersonalRecords(tx *gorm.DB, session *models.WorkoutSession, prevExerciseIDs []uint) ([]models.PersonalRecord, error) {
+	exerciseIDs := append([]uint{}, prevExerciseIDs...)
+	for _, rec := range session.Records {
+		exerciseIDs = append(exerciseIDs, rec.ExerciseID)
+	}
+	prs, err := rebuildPersonalRecords(tx, session.UserID, exerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range session.Records {
+		session.Records[i].PersonalRecords = personalRecordsOf(prs, session.Records[i].ID)
+	}
+	return prs, nil
+}
+
+// sessionExerciseIDs はセッションに含まれる記録の種目IDを返す。
+func sessionExerciseIDs(tx *gorm.DB, sessionID uint, userID uint) ([]uint, error) {
+	var ids []uint
+	if err := tx.Model(&models.WorkoutRecord{}).
+		Where("session_id = ? AND user_id = ?", sessionID, userID).
+		Distinct().
+		Pluck("exercise_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *workoutSessionRepository) FindByIDAndUserID(id uint, userID uint) (*models.WorkoutSession, error) {
+	var session models.WorkoutSession
+	err := preloadSessionRecords(r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *workoutSessionRepository) FindByUserAndDay(userID uint, day time.Time) ([]models.WorkoutSession, error) {
+	var sessions []models.WorkoutSession
+	err := preloadSessionRecords(r.db).
+		Where("user_id = ? AND trained_on = ?", userID, day).
+		Order("started_at ASC, id ASC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *workoutSessionRepository) Update(session *models.WorkoutSession) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		prevExerciseIDs, err := sessionExerciseIDs(tx, session.ID, session.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.
+			Model(&models.WorkoutSession{}).
+			Where("id = ?", session.ID).
+			Updates(map[string]any{
+				"trained_on":  session.TrainedOn,
+				"started_at":  session.StartedAt,
+				"ended_at":    session.EndedAt,
+				"body_weight": session.BodyWeight,
+				"is_public":   session.IsPublic,
+				"comment":     session.Comment,
+			}).Error; err != nil {
+			return err
+		}
+
+		staleIDs, err := saveSessionRecords(tx, session)
+		if err != nil {
+			return err
+		}
+		if err := retractPersonalRecords(tx, staleIDs); err != nil {
+			return err
+		}
+		if err := rememberTemplateWeights(tx, session); err != nil {
+			return err
+		}
+		if _, err := rebuildSessionPersonalRecords(tx, session, prevExerciseIDs); err != nil {
+			return err
+		}
+		if err := recomputeUserProgram(tx, session.UserID); err != nil {
+			return err
+		}
+		return achieveUserGoals(tx, session.UserID, session.TrainedOn)
+	})
+}
+
+func (r *workoutSessionRepository) Delete(id uint, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		exerciseIDs, err := sessionExerciseIDs(tx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		var recordIDs []uint
+		if err := tx.Model(&models.WorkoutRecord{}).
+			Where("session_id = ? AND user_id = ?", id, userID).
+			Pluck("id", &recordIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().
+			Where("workout_record_id IN ?", recordIDs).
+			Delete(&models.WorkoutSet{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().
+			Where("session_id = ? AND user_id = ?", id, userID).
+			Delete(&models.WorkoutRecord{}).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().
+			Where("id = ? AND user_id = ?", id, userID).
+			Delete(&models.WorkoutSession{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			if err := retractSessionNotifications(tx, id); err != nil {
+				return err
+			}
+		}
+		if err := retractPersonalRecords(tx, recordIDs); err != nil {
+			return err
+		}
+		if _, err := rebuildPersonalRecords(tx, userID, exerciseIDs); err != nil {
+			return err
+		}
+		return recomputeUserProgram(tx, userID)
+	})
+}
+
+func (r *workoutSessionRepository) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	return findUsableExercises(r.db, userID, exerciseIDs)
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newWorkoutSessionTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutSession{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+		&models.PersonalRecord{},
+		&models.WorkoutLike{},
+		&models.UserProgram{},
+		&models.UserProgramLift{},
+		&models.Goal{},
+		&models.Notification{},
+	))
+	return db
+}
+
+func seedSessionFixtures(t *testing.T, db *gorm.DB) (models.User, models.Exercise, models.Exercise) {
+	t.Helper()
+	u := models.User{Email: "session@example.com"}
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&u).Error)
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+	return u, bench, squat
+}
+
+func TestWorkoutSessionRepository_CreateAndFind(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		build       func(u models.User, bench, squat models.Exercise) models.WorkoutSession
+		expectError bool
+	}{
+		{
+			name: "【正常系】種目ブロックとセットを順序付きで保存・取得できること",
+			build: func(u models.User, bench, squat models.Exercise) models.WorkoutSession {
+				return models.WorkoutSession{
+					UserID:     u.ID,
+					TrainedOn:  day,
+					BodyWeight: 70,
+					IsPublic:   true,
+					Records: []models.WorkoutRecord{
+						{ExerciseID: squat.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}},
+						{ExerciseID: bench.ID, Sets: []models.WorkoutSet{
+							{SetNo: 2, Reps: 8, ExerciseWeight: 60},
+							{SetNo: 1, Reps: 10, ExerciseWeight: 55},
+						}},
+					},
+				}
+			},
+		},
+		{
+			name: "【異常系】存在しない種目を指定した場合はエラーを返すこと",
+			build: func(u models.User, _, _ models.Exercise) models.WorkoutSession {
+				return models.WorkoutSession{
+					UserID:    u.ID,
+					TrainedOn: day,
+					Records:   []models.WorkoutRecord{{ExerciseID: 9999}},
+				}
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutSessionTestDB(t)
+			repo := NewWorkoutSessionRepository(db)
+			u, bench, squat := seedSessionFixtures(t, db)
+
+			session := tt.build(u, bench, squat)
+			err := repo.Create(&session)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, session.ID)
+
+			got, err := repo.FindByIDAndUserID(session.ID, u.ID)
+			require.NoError(t, err)
+			require.Len(t, got.Records, 2)
+			require.Equal(t, "スクワット", got.Records[0].Exercise.Name)
+			require.Equal(t, "ベンチプレス", got.Records[1].Exercise.Name)
+			require.Equal(t, 1, got.Records[1].Sets[0].SetNo)
+			require.True(t, got.Records[0].IsPublic)
+			require.InDelta(t, 70.0, got.Records[0].BodyWeight, 1e-6)
+
+			daily, err := repo.FindByUserAndDay(u.ID, day)
+			require.NoError(t, err)
+			require.Len(t, daily, 1)
+
+			_, err = repo.FindByIDAndUserID(session.ID, u.ID+1)
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestWorkoutSessionRepository_Update(t *testing.T) {
+	db := newWorkoutSessionTestDB(t)
+	repo := NewWorkoutSessionRepository(db)
+	u, bench, squat := seedSessionFixtures(t, db)
+
+	session := models.WorkoutSession{
+		UserID:    u.ID,
+		TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Records: []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 10, ExerciseWeight: 50}}},
+		},
+	}
+	require.NoError(t, repo.Create(&session))
+
+	session.TrainedOn = time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC)
+	session.Comment = "更新"
+	session.Records = []models.WorkoutRecord{
+		{ExerciseID: squat.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}},
+		{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 60}}},
+	}
+	require.NoError(t, repo.Update(&session))
+
+	got, err := repo.FindByIDAndUserID(session.ID, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "更新", got.Comment)
+	require.Len(t, got.Records, 2)
+	require.Equal(t, squat.ID, got.Records[0].ExerciseID)
+	require.Equal(t, session.TrainedOn, got.Records[0].TrainedOn)
+
+	var setCnt int64
+	require.NoError(t, db.Model(&models.WorkoutSet{}).Count(&setCnt).Error)
+	require.EqualValues(t, 2, setCnt)
+}
+
+func TestWorkoutSessionRepository_UpdateInPlace(t *testing.T) {
+	db := newWorkoutSessionTestDB(t)
+	repo := NewWorkoutSessionRepository(db)
+	u, bench, squat := seedSessionFixtures(t, db)
+
+	session := models.WorkoutSession{
+		UserID:    u.ID,
+		TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Records: []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 10, ExerciseWeight: 50}, {SetNo: 2, Reps: 8, ExerciseWeight: 55}}},
+			{ExerciseID: squat.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}},
+		},
+	}
+	require.NoError(t, repo.Create(&session))
+	before, err := repo.FindByIDAndUserID(session.ID, u.ID)
+	require.NoError(t, err)
+
+	t.Run("【正常系】編集しても記録とセットの ID が変わらず、増えた分だけ作成されること", func(t *testing.T) {
+		session.Records = []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 12, ExerciseWeight: 50}, {SetNo: 2, Reps: 8, ExerciseWeight: 55}, {SetNo: 3, Reps: 6, ExerciseWeight: 60}}},
+			{ExerciseID: squat.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 5, ExerciseWeight: 110}}},
+			{ExerciseID: squat.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 10, ExerciseWeight: 80}}},
+		}
+		require.NoError(t, repo.Update(&session))
+
+		got, err := repo.FindByIDAndUserID(session.ID, u.ID)
+		require.NoError(t, err)
+		require.Len(t, got.Records, 3)
+		require.Equal(t, before.Records[0].ID, got.Records[0].ID)
+		require.Equal(t, before.Records[1].ID, got.Records[1].ID)
+		require.Equal(t, before.Records[0].Sets[0].ID, got.Records[0].Sets[0].ID)
+		require.Equal(t, before.Records[0].Sets[1].ID, got.Records[0].Sets[1].ID)
+		require.Equal(t, 12, got.Records[0].Sets[0].Reps)
+		require.Len(t, got.Records[0].Sets, 3)
+		require.InDelta(t, 110.0, got.Records[1].Sets[0].ExerciseWeight, 1e-6)
+	})
+
+	t.Run("【正常系】減った記録とセットだけが削除されること", func(t *testing.T) {
+		session.Records = []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 12, ExerciseWeight: 50}}},
+		}
+		require.NoError(t, repo.Update(&session))
+
+		got, err := repo.FindByIDAndUserID(session.ID, u.ID)
+		require.NoError(t, err)
+		require.Len(t, got.Records, 1)
+		require.Equal(t, before.Records[0].ID, got.Records[0].ID)
+		require.Len(t, got.Records[0].Sets, 1)
+		require.Equal(t, before.Records[0].Sets[0].ID, got.Records[0].Sets[0].ID)
+
+		for _, m := range []any{&models.WorkoutRecord{}, &models.WorkoutSet{}} {
+			var cnt int64
+			require.NoError(t, db.Unscoped().Model(m).Count(&cnt).Error)
+			require.EqualValues(t, 1, cnt)
+		}
+	})
+}
+
+func TestWorkoutSessionRepository_Delete(t *testing.T) {
+	db := newWorkoutSessionTestDB(t)
+	repo := NewWorkoutSessionRepository(db)
+	u, bench, _ := seedSessionFixtures(t, db)
+	other := models.User{Email: "other@example.com"}
+	require.NoError(t, db.Create(&other).Error)
+
+	session := models.WorkoutSession{
+		UserID:    u.ID,
+		TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		IsPublic:  true,
+		Records: []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 10, ExerciseWeight: 50}}},
+		},
+	}
+	require.NoError(t, repo.Create(&session))
+	require.NoError(t, db.Create(&models.WorkoutLike{UserID: other.ID, SessionID: session.ID}).Error)
+
+	// 他人のIDでは削除されない
+	require.NoError(t, repo.Delete(session.ID, other.ID))
+	_, err := repo.FindByIDAndUserID(session.ID, u.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(session.ID, u.ID))
+
+	for _, m := range []any{&models.WorkoutSession{}, &models.WorkoutRecord{}, &models.WorkoutSet{}, &models.WorkoutLike{}} {
+		var cnt int64
+		require.NoError(t, db.Unscoped().Model(m).Count(&cnt).Error)
+		require.EqualValues(t, 0, cnt)
+	}
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"gorm.io/gorm"
+)
+
+type WorkoutTemplateRepository interface {
+	Create(template *models.WorkoutTemplate) error
+	FindByUser(userID uint) ([]models.WorkoutTemplate, error)
+	FindByIDAndUserID(id uint, userID uint) (*models.WorkoutTemplate, error)
+	Update(template *models.WorkoutTemplate) error
+	Delete(id uint, userID uint) error
+	FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+}
+
+type workoutTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkoutTemplateRepository(db *gorm.DB) WorkoutTemplateRepository {
+	return &workoutTemplateRepository{db: db}
+}
+
+func preloadTemplateExercises(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).
+		Preload("Exercises.Exercise").
+		Preload("Exercises.Sets", func(db *gorm.DB) *gorm.DB {
+			return db.Order("set_no ASC, id ASC")
+		})
+}
+
+// syncTemplateExercises は種目ブロックの並び順を採番し、保存し直せるよう ID を初期化する。
+func syncTemplateExercises(template *models.WorkoutTemplate) {
+	for i := range template.Exercises {
+		ex := &template.Exercises[i]
+		ex.ID = 0
+		ex.TemplateID = template.ID
+		ex.Position = i
+		for j := range ex.Sets {
+			ex.Sets[j].ID = 0
+			ex.Sets[j].TemplateExerciseID = 0
+		}
+	}
+}
+
+func createTemplateExercises(tx *gorm.DB, template *models.WorkoutTemplate) error {
+	syncTemplateExercises(template)
+	if len(template.Exercises) == 0 {
+		return nil
+	}
+	if err := tx.Omit("Exercise").Create(&template.Exercises).Error; err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return &ConstraintError{Constraint: "foreign_key"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *workoutTemplateRepository) Create(template *models.WorkoutTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		exercises := template.Exercises
+		template.Exercises = nil
+		if err := tx.Create(template).Error; err != nil {
+			return err
+		}
+		template.Exercises = exercises
+		return createTemplateExercises(tx, template)
+	})
+}
+
+func (r *workoutTemplateRepository) FindByUser(userID uint) ([]models.WorkoutTemplate, error) {
+	var templates []models.WorkoutTemplate
+	if err := preloadTemplateExercises(r.db).
+		Where("user_id = ?", userID).
+		Order("name ASC, id ASC").
+		Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *workoutTemplateRepository) FindByIDAndUserID(id uint, userID uint) (*models.WorkoutTemplate, error) {
+	var template models.WorkoutTemplate
+	err := preloadTemplateExercises(r.db).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Update はテンプレートの名前・メモを更新し、種目ブロックと目標セットを入れ替える。
+func (r *workoutTemplateRepository) Update(template *models.WorkoutTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteTemplateExercises(tx, template.ID); err != nil {
+			return err
+		}
+		if err := tx.
+			Model(&models.WorkoutTemplate{}).
+			Where("id = ? AND user_id = ?", template.ID, template.UserID).
+			Updates(map[string]any{
+				"name": template.Name,
+				"note": template.Note,
+			}).Error; err != nil {
+			return err
+		}
+		return createTemplateExercises(tx, template)
+	})
+}
+
+func deleteTemplateExercises(tx *gorm.DB, templateID uint) error {
+	exerciseIDs := tx.Model(&models.WorkoutTemplateExercise{}).Select("id").Where("template_id = ?", templateID)
+	if err := tx.
+		Where("template_exercise_id IN (?)", exerciseIDs).
+		Delete(&models.WorkoutTemplateSet{}).Error; err != nil {
+		return err
+	}
+	return tx.
+		Where("template_id = ?", templateID).
+		Delete(&models.WorkoutTemplateExercise{}).Error
+}
+
+// Delete はテンプレートを削除する。このテンプレートから記録したトレーニングは残し、紐付けだけを外す。
+func (r *workoutTemplateRepository) Delete(id uint, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Unscoped().
+			Where("id = ? AND user_id = ?", id, userID).
+			Delete(&models.WorkoutTemplate{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if err := deleteTemplateExercises(tx, id); err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkoutSession{}).
+			Where("template_id = ? AND user_id = ?", id, userID).
+			Update("template_id", nil).Error
+	})
+}
+
+func (r *workoutTemplateRepository) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	return findUsableExercises(r.db, userID, exerciseIDs)
+}
+
+// checkSessionTemplate はセッションの作成元テンプレートがセッションの持ち主のものか確認する。
+func checkSessionTemplate(tx *gorm.DB, session *models.WorkoutSession) error {
+	if session.TemplateID == nil {
+		return nil
+	}
+	var count int64
+	if err := tx.Model(&models.WorkoutTemplate{}).
+		Where("id = ? AND user_id = ?", *session.TemplateID, session.UserID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// rememberTemplateWeights はテンプレートから記録したトレーニングの使用重量を、次回の入力値としてテンプレートへ書き戻す。
+// 種目ブロックは同じ種目の出現順、セットはセット番号で対応付ける。
+// 前回の実施日より前の日付のトレーニングでは、新しい使用重量を上書きしないよう書き戻さない。
+func rememberTemplateWeights(tx *gorm.DB, session *models.WorkoutSession) error {
+	if session.TemplateID == nil {
+		return nil
+	}
+
+	var template models.WorkoutTemplate
+	err := tx.
+		Preload("Exercises", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, id ASC")
+		}).
+		Preload("Exercises.Sets").
+		Where("id = ? AND user_id = ?", *session.TemplateID, session.UserID).
+		First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if template.LastPerformedOn != nil && session.TrainedOn.Before(*template.LastPerformedOn) {
+		return nil
+	}
+
+	used := make(map[uint]int, len(session.Records))
+	for _, tex := range template.Exercises {
+		rec := nthRecordOf(session.Records, tex.ExerciseID, used[tex.ExerciseID])
+		used[tex.ExerciseID]++
+		if rec == nil {
+			continue
+		}
+		for _, ts := range tex.Sets {
+			for _, st := range rec.Sets {
+				if st.SetNo != ts.SetNo {
+					continue
+				}
+				if err := tx.Model(&models.WorkoutTemplateSet{}).
+					Where("id = ?", ts.ID).
+					Update("last_weight", st.ExerciseWeight).Error; err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	return tx.Model(&models.WorkoutTemplate{}).
+		Where("id = ?", template.ID).
+		Update("last_performed_on", session.TrainedOn).Error
+}
+
+func nthRecordOf(records []models.WorkoutRecord, exerciseID uint, n int) *models.WorkoutRecord {
+	for i := range records {
+		if records[i].ExerciseID != exerciseID {
+			continue
+		}
+		if n == 0 {
+			return &records[i]
+		}
+		n--
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newWorkoutTemplateTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutSession{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+		&models.PersonalRecord{},
+		&models.WorkoutTemplate{},
+		&models.WorkoutTemplateExercise{},
+		&models.WorkoutTemplateSet{},
+		&models.UserProgram{},
+		&models.UserProgramLift{},
+		&models.Goal{},
+		&models.Notification{},
+	))
+	return db
+}
+
+func pushDayTemplate(userID, benchID, squatID uint) *models.WorkoutTemplate {
+	return &models.WorkoutTemplate{
+		UserID: userID,
+		Name:   "Push day",
+		Exercises: []models.WorkoutTemplateExercise{
+			{ExerciseID: benchID, Sets: []models.WorkoutTemplateSet{
+				{SetNo: 1, Reps: 8, ExerciseWeight: 60},
+				{SetNo: 2, Reps: 8, ExerciseWeight: 60},
+			}},
+			{ExerciseID: squatID, Sets: []models.WorkoutTemplateSet{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}},
+		},
+	}
+}
+
+func TestWorkoutTemplateRepository_CRUD(t *testing.T) {
+	db := newWorkoutTemplateTestDB(t)
+	u, bench, squat := seedSessionFixtures(t, db)
+	repo := NewWorkoutTemplateRepository(db)
+
+	tpl := pushDayTemplate(u.ID, bench.ID, squat.ID)
+	require.NoError(t, repo.Create(tpl))
+	require.NotZero(t, tpl.ID)
+
+	got, err := repo.FindByIDAndUserID(tpl.ID, u.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Push day", got.Name)
+	require.Len(t, got.Exercises, 2)
+	require.Equal(t, "ベンチプレス", got.Exercises[0].Exercise.Name)
+	require.Len(t, got.Exercises[0].Sets, 2)
+
+	_, err = repo.FindByIDAndUserID(tpl.ID, u.ID+1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	got.Name = "Leg day"
+	got.Exercises = []models.WorkoutTemplateExercise{
+		{ExerciseID: squat.ID, Sets: []models.WorkoutTemplateSet{{SetNo: 1, Reps: 5, ExerciseWeight: 110}}},
+	}
+	require.NoError(t, repo.Update(got))
+
+	list, err := repo.FindByUser(u.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "Leg day", list[0].Name)
+	require.Len(t, list[0].Exercises, 1)
+	require.Equal(t, 110.0, list[0].Exercises[0].Sets[0].ExerciseWeight)
+
+	var setCnt int64
+	require.NoError(t, db.Model(&models.WorkoutTemplateSet{}).Count(&setCnt).Error)
+	require.Equal(t, int64(1), setCnt)
+
+	require.ErrorIs(t, repo.Delete(tpl.ID, u.ID+1), ErrNotFound)
+	require.NoError(t, repo.Delete(tpl.ID, u.ID))
+	_, err = repo.FindByIDAndUserID(tpl.ID, u.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, db.Model(&models.WorkoutTemplateSet{}).Count(&setCnt).Error)
+	require.Zero(t, setCnt)
+}
+
+func TestWorkoutTemplateRepository_RemembersLastWeights(t *testing.T) {
+	day := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		write func(t *testing.T, sessions WorkoutSessionRepository, session *models.WorkoutSession)
+	}{
+		{
+			name: "【正常系】テンプレートから作成したトレーニングの重量が記録されること",
+			write: func(t *testing.T, sessions WorkoutSessionRepository, session *models.WorkoutSession) {
+				require.NoError(t, sessions.Create(session))
+			},
+		},
+		{
+			name: "【正常系】トレーニングを更新すると調整後の重量が記録されること",
+			write: func(t *testing.T, sessions WorkoutSessionRepository, session *models.WorkoutSession) {
+				sets := session.Records[0].Sets
+				session.Records[0].Sets = []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 50}}
+				require.NoError(t, sessions.Create(session))
+
+				session.Records[0].Sets = sets
+				require.NoError(t, sessions.Update(session))
+			},
+		},
+		{
+			name: "【正常系】後から前の日付のトレーニングを記録しても重量が上書きされないこと",
+			write: func(t *testing.T, sessions WorkoutSessionRepository, session *models.WorkoutSession) {
+				require.NoError(t, sessions.Create(session))
+
+				older := &models.WorkoutSession{
+					UserID:     session.UserID,
+					TrainedOn:  day.AddDate(0, 0, -7),
+					TemplateID: session.TemplateID,
+					Records: []models.WorkoutRecord{
+						{ExerciseID: session.Records[0].ExerciseID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 55}}},
+					},
+				}
+				require.NoError(t, sessions.Create(older))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newWorkoutTemplateTestDB(t)
+			u, bench, squat := seedSessionFixtures(t, db)
+			repo := NewWorkoutTemplateRepository(db)
+
+			tpl := pushDayTemplate(u.ID, bench.ID, squat.ID)
+			require.NoError(t, repo.Create(tpl))
+
+			session := &models.WorkoutSession{
+				UserID:     u.ID,
+				TrainedOn:  day,
+				TemplateID: &tpl.ID,
+				Records: []models.WorkoutRecord{
+					{ExerciseID: bench.ID, Sets: []models.WorkoutSet{
+						{SetNo: 1, Reps: 8, ExerciseWeight: 62.5},
+						{SetNo: 2, Reps: 6, ExerciseWeight: 65},
+					}},
+				},
+			}
+			tt.write(t, NewWorkoutSessionRepository(db), session)
+
+			got, err := repo.FindByIDAndUserID(tpl.ID, u.ID)
+			require.NoError(t, err)
+			require.NotNil(t, got.LastPerformedOn)
+			require.True(t, day.Equal(*got.LastPerformedOn))
+
+			benchSets := got.Exercises[0].Sets
+			require.NotNil(t, benchSets[0].LastWeight)
+			require.Equal(t, 62.5, *benchSets[0].LastWeight)
+			require.Equal(t, 62.5, benchSets[0].PrefillWeight())
+			require.Equal(t, 65.0, benchSets[1].PrefillWeight())
+			require.Equal(t, 60.0, benchSets[1].ExerciseWeight)
+
+			// 記録していない種目は目標重量のまま
+			require.Nil(t, got.Exercises[1].Sets[0].LastWeight)
+			require.Equal(t, 100.0, got.Exercises[1].Sets[0].PrefillWeight())
+		})
+	}
+}
+
+func TestWorkoutTemplateRepository_DeleteKeepsSessions(t *testing.T) {
+	db := newWorkoutTemplateTestDB(t)
+	u, bench, squat := seedSessionFixtures(t, db)
+	repo := NewWorkoutTemplateRepository(db)
+
+	tpl := pushDayTemplate(u.ID, bench.ID, squat.ID)
+	require.NoError(t, repo.Create(tpl))
+
+	session := &models.WorkoutSession{
+		UserID:     u.ID,
+		TrainedOn:  time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC),
+		TemplateID: &tpl.ID,
+		Records: []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 60}}},
+		},
+	}
+	require.NoError(t, NewWorkoutSessionRepository(db).Create(session))
+
+	require.NoError(t, repo.Delete(tpl.ID, u.ID))
+
+	var kept models.WorkoutSession
+	require.NoError(t, db.First(&kept, session.ID).Error)
+	require.Nil(t, kept.TemplateID)
+}
+
+func TestWorkoutSessionRepository_CreateRejectsOthersTemplate(t *testing.T) {
+	db := newWorkoutTemplateTestDB(t)
+	u, bench, squat := seedSessionFixtures(t, db)
+	other := models.User{Email: "other@example.com", Password: "x"}
+	require.NoError(t, db.Create(&other).Error)
+
+	tpl := pushDayTemplate(other.ID, bench.ID, squat.ID)
+	require.NoError(t, NewWorkoutTemplateRepository(db).Create(tpl))
+
+	session := &models.WorkoutSession{
+		UserID:     u.ID,
+		TrainedOn:  time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC),
+		TemplateID: &tpl.ID,
+		Records: []models.WorkoutRecord{
+			{ExerciseID: bench.ID, Sets: []models.WorkoutSet{{SetNo: 1, Reps: 8, ExerciseWeight: 60}}},
+		},
+	}
+	err := NewWorkoutSessionRepository(db).Create(session)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var cnt int64
+	require.NoError(t, db.Model(&models.WorkoutSession{}).Count(&cnt).Error)
+	require.Zero(t, cnt)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type BodyMetricService interface {
+	CreateMetric(userID uint, data BodyMetricData) (*models.BodyMetric, error)
+	ListMetrics(userID uint, from, to *time.Time) ([]models.BodyMetric, error)
+	GetMetric(userID uint, id uint) (*models.BodyMetric, error)
+	UpdateMetric(userID uint, id uint, data BodyMetricData) (*models.BodyMetric, error)
+	DeleteMetric(userID uint, id uint) error
+	ListBodyComposition(userID uint, from, to *time.Time) ([]BodyCompositionPoint, error)
+}
+
+// BodyCompositionPoint は体重を記録した日ごとの体格指数。FFMI は同じ日に体脂肪率も記録した場合のみ求める。
+type BodyCompositionPoint struct {
+	MeasuredOn     time.Time
+	Weight         float64
+	BodyFatPercent *float64
+	models.BodyComposition
+}
+
+// BodyMetricData は体組成の入力内容。未計測の項目は nil とする。
+type BodyMetricData struct {
+	MeasuredOn     time.Time
+	Weight         *float64
+	BodyFatPercent *float64
+	MuscleMass     *float64
+	Neck           *float64
+	Chest          *float64
+	Waist          *float64
+	Hips           *float64
+	Arm            *float64
+	Thigh          *float64
+	Note           string
+}
+
+// 入力値の上限（kg / % / cm）
+const (
+	maxBodyWeight     = 500
+	maxBodyFatPercent = 75
+	maxTapeMeasure    = 300
+)
+
+type bodyMetricService struct {
+	repo repository.BodyMetricRepository
+}
+
+func NewBodyMetricService(repo repository.BodyMetricRepository) BodyMetricService {
+	return &bodyMetricService{repo: repo}
+}
+
+func inRange(v *float64, limit float64) bool {
+	return v == nil || (*v > 0 && *v <= limit)
+}
+
+// buildBodyMetric は入力を検証してモデルに変換する。項目が1つも無い記録は受け付けない。
+func buildBodyMetric(userID uint, data BodyMetricData) (*models.BodyMetric, error) {
+	m := &models.BodyMetric{
+		UserID:         userID,
+		MeasuredOn:     data.MeasuredOn,
+		Weight:         data.Weight,
+		BodyFatPercent: data.BodyFatPercent,
+		MuscleMass:     data.MuscleMass,
+		Neck:           data.Neck,
+		Chest:          data.Chest,
+		Waist:          data.Waist,
+		Hips:           data.Hips,
+		Arm:            data.Arm,
+		Thigh:          data.Thigh,
+		Note:           strings.TrimSpace(data.Note),
+	}
+	if m.MeasuredOn.IsZero() {
+		return nil, ErrInvalidBodyMetric
+	}
+
+	measured := m.Weight != nil || m.BodyFatPercent != nil || m.MuscleMass != nil
+	for _, v := range m.TapeMeasurements() {
+		if !inRange(v, maxTapeMeasure) {
+			return nil, ErrInvalidBodyMetric
+		}
+		measured = measured || v != nil
+	}
+	if !measured {
+		return nil, ErrEmptyBodyMetric
+	}
+
+	if !inRange(m.Weight, maxBodyWeight) ||
+		!inRange(m.BodyFatPercent, maxBodyFatPercent) ||
+		!inRange(m.MuscleMass, maxBodyWeight) {
+		return nil, ErrInvalidBodyMetric
+	}
+	// 筋肉量が体重を超えることはない
+	if m.Weight != nil && m.MuscleMass != nil && *m.MuscleMass > *m.Weight {
+		return nil, ErrInvalidBodyMetric
+	}
+	return m, nil
+}
+
+func (s *bodyMetricService) CreateMetric(userID uint, data BodyMetricData) (*models.BodyMetric, error) {
+	m, err := buildBodyMetric(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(m); err != nil {
+		if errors.Is(err, repository.ErrUniqueViolation) {
+			return nil, ErrBodyMetricExists
+		}
+		return nil, fmt.Errorf("create body metric failed: %w", err)
+	}
+	return m, nil
+}
+
+func (s *bodyMetricService) ListMetrics(userID uint, from, to *time.Time) ([]models.BodyMetric, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return nil, ErrInvalidBodyMetricQuery
+	}
+	metrics, err := s.repo.FindByUserInRange(userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list body metrics failed: %w", err)
+	}
+	return metrics, nil
+}
+
+func (s *bodyMetricService) GetMetric(userID uint, id uint) (*models.BodyMetric, error) {
+	m, err := s.repo.FindByIDAndUserID(id, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBodyMetricNotFound
+		}
+		return nil, fmt.Errorf("find body metric failed: %w", err)
+	}
+	return m, nil
+}
+
+func (s *bodyMetricService) UpdateMetric(userID uint, id uint, data BodyMetricData) (*models.BodyMetric, error) {
+	m, err := buildBodyMetric(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	m.ID = id
+	if err := s.repo.Update(m); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrBodyMetricNotFound
+		case errors.Is(err, repository.ErrUniqueViolation):
+			return nil, ErrBodyMetricExists
+		}
+		return nil, fmt.Errorf("update body metric failed: %w", err)
+	}
+	return s.GetMetric(userID, id)
+}
+
+func (s *bodyMetricService) DeleteMetric(userID uint, id uint) error {
+	if err := s.repo.Delete(id, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrBodyMetricNotFound
+		}
+		return fmt.Errorf("delete body metric failed: %w", err)
+	}
+	return nil
+}
+
+// ListBodyComposition は from / to の範囲の体組成ログから、体格指数の推移を計測日の昇順で返す。
+func (s *bodyMetricService) ListBodyComposition(userID uint, from, to *time.Time) ([]BodyCompositionPoint, error) {
+	metrics, err := s.ListMetrics(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	height, err := s.repo.GetHeight(userID)
+	if err != nil {
+		return nil, fmt.Errorf("find height failed: %w", err)
+	}
+
+	out := make([]BodyCompositionPoint, 0, len(metrics))
+	for _, m := range metrics {
+		if m.Weight == nil {
+			continue
+		}
+		out = append(out, BodyCompositionPoint{
+			MeasuredOn:      m.MeasuredOn,
+			Weight:          *m.Weight,
+			BodyFatPercent:  m.BodyFatPercent,
+			BodyComposition: models.ComputeBodyComposition(height, m.Weight, m.BodyFatPercent),
+		})
+	}
+	return out, nil
+}
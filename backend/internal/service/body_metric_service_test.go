@@ -0,0 +1,237 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBodyMetricRepo struct {
+	createFn  func(metric *models.BodyMetric) error
+	findFn    func(userID uint, from, to *time.Time) ([]models.BodyMetric, error)
+	findOneFn func(id uint, userID uint) (*models.BodyMetric, error)
+	updateFn  func(metric *models.BodyMetric) error
+	deleteFn  func(id uint, userID uint) error
+	heightFn  func(userID uint) (*float64, error)
+}
+
+func (f *fakeBodyMetricRepo) Create(metric *models.BodyMetric) error {
+	return f.createFn(metric)
+}
+func (f *fakeBodyMetricRepo) FindByUserInRange(userID uint, from, to *time.Time) ([]models.BodyMetric, error) {
+	return f.findFn(userID, from, to)
+}
+func (f *fakeBodyMetricRepo) FindByIDAndUserID(id uint, userID uint) (*models.BodyMetric, error) {
+	return f.findOneFn(id, userID)
+}
+func (f *fakeBodyMetricRepo) Update(metric *models.BodyMetric) error {
+	return f.updateFn(metric)
+}
+func (f *fakeBodyMetricRepo) GetHeight(userID uint) (*float64, error) {
+	return f.heightFn(userID)
+}
+func (f *fakeBodyMetricRepo) Delete(id uint, userID uint) error {
+	return f.deleteFn(id, userID)
+}
+
+func TestBodyMetricService_CreateMetric(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		repo       fakeBodyMetricRepo
+		data       BodyMetricData
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】体重と周囲径を記録できること",
+			repo: fakeBodyMetricRepo{
+				createFn: func(m *models.BodyMetric) error {
+					require.Equal(t, uint(1), m.UserID)
+					require.Equal(t, 70.5, *m.Weight)
+					require.Equal(t, 81.0, *m.Waist)
+					require.Equal(t, "朝食前", m.Note)
+					m.ID = 5
+					return nil
+				},
+			},
+			data: BodyMetricData{MeasuredOn: day, Weight: ptr(70.5), Waist: ptr(81.0), Note: " 朝食前 "},
+		},
+		{
+			name: "【正常系】体脂肪率だけでも記録できること",
+			repo: fakeBodyMetricRepo{
+				createFn: func(m *models.BodyMetric) error { return nil },
+			},
+			data: BodyMetricData{MeasuredOn: day, BodyFatPercent: ptr(18.2)},
+		},
+		{
+			name:    "【異常系】項目が1つも無い場合は ErrEmptyBodyMetric を返すこと",
+			data:    BodyMetricData{MeasuredOn: day, Note: "メモだけ"},
+			wantErr: ErrEmptyBodyMetric,
+		},
+		{
+			name:    "【異常系】体重が0以下の場合は ErrInvalidBodyMetric を返すこと",
+			data:    BodyMetricData{MeasuredOn: day, Weight: ptr(0.0)},
+			wantErr: ErrInvalidBodyMetric,
+		},
+		{
+			name:    "【異常系】体脂肪率が上限を超える場合は ErrInvalidBodyMetric を返すこと",
+			data:    BodyMetricData{MeasuredOn: day, BodyFatPercent: ptr(80.0)},
+			wantErr: ErrInvalidBodyMetric,
+		},
+		{
+			name:    "【異常系】筋肉量が体重を超える場合は ErrInvalidBodyMetric を返すこと",
+			data:    BodyMetricData{MeasuredOn: day, Weight: ptr(60.0), MuscleMass: ptr(61.0)},
+			wantErr: ErrInvalidBodyMetric,
+		},
+		{
+			name:    "【異常系】周囲径が負の場合は ErrInvalidBodyMetric を返すこと",
+			data:    BodyMetricData{MeasuredOn: day, Arm: ptr(-1.0)},
+			wantErr: ErrInvalidBodyMetric,
+		},
+		{
+			name: "【異常系】同じ日の記録がある場合は ErrBodyMetricExists を返すこと",
+			repo: fakeBodyMetricRepo{
+				createFn: func(*models.BodyMetric) error { return repository.ErrUniqueViolation },
+			},
+			data:    BodyMetricData{MeasuredOn: day, Weight: ptr(70.0)},
+			wantErr: ErrBodyMetricExists,
+		},
+		{
+			name: "【異常系】保存に失敗した場合はエラーを返すこと",
+			repo: fakeBodyMetricRepo{
+				createFn: func(*models.BodyMetric) error { return errors.New("db down") },
+			},
+			data:       BodyMetricData{MeasuredOn: day, Weight: ptr(70.0)},
+			wantErrSub: "create body metric failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewBodyMetricService(&tt.repo)
+
+			got, err := svc.CreateMetric(1, tt.data)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, got)
+			case tt.wantErrSub != "":
+				require.ErrorContains(t, err, tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, day, got.MeasuredOn)
+			}
+		})
+	}
+}
+
+func TestBodyMetricService_ListMetrics(t *testing.T) {
+	from := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	svc := NewBodyMetricService(&fakeBodyMetricRepo{
+		findFn: func(userID uint, f, tt *time.Time) ([]models.BodyMetric, error) {
+			return []models.BodyMetric{{ID: 1}}, nil
+		},
+	})
+
+	_, err := svc.ListMetrics(1, &from, &to)
+	require.ErrorIs(t, err, ErrInvalidBodyMetricQuery)
+
+	got, err := svc.ListMetrics(1, &to, &from)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+func TestBodyMetricService_ListBodyComposition(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	metrics := []models.BodyMetric{
+		{MeasuredOn: day(1), Weight: ptr(70.0), BodyFatPercent: ptr(15.0)},
+		{MeasuredOn: day(2), BodyFatPercent: ptr(14.5)}, // 体重なし
+		{MeasuredOn: day(3), Weight: ptr(69.5)},
+	}
+
+	t.Run("【正常系】体重のある日だけ指数を求めること", func(t *testing.T) {
+		svc := NewBodyMetricService(&fakeBodyMetricRepo{
+			findFn:   func(uint, *time.Time, *time.Time) ([]models.BodyMetric, error) { return metrics, nil },
+			heightFn: func(uint) (*float64, error) { return ptr(175.0), nil },
+		})
+
+		got, err := svc.ListBodyComposition(1, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, day(1), got[0].MeasuredOn)
+		require.Equal(t, ptr(22.9), got[0].BMI)
+		require.Equal(t, ptr(19.4), got[0].FFMI)
+		require.Equal(t, day(3), got[1].MeasuredOn)
+		require.Equal(t, ptr(22.7), got[1].BMI)
+		require.Nil(t, got[1].FFMI)
+	})
+
+	t.Run("【正常系】身長が未設定の場合は指数を nil で返すこと", func(t *testing.T) {
+		svc := NewBodyMetricService(&fakeBodyMetricRepo{
+			findFn:   func(uint, *time.Time, *time.Time) ([]models.BodyMetric, error) { return metrics, nil },
+			heightFn: func(uint) (*float64, error) { return nil, nil },
+		})
+
+		got, err := svc.ListBodyComposition(1, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Nil(t, got[0].BMI)
+		require.Equal(t, 70.0, got[0].Weight)
+	})
+}
+
+func TestBodyMetricService_UpdateMetric(t *testing.T) {
+	day := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		updateFn func(m *models.BodyMetric) error
+		wantErr  error
+	}{
+		{
+			name: "【正常系】記録を更新できること",
+			updateFn: func(m *models.BodyMetric) error {
+				require.Equal(t, uint(7), m.ID)
+				require.Equal(t, uint(1), m.UserID)
+				return nil
+			},
+		},
+		{
+			name:     "【異常系】記録が無い場合は ErrBodyMetricNotFound を返すこと",
+			updateFn: func(*models.BodyMetric) error { return repository.ErrNotFound },
+			wantErr:  ErrBodyMetricNotFound,
+		},
+		{
+			name:     "【異常系】別の記録と日付が重なる場合は ErrBodyMetricExists を返すこと",
+			updateFn: func(*models.BodyMetric) error { return repository.ErrUniqueViolation },
+			wantErr:  ErrBodyMetricExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewBodyMetricService(&fakeBodyMetricRepo{
+				updateFn: tt.updateFn,
+				findOneFn: func(id uint, userID uint) (*models.BodyMetric, error) {
+					return &models.BodyMetric{ID: id, UserID: userID, MeasuredOn: day, Weight: ptr(70.0)}, nil
+				},
+			})
+
+			got, err := svc.UpdateMetric(1, 7, BodyMetricData{MeasuredOn: day, Weight: ptr(70.0)})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint(7), got.ID)
+		})
+	}
+}
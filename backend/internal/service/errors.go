@@ -7,13 +7,110 @@ var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidProfile     = errors.New("invalid profile")
 )
 
 // Workoutドメインで利用可能
 var (
-	ErrNoSets                 = errors.New("no sets")
-	ErrInvalidSetValue        = errors.New("invalid set value")
-	ErrExerciseNotFound       = errors.New("exercise not found")
-	ErrRecordNotFound         = errors.New("record not found")
-	ErrForbiddenPrivateRecord = errors.New("forbidden private record")
+	ErrNoSets                  = errors.New("no sets")
+	ErrInvalidSetValue         = errors.New("invalid set value")
+	ErrExerciseNotFound        = errors.New("exercise not found")
+	ErrRecordNotFound          = errors.New("record not found")
+	ErrForbiddenPrivateRecord  = errors.New("forbidden private record")
+	ErrSessionNotFound         = errors.New("session not found")
+	ErrNoExercises             = errors.New("no exercises")
+	ErrInvalidSessionTime      = errors.New("invalid session time")
+	ErrExerciseArchived        = errors.New("exercise archived")
+	ErrInvalidProgressionQuery = errors.New("invalid progression query")
+)
+
+// Exerciseドメインで利用可能
+var (
+	ErrInvalidExerciseName     = errors.New("invalid exercise name")
+	ErrInvalidMeasurementKind  = errors.New("invalid measurement kind")
+	ErrExerciseNameTaken       = errors.New("exercise name already taken")
+	ErrExerciseInUse           = errors.New("exercise has workout records")
+	ErrInvalidExerciseTaxonomy = errors.New("invalid exercise taxonomy")
+)
+
+// PersonalRecordドメインで利用可能
+var (
+	ErrInvalidPersonalRecordType = errors.New("invalid personal record type")
+)
+
+// WorkoutTemplateドメインで利用可能
+var (
+	ErrTemplateNotFound    = errors.New("template not found")
+	ErrInvalidTemplateName = errors.New("invalid template name")
+)
+
+// Programドメインで利用可能
+var (
+	ErrProgramNotFound     = errors.New("program not found")
+	ErrNoActiveProgram     = errors.New("no active program")
+	ErrInvalidProgramLifts = errors.New("invalid program lifts")
+	ErrTrainingMaxUnknown  = errors.New("training max unknown")
+)
+
+// BodyMetricドメインで利用可能
+var (
+	ErrBodyMetricNotFound     = errors.New("body metric not found")
+	ErrBodyMetricExists       = errors.New("body metric already exists for the day")
+	ErrEmptyBodyMetric        = errors.New("empty body metric")
+	ErrInvalidBodyMetric      = errors.New("invalid body metric")
+	ErrInvalidBodyMetricQuery = errors.New("invalid body metric query")
+)
+
+// Goalドメインで利用可能
+var (
+	ErrGoalNotFound     = errors.New("goal not found")
+	ErrInvalidGoal      = errors.New("invalid goal")
+	ErrInvalidGoalQuery = errors.New("invalid goal query")
+)
+
+// Statsドメインで利用可能
+var (
+	ErrInvalidStreakRule = errors.New("invalid streak rule")
+)
+
+// Reportドメインで利用可能
+var (
+	ErrInvalidReportQuery = errors.New("invalid report query")
+)
+
+// Recapドメインで利用可能
+var (
+	ErrRecapNotFound      = errors.New("recap not found")
+	ErrInvalidRecapPeriod = errors.New("invalid recap period")
+	ErrRecapNotFinal      = errors.New("recap period not finished")
+)
+
+// Rankingドメインで利用可能
+var (
+	ErrInvalidRankingExercise = errors.New("exercise is not ranked by weight")
+	ErrInvalidRankingQuery    = errors.New("invalid ranking query")
+)
+
+// Follow・Timelineドメインで利用可能
+var (
+	ErrCannotFollowSelf      = errors.New("cannot follow yourself")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	ErrPrivateAccount        = errors.New("private account")
+	ErrInvalidTimelineScope  = errors.New("invalid timeline scope")
+	ErrInvalidTimelineQuery  = errors.New("invalid timeline query")
+	ErrInvalidTimelineCursor = errors.New("invalid timeline cursor")
+)
+
+// WorkoutCommentドメインで利用可能
+var (
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrInvalidComment      = errors.New("invalid comment")
+	ErrInvalidCommentQuery = errors.New("invalid comment query")
+	ErrForbiddenComment    = errors.New("forbidden comment")
+)
+
+// Notificationドメインで利用可能
+var (
+	ErrNotificationNotFound     = errors.New("notification not found")
+	ErrInvalidNotificationQuery = errors.New("invalid notification query")
 )
@@ -2,20 +2,65 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 )
 
 type ExerciseService interface {
-	List(ctx context.Context) ([]ExerciseDTO, error)
+	List(ctx context.Context, userID uint, filter ExerciseFilter) ([]ExerciseDTO, error)
+	Create(ctx context.Context, userID uint, input ExerciseInput) (*ExerciseDTO, error)
+	Update(ctx context.Context, userID uint, exerciseID uint, name string, isShared bool) (*ExerciseDTO, error)
+	Archive(ctx context.Context, userID uint, exerciseID uint) error
+	Unarchive(ctx context.Context, userID uint, exerciseID uint) error
+	Delete(ctx context.Context, userID uint, exerciseID uint) error
 }
 
 type ExerciseDTO struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID               uint               `json:"id"`
+	Name             string             `json:"name"`
+	MeasurementKind  string             `json:"measurement_kind"`
+	PrimaryMuscle    string             `json:"primary_muscle"`
+	SecondaryMuscles []string           `json:"secondary_muscles"`
+	Equipment        string             `json:"equipment"`
+	MovementPattern  string             `json:"movement_pattern"`
+	Aliases          []ExerciseAliasDTO `json:"aliases"`
+	IsCustom         bool               `json:"is_custom"`
+	IsShared         bool               `json:"is_shared"`
+	Archived         bool               `json:"archived"`
 }
 
+type ExerciseAliasDTO struct {
+	Locale string `json:"locale"`
+	Name   string `json:"name"`
+}
+
+type ExerciseInput struct {
+	Name             string
+	MeasurementKind  models.MeasurementKind
+	PrimaryMuscle    models.MuscleGroup
+	SecondaryMuscles []models.MuscleGroup
+	Equipment        models.Equipment
+	MovementPattern  models.MovementPattern
+	IsShared         bool
+}
+
+// ExerciseFilter は種目一覧の検索条件（Query は種目名・別名の部分一致）
+type ExerciseFilter struct {
+	Query           string
+	MuscleGroup     models.MuscleGroup
+	Equipment       models.Equipment
+	MovementPattern models.MovementPattern
+	IncludeArchived bool
+}
+
+const maxExerciseNameLength = 50
+
 type exerciseService struct {
 	repo repository.ExerciseRepository
 }
@@ -24,19 +69,225 @@ func NewExerciseService(repo repository.ExerciseRepository) ExerciseService {
 	return &exerciseService{repo: repo}
 }
 
-func (s *exerciseService) List(ctx context.Context) ([]ExerciseDTO, error) {
-	rows, err := s.repo.List(ctx)
+func toExerciseDTO(m models.Exercise) ExerciseDTO {
+	secondary := make([]string, 0, len(m.SecondaryMuscles))
+	for _, sm := range m.SecondaryMuscles {
+		secondary = append(secondary, string(sm.MuscleGroup))
+	}
+	aliases := make([]ExerciseAliasDTO, 0, len(m.Aliases))
+	for _, a := range m.Aliases {
+		aliases = append(aliases, ExerciseAliasDTO{Locale: a.Locale, Name: a.Name})
+	}
+	return ExerciseDTO{
+		ID:               m.ID,
+		Name:             m.Name,
+		MeasurementKind:  string(m.MeasurementKind),
+		PrimaryMuscle:    string(m.PrimaryMuscle),
+		SecondaryMuscles: secondary,
+		Equipment:        string(m.Equipment),
+		MovementPattern:  string(m.MovementPattern),
+		Aliases:          aliases,
+		IsCustom:         m.IsCustom(),
+		IsShared:         m.IsShared,
+		Archived:         m.IsArchived(),
+	}
+}
+
+// validateTaxonomy は部位・器具・動作パターンを検証する。空文字は未指定として許可する。
+func validateTaxonomy(muscle models.MuscleGroup, equipment models.Equipment, pattern models.MovementPattern) error {
+	if muscle != "" && !muscle.Valid() {
+		return ErrInvalidExerciseTaxonomy
+	}
+	if equipment != "" && !equipment.Valid() {
+		return ErrInvalidExerciseTaxonomy
+	}
+	if pattern != "" && !pattern.Valid() {
+		return ErrInvalidExerciseTaxonomy
+	}
+	return nil
+}
+
+// toSecondaryMuscles は補助部位を検証し、主働筋・重複を除いたモデルに変換する。
+func toSecondaryMuscles(primary models.MuscleGroup, groups []models.MuscleGroup) ([]models.ExerciseMuscle, error) {
+	seen := map[models.MuscleGroup]bool{primary: true}
+	muscles := make([]models.ExerciseMuscle, 0, len(groups))
+	for _, g := range groups {
+		if !g.Valid() {
+			return nil, ErrInvalidExerciseTaxonomy
+		}
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		muscles = append(muscles, models.ExerciseMuscle{MuscleGroup: g})
+	}
+	return muscles, nil
+}
+
+func normalizeExerciseName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxExerciseNameLength {
+		return "", ErrInvalidExerciseName
+	}
+	return name, nil
+}
+
+func (s *exerciseService) List(ctx context.Context, userID uint, filter ExerciseFilter) ([]ExerciseDTO, error) {
+	if err := validateTaxonomy(filter.MuscleGroup, filter.Equipment, filter.MovementPattern); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.List(ctx, userID, repository.ExerciseFilter{
+		Query:           strings.TrimSpace(filter.Query),
+		MuscleGroup:     filter.MuscleGroup,
+		Equipment:       filter.Equipment,
+		MovementPattern: filter.MovementPattern,
+		IncludeArchived: filter.IncludeArchived,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("種目一覧の取得に失敗しました: %w", err)
 	}
 
 	exercises := make([]ExerciseDTO, 0, len(rows))
 	for _, m := range rows {
-		exercises = append(exercises, ExerciseDTO{
-			ID:   m.ID,
-			Name: m.Name,
-		})
+		exercises = append(exercises, toExerciseDTO(m))
 	}
 
 	return exercises, nil
 }
+
+// ensureNameAvailable は共通種目・自分の独自種目と名前が重複しないことを確認する。
+func (s *exerciseService) ensureNameAvailable(ctx context.Context, userID uint, name string, excludeID uint) error {
+	exists, err := s.repo.NameExists(ctx, userID, name, excludeID)
+	if err != nil {
+		return fmt.Errorf("種目名の確認に失敗しました: %w", err)
+	}
+	if exists {
+		return ErrExerciseNameTaken
+	}
+	return nil
+}
+
+func (s *exerciseService) findOwned(ctx context.Context, userID uint, exerciseID uint) (*models.Exercise, error) {
+	ex, err := s.repo.FindOwnedByID(ctx, exerciseID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("種目の取得に失敗しました: %w", err)
+	}
+	return ex, nil
+}
+
+func (s *exerciseService) Create(ctx context.Context, userID uint, input ExerciseInput) (*ExerciseDTO, error) {
+	name, err := normalizeExerciseName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	kind := input.MeasurementKind
+	if kind == "" {
+		kind = models.MeasurementRepsWeight
+	}
+	if !kind.Valid() {
+		return nil, ErrInvalidMeasurementKind
+	}
+	if err := validateTaxonomy(input.PrimaryMuscle, input.Equipment, input.MovementPattern); err != nil {
+		return nil, err
+	}
+	secondary, err := toSecondaryMuscles(input.PrimaryMuscle, input.SecondaryMuscles)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	ex := &models.Exercise{
+		OwnerID:          &userID,
+		Name:             name,
+		MeasurementKind:  kind,
+		PrimaryMuscle:    input.PrimaryMuscle,
+		SecondaryMuscles: secondary,
+		Equipment:        input.Equipment,
+		MovementPattern:  input.MovementPattern,
+		IsShared:         input.IsShared,
+	}
+	if err := s.repo.Create(ctx, ex); err != nil {
+		if errors.Is(err, repository.ErrUniqueViolation) {
+			return nil, ErrExerciseNameTaken
+		}
+		return nil, fmt.Errorf("種目の作成に失敗しました: %w", err)
+	}
+
+	dto := toExerciseDTO(*ex)
+	return &dto, nil
+}
+
+func (s *exerciseService) Update(ctx context.Context, userID uint, exerciseID uint, name string, isShared bool) (*ExerciseDTO, error) {
+	name, err := normalizeExerciseName(name)
+	if err != nil {
+		return nil, err
+	}
+	ex, err := s.findOwned(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, userID, name, ex.ID); err != nil {
+		return nil, err
+	}
+
+	ex.Name = name
+	ex.IsShared = isShared
+	if err := s.repo.Update(ctx, ex); err != nil {
+		if errors.Is(err, repository.ErrUniqueViolation) {
+			return nil, ErrExerciseNameTaken
+		}
+		return nil, fmt.Errorf("種目の更新に失敗しました: %w", err)
+	}
+
+	dto := toExerciseDTO(*ex)
+	return &dto, nil
+}
+
+// Archive は種目を一覧と新規記録から外す。過去の記録はそのまま残る。
+func (s *exerciseService) Archive(ctx context.Context, userID uint, exerciseID uint) error {
+	if _, err := s.findOwned(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := s.repo.SetArchivedAt(ctx, exerciseID, userID, &now); err != nil {
+		return fmt.Errorf("種目のアーカイブに失敗しました: %w", err)
+	}
+	return nil
+}
+
+func (s *exerciseService) Unarchive(ctx context.Context, userID uint, exerciseID uint) error {
+	if _, err := s.findOwned(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+	if err := s.repo.SetArchivedAt(ctx, exerciseID, userID, nil); err != nil {
+		return fmt.Errorf("種目のアーカイブ解除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Delete は記録が1件も無い独自種目のみ削除する。記録がある場合はアーカイブを使う。
+func (s *exerciseService) Delete(ctx context.Context, userID uint, exerciseID uint) error {
+	if _, err := s.findOwned(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+	used, err := s.repo.HasRecords(ctx, exerciseID)
+	if err != nil {
+		return fmt.Errorf("種目の利用状況の確認に失敗しました: %w", err)
+	}
+	if used {
+		return ErrExerciseInUse
+	}
+	if err := s.repo.Delete(ctx, exerciseID, userID); err != nil {
+		if errors.Is(err, repository.ErrFKViolation) {
+			return ErrExerciseInUse
+		}
+		return fmt.Errorf("種目の削除に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -4,17 +4,54 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 	"github.com/stretchr/testify/require"
 )
 
 type fakeExerciseRepo struct {
-	listFunc func(ctx context.Context) ([]models.Exercise, error)
+	listFunc       func(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error)
+	findOwnedFunc  func(ctx context.Context, id uint, userID uint) (*models.Exercise, error)
+	nameExistsFunc func(ctx context.Context, userID uint, name string, excludeID uint) (bool, error)
+	createFunc     func(ctx context.Context, ex *models.Exercise) error
+	updateFunc     func(ctx context.Context, ex *models.Exercise) error
+	archiveFunc    func(ctx context.Context, id uint, userID uint, archivedAt *time.Time) error
+	deleteFunc     func(ctx context.Context, id uint, userID uint) error
+	hasRecordsFunc func(ctx context.Context, id uint) (bool, error)
 }
 
-func (f *fakeExerciseRepo) List(ctx context.Context) ([]models.Exercise, error) {
-	return f.listFunc(ctx)
+func (f *fakeExerciseRepo) List(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error) {
+	return f.listFunc(ctx, userID, filter)
+}
+func (f *fakeExerciseRepo) FindOwnedByID(ctx context.Context, id uint, userID uint) (*models.Exercise, error) {
+	return f.findOwnedFunc(ctx, id, userID)
+}
+func (f *fakeExerciseRepo) NameExists(ctx context.Context, userID uint, name string, excludeID uint) (bool, error) {
+	if f.nameExistsFunc == nil {
+		return false, nil
+	}
+	return f.nameExistsFunc(ctx, userID, name, excludeID)
+}
+func (f *fakeExerciseRepo) Create(ctx context.Context, ex *models.Exercise) error {
+	return f.createFunc(ctx, ex)
+}
+func (f *fakeExerciseRepo) Update(ctx context.Context, ex *models.Exercise) error {
+	return f.updateFunc(ctx, ex)
+}
+func (f *fakeExerciseRepo) SetArchivedAt(ctx context.Context, id uint, userID uint, archivedAt *time.Time) error {
+	return f.archiveFunc(ctx, id, userID, archivedAt)
+}
+func (f *fakeExerciseRepo) Delete(ctx context.Context, id uint, userID uint) error {
+	return f.deleteFunc(ctx, id, userID)
+}
+func (f *fakeExerciseRepo) HasRecords(ctx context.Context, id uint) (bool, error) {
+	return f.hasRecordsFunc(ctx, id)
+}
+
+func ownedExercise(ctx context.Context, id uint, userID uint) (*models.Exercise, error) {
+	return &models.Exercise{OwnerID: &userID, Name: "マイ種目", MeasurementKind: models.MeasurementRepsWeight}, nil
 }
 
 func TestExerciseService_List(t *testing.T) {
@@ -22,41 +59,103 @@ func TestExerciseService_List(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		filter      ExerciseFilter
 		repo        fakeExerciseRepo
 		wantDTOs    []ExerciseDTO
 		wantErr     bool
+		wantErrIs   error
 		errContains string
 	}{
 		{
 			name: "【正常系】レコードが存在する場合、DTOリストを返すこと",
 			repo: fakeExerciseRepo{
-				listFunc: func(ctx context.Context) ([]models.Exercise, error) {
+				listFunc: func(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error) {
+					require.Equal(t, uint(1), userID)
+					owner := uint(1)
 					return []models.Exercise{
-						{Name: "Bench Press"},
-						{Name: "Squat"},
+						{
+							Name:             "Bench Press",
+							MeasurementKind:  models.MeasurementRepsWeight,
+							PrimaryMuscle:    models.MuscleChest,
+							SecondaryMuscles: []models.ExerciseMuscle{{MuscleGroup: models.MuscleTriceps}},
+							Equipment:        models.EquipmentBarbell,
+							MovementPattern:  models.MovementHorizontalPush,
+							Aliases:          []models.ExerciseAlias{{Locale: "ja", Name: "ベンチプレス"}},
+						},
+						{Name: "Plank", MeasurementKind: models.MeasurementDuration, OwnerID: &owner, IsShared: true},
 					}, nil
 				},
 			},
 			wantDTOs: []ExerciseDTO{
-				{Name: "Bench Press"},
-				{Name: "Squat"},
+				{
+					Name:             "Bench Press",
+					MeasurementKind:  "reps_weight",
+					PrimaryMuscle:    "chest",
+					SecondaryMuscles: []string{"triceps"},
+					Equipment:        "barbell",
+					MovementPattern:  "horizontal_push",
+					Aliases:          []ExerciseAliasDTO{{Locale: "ja", Name: "ベンチプレス"}},
+				},
+				{
+					Name:             "Plank",
+					MeasurementKind:  "duration",
+					SecondaryMuscles: []string{},
+					Aliases:          []ExerciseAliasDTO{},
+					IsCustom:         true,
+					IsShared:         true,
+				},
 			},
 			wantErr: false,
 		},
 		{
 			name: "【正常系】レコードが0件の場合、空スライスを返すこと",
 			repo: fakeExerciseRepo{
-				listFunc: func(ctx context.Context) ([]models.Exercise, error) {
+				listFunc: func(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error) {
 					return []models.Exercise{}, nil
 				},
 			},
 			wantDTOs: []ExerciseDTO{},
 			wantErr:  false,
 		},
+		{
+			name: "【正常系】検索条件が前後の空白を除いてリポジトリに渡されること",
+			filter: ExerciseFilter{
+				Query:           "  ベンチ ",
+				MuscleGroup:     models.MuscleChest,
+				Equipment:       models.EquipmentBarbell,
+				MovementPattern: models.MovementHorizontalPush,
+				IncludeArchived: true,
+			},
+			repo: fakeExerciseRepo{
+				listFunc: func(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error) {
+					require.Equal(t, repository.ExerciseFilter{
+						Query:           "ベンチ",
+						MuscleGroup:     models.MuscleChest,
+						Equipment:       models.EquipmentBarbell,
+						MovementPattern: models.MovementHorizontalPush,
+						IncludeArchived: true,
+					}, filter)
+					return []models.Exercise{}, nil
+				},
+			},
+			wantDTOs: []ExerciseDTO{},
+		},
+		{
+			name:      "【異常系】未知の部位で絞り込んだ場合、ErrInvalidExerciseTaxonomy を返すこと",
+			filter:    ExerciseFilter{MuscleGroup: "wings"},
+			wantErr:   true,
+			wantErrIs: ErrInvalidExerciseTaxonomy,
+		},
+		{
+			name:      "【異常系】未知の器具で絞り込んだ場合、ErrInvalidExerciseTaxonomy を返すこと",
+			filter:    ExerciseFilter{Equipment: "kettlebell"},
+			wantErr:   true,
+			wantErrIs: ErrInvalidExerciseTaxonomy,
+		},
 		{
 			name: "【異常系】リポジトリがエラーを返した場合、エラーが伝搬されること",
 			repo: fakeExerciseRepo{
-				listFunc: func(ctx context.Context) ([]models.Exercise, error) {
+				listFunc: func(ctx context.Context, userID uint, filter repository.ExerciseFilter) ([]models.Exercise, error) {
 					return nil, errors.New("db down")
 				},
 			},
@@ -68,11 +167,14 @@ func TestExerciseService_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := NewExerciseService(&tt.repo)
-			got, err := svc.List(ctx)
+			got, err := svc.List(ctx, 1, tt.filter)
 
 			switch {
 			case tt.wantErr:
 				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					require.ErrorIs(t, err, tt.wantErrIs)
+				}
 				require.Contains(t, err.Error(), tt.errContains)
 				require.Nil(t, got)
 			default:
@@ -82,3 +184,265 @@ func TestExerciseService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestExerciseService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		repo    fakeExerciseRepo
+		input   ExerciseInput
+		wantErr error
+		wantSub string
+	}{
+		{
+			name: "【正常系】独自種目を作成できること（計測方法の既定は回数×重量）",
+			repo: fakeExerciseRepo{
+				createFunc: func(ctx context.Context, ex *models.Exercise) error {
+					require.Equal(t, uint(1), *ex.OwnerID)
+					require.Equal(t, "ブルガリアンスクワット", ex.Name)
+					require.Equal(t, models.MeasurementRepsWeight, ex.MeasurementKind)
+					ex.ID = 10
+					return nil
+				},
+			},
+			input: ExerciseInput{Name: "  ブルガリアンスクワット "},
+		},
+		{
+			name: "【正常系】部位・器具・動作パターンを指定でき、主働筋と重複する補助部位は除外されること",
+			repo: fakeExerciseRepo{
+				createFunc: func(ctx context.Context, ex *models.Exercise) error {
+					require.Equal(t, models.MuscleQuads, ex.PrimaryMuscle)
+					require.Equal(t, models.EquipmentDumbbell, ex.Equipment)
+					require.Equal(t, models.MovementLunge, ex.MovementPattern)
+					require.Equal(t, []models.ExerciseMuscle{
+						{MuscleGroup: models.MuscleGlutes},
+						{MuscleGroup: models.MuscleHamstrings},
+					}, ex.SecondaryMuscles)
+					ex.ID = 10
+					return nil
+				},
+			},
+			input: ExerciseInput{
+				Name:             "ブルガリアンスクワット",
+				PrimaryMuscle:    models.MuscleQuads,
+				SecondaryMuscles: []models.MuscleGroup{models.MuscleGlutes, models.MuscleQuads, models.MuscleHamstrings, models.MuscleGlutes},
+				Equipment:        models.EquipmentDumbbell,
+				MovementPattern:  models.MovementLunge,
+			},
+		},
+		{
+			name:    "【異常系】補助部位が不正な場合は ErrInvalidExerciseTaxonomy を返すこと",
+			repo:    fakeExerciseRepo{},
+			input:   ExerciseInput{Name: "種目", SecondaryMuscles: []models.MuscleGroup{"wings"}},
+			wantErr: ErrInvalidExerciseTaxonomy,
+		},
+		{
+			name:    "【異常系】動作パターンが不正な場合は ErrInvalidExerciseTaxonomy を返すこと",
+			repo:    fakeExerciseRepo{},
+			input:   ExerciseInput{Name: "種目", MovementPattern: "jump"},
+			wantErr: ErrInvalidExerciseTaxonomy,
+		},
+		{
+			name:    "【異常系】種目名が空の場合は ErrInvalidExerciseName を返すこと",
+			repo:    fakeExerciseRepo{},
+			input:   ExerciseInput{Name: "   "},
+			wantErr: ErrInvalidExerciseName,
+		},
+		{
+			name:    "【異常系】計測方法が不正な場合は ErrInvalidMeasurementKind を返すこと",
+			repo:    fakeExerciseRepo{},
+			input:   ExerciseInput{Name: "種目", MeasurementKind: "calories"},
+			wantErr: ErrInvalidMeasurementKind,
+		},
+		{
+			name: "【異常系】共通種目・自分の種目と同名の場合は ErrExerciseNameTaken を返すこと",
+			repo: fakeExerciseRepo{
+				nameExistsFunc: func(context.Context, uint, string, uint) (bool, error) { return true, nil },
+			},
+			input:   ExerciseInput{Name: "ベンチプレス"},
+			wantErr: ErrExerciseNameTaken,
+		},
+		{
+			name: "【異常系】一意制約違反は ErrExerciseNameTaken に変換されること",
+			repo: fakeExerciseRepo{
+				createFunc: func(context.Context, *models.Exercise) error { return repository.ErrUniqueViolation },
+			},
+			input:   ExerciseInput{Name: "種目"},
+			wantErr: ErrExerciseNameTaken,
+		},
+		{
+			name: "【異常系】その他の repo エラーは wrap されて返すこと",
+			repo: fakeExerciseRepo{
+				createFunc: func(context.Context, *models.Exercise) error { return errors.New("db down") },
+			},
+			input:   ExerciseInput{Name: "種目"},
+			wantSub: "種目の作成に失敗しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewExerciseService(&tt.repo)
+			got, err := svc.Create(ctx, 1, tt.input)
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantSub)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, uint(10), got.ID)
+				require.True(t, got.IsCustom)
+			}
+		})
+	}
+}
+
+func TestExerciseService_Update(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		repo    fakeExerciseRepo
+		wantErr error
+	}{
+		{
+			name: "【正常系】名前と共有設定を変更できること",
+			repo: fakeExerciseRepo{
+				findOwnedFunc: ownedExercise,
+				nameExistsFunc: func(ctx context.Context, userID uint, name string, excludeID uint) (bool, error) {
+					require.Equal(t, "新しい名前", name)
+					return false, nil
+				},
+				updateFunc: func(ctx context.Context, ex *models.Exercise) error {
+					require.Equal(t, "新しい名前", ex.Name)
+					require.True(t, ex.IsShared)
+					return nil
+				},
+			},
+		},
+		{
+			name: "【異常系】他人の種目・共通種目は ErrExerciseNotFound を返すこと",
+			repo: fakeExerciseRepo{
+				findOwnedFunc: func(context.Context, uint, uint) (*models.Exercise, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "【異常系】同名の種目がある場合は ErrExerciseNameTaken を返すこと",
+			repo: fakeExerciseRepo{
+				findOwnedFunc:  ownedExercise,
+				nameExistsFunc: func(context.Context, uint, string, uint) (bool, error) { return true, nil },
+			},
+			wantErr: ErrExerciseNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewExerciseService(&tt.repo)
+			got, err := svc.Update(ctx, 1, 5, "新しい名前", true)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "新しい名前", got.Name)
+		})
+	}
+}
+
+func TestExerciseService_ArchiveAndUnarchive(t *testing.T) {
+	ctx := context.Background()
+
+	var archivedAt *time.Time
+	repo := &fakeExerciseRepo{
+		findOwnedFunc: ownedExercise,
+		archiveFunc: func(ctx context.Context, id uint, userID uint, at *time.Time) error {
+			archivedAt = at
+			return nil
+		},
+	}
+	svc := NewExerciseService(repo)
+
+	require.NoError(t, svc.Archive(ctx, 1, 5))
+	require.NotNil(t, archivedAt)
+
+	require.NoError(t, svc.Unarchive(ctx, 1, 5))
+	require.Nil(t, archivedAt)
+
+	repo.findOwnedFunc = func(context.Context, uint, uint) (*models.Exercise, error) { return nil, repository.ErrNotFound }
+	require.ErrorIs(t, svc.Archive(ctx, 2, 5), ErrExerciseNotFound)
+}
+
+func TestExerciseService_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		repo       fakeExerciseRepo
+		wantErr    error
+		wantDelete bool
+	}{
+		{
+			name: "【正常系】記録の無い独自種目は削除できること",
+			repo: fakeExerciseRepo{
+				findOwnedFunc:  ownedExercise,
+				hasRecordsFunc: func(context.Context, uint) (bool, error) { return false, nil },
+				deleteFunc:     func(context.Context, uint, uint) error { return nil },
+			},
+			wantDelete: true,
+		},
+		{
+			name: "【異常系】記録がある種目は ErrExerciseInUse を返し削除しないこと",
+			repo: fakeExerciseRepo{
+				findOwnedFunc:  ownedExercise,
+				hasRecordsFunc: func(context.Context, uint) (bool, error) { return true, nil },
+			},
+			wantErr: ErrExerciseInUse,
+		},
+		{
+			name: "【異常系】削除時の FK 違反は ErrExerciseInUse に変換されること",
+			repo: fakeExerciseRepo{
+				findOwnedFunc:  ownedExercise,
+				hasRecordsFunc: func(context.Context, uint) (bool, error) { return false, nil },
+				deleteFunc:     func(context.Context, uint, uint) error { return repository.ErrFKViolation },
+			},
+			wantErr:    ErrExerciseInUse,
+			wantDelete: true,
+		},
+		{
+			name: "【異常系】自分の種目でない場合は ErrExerciseNotFound を返すこと",
+			repo: fakeExerciseRepo{
+				findOwnedFunc: func(context.Context, uint, uint) (*models.Exercise, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			if tt.repo.deleteFunc != nil {
+				inner := tt.repo.deleteFunc
+				tt.repo.deleteFunc = func(ctx context.Context, id uint, userID uint) error {
+					deleted = true
+					return inner(ctx, id, userID)
+				}
+			}
+			svc := NewExerciseService(&tt.repo)
+			err := svc.Delete(ctx, 1, 5)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantDelete, deleted)
+		})
+	}
+}
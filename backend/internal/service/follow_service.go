@@ -0,0 +1,231 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// FollowService はフォローの管理。非公開アカウントへのフォローは承認されるまで pending で、
+// 承認前はタイムラインに記録が表示されない。
+type FollowService interface {
+	Follow(userID uint, targetID uint) (models.FollowStatus, error)
+	Unfollow(userID uint, targetID uint) error
+	ListFollowers(viewerID uint, userID uint) ([]FollowUser, error)
+	ListFollowing(viewerID uint, userID uint) ([]FollowUser, error)
+	GetFollowSummary(viewerID uint, userID uint) (*FollowSummary, error)
+	ListFollowRequests(userID uint) ([]FollowUser, error)
+	ApproveFollowRequest(userID uint, followerID uint) error
+	RejectFollowRequest(userID uint, followerID uint) error
+	UpdatePrivacy(userID uint, isPrivate bool) error
+}
+
+type FollowUser struct {
+	UserID     uint
+	Email      string
+	FollowedAt time.Time
+}
+
+// FollowSummary はユーザーのフォロー数と、閲覧者からのフォローの状態（MyStatus、未フォローなら nil）。
+type FollowSummary struct {
+	UserID         uint
+	IsPrivate      bool
+	FollowersCount int64
+	FollowingCount int64
+	MyStatus       *models.FollowStatus
+}
+
+type followService struct {
+	repo repository.FollowRepository
+}
+
+func NewFollowService(repo repository.FollowRepository) FollowService {
+	return &followService{repo: repo}
+}
+
+func (s *followService) findUser(userID uint) (*models.User, error) {
+	user, err := s.repo.FindUser(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user failed: %w", err)
+	}
+	return user, nil
+}
+
+// Follow は targetID をフォローし、フォローの状態を返す。既にフォロー（申請）している場合はその状態を返す。
+func (s *followService) Follow(userID uint, targetID uint) (models.FollowStatus, error) {
+	if userID == targetID {
+		return "", ErrCannotFollowSelf
+	}
+	target, err := s.findUser(targetID)
+	if err != nil {
+		return "", err
+	}
+
+	follow := &models.Follow{
+		FollowerID: userID,
+		FolloweeID: targetID,
+		Status:     models.FollowAccepted,
+	}
+	if target.IsPrivate {
+		follow.Status = models.FollowPending
+	}
+
+	if err := s.repo.CreateFollow(follow); err != nil {
+		if errors.Is(err, repository.ErrUniqueViolation) {
+			existing, err := s.repo.FindFollow(userID, targetID)
+			if err != nil {
+				return "", fmt.Errorf("find follow failed: %w", err)
+			}
+			return existing.Status, nil
+		}
+		return "", fmt.Errorf("create follow failed: %w", err)
+	}
+	return follow.Status, nil
+}
+
+// Unfollow はフォロー（承認待ちの申請を含む）を取り消す。フォローしていない場合も成功扱い。
+func (s *followService) Unfollow(userID uint, targetID uint) error {
+	if err := s.repo.DeleteFollow(userID, targetID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("delete follow failed: %w", err)
+	}
+	return nil
+}
+
+// canView は viewerID が userID のフォロー関係を見られるか。非公開アカウントは本人と承認済みのフォロワーのみ。
+func (s *followService) canView(viewerID uint, user *models.User) (bool, error) {
+	if !user.IsPrivate || viewerID == user.ID {
+		return true, nil
+	}
+	follow, err := s.repo.FindFollow(viewerID, user.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("find follow failed: %w", err)
+	}
+	return follow.Status == models.FollowAccepted, nil
+}
+
+func (s *followService) listVisible(viewerID uint, userID uint, list func(uint) ([]repository.FollowUser, error)) ([]FollowUser, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.canView(viewerID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrPrivateAccount
+	}
+
+	rows, err := list(userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch follows failed: %w", err)
+	}
+	return toFollowUsers(rows), nil
+}
+
+func (s *followService) ListFollowers(viewerID uint, userID uint) ([]FollowUser, error) {
+	return s.listVisible(viewerID, userID, func(id uint) ([]repository.FollowUser, error) {
+		return s.repo.FindFollowers(id, models.FollowAccepted)
+	})
+}
+
+func (s *followService) ListFollowing(viewerID uint, userID uint) ([]FollowUser, error) {
+	return s.listVisible(viewerID, userID, s.repo.FindFollowing)
+}
+
+func (s *followService) GetFollowSummary(viewerID uint, userID uint) (*FollowSummary, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	followers, following, err := s.repo.CountFollows(userID)
+	if err != nil {
+		return nil, fmt.Errorf("count follows failed: %w", err)
+	}
+
+	summary := &FollowSummary{
+		UserID:         user.ID,
+		IsPrivate:      user.IsPrivate,
+		FollowersCount: followers,
+		FollowingCount: following,
+	}
+	if viewerID != userID {
+		follow, err := s.repo.FindFollow(viewerID, userID)
+		switch {
+		case err == nil:
+			summary.MyStatus = &follow.Status
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, fmt.Errorf("find follow failed: %w", err)
+		}
+	}
+	return summary, nil
+}
+
+// ListFollowRequests は自分への承認待ちのフォロー申請を新しい順に返す。
+func (s *followService) ListFollowRequests(userID uint) ([]FollowUser, error) {
+	rows, err := s.repo.FindFollowers(userID, models.FollowPending)
+	if err != nil {
+		return nil, fmt.Errorf("fetch follow requests failed: %w", err)
+	}
+	return toFollowUsers(rows), nil
+}
+
+func (s *followService) ApproveFollowRequest(userID uint, followerID uint) error {
+	if err := s.repo.AcceptFollow(followerID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrFollowRequestNotFound
+		}
+		return fmt.Errorf("accept follow failed: %w", err)
+	}
+	return nil
+}
+
+// RejectFollowRequest は承認待ちの申請を削除する。承認済みのフォローは対象外。
+func (s *followService) RejectFollowRequest(userID uint, followerID uint) error {
+	follow, err := s.repo.FindFollow(followerID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrFollowRequestNotFound
+		}
+		return fmt.Errorf("find follow failed: %w", err)
+	}
+	if follow.Status != models.FollowPending {
+		return ErrFollowRequestNotFound
+	}
+
+	if err := s.repo.DeleteFollow(followerID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrFollowRequestNotFound
+		}
+		return fmt.Errorf("delete follow failed: %w", err)
+	}
+	return nil
+}
+
+// UpdatePrivacy はアカウントの公開設定を変更する。公開に戻すと承認待ちの申請はすべて承認される。
+func (s *followService) UpdatePrivacy(userID uint, isPrivate bool) error {
+	if err := s.repo.UpdatePrivacy(userID, isPrivate); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("update privacy failed: %w", err)
+	}
+	return nil
+}
+
+func toFollowUsers(rows []repository.FollowUser) []FollowUser {
+	out := make([]FollowUser, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, FollowUser{UserID: r.UserID, Email: r.Email, FollowedAt: r.FollowedAt})
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFollowRepo struct {
+	users   map[uint]*models.User
+	follows map[[2]uint]*models.Follow
+}
+
+func newFakeFollowRepo(users ...*models.User) *fakeFollowRepo {
+	f := &fakeFollowRepo{users: map[uint]*models.User{}, follows: map[[2]uint]*models.Follow{}}
+	for _, u := range users {
+		f.users[u.ID] = u
+	}
+	return f
+}
+
+func (f *fakeFollowRepo) FindUser(userID uint) (*models.User, error) {
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return u, nil
+}
+func (f *fakeFollowRepo) FindFollow(followerID uint, followeeID uint) (*models.Follow, error) {
+	fl, ok := f.follows[[2]uint{followerID, followeeID}]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return fl, nil
+}
+func (f *fakeFollowRepo) CreateFollow(follow *models.Follow) error {
+	key := [2]uint{follow.FollowerID, follow.FolloweeID}
+	if _, ok := f.follows[key]; ok {
+		return repository.ErrUniqueViolation
+	}
+	f.follows[key] = follow
+	return nil
+}
+func (f *fakeFollowRepo) DeleteFollow(followerID uint, followeeID uint) error {
+	key := [2]uint{followerID, followeeID}
+	if _, ok := f.follows[key]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(f.follows, key)
+	return nil
+}
+func (f *fakeFollowRepo) AcceptFollow(followerID uint, followeeID uint) error {
+	fl, ok := f.follows[[2]uint{followerID, followeeID}]
+	if !ok || fl.Status != models.FollowPending {
+		return repository.ErrNotFound
+	}
+	fl.Status = models.FollowAccepted
+	return nil
+}
+func (f *fakeFollowRepo) FindFollowers(userID uint, status models.FollowStatus) ([]repository.FollowUser, error) {
+	var out []repository.FollowUser
+	for key, fl := range f.follows {
+		if key[1] == userID && fl.Status == status {
+			out = append(out, repository.FollowUser{UserID: key[0], Email: f.users[key[0]].Email, FollowedAt: time.Now()})
+		}
+	}
+	return out, nil
+}
+func (f *fakeFollowRepo) FindFollowing(userID uint) ([]repository.FollowUser, error) {
+	var out []repository.FollowUser
+	for key, fl := range f.follows {
+		if key[0] == userID && fl.Status == models.FollowAccepted {
+			out = append(out, repository.FollowUser{UserID: key[1], Email: f.users[key[1]].Email})
+		}
+	}
+	return out, nil
+}
+func (f *fakeFollowRepo) CountFollows(userID uint) (int64, int64, error) {
+	followers, _ := f.FindFollowers(userID, models.FollowAccepted)
+	following, _ := f.FindFollowing(userID)
+	return int64(len(followers)), int64(len(following)), nil
+}
+func (f *fakeFollowRepo) UpdatePrivacy(userID uint, isPrivate bool) error {
+	u, ok := f.users[userID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.IsPrivate = isPrivate
+	return nil
+}
+
+func followTestUsers() (*models.User, *models.User, *models.User) {
+	me := &models.User{Email: "me@example.com"}
+	me.ID = 1
+	public := &models.User{Email: "public@example.com"}
+	public.ID = 2
+	private := &models.User{Email: "private@example.com", IsPrivate: true}
+	private.ID = 3
+	return me, public, private
+}
+
+func TestFollowService_Follow(t *testing.T) {
+	me, public, private := followTestUsers()
+
+	tests := []struct {
+		name       string
+		targetID   uint
+		wantStatus models.FollowStatus
+		wantErr    error
+	}{
+		{name: "【正常系】公開アカウントはすぐにフォローできること", targetID: public.ID, wantStatus: models.FollowAccepted},
+		{name: "【正常系】非公開アカウントへのフォローは承認待ちになること", targetID: private.ID, wantStatus: models.FollowPending},
+		{name: "【異常系】自分自身は ErrCannotFollowSelf を返すこと", targetID: me.ID, wantErr: ErrCannotFollowSelf},
+		{name: "【異常系】存在しないユーザーは ErrUserNotFound を返すこと", targetID: 99, wantErr: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewFollowService(newFakeFollowRepo(me, public, private))
+			got, err := svc.Follow(me.ID, tt.targetID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, got)
+
+			again, err := svc.Follow(me.ID, tt.targetID)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, again)
+		})
+	}
+}
+
+func TestFollowService_PrivateAccount(t *testing.T) {
+	me, public, private := followTestUsers()
+	repo := newFakeFollowRepo(me, public, private)
+	svc := NewFollowService(repo)
+
+	_, err := svc.Follow(me.ID, private.ID)
+	require.NoError(t, err)
+	_, err = svc.Follow(public.ID, private.ID)
+	require.NoError(t, err)
+
+	t.Run("【異常系】承認前は非公開アカウントのフォロー一覧を見られないこと", func(t *testing.T) {
+		_, err := svc.ListFollowers(me.ID, private.ID)
+		require.ErrorIs(t, err, ErrPrivateAccount)
+
+		summary, err := svc.GetFollowSummary(me.ID, private.ID)
+		require.NoError(t, err)
+		require.Equal(t, models.FollowPending, *summary.MyStatus)
+		require.Equal(t, int64(0), summary.FollowersCount)
+	})
+
+	t.Run("【正常系】本人は承認待ちの申請を一覧して承認・却下できること", func(t *testing.T) {
+		requests, err := svc.ListFollowRequests(private.ID)
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+
+		require.NoError(t, svc.ApproveFollowRequest(private.ID, me.ID))
+		require.NoError(t, svc.RejectFollowRequest(private.ID, public.ID))
+		_, err = repo.FindFollow(public.ID, private.ID)
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("【正常系】承認後は非公開アカウントのフォロー一覧を見られること", func(t *testing.T) {
+		followers, err := svc.ListFollowers(me.ID, private.ID)
+		require.NoError(t, err)
+		require.Len(t, followers, 1)
+		require.Equal(t, me.ID, followers[0].UserID)
+	})
+
+	t.Run("【異常系】承認済みのフォローは却下できないこと", func(t *testing.T) {
+		require.ErrorIs(t, svc.RejectFollowRequest(private.ID, me.ID), ErrFollowRequestNotFound)
+		require.ErrorIs(t, svc.ApproveFollowRequest(private.ID, me.ID), ErrFollowRequestNotFound)
+	})
+
+	t.Run("【正常系】フォローしていない相手の取り消しも成功扱いにすること", func(t *testing.T) {
+		require.NoError(t, svc.Unfollow(me.ID, public.ID))
+		require.NoError(t, svc.Unfollow(me.ID, private.ID))
+		_, err := repo.FindFollow(me.ID, private.ID)
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("【異常系】存在しないユーザーの公開設定は ErrUserNotFound を返すこと", func(t *testing.T) {
+		require.ErrorIs(t, svc.UpdatePrivacy(99, true), ErrUserNotFound)
+	})
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type GoalService interface {
+	CreateGoal(userID uint, data GoalData, today time.Time) (*EvaluatedGoal, error)
+	ListGoals(userID uint, status models.GoalStatus, today time.Time) ([]EvaluatedGoal, error)
+	GetGoal(userID uint, id uint, today time.Time) (*EvaluatedGoal, error)
+	UpdateGoal(userID uint, id uint, data GoalData, today time.Time) (*EvaluatedGoal, error)
+	DeleteGoal(userID uint, id uint) error
+}
+
+// GoalData は目標の入力内容。StartsOn が zero の場合は今日から数える。
+type GoalData struct {
+	Type        models.GoalType
+	ExerciseID  *uint
+	TargetValue float64
+	StartsOn    time.Time
+	Deadline    *time.Time
+}
+
+// EvaluatedGoal は目標と today 時点の状態・進み具合。ProgressPercent は 100 を上限とする。
+type EvaluatedGoal struct {
+	Goal            models.Goal
+	Status          models.GoalStatus
+	CurrentValue    float64
+	ProgressPercent float64
+}
+
+// 目標値の上限（kg / 倍率）
+const (
+	maxGoalLiftWeight = 1000
+	maxGoalMultiple   = 10
+)
+
+type goalService struct {
+	repo repository.GoalRepository
+}
+
+func NewGoalService(repo repository.GoalRepository) GoalService {
+	return &goalService{repo: repo}
+}
+
+// buildGoal は入力を検証してモデルに変換する。
+func (s *goalService) buildGoal(userID uint, data GoalData, today time.Time) (*models.Goal, error) {
+	if !data.Type.Valid() || data.TargetValue <= 0 {
+		return nil, ErrInvalidGoal
+	}
+	startsOn := data.StartsOn
+	if startsOn.IsZero() {
+		startsOn = today
+	}
+	if data.Deadline != nil && data.Deadline.Before(startsOn) {
+		return nil, ErrInvalidGoal
+	}
+
+	goal := &models.Goal{
+		UserID:      userID,
+		Type:        data.Type,
+		TargetValue: data.TargetValue,
+		StartsOn:    startsOn,
+		Deadline:    data.Deadline,
+	}
+
+	if !data.Type.NeedsExercise() {
+		// 日数の目標は期間が決まっていないと判定できない
+		if data.ExerciseID != nil || data.Deadline == nil {
+			return nil, ErrInvalidGoal
+		}
+		days := data.Deadline.Sub(startsOn).Hours()/24 + 1
+		if data.TargetValue != math.Trunc(data.TargetValue) || data.TargetValue > days {
+			return nil, ErrInvalidGoal
+		}
+		return goal, nil
+	}
+
+	if data.ExerciseID == nil || *data.ExerciseID == 0 {
+		return nil, ErrExerciseNotFound
+	}
+	limit := float64(maxGoalLiftWeight)
+	if data.Type == models.GoalBodyweightMultiple {
+		limit = maxGoalMultiple
+	}
+	if data.TargetValue > limit {
+		return nil, ErrInvalidGoal
+	}
+
+	ex, err := s.repo.FindUsableExercise(userID, *data.ExerciseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("find exercise failed: %w", err)
+	}
+	// 重量で判定するため、重量を記録する種目に限る
+	if ex.MeasurementKind != models.MeasurementRepsWeight && ex.MeasurementKind != models.MeasurementBodyweightLoad {
+		return nil, ErrInvalidGoal
+	}
+	goal.ExerciseID = data.ExerciseID
+	return goal, nil
+}
+
+func (s *goalService) evaluate(goal models.Goal, today time.Time) (*EvaluatedGoal, error) {
+	value, err := s.repo.CurrentValue(goal)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate goal failed: %w", err)
+	}
+	return &EvaluatedGoal{
+		Goal:            goal,
+		Status:          goal.Status(today),
+		CurrentValue:    round2(value),
+		ProgressPercent: round2(math.Min(100, value/goal.TargetValue*100)),
+	}, nil
+}
+
+func (s *goalService) CreateGoal(userID uint, data GoalData, today time.Time) (*EvaluatedGoal, error) {
+	goal, err := s.buildGoal(userID, data, today)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(goal); err != nil {
+		return nil, fmt.Errorf("create goal failed: %w", err)
+	}
+	return s.GetGoal(userID, goal.ID, today)
+}
+
+// ListGoals は status が空なら全ての目標を、指定があればその状態の目標だけを返す。
+func (s *goalService) ListGoals(userID uint, status models.GoalStatus, today time.Time) ([]EvaluatedGoal, error) {
+	if status != "" && !status.Valid() {
+		return nil, ErrInvalidGoalQuery
+	}
+	goals, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("list goals failed: %w", err)
+	}
+
+	out := make([]EvaluatedGoal, 0, len(goals))
+	for _, g := range goals {
+		if status != "" && g.Status(today) != status {
+			continue
+		}
+		eg, err := s.evaluate(g, today)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *eg)
+	}
+	return out, nil
+}
+
+func (s *goalService) GetGoal(userID uint, id uint, today time.Time) (*EvaluatedGoal, error) {
+	goal, err := s.repo.FindByIDAndUserID(id, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, fmt.Errorf("find goal failed: %w", err)
+	}
+	return s.evaluate(*goal, today)
+}
+
+func (s *goalService) UpdateGoal(userID uint, id uint, data GoalData, today time.Time) (*EvaluatedGoal, error) {
+	goal, err := s.buildGoal(userID, data, today)
+	if err != nil {
+		return nil, err
+	}
+	goal.ID = id
+	if err := s.repo.Update(goal); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, fmt.Errorf("update goal failed: %w", err)
+	}
+	return s.GetGoal(userID, id, today)
+}
+
+func (s *goalService) DeleteGoal(userID uint, id uint) error {
+	if err := s.repo.Delete(id, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrGoalNotFound
+		}
+		return fmt.Errorf("delete goal failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGoalRepo struct {
+	createFn   func(goal *models.Goal) error
+	findFn     func(userID uint) ([]models.Goal, error)
+	findOneFn  func(id uint, userID uint) (*models.Goal, error)
+	updateFn   func(goal *models.Goal) error
+	deleteFn   func(id uint, userID uint) error
+	valueFn    func(goal models.Goal) (float64, error)
+	exerciseFn func(userID uint, exerciseID uint) (*models.Exercise, error)
+}
+
+func (f *fakeGoalRepo) Create(goal *models.Goal) error { return f.createFn(goal) }
+func (f *fakeGoalRepo) FindByUser(userID uint) ([]models.Goal, error) {
+	return f.findFn(userID)
+}
+func (f *fakeGoalRepo) FindByIDAndUserID(id uint, userID uint) (*models.Goal, error) {
+	return f.findOneFn(id, userID)
+}
+func (f *fakeGoalRepo) Update(goal *models.Goal) error { return f.updateFn(goal) }
+func (f *fakeGoalRepo) Delete(id uint, userID uint) error {
+	return f.deleteFn(id, userID)
+}
+func (f *fakeGoalRepo) CurrentValue(goal models.Goal) (float64, error) {
+	return f.valueFn(goal)
+}
+func (f *fakeGoalRepo) FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error) {
+	return f.exerciseFn(userID, exerciseID)
+}
+
+func TestGoalService_CreateGoal(t *testing.T) {
+	today := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+	monthEnd := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+	exercises := func(_ uint, id uint) (*models.Exercise, error) {
+		switch id {
+		case 1:
+			return &models.Exercise{MeasurementKind: models.MeasurementRepsWeight}, nil
+		case 2:
+			return &models.Exercise{MeasurementKind: models.MeasurementDuration}, nil
+		}
+		return nil, repository.ErrNotFound
+	}
+
+	tests := []struct {
+		name         string
+		data         GoalData
+		wantErr      error
+		wantStartsOn time.Time
+	}{
+		{
+			name:         "【正常系】重量の目標は開始日を省略すると今日から数えること",
+			data:         GoalData{Type: models.GoalLiftWeight, ExerciseID: ptr(uint(1)), TargetValue: 100, Deadline: &monthEnd},
+			wantStartsOn: today,
+		},
+		{
+			name:         "【正常系】期間内の日数の目標を作成できること",
+			data:         GoalData{Type: models.GoalTrainingDays, TargetValue: 12, StartsOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), Deadline: &monthEnd},
+			wantStartsOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "【異常系】未知の種類は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: "weekly_volume", TargetValue: 1},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】日数の目標で期限が無い場合は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: models.GoalTrainingDays, TargetValue: 12},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】日数の目標が期間の日数を超える場合は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: models.GoalTrainingDays, TargetValue: 18, Deadline: &monthEnd},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】期限が開始日より前の場合は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: models.GoalLiftWeight, ExerciseID: ptr(uint(1)), TargetValue: 100, Deadline: ptr(today.AddDate(0, 0, -1))},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】体重の倍率が上限を超える場合は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: models.GoalBodyweightMultiple, ExerciseID: ptr(uint(1)), TargetValue: 20},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】重量を記録しない種目は ErrInvalidGoal を返すこと",
+			data:    GoalData{Type: models.GoalLiftWeight, ExerciseID: ptr(uint(2)), TargetValue: 60},
+			wantErr: ErrInvalidGoal,
+		},
+		{
+			name:    "【異常系】種目が無い場合は ErrExerciseNotFound を返すこと",
+			data:    GoalData{Type: models.GoalLiftWeight, ExerciseID: ptr(uint(9)), TargetValue: 60},
+			wantErr: ErrExerciseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved models.Goal
+			svc := NewGoalService(&fakeGoalRepo{
+				exerciseFn: exercises,
+				createFn: func(g *models.Goal) error {
+					g.ID = 4
+					saved = *g
+					return nil
+				},
+				findOneFn: func(id uint, userID uint) (*models.Goal, error) {
+					return &saved, nil
+				},
+				valueFn: func(models.Goal) (float64, error) { return 6, nil },
+			})
+
+			got, err := svc.CreateGoal(1, tt.data, today)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint(1), saved.UserID)
+			require.Equal(t, tt.wantStartsOn, saved.StartsOn)
+			require.Equal(t, models.GoalActive, got.Status)
+			require.Equal(t, 6.0, got.CurrentValue)
+		})
+	}
+}
+
+func TestGoalService_ListGoals(t *testing.T) {
+	today := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+	achievedAt := today.Add(-time.Hour)
+	svc := NewGoalService(&fakeGoalRepo{
+		findFn: func(uint) ([]models.Goal, error) {
+			return []models.Goal{
+				{ID: 1, Type: models.GoalLiftWeight, TargetValue: 100, Deadline: ptr(today.AddDate(0, 0, -1))},
+				{ID: 2, Type: models.GoalLiftWeight, TargetValue: 100, AchievedAt: &achievedAt},
+				{ID: 3, Type: models.GoalLiftWeight, TargetValue: 100},
+			}, nil
+		},
+		valueFn: func(g models.Goal) (float64, error) { return 50 * float64(g.ID), nil },
+	})
+
+	t.Run("【正常系】状態と進捗率（上限100）を付けて返すこと", func(t *testing.T) {
+		got, err := svc.ListGoals(1, "", today)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		require.Equal(t, models.GoalExpired, got[0].Status)
+		require.Equal(t, 50.0, got[0].ProgressPercent)
+		require.Equal(t, models.GoalAchieved, got[1].Status)
+		require.Equal(t, models.GoalActive, got[2].Status)
+		require.Equal(t, 100.0, got[2].ProgressPercent)
+	})
+
+	t.Run("【正常系】状態で絞り込めること", func(t *testing.T) {
+		got, err := svc.ListGoals(1, models.GoalActive, today)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, uint(3), got[0].Goal.ID)
+	})
+
+	t.Run("【異常系】未知の状態は ErrInvalidGoalQuery を返すこと", func(t *testing.T) {
+		_, err := svc.ListGoals(1, "done", today)
+		require.ErrorIs(t, err, ErrInvalidGoalQuery)
+	})
+}
+
+func TestGoalService_UpdateAndDeleteNotFound(t *testing.T) {
+	svc := NewGoalService(&fakeGoalRepo{
+		updateFn: func(*models.Goal) error { return repository.ErrNotFound },
+		deleteFn: func(uint, uint) error { return repository.ErrNotFound },
+	})
+	deadline := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := svc.UpdateGoal(1, 9, GoalData{Type: models.GoalTrainingDays, TargetValue: 3, Deadline: &deadline}, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrGoalNotFound)
+	require.ErrorIs(t, svc.DeleteGoal(1, 9), ErrGoalNotFound)
+}
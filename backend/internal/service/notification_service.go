@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+const (
+	defaultNotificationsLimit = 20
+	maxNotificationsLimit     = 100
+)
+
+type NotificationService interface {
+	ListNotifications(userID uint, q NotificationQuery) (*NotificationPage, error)
+	MarkRead(userID uint, id uint) error
+	MarkAllRead(userID uint) error
+}
+
+// NotificationQuery は通知一覧の取得位置。Limit の省略時は20件（上限100件）、Cursor は前のページの NextCursor。
+type NotificationQuery struct {
+	Cursor string
+	Limit  int
+}
+
+// Notification はまとめた通知1件。ID はまとまりの最新の通知の ID で、既読にするときに指定する。
+type Notification struct {
+	ID           uint
+	Type         models.NotificationType
+	ActorID      *uint
+	ActorEmail   *string
+	ActorCount   int
+	IsRead       bool
+	SessionID    *uint
+	CommentID    *uint
+	GoalID       *uint
+	ExerciseName *string
+	Message      string
+	CreatedAt    time.Time
+}
+
+// NotificationPage は通知一覧の1ページ。UnreadCount は未読の通知を含むまとまりの数で、続きが無ければ NextCursor は空。
+type NotificationPage struct {
+	Items       []Notification
+	UnreadCount int64
+	NextCursor  string
+}
+
+type notificationService struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+	return &notificationService{repo: repo}
+}
+
+// notificationSubject は「Aさん」「Aさん他4人」のように通知の主語を作る。
+func notificationSubject(g repository.NotificationGroup) string {
+	name := "ユーザー"
+	if g.ActorEmail != nil {
+		name = *g.ActorEmail + "さん"
+	}
+	if g.ActorCount > 1 {
+		return fmt.Sprintf("%s他%d人", name, g.ActorCount-1)
+	}
+	return name
+}
+
+func notificationMessage(g repository.NotificationGroup) string {
+	target := "トレーニング"
+	if g.ExerciseName != nil {
+		target = *g.ExerciseName
+	}
+
+	switch g.Type {
+	case models.NotificationLike:
+		return fmt.Sprintf("%sがあなたの%sにいいねしました", notificationSubject(g), target)
+	case models.NotificationComment:
+		return fmt.Sprintf("%sがあなたの%sにコメントしました", notificationSubject(g), target)
+	case models.NotificationReply:
+		return fmt.Sprintf("%sがあなたのコメントに返信しました", notificationSubject(g))
+	case models.NotificationFollow:
+		return fmt.Sprintf("%sがあなたをフォローしました", notificationSubject(g))
+	case models.NotificationFollowRequest:
+		return fmt.Sprintf("%sからフォロー申請が届きました", notificationSubject(g))
+	case models.NotificationGoalAchieved:
+		if g.ExerciseName != nil {
+			return fmt.Sprintf("%sの目標を達成しました", *g.ExerciseName)
+		}
+		return "目標を達成しました"
+	case models.NotificationPersonalRecord:
+		if g.TotalCount > 1 {
+			return fmt.Sprintf("%sで自己ベストを%d件更新しました", target, g.TotalCount)
+		}
+		return fmt.Sprintf("%sで自己ベストを更新しました", target)
+	default:
+		return "新しいお知らせがあります"
+	}
+}
+
+// ListNotifications は同じ対象への通知をまとめ、新しい順に1ページ分と未読のまとまりの数を返す。
+func (s *notificationService) ListNotifications(userID uint, q NotificationQuery) (*NotificationPage, error) {
+	if q.Limit == 0 {
+		q.Limit = defaultNotificationsLimit
+	}
+	if q.Limit < 1 || q.Limit > maxNotificationsLimit {
+		return nil, ErrInvalidNotificationQuery
+	}
+	var beforeID uint64
+	if q.Cursor != "" {
+		id, err := strconv.ParseUint(q.Cursor, 10, 32)
+		if err != nil || id == 0 {
+			return nil, ErrInvalidNotificationQuery
+		}
+		beforeID = id
+	}
+
+	// 続きの有無を知るため1件多く取得する
+	groups, err := s.repo.FindGroups(userID, uint(beforeID), q.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications failed: %w", err)
+	}
+	unread, err := s.repo.CountUnreadGroups(userID)
+	if err != nil {
+		return nil, fmt.Errorf("count unread notifications failed: %w", err)
+	}
+
+	page := &NotificationPage{UnreadCount: unread}
+	if len(groups) > q.Limit {
+		groups = groups[:q.Limit]
+		page.NextCursor = strconv.FormatUint(uint64(groups[len(groups)-1].LatestID), 10)
+	}
+	page.Items = make([]Notification, 0, len(groups))
+	for _, g := range groups {
+		page.Items = append(page.Items, Notification{
+			ID:           g.LatestID,
+			Type:         g.Type,
+			ActorID:      g.ActorID,
+			ActorEmail:   g.ActorEmail,
+			ActorCount:   g.ActorCount,
+			IsRead:       g.UnreadCount == 0,
+			SessionID:    g.SessionID,
+			CommentID:    g.CommentID,
+			GoalID:       g.GoalID,
+			ExerciseName: g.ExerciseName,
+			Message:      notificationMessage(g),
+			CreatedAt:    g.CreatedAt,
+		})
+	}
+	return page, nil
+}
+
+// MarkRead は id の通知をまとめた通知ごと既読にする。
+func (s *notificationService) MarkRead(userID uint, id uint) error {
+	if err := s.repo.MarkGroupRead(userID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNotificationNotFound
+		}
+		return fmt.Errorf("mark notification read failed: %w", err)
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(userID uint) error {
+	if err := s.repo.MarkAllRead(userID); err != nil {
+		return fmt.Errorf("mark all notifications read failed: %w", err)
+	}
+	return nil
+}
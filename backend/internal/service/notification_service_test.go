@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotificationRepo struct {
+	findFn    func(userID uint, beforeID uint, limit int) ([]repository.NotificationGroup, error)
+	countFn   func(userID uint) (int64, error)
+	markFn    func(userID uint, id uint) error
+	markAllFn func(userID uint) error
+}
+
+func (f *fakeNotificationRepo) FindGroups(userID uint, beforeID uint, limit int) ([]repository.NotificationGroup, error) {
+	return f.findFn(userID, beforeID, limit)
+}
+func (f *fakeNotificationRepo) CountUnreadGroups(userID uint) (int64, error) {
+	return f.countFn(userID)
+}
+func (f *fakeNotificationRepo) MarkGroupRead(userID uint, id uint) error {
+	return f.markFn(userID, id)
+}
+func (f *fakeNotificationRepo) MarkAllRead(userID uint) error { return f.markAllFn(userID) }
+
+func TestNotificationService_Message(t *testing.T) {
+	tests := []struct {
+		name  string
+		group repository.NotificationGroup
+		want  string
+	}{
+		{
+			name:  "【正常系】複数人のいいねは「他N人」とまとめること",
+			group: repository.NotificationGroup{Type: models.NotificationLike, ActorEmail: ptr("a@example.com"), ActorCount: 5, ExerciseName: ptr("ベンチプレス")},
+			want:  "a@example.comさん他4人があなたのベンチプレスにいいねしました",
+		},
+		{
+			name:  "【正常系】種目の無いセッションへのコメントは「トレーニング」とすること",
+			group: repository.NotificationGroup{Type: models.NotificationComment, ActorEmail: ptr("a@example.com"), ActorCount: 1},
+			want:  "a@example.comさんがあなたのトレーニングにコメントしました",
+		},
+		{
+			name:  "【正常系】フォロー申請を通知すること",
+			group: repository.NotificationGroup{Type: models.NotificationFollowRequest, ActorEmail: ptr("b@example.com"), ActorCount: 2},
+			want:  "b@example.comさん他1人からフォロー申請が届きました",
+		},
+		{
+			name:  "【正常系】種目の無い目標の達成を通知すること",
+			group: repository.NotificationGroup{Type: models.NotificationGoalAchieved},
+			want:  "目標を達成しました",
+		},
+		{
+			name:  "【正常系】同じ記録で更新した自己ベストの件数を通知すること",
+			group: repository.NotificationGroup{Type: models.NotificationPersonalRecord, TotalCount: 3, ExerciseName: ptr("スクワット")},
+			want:  "スクワットで自己ベストを3件更新しました",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, notificationMessage(tt.group))
+		})
+	}
+}
+
+func TestNotificationService_ListNotifications(t *testing.T) {
+	groups := []repository.NotificationGroup{
+		{LatestID: 9, Type: models.NotificationFollow, ActorCount: 1, UnreadCount: 1, ActorEmail: ptr("a@example.com")},
+		{LatestID: 7, Type: models.NotificationGoalAchieved, TotalCount: 1},
+		{LatestID: 4, Type: models.NotificationGoalAchieved, TotalCount: 1},
+	}
+
+	tests := []struct {
+		name         string
+		q            NotificationQuery
+		wantBeforeID uint
+		wantLimit    int
+		wantItems    int
+		wantCursor   string
+		wantErr      error
+	}{
+		{
+			name:      "【正常系】省略時は20件まで返し、続きが無ければカーソルは空であること",
+			q:         NotificationQuery{},
+			wantLimit: 21,
+			wantItems: 3,
+		},
+		{
+			name:         "【正常系】続きがある場合は最後の通知の ID をカーソルにすること",
+			q:            NotificationQuery{Cursor: "12", Limit: 2},
+			wantBeforeID: 12,
+			wantLimit:    3,
+			wantItems:    2,
+			wantCursor:   "7",
+		},
+		{
+			name:    "【異常系】limit が上限を超える場合は ErrInvalidNotificationQuery を返すこと",
+			q:       NotificationQuery{Limit: 101},
+			wantErr: ErrInvalidNotificationQuery,
+		},
+		{
+			name:    "【異常系】cursor が数値でない場合は ErrInvalidNotificationQuery を返すこと",
+			q:       NotificationQuery{Cursor: "abc"},
+			wantErr: ErrInvalidNotificationQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewNotificationService(&fakeNotificationRepo{
+				findFn: func(userID uint, beforeID uint, limit int) ([]repository.NotificationGroup, error) {
+					require.Equal(t, tt.wantBeforeID, beforeID)
+					require.Equal(t, tt.wantLimit, limit)
+					if limit < len(groups) {
+						return groups[:limit], nil
+					}
+					return groups, nil
+				},
+				countFn: func(uint) (int64, error) { return 1, nil },
+			})
+
+			page, err := svc.ListNotifications(1, tt.q)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, page.Items, tt.wantItems)
+			require.Equal(t, tt.wantCursor, page.NextCursor)
+			require.Equal(t, int64(1), page.UnreadCount)
+			require.False(t, page.Items[0].IsRead)
+			require.True(t, page.Items[1].IsRead)
+			require.Equal(t, "a@example.comさんがあなたをフォローしました", page.Items[0].Message)
+		})
+	}
+}
+
+func TestNotificationService_MarkReadNotFound(t *testing.T) {
+	svc := NewNotificationService(&fakeNotificationRepo{
+		markFn: func(uint, uint) error { return repository.ErrNotFound },
+	})
+	require.ErrorIs(t, svc.MarkRead(1, 9), ErrNotificationNotFound)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type PersonalRecordService interface {
+	GetCurrentRecords(userID uint) ([]models.PersonalRecord, error)
+	GetExerciseHistory(userID uint, exerciseID uint, prType models.PersonalRecordType) ([]models.PersonalRecord, error)
+}
+
+type personalRecordService struct {
+	repo repository.PersonalRecordRepository
+}
+
+func NewPersonalRecordService(repo repository.PersonalRecordRepository) PersonalRecordService {
+	return &personalRecordService{repo: repo}
+}
+
+// 一覧での自己ベストの種類の並び順
+var personalRecordTypeOrder = map[models.PersonalRecordType]int{
+	models.PRMaxWeight:       0,
+	models.PREstimated1RM:    1,
+	models.PRSessionVolume:   2,
+	models.PRMaxRepsAtWeight: 3,
+}
+
+// GetCurrentRecords は種目・種類ごと（回数は重量ごと）の現在の自己ベストを返す。
+func (s *personalRecordService) GetCurrentRecords(userID uint) ([]models.PersonalRecord, error) {
+	history, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch personal records failed: %w", err)
+	}
+
+	type key struct {
+		exerciseID uint
+		prType     models.PersonalRecordType
+		weight     float64
+	}
+	latest := make(map[key]models.PersonalRecord, len(history))
+	for _, pr := range history {
+		k := key{exerciseID: pr.ExerciseID, prType: pr.Type}
+		if pr.Type == models.PRMaxRepsAtWeight {
+			k.weight = pr.Weight
+		}
+		// 履歴は達成日の昇順なので、後のものほど新しいベスト
+		latest[k] = pr
+	}
+
+	out := make([]models.PersonalRecord, 0, len(latest))
+	for _, pr := range latest {
+		out = append(out, pr)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.ExerciseID != b.ExerciseID {
+			return a.ExerciseID < b.ExerciseID
+		}
+		if a.Type != b.Type {
+			return personalRecordTypeOrder[a.Type] < personalRecordTypeOrder[b.Type]
+		}
+		return a.Weight < b.Weight
+	})
+	return out, nil
+}
+
+// GetExerciseHistory は種目の自己ベスト更新履歴を達成日の昇順で返す。prType が空なら全種類。
+func (s *personalRecordService) GetExerciseHistory(userID uint, exerciseID uint, prType models.PersonalRecordType) ([]models.PersonalRecord, error) {
+	if prType != "" && !prType.Valid() {
+		return nil, ErrInvalidPersonalRecordType
+	}
+
+	history, err := s.repo.FindByUserAndExercise(userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch exercise personal records failed: %w", err)
+	}
+	if prType == "" {
+		return history, nil
+	}
+
+	out := make([]models.PersonalRecord, 0, len(history))
+	for _, pr := range history {
+		if pr.Type == prType {
+			out = append(out, pr)
+		}
+	}
+	return out, nil
+}
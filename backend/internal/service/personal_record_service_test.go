@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePersonalRecordRepo struct {
+	findByUserFn     func(userID uint) ([]models.PersonalRecord, error)
+	findByExerciseFn func(userID uint, exerciseID uint) ([]models.PersonalRecord, error)
+}
+
+func (f *fakePersonalRecordRepo) FindByUser(userID uint) ([]models.PersonalRecord, error) {
+	return f.findByUserFn(userID)
+}
+func (f *fakePersonalRecordRepo) FindByUserAndExercise(userID uint, exerciseID uint) ([]models.PersonalRecord, error) {
+	return f.findByExerciseFn(userID, exerciseID)
+}
+
+func prOf(id uint, exerciseID uint, t models.PersonalRecordType, value, weight float64) models.PersonalRecord {
+	return models.PersonalRecord{
+		ID:         id,
+		ExerciseID: exerciseID,
+		Type:       t,
+		Value:      value,
+		Weight:     weight,
+		TrainedOn:  time.Date(2025, 10, int(id), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestPersonalRecordService_GetCurrentRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    fakePersonalRecordRepo
+		wantIDs []uint
+		wantErr bool
+	}{
+		{
+			name: "【正常系】種目・種類ごとに最新のベストのみを返し、回数は重量ごとに返すこと",
+			repo: fakePersonalRecordRepo{
+				findByUserFn: func(userID uint) ([]models.PersonalRecord, error) {
+					require.Equal(t, uint(1), userID)
+					return []models.PersonalRecord{
+						prOf(1, 2, models.PRMaxWeight, 60, 60),
+						prOf(2, 2, models.PRMaxRepsAtWeight, 5, 60),
+						prOf(3, 1, models.PRSessionVolume, 1000, 0),
+						prOf(4, 1, models.PRMaxWeight, 100, 100),
+						prOf(5, 2, models.PRMaxRepsAtWeight, 8, 60),
+						prOf(6, 2, models.PRMaxRepsAtWeight, 3, 70),
+						prOf(7, 2, models.PRMaxWeight, 70, 70),
+					}, nil
+				},
+			},
+			wantIDs: []uint{4, 3, 7, 5, 6},
+		},
+		{
+			name: "【正常系】自己ベストが無い場合は空スライスを返すこと",
+			repo: fakePersonalRecordRepo{
+				findByUserFn: func(uint) ([]models.PersonalRecord, error) { return nil, nil },
+			},
+			wantIDs: []uint{},
+		},
+		{
+			name: "【異常系】リポジトリのエラーは wrap されて返ること",
+			repo: fakePersonalRecordRepo{
+				findByUserFn: func(uint) ([]models.PersonalRecord, error) { return nil, errors.New("db down") },
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPersonalRecordService(&tt.repo)
+			got, err := svc.GetCurrentRecords(1)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "fetch personal records failed")
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]uint, 0, len(got))
+			for _, pr := range got {
+				ids = append(ids, pr.ID)
+			}
+			require.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestPersonalRecordService_GetExerciseHistory(t *testing.T) {
+	history := []models.PersonalRecord{
+		prOf(1, 3, models.PRMaxWeight, 60, 60),
+		prOf(2, 3, models.PREstimated1RM, 70, 60),
+		prOf(3, 3, models.PRMaxWeight, 65, 65),
+	}
+
+	tests := []struct {
+		name    string
+		prType  models.PersonalRecordType
+		repo    fakePersonalRecordRepo
+		wantIDs []uint
+		wantErr error
+	}{
+		{
+			name:   "【正常系】種類の指定が無い場合は履歴をそのまま返すこと",
+			prType: "",
+			repo: fakePersonalRecordRepo{
+				findByExerciseFn: func(userID uint, exerciseID uint) ([]models.PersonalRecord, error) {
+					require.Equal(t, uint(3), exerciseID)
+					return history, nil
+				},
+			},
+			wantIDs: []uint{1, 2, 3},
+		},
+		{
+			name:   "【正常系】種類を指定した場合はその種類の履歴のみ返すこと",
+			prType: models.PRMaxWeight,
+			repo: fakePersonalRecordRepo{
+				findByExerciseFn: func(uint, uint) ([]models.PersonalRecord, error) { return history, nil },
+			},
+			wantIDs: []uint{1, 3},
+		},
+		{
+			name:    "【異常系】未知の種類は ErrInvalidPersonalRecordType を返すこと",
+			prType:  "fastest",
+			repo:    fakePersonalRecordRepo{},
+			wantErr: ErrInvalidPersonalRecordType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPersonalRecordService(&tt.repo)
+			got, err := svc.GetExerciseHistory(1, 3, tt.prType)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]uint, 0, len(got))
+			for _, pr := range got {
+				ids = append(ids, pr.ID)
+			}
+			require.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
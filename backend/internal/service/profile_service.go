@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
@@ -9,7 +10,26 @@ import (
 
 type ProfileService interface {
 	GetProfile(userID uint) (*models.User, error)
-	UpdateProfile(userID uint, height *float64, goalWeight *float64) (*models.User, error)
+	UpdateProfile(userID uint, data ProfileData) (*models.User, error)
+	GetBodyComposition(userID uint, height *float64) (models.BodyComposition, error)
+}
+
+// 身長(cm)・目標体重(kg)として受け付ける範囲
+const (
+	minHeightCM     = 50
+	maxHeightCM     = 250
+	minGoalWeightKG = 20
+	maxGoalWeightKG = 300
+)
+
+// ProfileData はプロフィールの更新内容。GoalDateSet・SexSet が false の場合、その項目は変更しない。
+type ProfileData struct {
+	HeightCM     *float64
+	GoalWeightKG *float64
+	GoalDate     *time.Time
+	GoalDateSet  bool
+	Sex          *models.Sex
+	SexSet       bool
 }
 
 type profileService struct {
@@ -31,8 +51,31 @@ func (s *profileService) GetProfile(userID uint) (*models.User, error) {
 	return user, nil
 }
 
-func (s *profileService) UpdateProfile(userID uint, height *float64, goalWeight *float64) (*models.User, error) {
-	if err := s.repo.UpdateProfile(userID, height, goalWeight); err != nil {
+func (s *profileService) UpdateProfile(userID uint, data ProfileData) (*models.User, error) {
+	if h := data.HeightCM; h != nil && (*h < minHeightCM || *h > maxHeightCM) {
+		return nil, ErrInvalidProfile
+	}
+	if w := data.GoalWeightKG; w != nil && (*w < minGoalWeightKG || *w > maxGoalWeightKG) {
+		return nil, ErrInvalidProfile
+	}
+	if data.Sex != nil && !data.Sex.Valid() {
+		return nil, ErrInvalidProfile
+	}
+
+	profile := models.User{
+		Height:     data.HeightCM,
+		GoalWeight: data.GoalWeightKG,
+		GoalDate:   data.GoalDate,
+		Sex:        data.Sex,
+	}
+	columns := []string{"height", "goal_weight"}
+	if data.GoalDateSet {
+		columns = append(columns, "goal_date")
+	}
+	if data.SexSet {
+		columns = append(columns, "sex")
+	}
+	if err := s.repo.UpdateProfile(userID, profile, columns); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -41,3 +84,19 @@ func (s *profileService) UpdateProfile(userID uint, height *float64, goalWeight
 
 	return s.GetProfile(userID)
 }
+
+// GetBodyComposition は身長と体組成ログの最新の体重・体脂肪率から BMI / FFMI を求める。
+func (s *profileService) GetBodyComposition(userID uint, height *float64) (models.BodyComposition, error) {
+	if height == nil {
+		return models.BodyComposition{}, nil
+	}
+	weight, err := s.repo.GetLatestWeight(userID)
+	if err != nil {
+		return models.BodyComposition{}, err
+	}
+	bodyFat, err := s.repo.GetLatestBodyFat(userID)
+	if err != nil {
+		return models.BodyComposition{}, err
+	}
+	return models.ComputeBodyComposition(height, weight, bodyFat), nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
@@ -11,16 +12,26 @@ import (
 )
 
 type fakeProfileRepo struct {
-	getFunc    func(userID uint) (*models.User, error)
-	updateFunc func(userID uint, height *float64, goalWeight *float64) error
+	getFunc     func(userID uint) (*models.User, error)
+	updateFunc  func(userID uint, profile models.User, columns []string) error
+	weightFunc  func(userID uint) (*float64, error)
+	bodyFatFunc func(userID uint) (*float64, error)
 }
 
 func (f *fakeProfileRepo) GetProfile(userID uint) (*models.User, error) {
 	return f.getFunc(userID)
 }
 
-func (f *fakeProfileRepo) UpdateProfile(userID uint, height *float64, goalWeight *float64) error {
-	return f.updateFunc(userID, height, goalWeight)
+func (f *fakeProfileRepo) UpdateProfile(userID uint, profile models.User, columns []string) error {
+	return f.updateFunc(userID, profile, columns)
+}
+
+func (f *fakeProfileRepo) GetLatestWeight(userID uint) (*float64, error) {
+	return f.weightFunc(userID)
+}
+
+func (f *fakeProfileRepo) GetLatestBodyFat(userID uint) (*float64, error) {
+	return f.bodyFatFunc(userID)
 }
 
 func TestProfileService_GetProfile(t *testing.T) {
@@ -94,8 +105,8 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 		name        string
 		mockRepo    fakeProfileRepo
 		userID      uint
-		height      *float64
-		goalWeight  *float64
+		data        ProfileData
+		wantColumns []string
 		wantUser    *models.User
 		wantErr     error
 		errContains string
@@ -103,7 +114,25 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 		{
 			name: "【正常系】身長・目標体重を更新して取得できること",
 			mockRepo: fakeProfileRepo{
-				updateFunc: func(userID uint, height *float64, goalWeight *float64) error {
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
+					return nil
+				},
+				getFunc: func(userID uint) (*models.User, error) {
+					return &models.User{
+						Email:      "updated@example.com",
+						Height:     utils.Ptr(175.0),
+						GoalWeight: utils.Ptr(65.0),
+					}, nil
+				},
+			},
+			userID:   1,
+			data:     ProfileData{HeightCM: utils.Ptr(175.0), GoalWeightKG: utils.Ptr(65.0)},
+			wantUser: &models.User{Email: "updated@example.com"},
+		},
+		{
+			name: "【正常系】目標日が指定されていない場合は goal_date を更新対象に含めないこと",
+			mockRepo: fakeProfileRepo{
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
 					return nil
 				},
 				getFunc: func(userID uint) (*models.User, error) {
@@ -114,27 +143,76 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 					}, nil
 				},
 			},
-			userID:     1,
-			height:     utils.Ptr(175.0),
-			goalWeight: utils.Ptr(65.0),
-			wantUser:   &models.User{Email: "updated@example.com"},
+			userID:      1,
+			data:        ProfileData{HeightCM: utils.Ptr(175.0), GoalWeightKG: utils.Ptr(65.0)},
+			wantColumns: []string{"height", "goal_weight"},
+			wantUser:    &models.User{Email: "updated@example.com"},
+		},
+		{
+			name: "【正常系】目標日が指定された場合は goal_date を更新対象に含めること",
+			mockRepo: fakeProfileRepo{
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
+					return nil
+				},
+				getFunc: func(userID uint) (*models.User, error) {
+					return &models.User{
+						Email:      "updated@example.com",
+						Height:     utils.Ptr(175.0),
+						GoalWeight: utils.Ptr(65.0),
+						GoalDate:   utils.Ptr(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)),
+					}, nil
+				},
+			},
+			userID: 1,
+			data: ProfileData{
+				HeightCM:     utils.Ptr(175.0),
+				GoalWeightKG: utils.Ptr(65.0),
+				GoalDate:     utils.Ptr(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)),
+				GoalDateSet:  true,
+			},
+			wantColumns: []string{"height", "goal_weight", "goal_date"},
+			wantUser:    &models.User{Email: "updated@example.com"},
+		},
+		{
+			name: "【正常系】性別が指定された場合は sex を更新対象に含めること",
+			mockRepo: fakeProfileRepo{
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
+					return nil
+				},
+				getFunc: func(userID uint) (*models.User, error) {
+					return &models.User{
+						Email:      "updated@example.com",
+						Height:     utils.Ptr(175.0),
+						GoalWeight: utils.Ptr(65.0),
+						Sex:        utils.Ptr(models.SexMale),
+					}, nil
+				},
+			},
+			userID: 1,
+			data: ProfileData{
+				HeightCM:     utils.Ptr(175.0),
+				GoalWeightKG: utils.Ptr(65.0),
+				Sex:          utils.Ptr(models.SexMale),
+				SexSet:       true,
+			},
+			wantColumns: []string{"height", "goal_weight", "sex"},
+			wantUser:    &models.User{Email: "updated@example.com"},
 		},
 		{
 			name: "【異常系】UpdateProfileでエラーが発生した場合はそのまま返すこと",
 			mockRepo: fakeProfileRepo{
-				updateFunc: func(userID uint, height *float64, goalWeight *float64) error {
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
 					return errors.New("update failed")
 				},
 			},
 			userID:      1,
-			height:      utils.Ptr(180.0),
-			goalWeight:  utils.Ptr(70.0),
+			data:        ProfileData{HeightCM: utils.Ptr(180.0), GoalWeightKG: utils.Ptr(70.0)},
 			errContains: "update failed",
 		},
 		{
 			name: "【異常系】更新後のGetProfileでエラーが発生した場合はそのまま返すこと",
 			mockRepo: fakeProfileRepo{
-				updateFunc: func(userID uint, height *float64, goalWeight *float64) error {
+				updateFunc: func(userID uint, profile models.User, columns []string) error {
 					return nil
 				},
 				getFunc: func(userID uint) (*models.User, error) {
@@ -142,16 +220,44 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 				},
 			},
 			userID:      1,
-			height:      utils.Ptr(180.0),
-			goalWeight:  utils.Ptr(70.0),
+			data:        ProfileData{HeightCM: utils.Ptr(180.0), GoalWeightKG: utils.Ptr(70.0)},
 			errContains: "select failed",
 		},
+		{
+			name:     "【異常系】身長が範囲外の場合は ErrInvalidProfile を返すこと",
+			mockRepo: fakeProfileRepo{},
+			userID:   1,
+			data:     ProfileData{HeightCM: utils.Ptr(17.5), GoalWeightKG: utils.Ptr(65.0)},
+			wantErr:  ErrInvalidProfile,
+		},
+		{
+			name:     "【異常系】性別が不正な場合は ErrInvalidProfile を返すこと",
+			mockRepo: fakeProfileRepo{},
+			userID:   1,
+			data:     ProfileData{HeightCM: utils.Ptr(175.0), GoalWeightKG: utils.Ptr(65.0), Sex: utils.Ptr(models.Sex("other"))},
+			wantErr:  ErrInvalidProfile,
+		},
+		{
+			name:     "【異常系】目標体重が範囲外の場合は ErrInvalidProfile を返すこと",
+			mockRepo: fakeProfileRepo{},
+			userID:   1,
+			data:     ProfileData{HeightCM: utils.Ptr(175.0), GoalWeightKG: utils.Ptr(650.0)},
+			wantErr:  ErrInvalidProfile,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var gotColumns []string
+			if update := tt.mockRepo.updateFunc; update != nil {
+				tt.mockRepo.updateFunc = func(userID uint, profile models.User, columns []string) error {
+					gotColumns = columns
+					return update(userID, profile, columns)
+				}
+			}
+
 			svc := NewProfileService(&tt.mockRepo)
-			got, err := svc.UpdateProfile(tt.userID, tt.height, tt.goalWeight)
+			got, err := svc.UpdateProfile(tt.userID, tt.data)
 
 			switch {
 			case tt.wantErr != nil:
@@ -164,9 +270,63 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, got)
 				require.Equal(t, tt.wantUser.Email, got.Email)
-				require.Equal(t, *tt.height, *got.Height)
-				require.Equal(t, *tt.goalWeight, *got.GoalWeight)
+				require.Equal(t, *tt.data.HeightCM, *got.Height)
+				require.Equal(t, *tt.data.GoalWeightKG, *got.GoalWeight)
+				if tt.wantColumns != nil {
+					require.Equal(t, tt.wantColumns, gotColumns)
+				}
 			}
 		})
 	}
 }
+
+func TestProfileService_GetBodyComposition(t *testing.T) {
+	tests := []struct {
+		name           string
+		height         *float64
+		weight         *float64
+		bodyFat        *float64
+		wantBMI        *float64
+		wantFFMI       *float64
+		wantNormalized *float64
+	}{
+		{
+			name:           "【正常系】身長・体重・体脂肪率から BMI と FFMI を求めること",
+			height:         utils.Ptr(175.0),
+			weight:         utils.Ptr(70.0),
+			bodyFat:        utils.Ptr(15.0),
+			wantBMI:        utils.Ptr(22.9),
+			wantFFMI:       utils.Ptr(19.4),
+			wantNormalized: utils.Ptr(19.7),
+		},
+		{
+			name:    "【正常系】体脂肪率が無い場合は BMI だけを求めること",
+			height:  utils.Ptr(175.0),
+			weight:  utils.Ptr(70.0),
+			wantBMI: utils.Ptr(22.9),
+		},
+		{
+			name:   "【正常系】体重が無い場合は何も求めないこと",
+			height: utils.Ptr(175.0),
+		},
+		{
+			name:   "【正常系】身長が無い場合は何も求めないこと",
+			weight: utils.Ptr(70.0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewProfileService(&fakeProfileRepo{
+				weightFunc:  func(uint) (*float64, error) { return tt.weight, nil },
+				bodyFatFunc: func(uint) (*float64, error) { return tt.bodyFat, nil },
+			})
+
+			got, err := svc.GetBodyComposition(1, tt.height)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBMI, got.BMI)
+			require.Equal(t, tt.wantFFMI, got.FFMI)
+			require.Equal(t, tt.wantNormalized, got.NormalizedFFMI)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type ProgramService interface {
+	ListPrograms() []models.TrainingProgram
+	Enroll(userID uint, data ProgramEnrollData) (*models.UserProgram, error)
+	GetProgram(userID uint) (*models.UserProgram, error)
+	GetTodayPrescription(userID uint, today time.Time) (*ProgramPrescription, error)
+	QuitProgram(userID uint) error
+}
+
+// ProgramEnrollData はプログラムへの参加内容。Lifts はプログラムの全スロットに1種目ずつ割り当てる。
+type ProgramEnrollData struct {
+	ProgramKey string
+	StartedOn  time.Time
+	Lifts      []ProgramLiftData
+}
+
+// ProgramLiftData の TrainingMax が nil の場合は履歴の推定1RMから求める。
+type ProgramLiftData struct {
+	Slot        string
+	ExerciseID  uint
+	TrainingMax *float64
+}
+
+// ProgramPrescription は次に行う日のメニュー。
+type ProgramPrescription struct {
+	Program *models.TrainingProgram
+	Cycle   int
+	Week    int
+	Day     int
+	Deload  bool
+	Lifts   []PrescribedLift
+}
+
+// PrescribedLift の LastTopSet は直近の記録日で最も重いセット。
+type PrescribedLift struct {
+	Slot         string
+	SlotName     string
+	ExerciseID   uint
+	ExerciseName string
+	TrainingMax  float64
+	Done         bool
+	Sets         []PrescribedSet
+	LastTopSet   *TopSet
+}
+
+type PrescribedSet struct {
+	SetNo   int
+	Percent float64
+	Weight  float64
+	Reps    int
+	AMRAP   bool
+}
+
+// トレーニングマックスの算出と直近の実績に使う履歴の期間
+const programHistoryDays = 90
+
+type programService struct {
+	repo repository.ProgramRepository
+}
+
+func NewProgramService(repo repository.ProgramRepository) ProgramService {
+	return &programService{repo: repo}
+}
+
+func (s *programService) ListPrograms() []models.TrainingProgram {
+	return models.TrainingPrograms()
+}
+
+func (s *programService) Enroll(userID uint, data ProgramEnrollData) (*models.UserProgram, error) {
+	program, ok := models.FindTrainingProgram(data.ProgramKey)
+	if !ok {
+		return nil, ErrProgramNotFound
+	}
+
+	assigned := make(map[string]bool, len(data.Lifts))
+	exerciseIDs := make([]uint, 0, len(data.Lifts))
+	for _, l := range data.Lifts {
+		if _, ok := program.Slot(l.Slot); !ok || assigned[l.Slot] {
+			return nil, ErrInvalidProgramLifts
+		}
+		if l.TrainingMax != nil && *l.TrainingMax <= 0 {
+			return nil, ErrInvalidProgramLifts
+		}
+		if l.ExerciseID == 0 {
+			return nil, ErrExerciseNotFound
+		}
+		assigned[l.Slot] = true
+		exerciseIDs = append(exerciseIDs, l.ExerciseID)
+	}
+	if len(assigned) != len(program.Slots) {
+		return nil, ErrInvalidProgramLifts
+	}
+
+	exercises, err := s.repo.FindUsableExercises(userID, exerciseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find exercises failed: %w", err)
+	}
+	for _, l := range data.Lifts {
+		ex, ok := exercises[l.ExerciseID]
+		if !ok {
+			return nil, ErrExerciseNotFound
+		}
+		// 割合で重量を決めるため、回数×重量の種目に限る
+		if ex.MeasurementKind != models.MeasurementRepsWeight {
+			return nil, ErrInvalidProgramLifts
+		}
+	}
+	if err := rejectArchivedExercises(exercises, nil); err != nil {
+		return nil, err
+	}
+
+	up := &models.UserProgram{
+		UserID:     userID,
+		ProgramKey: program.Key,
+		StartedOn:  data.StartedOn,
+		Lifts:      make([]models.UserProgramLift, 0, len(data.Lifts)),
+	}
+	for _, l := range data.Lifts {
+		tm, err := s.trainingMax(userID, program, l, data.StartedOn)
+		if err != nil {
+			return nil, err
+		}
+		up.Lifts = append(up.Lifts, models.UserProgramLift{
+			Slot:                l.Slot,
+			ExerciseID:          l.ExerciseID,
+			TrainingMax:         tm,
+			StartingTrainingMax: tm,
+		})
+	}
+
+	if err := s.repo.Replace(up); err != nil {
+		var ce *repository.ConstraintError
+		if errors.As(err, &ce) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("enroll program failed: %w", err)
+	}
+	return s.GetProgram(userID)
+}
+
+// trainingMax は指定が無ければ、直近の履歴の推定1RM（Epley）にプログラムの割合を掛けて求める。
+func (s *programService) trainingMax(userID uint, program *models.TrainingProgram, l ProgramLiftData, on time.Time) (float64, error) {
+	if l.TrainingMax != nil {
+		return models.RoundToPlate(*l.TrainingMax), nil
+	}
+
+	from := on.AddDate(0, 0, -programHistoryDays)
+	rows, err := s.repo.FindSetsByUserAndExerciseInRange(userID, l.ExerciseID, &from, &on)
+	if err != nil {
+		return 0, fmt.Errorf("fetch exercise history failed: %w", err)
+	}
+	var best float64
+	for _, r := range rows {
+		if r.SetType == models.SetTypeWarmup || r.Reps > models.MaxRepsForEstimated1RM {
+			continue
+		}
+		best = max(best, models.OneRepMaxEpley.Estimate(r.ExerciseWeight, r.Reps))
+	}
+	tm := models.RoundToPlate(best * program.TrainingMaxPercent)
+	if tm <= 0 {
+		return 0, ErrTrainingMaxUnknown
+	}
+	return tm, nil
+}
+
+func (s *programService) GetProgram(userID uint) (*models.UserProgram, error) {
+	up, err := s.repo.FindByUser(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrNoActiveProgram
+		}
+		return nil, fmt.Errorf("find program failed: %w", err)
+	}
+	return up, nil
+}
+
+// GetTodayPrescription は進行状況とトレーニングマックスから次に行う日の重量を計算し、種目ごとの直近の実績を添える。
+func (s *programService) GetTodayPrescription(userID uint, today time.Time) (*ProgramPrescription, error) {
+	up, err := s.GetProgram(userID)
+	if err != nil {
+		return nil, err
+	}
+	program, ok := models.FindTrainingProgram(up.ProgramKey)
+	if !ok {
+		return nil, ErrProgramNotFound
+	}
+
+	out := &ProgramPrescription{
+		Program: program,
+		Cycle:   up.Cycle,
+		Week:    up.Week,
+		Day:     up.Day,
+		Deload:  program.Weeks[up.Week].Deload,
+	}
+	from := today.AddDate(0, 0, -programHistoryDays)
+	for _, spec := range up.CurrentDay(program).Lifts {
+		var lift *models.UserProgramLift
+		for i := range up.Lifts {
+			if up.Lifts[i].Slot == spec.Slot {
+				lift = &up.Lifts[i]
+				break
+			}
+		}
+		if lift == nil {
+			continue
+		}
+
+		pl := PrescribedLift{
+			Slot:         spec.Slot,
+			ExerciseID:   lift.ExerciseID,
+			ExerciseName: lift.Exercise.Name,
+			TrainingMax:  lift.TrainingMax,
+			Done:         lift.Done,
+			Sets:         make([]PrescribedSet, 0, len(spec.Sets)),
+		}
+		if slot, ok := program.Slot(spec.Slot); ok {
+			pl.SlotName = slot.Name
+		}
+		for i, st := range spec.Sets {
+			pl.Sets = append(pl.Sets, PrescribedSet{
+				SetNo:   i + 1,
+				Percent: st.Percent,
+				Weight:  models.RoundToPlate(lift.TrainingMax * st.Percent),
+				Reps:    st.Reps,
+				AMRAP:   st.AMRAP,
+			})
+		}
+
+		rows, err := s.repo.FindSetsByUserAndExerciseInRange(userID, lift.ExerciseID, &from, &today)
+		if err != nil {
+			return nil, fmt.Errorf("fetch exercise history failed: %w", err)
+		}
+		for _, r := range rows {
+			if r.Reps <= 0 {
+				continue
+			}
+			top := pl.LastTopSet
+			if top == nil || r.TrainedOn.After(top.TrainedOn) ||
+				(r.TrainedOn.Equal(top.TrainedOn) && (r.ExerciseWeight > top.Weight ||
+					(r.ExerciseWeight == top.Weight && r.Reps > top.Reps))) {
+				pl.LastTopSet = &TopSet{Weight: r.ExerciseWeight, Reps: r.Reps, TrainedOn: r.TrainedOn}
+			}
+		}
+		out.Lifts = append(out.Lifts, pl)
+	}
+	return out, nil
+}
+
+func (s *programService) QuitProgram(userID uint) error {
+	if err := s.repo.Delete(userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNoActiveProgram
+		}
+		return fmt.Errorf("quit program failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProgramRepo struct {
+	findFn    func(userID uint) (*models.UserProgram, error)
+	replaceFn func(program *models.UserProgram) error
+	deleteFn  func(userID uint) error
+	findExFn  func(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+	setsFn    func(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error)
+}
+
+func (f *fakeProgramRepo) FindByUser(userID uint) (*models.UserProgram, error) {
+	return f.findFn(userID)
+}
+func (f *fakeProgramRepo) Replace(program *models.UserProgram) error {
+	return f.replaceFn(program)
+}
+func (f *fakeProgramRepo) Delete(userID uint) error {
+	return f.deleteFn(userID)
+}
+
+func (f *fakeProgramRepo) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	if f.findExFn != nil {
+		return f.findExFn(userID, exerciseIDs)
+	}
+	exercises := make(map[uint]models.Exercise, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		exercises[id] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+	}
+	return exercises, nil
+}
+
+func (f *fakeProgramRepo) FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error) {
+	if f.setsFn != nil {
+		return f.setsFn(userID, exerciseID, from, to)
+	}
+	return nil, nil
+}
+
+// linearEnrollData は linear の全スロットに種目を割り当てる。
+func linearEnrollData() ProgramEnrollData {
+	return ProgramEnrollData{
+		ProgramKey: "linear",
+		StartedOn:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Lifts: []ProgramLiftData{
+			{Slot: "squat", ExerciseID: 1, TrainingMax: ptr(float64(61))},
+			{Slot: "bench", ExerciseID: 2, TrainingMax: ptr(float64(50))},
+			{Slot: "row", ExerciseID: 3, TrainingMax: ptr(float64(40))},
+			{Slot: "ohp", ExerciseID: 4, TrainingMax: ptr(float64(30))},
+			{Slot: "deadlift", ExerciseID: 5},
+		},
+	}
+}
+
+func TestProgramService_Enroll(t *testing.T) {
+	history := func(_ uint, exerciseID uint, _, _ *time.Time) ([]repository.FlatWorkoutSet, error) {
+		if exerciseID != 5 {
+			return nil, nil
+		}
+		return []repository.FlatWorkoutSet{
+			{Reps: 5, ExerciseWeight: 60, SetType: models.SetTypeWarmup},
+			{Reps: 3, ExerciseWeight: 100, SetType: models.SetTypeWorking},
+			{Reps: 15, ExerciseWeight: 110, SetType: models.SetTypeWorking},
+		}, nil
+	}
+
+	tests := []struct {
+		name    string
+		repo    fakeProgramRepo
+		data    func() ProgramEnrollData
+		wantErr error
+	}{
+		{
+			name: "【正常系】指定のトレーニングマックスと履歴から求めた値で参加できること",
+			repo: fakeProgramRepo{
+				setsFn: history,
+				replaceFn: func(up *models.UserProgram) error {
+					require.Equal(t, "linear", up.ProgramKey)
+					require.Len(t, up.Lifts, 5)
+					// 61kg はプレート単位に丸める
+					require.Equal(t, 60.0, up.Lifts[0].TrainingMax)
+					// 100kg×3 の推定1RM 110kg × 80% = 88kg → 87.5kg（ウォームアップと12回超は除外）
+					require.Equal(t, 87.5, up.Lifts[4].TrainingMax)
+					return nil
+				},
+				findFn: func(userID uint) (*models.UserProgram, error) {
+					return &models.UserProgram{UserID: userID, ProgramKey: "linear"}, nil
+				},
+			},
+			data: linearEnrollData,
+		},
+		{
+			name:    "【異常系】存在しないプログラムの場合は ErrProgramNotFound を返すこと",
+			data:    func() ProgramEnrollData { return ProgramEnrollData{ProgramKey: "unknown"} },
+			wantErr: ErrProgramNotFound,
+		},
+		{
+			name: "【異常系】スロットが足りない場合は ErrInvalidProgramLifts を返すこと",
+			data: func() ProgramEnrollData {
+				d := linearEnrollData()
+				d.Lifts = d.Lifts[:4]
+				return d
+			},
+			wantErr: ErrInvalidProgramLifts,
+		},
+		{
+			name: "【異常系】同じスロットを重複して指定した場合は ErrInvalidProgramLifts を返すこと",
+			data: func() ProgramEnrollData {
+				d := linearEnrollData()
+				d.Lifts[4].Slot = "squat"
+				return d
+			},
+			wantErr: ErrInvalidProgramLifts,
+		},
+		{
+			name: "【異常系】トレーニングマックスが0以下の場合は ErrInvalidProgramLifts を返すこと",
+			data: func() ProgramEnrollData {
+				d := linearEnrollData()
+				d.Lifts[0].TrainingMax = ptr(float64(0))
+				return d
+			},
+			wantErr: ErrInvalidProgramLifts,
+		},
+		{
+			name: "【異常系】回数×重量以外の種目の場合は ErrInvalidProgramLifts を返すこと",
+			repo: fakeProgramRepo{
+				findExFn: func(_ uint, ids []uint) (map[uint]models.Exercise, error) {
+					out := make(map[uint]models.Exercise, len(ids))
+					for _, id := range ids {
+						out[id] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+					}
+					out[3] = models.Exercise{MeasurementKind: models.MeasurementDuration}
+					return out, nil
+				},
+			},
+			data:    linearEnrollData,
+			wantErr: ErrInvalidProgramLifts,
+		},
+		{
+			name: "【異常系】使用できない種目の場合は ErrExerciseNotFound を返すこと",
+			repo: fakeProgramRepo{
+				findExFn: func(uint, []uint) (map[uint]models.Exercise, error) {
+					return map[uint]models.Exercise{}, nil
+				},
+			},
+			data:    linearEnrollData,
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目の場合は ErrExerciseArchived を返すこと",
+			repo: fakeProgramRepo{
+				findExFn: func(_ uint, ids []uint) (map[uint]models.Exercise, error) {
+					now := time.Now()
+					out := make(map[uint]models.Exercise, len(ids))
+					for _, id := range ids {
+						out[id] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+					}
+					out[2] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight, ArchivedAt: &now}
+					return out, nil
+				},
+			},
+			data:    linearEnrollData,
+			wantErr: ErrExerciseArchived,
+		},
+		{
+			name: "【異常系】履歴もトレーニングマックスの指定も無い場合は ErrTrainingMaxUnknown を返すこと",
+			data: func() ProgramEnrollData {
+				d := linearEnrollData()
+				d.Lifts[0].TrainingMax = nil
+				return d
+			},
+			wantErr: ErrTrainingMaxUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.repo
+			if repo.setsFn == nil {
+				repo.setsFn = history
+			}
+			svc := NewProgramService(&repo)
+
+			up, err := svc.Enroll(1, tt.data())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, up)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "linear", up.ProgramKey)
+		})
+	}
+}
+
+func TestProgramService_GetTodayPrescription(t *testing.T) {
+	today := time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		repo    fakeProgramRepo
+		check   func(t *testing.T, p *ProgramPrescription)
+		wantErr error
+	}{
+		{
+			name: "【正常系】現在の週・日の重量と直近の実績を返すこと",
+			repo: fakeProgramRepo{
+				findFn: func(userID uint) (*models.UserProgram, error) {
+					return &models.UserProgram{
+						UserID: userID, ProgramKey: "531", Week: 2, Day: 3,
+						Lifts: []models.UserProgramLift{
+							{Slot: "ohp", ExerciseID: 4, TrainingMax: 40},
+							{Slot: "squat", ExerciseID: 1, TrainingMax: 100, Done: true, Exercise: models.Exercise{Name: "スクワット"}},
+						},
+					}, nil
+				},
+				setsFn: func(_ uint, exerciseID uint, _, _ *time.Time) ([]repository.FlatWorkoutSet, error) {
+					require.Equal(t, uint(1), exerciseID)
+					return []repository.FlatWorkoutSet{
+						{TrainedOn: today.AddDate(0, 0, -7), Reps: 1, ExerciseWeight: 120},
+						{TrainedOn: today.AddDate(0, 0, -3), Reps: 5, ExerciseWeight: 90},
+						{TrainedOn: today.AddDate(0, 0, -3), Reps: 3, ExerciseWeight: 95},
+					}, nil
+				},
+			},
+			check: func(t *testing.T, p *ProgramPrescription) {
+				require.Equal(t, 2, p.Week)
+				require.Equal(t, 3, p.Day)
+				require.Len(t, p.Lifts, 1)
+				l := p.Lifts[0]
+				require.Equal(t, "squat", l.Slot)
+				require.Equal(t, "スクワット", l.ExerciseName)
+				require.True(t, l.Done)
+				require.Len(t, l.Sets, 3)
+				// 3週目の最終セットは 95% の AMRAP
+				last := l.Sets[2]
+				require.Equal(t, 95.0, last.Weight)
+				require.True(t, last.AMRAP)
+				require.NotNil(t, l.LastTopSet)
+				require.Equal(t, 95.0, l.LastTopSet.Weight)
+				require.Equal(t, 3, l.LastTopSet.Reps)
+			},
+		},
+		{
+			name: "【異常系】参加中のプログラムが無い場合は ErrNoActiveProgram を返すこと",
+			repo: fakeProgramRepo{
+				findFn: func(uint) (*models.UserProgram, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrNoActiveProgram,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewProgramService(&tt.repo)
+
+			p, err := svc.GetTodayPrescription(1, today)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, p)
+		})
+	}
+}
+
+func TestProgramService_QuitProgram(t *testing.T) {
+	tests := []struct {
+		name    string
+		delErr  error
+		wantErr error
+		wantSub bool
+	}{
+		{name: "【正常系】参加中のプログラムをやめられること"},
+		{name: "【異常系】参加中のプログラムが無い場合は ErrNoActiveProgram を返すこと", delErr: repository.ErrNotFound, wantErr: ErrNoActiveProgram},
+		{name: "【異常系】削除に失敗した場合はエラーを返すこと", delErr: errors.New("db down"), wantSub: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewProgramService(&fakeProgramRepo{
+				deleteFn: func(uint) error { return tt.delErr },
+			})
+
+			err := svc.QuitProgram(1)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantSub:
+				require.ErrorContains(t, err, "quit program failed")
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
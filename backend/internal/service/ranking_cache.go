@@ -1,44 +1,236 @@
 package service
 
 import (
+	"context"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// rankingCacheTTL を過ぎた結果は使わずに取り直す
+	rankingCacheTTL = 5 * time.Minute
+	// rankingCacheTTL のこの割合を過ぎた結果は、返しつつ裏で取り直す
+	rankingRefreshAfterRatio = 0.5
+	// 裏での取り直しを同時に行う上限。上限に達している間は取り直しを見送る
+	maxRankingRefreshes = 2
+	// 1回の取得の上限。取得はリクエストから切り離して行うため、この時間で打ち切る
+	rankingLoadTimeout = 5 * time.Second
 )
 
+// RankingInvalidator は記録の追加・更新・削除を受けて、その日を含む期間のランキングのキャッシュを破棄する。
+type RankingInvalidator interface {
+	InvalidateDay(day time.Time)
+}
+
+// invalidateRankings は days を含む期間のキャッシュを破棄する。inv が nil なら何もしない。
+func invalidateRankings(inv RankingInvalidator, days ...time.Time) {
+	if inv == nil {
+		return
+	}
+	for _, d := range days {
+		inv.InvalidateDay(d)
+	}
+}
+
+// RankingCache はランキングの種類 × 集計期間ごとに結果を TTL 付きで保持する。
+// 同じキーの同時の取得は1回にまとめ、記録が変わった期間のキャッシュは InvalidateDay で破棄する。
 type RankingCache struct {
-	mu          sync.RWMutex
-	gymDays     []GymDaysDTO
-	lastUpdated time.Time
+	mu         sync.RWMutex
+	entries    map[string]rankingCacheEntry
+	generation uint64
+	ttl        time.Duration
+
+	group   singleflight.Group
+	refresh chan struct{}
+
+	hits           atomic.Int64
+	misses         atomic.Int64
+	refreshes      atomic.Int64
+	skippedRefresh atomic.Int64
+	invalidations  atomic.Int64
+	staleDiscarded atomic.Int64
+}
+
+type rankingCacheEntry struct {
+	period   RankingPeriod
+	data     any
+	storedAt time.Time
+}
+
+// RankingCacheStats はキャッシュの効果を確認するための累計値。
+type RankingCacheStats struct {
+	Entries          int   `json:"entries"`
+	Hits             int64 `json:"hits"`
+	Misses           int64 `json:"misses"`
+	Refreshes        int64 `json:"refreshes"`
+	SkippedRefreshes int64 `json:"skipped_refreshes"`
+	Invalidations    int64 `json:"invalidations"`
+	StaleDiscarded   int64 `json:"stale_discarded"`
 }
 
 func NewRankingCache() *RankingCache {
-	return &RankingCache{}
+	return newRankingCache(rankingCacheTTL)
 }
 
-func (c *RankingCache) GetGymDays() ([]GymDaysDTO, time.Time) {
+func newRankingCache(ttl time.Duration) *RankingCache {
+	return &RankingCache{
+		entries: map[string]rankingCacheEntry{},
+		ttl:     ttl,
+		refresh: make(chan struct{}, maxRankingRefreshes),
+	}
+}
+
+func rankingCacheKey(kind string, p RankingPeriod) string {
+	return kind + "@" + string(p.Granularity) + ":" + p.From.Format("2006-01-02") + "~" + p.To.Format("2006-01-02")
+}
+
+// LoadRanking は kind × 期間 p のランキングをキャッシュから返す（hit が true）。無い・期限切れなら load で取得して保持する。
+// 期限の半分を過ぎた結果は返しつつ、上限の範囲で裏で取り直す。
+func LoadRanking[T any](
+	ctx context.Context,
+	c *RankingCache,
+	kind string,
+	p RankingPeriod,
+	load func(ctx context.Context) ([]T, error),
+) ([]T, bool, error) {
+	key := rankingCacheKey(kind, p)
+
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
 
-	if len(c.gymDays) == 0 {
-		return nil, c.lastUpdated
+	if ok {
+		age := time.Since(entry.storedAt)
+		if data, typed := entry.data.([]T); typed && age < c.ttl {
+			c.hits.Add(1)
+			if age >= time.Duration(float64(c.ttl)*rankingRefreshAfterRatio) {
+				refreshRanking(c, key, p, load)
+			}
+			return copyRanking(data), true, nil
+		}
 	}
 
-	out := make([]GymDaysDTO, len(c.gymDays))
-	copy(out, c.gymDays)
-	return out, c.lastUpdated
+	c.misses.Add(1)
+	ch := c.group.DoChan(key, func() (any, error) {
+		// 同じキーを待つ他の呼び出し元を巻き込まないよう、最初の呼び出し元のキャンセルからは切り離す
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rankingLoadTimeout)
+		defer cancel()
+		return fetchRanking(loadCtx, c, key, p, load)
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, false, res.Err
+		}
+		return copyRanking(res.Val.([]T)), false, nil
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
 }
 
-func (c *RankingCache) SetGymDays(data []GymDaysDTO) {
+// fetchRanking は load の結果を保持する。取得中に InvalidateDay があった場合は古い結果の可能性があるため保持しない。
+func fetchRanking[T any](
+	ctx context.Context,
+	c *RankingCache,
+	key string,
+	p RankingPeriod,
+	load func(ctx context.Context) ([]T, error),
+) ([]T, error) {
+	c.mu.RLock()
+	generation := c.generation
+	c.mu.RUnlock()
+
+	data, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.generation != generation {
+		c.staleDiscarded.Add(1)
+		return data, nil
+	}
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.Sub(e.storedAt) >= c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = rankingCacheEntry{period: p, data: copyRanking(data), storedAt: now}
+	return data, nil
+}
 
-	if len(data) == 0 {
-		c.gymDays = nil
-		c.lastUpdated = time.Time{}
+func refreshRanking[T any](
+	c *RankingCache,
+	key string,
+	p RankingPeriod,
+	load func(ctx context.Context) ([]T, error),
+) {
+	select {
+	case c.refresh <- struct{}{}:
+	default:
+		c.skippedRefresh.Add(1)
 		return
 	}
+	c.refreshes.Add(1)
+
+	go func() {
+		defer func() { <-c.refresh }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), rankingLoadTimeout)
+		defer cancel()
 
-	c.gymDays = make([]GymDaysDTO, len(data))
-	copy(c.gymDays, data)
-	c.lastUpdated = time.Now()
+		_, err, _ := c.group.Do(key, func() (any, error) {
+			return fetchRanking(ctx, c, key, p, load)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "ranking_cache_refresh_failed",
+				"key", key,
+				"err", err,
+			)
+		}
+	}()
+}
+
+func copyRanking[T any](data []T) []T {
+	out := make([]T, len(data))
+	copy(out, data)
+	return out
+}
+
+// InvalidateDay は day を含む期間のキャッシュを破棄する。
+func (c *RankingCache) InvalidateDay(day time.Time) {
+	day = dayOf(day)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for k, e := range c.entries {
+		if !day.Before(e.period.From) && day.Before(e.period.To) {
+			delete(c.entries, k)
+			c.invalidations.Add(1)
+		}
+	}
+}
+
+func (c *RankingCache) Stats() RankingCacheStats {
+	c.mu.RLock()
+	entries := len(c.entries)
+	c.mu.RUnlock()
+
+	return RankingCacheStats{
+		Entries:          entries,
+		Hits:             c.hits.Load(),
+		Misses:           c.misses.Load(),
+		Refreshes:        c.refreshes.Load(),
+		SkippedRefreshes: c.skippedRefresh.Load(),
+		Invalidations:    c.invalidations.Load(),
+		StaleDiscarded:   c.staleDiscarded.Load(),
+	}
 }
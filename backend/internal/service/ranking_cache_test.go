@@ -1,51 +1,280 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/stretchr/testify/require"
 )
 
-func TestRankingCache_SetAndGetGymDays(t *testing.T) {
-	cache := NewRankingCache()
+func cacheDay(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
 
-	gotData, gotTime := cache.GetGymDays()
-	require.Len(t, gotData, 0)
-	require.True(t, gotTime.IsZero())
+func mustRankingPeriod(p RankingPeriod, err error) RankingPeriod {
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
 
-	data := []GymDaysDTO{
-		{
-			UserID:            1,
-			Email:             "test@example.com",
-			TotalTrainingDays: 3,
-		},
+func loadGymDays(calls *atomic.Int32, data []GymDaysDTO) func(context.Context) ([]GymDaysDTO, error) {
+	return func(context.Context) ([]GymDaysDTO, error) {
+		calls.Add(1)
+		return data, nil
 	}
+}
+
+func TestRankingCache_LoadRanking(t *testing.T) {
+	ctx := context.Background()
+	october := mustRankingPeriod(MonthRankingPeriod(2025, 10))
+
+	t.Run("【正常系】初回は取得して保持し、2回目はキャッシュから返すこと", func(t *testing.T) {
+		cache := NewRankingCache()
+		var calls atomic.Int32
+		load := loadGymDays(&calls, []GymDaysDTO{{UserID: 1, Email: "test@example.com", TotalTrainingDays: 3}})
+
+		got, hit, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		require.False(t, hit)
+		require.Equal(t, int64(3), got[0].TotalTrainingDays)
+
+		got, hit, err = LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.Equal(t, "test@example.com", got[0].Email)
+		require.Equal(t, int32(1), calls.Load())
+
+		stats := cache.Stats()
+		require.Equal(t, int64(1), stats.Hits)
+		require.Equal(t, int64(1), stats.Misses)
+		require.Equal(t, 1, stats.Entries)
+	})
+
+	t.Run("【正常系】返した結果を変更してもキャッシュに影響しないこと", func(t *testing.T) {
+		cache := NewRankingCache()
+		var calls atomic.Int32
+		load := loadGymDays(&calls, []GymDaysDTO{{UserID: 1, Email: "a@example.com"}})
+
+		got1, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		got1[0].Email = "modified@example.com"
+
+		got2, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		require.Equal(t, "a@example.com", got2[0].Email)
+	})
+
+	t.Run("【正常系】ランキングの種類と期間ごとに保持すること", func(t *testing.T) {
+		cache := NewRankingCache()
+		var calls atomic.Int32
+		load := loadGymDays(&calls, []GymDaysDTO{{UserID: 1}})
+
+		for _, key := range []struct {
+			kind string
+			p    RankingPeriod
+		}{
+			{RankingKindGymDays, october},
+			{RankingKindTotalVolume, october},
+			{RankingKindExerciseBest(1), october},
+			{RankingKindExerciseBest(2), october},
+			{RankingKindGymDays, mustRankingPeriod(YearRankingPeriod(2025))},
+			{RankingKindGymDays, mustRankingPeriod(RankingPeriodContaining(models.RankingWeek, cacheDay(10, 15)))},
+		} {
+			_, hit, err := LoadRanking(ctx, cache, key.kind, key.p, load)
+			require.NoError(t, err)
+			require.False(t, hit)
+		}
+		require.Equal(t, int32(6), calls.Load())
+		require.Equal(t, 6, cache.Stats().Entries)
+	})
+
+	t.Run("【正常系】TTLを過ぎた結果は取り直すこと", func(t *testing.T) {
+		cache := newRankingCache(time.Minute)
+		var calls atomic.Int32
+		load := loadGymDays(&calls, []GymDaysDTO{{UserID: 1}})
+
+		_, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		expireRankingEntries(cache, time.Minute)
+
+		_, hit, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		require.False(t, hit)
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("【正常系】TTLの半分を過ぎた結果は返しつつ裏で取り直すこと", func(t *testing.T) {
+		cache := newRankingCache(time.Minute)
+		refreshed := make(chan struct{}, 1)
+		var calls atomic.Int32
+		load := func(context.Context) ([]GymDaysDTO, error) {
+			if calls.Add(1) > 1 {
+				refreshed <- struct{}{}
+				return []GymDaysDTO{{UserID: 2}}, nil
+			}
+			return []GymDaysDTO{{UserID: 1}}, nil
+		}
 
-	cache.SetGymDays(data)
+		_, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		expireRankingEntries(cache, 40*time.Second)
 
-	gotData2, gotTime2 := cache.GetGymDays()
-	require.Len(t, gotData2, 1)
-	require.Equal(t, uint(1), gotData2[0].UserID)
-	require.Equal(t, "test@example.com", gotData2[0].Email)
-	require.Equal(t, int64(3), gotData2[0].TotalTrainingDays)
-	require.False(t, gotTime2.IsZero())
+		got, hit, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+		require.NoError(t, err)
+		require.True(t, hit)
+		require.Equal(t, uint(1), got[0].UserID)
+
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("background refresh did not run")
+		}
+		require.Eventually(t, func() bool {
+			cache.mu.RLock()
+			defer cache.mu.RUnlock()
+			e := cache.entries[rankingCacheKey(RankingKindGymDays, october)]
+			data, _ := e.data.([]GymDaysDTO)
+			return len(data) == 1 && data[0].UserID == 2
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, int64(1), cache.Stats().Refreshes)
+	})
+
+	t.Run("【正常系】同じキーの同時の取得は1回にまとめること", func(t *testing.T) {
+		cache := NewRankingCache()
+		release := make(chan struct{})
+		var calls atomic.Int32
+		load := func(context.Context) ([]GymDaysDTO, error) {
+			calls.Add(1)
+			<-release
+			return []GymDaysDTO{{UserID: 1}}, nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+				require.NoError(t, err)
+				require.Len(t, got, 1)
+			}()
+		}
+		require.Eventually(t, func() bool { return cache.Stats().Misses == 10 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("【正常系】最初の呼び出し元がキャンセルしても同時に待つ呼び出し元には結果を返すこと", func(t *testing.T) {
+		cache := NewRankingCache()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		load := func(loadCtx context.Context) ([]GymDaysDTO, error) {
+			close(started)
+			<-release
+			if err := loadCtx.Err(); err != nil {
+				return nil, err
+			}
+			return []GymDaysDTO{{UserID: 1}}, nil
+		}
+
+		firstCtx, cancel := context.WithCancel(ctx)
+		firstErr := make(chan error, 1)
+		go func() {
+			_, _, err := LoadRanking(firstCtx, cache, RankingKindGymDays, october, load)
+			firstErr <- err
+		}()
+		<-started
+
+		type result struct {
+			got []GymDaysDTO
+			err error
+		}
+		second := make(chan result, 1)
+		go func() {
+			got, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, load)
+			second <- result{got, err}
+		}()
+		require.Eventually(t, func() bool { return cache.Stats().Misses == 2 }, time.Second, time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		close(release)
+
+		res := <-second
+		require.NoError(t, res.err)
+		require.Len(t, res.got, 1)
+		require.Equal(t, 1, cache.Stats().Entries)
+	})
+
+	t.Run("【異常系】取得に失敗した場合はエラーを返し保持しないこと", func(t *testing.T) {
+		cache := NewRankingCache()
+		_, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, func(context.Context) ([]GymDaysDTO, error) {
+			return nil, errors.New("db down")
+		})
+		require.Error(t, err)
+		require.Equal(t, 0, cache.Stats().Entries)
+	})
 }
 
-func TestRankingCache_GetGymDays_ReturnsCopy(t *testing.T) {
-	cache := NewRankingCache()
+func TestRankingCache_InvalidateDay(t *testing.T) {
+	ctx := context.Background()
 
-	orig := []GymDaysDTO{
-		{UserID: 1, Email: "a@example.com", TotalTrainingDays: 3},
-	}
-	cache.SetGymDays(orig)
+	t.Run("【正常系】その日を含む期間のキャッシュだけ破棄すること", func(t *testing.T) {
+		cache := NewRankingCache()
+		var calls atomic.Int32
+		load := loadGymDays(&calls, []GymDaysDTO{{UserID: 1}})
+
+		october := mustRankingPeriod(MonthRankingPeriod(2025, 10))
+		september := mustRankingPeriod(MonthRankingPeriod(2025, 9))
+		year := mustRankingPeriod(YearRankingPeriod(2025))
+		for _, p := range []RankingPeriod{october, september, year} {
+			_, _, err := LoadRanking(ctx, cache, RankingKindTotalVolume, p, load)
+			require.NoError(t, err)
+		}
+
+		cache.InvalidateDay(cacheDay(10, 31))
 
-	got1, _ := cache.GetGymDays()
-	require.Len(t, got1, 1)
-	require.Equal(t, uint(1), got1[0].UserID)
+		_, hit, err := LoadRanking(ctx, cache, RankingKindTotalVolume, september, load)
+		require.NoError(t, err)
+		require.True(t, hit)
+		_, hit, err = LoadRanking(ctx, cache, RankingKindTotalVolume, october, load)
+		require.NoError(t, err)
+		require.False(t, hit)
+		_, hit, err = LoadRanking(ctx, cache, RankingKindTotalVolume, year, load)
+		require.NoError(t, err)
+		require.False(t, hit)
 
-	got1[0].Email = "modified@example.com"
+		require.Equal(t, int64(2), cache.Stats().Invalidations)
+	})
 
-	got2, _ := cache.GetGymDays()
+	t.Run("【正常系】取得中に破棄された場合は結果を保持しないこと", func(t *testing.T) {
+		cache := NewRankingCache()
+		october := mustRankingPeriod(MonthRankingPeriod(2025, 10))
 
-	require.Equal(t, "a@example.com", got2[0].Email)
+		_, _, err := LoadRanking(ctx, cache, RankingKindGymDays, october, func(context.Context) ([]GymDaysDTO, error) {
+			cache.InvalidateDay(cacheDay(10, 15))
+			return []GymDaysDTO{{UserID: 1}}, nil
+		})
+		require.NoError(t, err)
+
+		stats := cache.Stats()
+		require.Equal(t, 0, stats.Entries)
+		require.Equal(t, int64(1), stats.StaleDiscarded)
+	})
+}
+
+// expireRankingEntries は保持している結果を age だけ古くする。
+func expireRankingEntries(c *RankingCache, age time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		e.storedAt = e.storedAt.Add(-age)
+		c.entries[k] = e
+	}
 }
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// 任意の期間（custom）で集計できる最大日数
+const maxCustomRankingDays = 366
+
+// RankingPeriod はランキングの集計期間。From 以上 To 未満の日付（日本時間の日付を UTC の0時で表す）。
+type RankingPeriod struct {
+	Granularity models.RankingGranularity
+	From        time.Time
+	To          time.Time
+}
+
+// Closed は期間が today より前に終わっているかを返す。
+func (p RankingPeriod) Closed(today time.Time) bool {
+	return !today.Before(p.To)
+}
+
+// Contains は today が期間内かを返す。
+func (p RankingPeriod) Contains(today time.Time) bool {
+	return !today.Before(p.From) && today.Before(p.To)
+}
+
+// MonthRankingPeriod は year 年 month 月の期間を返す。
+func MonthRankingPeriod(year, month int) (RankingPeriod, error) {
+	from, to, err := calcMonthRange(year, month)
+	if err != nil {
+		return RankingPeriod{}, fmt.Errorf("%w: %v", ErrInvalidRankingQuery, err)
+	}
+	return RankingPeriod{Granularity: models.RankingMonth, From: from, To: to}, nil
+}
+
+// YearRankingPeriod は year 年の期間を返す。
+func YearRankingPeriod(year int) (RankingPeriod, error) {
+	from, _, err := calcMonthRange(year, 1)
+	if err != nil {
+		return RankingPeriod{}, fmt.Errorf("%w: %v", ErrInvalidRankingQuery, err)
+	}
+	return RankingPeriod{Granularity: models.RankingYear, From: from, To: from.AddDate(1, 0, 0)}, nil
+}
+
+// RankingPeriodContaining は day を含む週（月曜始まり）・月・年の期間を返す。
+func RankingPeriodContaining(granularity models.RankingGranularity, day time.Time) (RankingPeriod, error) {
+	switch granularity {
+	case models.RankingWeek:
+		if _, _, err := calcMonthRange(day.Year(), int(day.Month())); err != nil {
+			return RankingPeriod{}, fmt.Errorf("%w: %v", ErrInvalidRankingQuery, err)
+		}
+		from := isoWeekStart(day)
+		return RankingPeriod{Granularity: models.RankingWeek, From: from, To: from.AddDate(0, 0, 7)}, nil
+	case models.RankingMonth:
+		return MonthRankingPeriod(day.Year(), int(day.Month()))
+	case models.RankingYear:
+		return YearRankingPeriod(day.Year())
+	}
+	return RankingPeriod{}, ErrInvalidRankingQuery
+}
+
+// CustomRankingPeriod は from〜to（to を含む）の任意の期間を返す。
+func CustomRankingPeriod(from, to time.Time) (RankingPeriod, error) {
+	for _, d := range []time.Time{from, to} {
+		if _, _, err := calcMonthRange(d.Year(), int(d.Month())); err != nil {
+			return RankingPeriod{}, fmt.Errorf("%w: %v", ErrInvalidRankingQuery, err)
+		}
+	}
+	from, to = dayOf(from), dayOf(to).AddDate(0, 0, 1)
+	if !from.Before(to) || to.Sub(from) > maxCustomRankingDays*24*time.Hour {
+		return RankingPeriod{}, ErrInvalidRankingQuery
+	}
+	return RankingPeriod{Granularity: models.RankingCustom, From: from, To: to}, nil
+}
+
+// rankingWithSnapshot は締まった週・月・年のランキングを初回に集計してスナップショットとして保存し、以降は保存した結果を返す。
+// 進行中の期間と任意の期間は毎回集計する。
+func rankingWithSnapshot[T any](
+	ctx context.Context,
+	repo repository.RankingRepository,
+	kind string,
+	p RankingPeriod,
+	today time.Time,
+	compute func() ([]T, error),
+) ([]T, error) {
+	if p.Granularity == models.RankingCustom || !p.Closed(today) {
+		return compute()
+	}
+
+	snap, err := repo.FindSnapshot(ctx, kind, p.Granularity, p.From)
+	if err == nil {
+		return decodeSnapshot[T](snap)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("find ranking snapshot failed: %w", err)
+	}
+
+	out, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("encode ranking snapshot failed: %w", err)
+	}
+	err = repo.CreateSnapshot(ctx, &models.RankingSnapshot{
+		Kind:        kind,
+		Granularity: p.Granularity,
+		PeriodStart: p.From,
+		Entries:     string(entries),
+	})
+	if err == nil {
+		return out, nil
+	}
+	if !errors.Is(err, repository.ErrUniqueViolation) {
+		return nil, fmt.Errorf("save ranking snapshot failed: %w", err)
+	}
+	// 同時に保存された場合は先に保存された結果にそろえる
+	snap, err = repo.FindSnapshot(ctx, kind, p.Granularity, p.From)
+	if err != nil {
+		return nil, fmt.Errorf("find ranking snapshot failed: %w", err)
+	}
+	return decodeSnapshot[T](snap)
+}
+
+func decodeSnapshot[T any](snap *models.RankingSnapshot) ([]T, error) {
+	out := []T{}
+	if err := json.Unmarshal([]byte(snap.Entries), &out); err != nil {
+		return nil, fmt.Errorf("decode ranking snapshot failed: %w", err)
+	}
+	return out, nil
+}
@@ -3,14 +3,36 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 )
 
+// RankingService の各ランキングは期間 p で集計する。締まった週・月・年は初回の結果を保存し、以降はそれを返す。
 type RankingService interface {
-	MonthlyGymDays(ctx context.Context, year, month int) ([]GymDaysDTO, error)
+	GymDays(ctx context.Context, p RankingPeriod, today time.Time) ([]GymDaysDTO, error)
+	TotalVolume(ctx context.Context, p RankingPeriod, today time.Time) ([]TotalVolumeDTO, error)
+	ExerciseBest(ctx context.Context, exerciseID uint, p RankingPeriod, today time.Time) ([]ExerciseBestDTO, error)
+	RelativeStrength(ctx context.Context, q RelativeStrengthQuery, p RankingPeriod, today time.Time) ([]RelativeStrengthDTO, error)
+}
+
+// スナップショットとキャッシュで使うランキングの種類
+const (
+	RankingKindGymDays     = "gym_days"
+	RankingKindTotalVolume = "total_volume"
+)
+
+func RankingKindExerciseBest(exerciseID uint) string {
+	return fmt.Sprintf("exercise_best:%d", exerciseID)
+}
+
+// RankingKindRelativeStrength の v2 はスコアをトータルから求めるようにした版で、それ以前に保存したスナップショットは使わない。
+func RankingKindRelativeStrength(q RelativeStrengthQuery) string {
+	return fmt.Sprintf("relative_strength:v2:%s:%s", q.Formula, q.WeightClass)
 }
 
 type rankingService struct {
@@ -33,6 +55,32 @@ type TotalVolumeDTO struct {
 	TotalVolume float64 `json:"total_volume"`
 }
 
+type ExerciseBestDTO struct {
+	UserID     uint    `json:"user_id"`
+	Email      string  `json:"email"`
+	BestWeight float64 `json:"best_weight"`
+}
+
+// RelativeStrengthQuery の WeightClass は IPF の体重階級（"83" や "84+"、空なら全階級）。
+type RelativeStrengthQuery struct {
+	Formula     models.StrengthFormula
+	WeightClass string
+}
+
+// RelativeStrengthDTO の BodyWeight は3種目の記録時の体重のうち最も重いもので、階級はこの体重で決める。
+type RelativeStrengthDTO struct {
+	UserID      uint    `json:"user_id"`
+	Email       string  `json:"email"`
+	Sex         string  `json:"sex"`
+	WeightClass string  `json:"weight_class"`
+	BodyWeight  float64 `json:"body_weight"`
+	Squat       float64 `json:"squat"`
+	Bench       float64 `json:"bench"`
+	Deadlift    float64 `json:"deadlift"`
+	Total       float64 `json:"total"`
+	Score       float64 `json:"score"`
+}
+
 func calcMonthRange(year, month int) (time.Time, time.Time, error) {
 	if month < 1 || month > 12 {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid month: %d", month)
@@ -41,21 +89,21 @@ func calcMonthRange(year, month int) (time.Time, time.Time, error) {
 		return time.Time{}, time.Time{}, fmt.Errorf("invalid year: %d", year)
 	}
 
-	loc, _ := time.LoadLocation("Asia/Tokyo")
-	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	to := from.AddDate(0, 1, 0)
 	return from, to, nil
 }
 
-func (s *rankingService) MonthlyGymDays(
-	ctx context.Context, year, month int,
+func (s *rankingService) GymDays(
+	ctx context.Context, p RankingPeriod, today time.Time,
 ) ([]GymDaysDTO, error) {
-	from, to, err := calcMonthRange(year, month)
-	if err != nil {
-		return nil, err
-	}
+	return rankingWithSnapshot(ctx, s.repo, RankingKindGymDays, p, today, func() ([]GymDaysDTO, error) {
+		return s.gymDays(ctx, p)
+	})
+}
 
-	rows, err := s.repo.MonthlyGymDays(ctx, from, to)
+func (s *rankingService) gymDays(ctx context.Context, p RankingPeriod) ([]GymDaysDTO, error) {
+	rows, err := s.repo.MonthlyGymDays(ctx, p.From, p.To)
 	if err != nil {
 		return nil, err
 	}
@@ -70,3 +118,138 @@ func (s *rankingService) MonthlyGymDays(
 	}
 	return out, nil
 }
+
+func (s *rankingService) TotalVolume(
+	ctx context.Context, p RankingPeriod, today time.Time,
+) ([]TotalVolumeDTO, error) {
+	return rankingWithSnapshot(ctx, s.repo, RankingKindTotalVolume, p, today, func() ([]TotalVolumeDTO, error) {
+		return s.totalVolume(ctx, p)
+	})
+}
+
+func (s *rankingService) totalVolume(ctx context.Context, p RankingPeriod) ([]TotalVolumeDTO, error) {
+	rows, err := s.repo.MonthlyTotalVolume(ctx, p.From, p.To)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]TotalVolumeDTO, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, TotalVolumeDTO{
+			UserID:      r.UserID,
+			Email:       r.Email,
+			TotalVolume: round2(r.TotalVolume),
+		})
+	}
+	return out, nil
+}
+
+// ExerciseBest は種目ごとの最高重量ランキング。重量を記録しない種目は ErrInvalidRankingExercise を返す。
+func (s *rankingService) ExerciseBest(
+	ctx context.Context, exerciseID uint, p RankingPeriod, today time.Time,
+) ([]ExerciseBestDTO, error) {
+	ex, err := s.repo.FindRankableExercise(ctx, exerciseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("find exercise failed: %w", err)
+	}
+	if ex.MeasurementKind != models.MeasurementRepsWeight && ex.MeasurementKind != models.MeasurementBodyweightLoad {
+		return nil, ErrInvalidRankingExercise
+	}
+
+	return rankingWithSnapshot(ctx, s.repo, RankingKindExerciseBest(exerciseID), p, today, func() ([]ExerciseBestDTO, error) {
+		return s.exerciseBest(ctx, exerciseID, p)
+	})
+}
+
+func (s *rankingService) exerciseBest(ctx context.Context, exerciseID uint, p RankingPeriod) ([]ExerciseBestDTO, error) {
+	rows, err := s.repo.MonthlyExerciseBest(ctx, exerciseID, p.From, p.To)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ExerciseBestDTO, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, ExerciseBestDTO{
+			UserID:     r.UserID,
+			Email:      r.Email,
+			BestWeight: r.BestWeight,
+		})
+	}
+	return out, nil
+}
+
+// RelativeStrength は期間内の BIG3 の最高重量から求めた相対筋力スコアの高い順に返す。
+// 3種目とも記録があり、性別を設定しているユーザーが対象。スコアは3種目のトータルに、
+// 記録時の最も重い体重（体重階級と同じ）で求めた係数を1回だけ掛ける。
+func (s *rankingService) RelativeStrength(
+	ctx context.Context, q RelativeStrengthQuery, p RankingPeriod, today time.Time,
+) ([]RelativeStrengthDTO, error) {
+	if !q.Formula.Valid() {
+		return nil, ErrInvalidRankingQuery
+	}
+	if q.WeightClass != "" {
+		if _, ok := models.WeightClassSex(q.WeightClass); !ok {
+			return nil, ErrInvalidRankingQuery
+		}
+	}
+	return rankingWithSnapshot(ctx, s.repo, RankingKindRelativeStrength(q), p, today, func() ([]RelativeStrengthDTO, error) {
+		return s.relativeStrength(ctx, q, p)
+	})
+}
+
+func (s *rankingService) relativeStrength(ctx context.Context, q RelativeStrengthQuery, p RankingPeriod) ([]RelativeStrengthDTO, error) {
+	rows, err := s.repo.MonthlyBigThreeBests(ctx, p.From, p.To)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		dto   RelativeStrengthDTO
+		lifts int
+	}
+	byUser := map[uint]*entry{}
+	var order []uint
+	for _, r := range rows {
+		e, ok := byUser[r.UserID]
+		if !ok {
+			e = &entry{dto: RelativeStrengthDTO{UserID: r.UserID, Email: r.Email, Sex: string(r.Sex)}}
+			byUser[r.UserID] = e
+			order = append(order, r.UserID)
+		}
+		switch r.Lift {
+		case models.BigThreeLiftNames[0]:
+			e.dto.Squat = r.Weight
+		case models.BigThreeLiftNames[1]:
+			e.dto.Bench = r.Weight
+		case models.BigThreeLiftNames[2]:
+			e.dto.Deadlift = r.Weight
+		default:
+			continue
+		}
+		e.lifts++
+		if r.BodyWeight > e.dto.BodyWeight {
+			e.dto.BodyWeight = r.BodyWeight
+		}
+	}
+
+	out := make([]RelativeStrengthDTO, 0, len(order))
+	for _, id := range order {
+		e := byUser[id]
+		if e.lifts < len(models.BigThreeLiftNames) {
+			continue
+		}
+		e.dto.WeightClass = models.WeightClass(models.Sex(e.dto.Sex), e.dto.BodyWeight)
+		if q.WeightClass != "" && e.dto.WeightClass != q.WeightClass {
+			continue
+		}
+		total := e.dto.Squat + e.dto.Bench + e.dto.Deadlift
+		e.dto.Total = round2(total)
+		e.dto.Score = round2(total * q.Formula.Coefficient(models.Sex(e.dto.Sex), e.dto.BodyWeight))
+		out = append(out, e.dto)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out, nil
+}
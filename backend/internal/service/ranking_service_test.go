@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRankingRepo struct {
+	gymDaysFn  func(from, to time.Time) ([]repository.GymDaysRow, error)
+	volumeFn   func(from, to time.Time) ([]repository.TotalVolumeRow, error)
+	exerciseFn func(exerciseID uint) (*models.Exercise, error)
+	bestFn     func(exerciseID uint, from, to time.Time) ([]repository.ExerciseBestRow, error)
+	bigThreeFn func(from, to time.Time) ([]repository.BigThreeLiftRow, error)
+	snapshots  map[string]models.RankingSnapshot
+}
+
+func (f *fakeRankingRepo) MonthlyGymDays(_ context.Context, from, to time.Time) ([]repository.GymDaysRow, error) {
+	return f.gymDaysFn(from, to)
+}
+func (f *fakeRankingRepo) MonthlyTotalVolume(_ context.Context, from, to time.Time) ([]repository.TotalVolumeRow, error) {
+	return f.volumeFn(from, to)
+}
+func (f *fakeRankingRepo) FindRankableExercise(_ context.Context, exerciseID uint) (*models.Exercise, error) {
+	return f.exerciseFn(exerciseID)
+}
+func (f *fakeRankingRepo) MonthlyExerciseBest(_ context.Context, exerciseID uint, from, to time.Time) ([]repository.ExerciseBestRow, error) {
+	return f.bestFn(exerciseID, from, to)
+}
+
+func (f *fakeRankingRepo) MonthlyBigThreeBests(_ context.Context, from, to time.Time) ([]repository.BigThreeLiftRow, error) {
+	return f.bigThreeFn(from, to)
+}
+
+func (f *fakeRankingRepo) FindSnapshot(_ context.Context, kind string, g models.RankingGranularity, start time.Time) (*models.RankingSnapshot, error) {
+	snap, ok := f.snapshots[kind+string(g)+start.Format("2006-01-02")]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &snap, nil
+}
+func (f *fakeRankingRepo) CreateSnapshot(_ context.Context, snap *models.RankingSnapshot) error {
+	if f.snapshots == nil {
+		f.snapshots = map[string]models.RankingSnapshot{}
+	}
+	f.snapshots[snap.Kind+string(snap.Granularity)+snap.PeriodStart.Format("2006-01-02")] = *snap
+	return nil
+}
+
+// 2025年10月の期間と、その期間中の今日
+func october2025(t *testing.T) (RankingPeriod, time.Time) {
+	t.Helper()
+	p, err := MonthRankingPeriod(2025, 10)
+	require.NoError(t, err)
+	return p, time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRankingService_TotalVolume(t *testing.T) {
+	svc := NewRankingService(&fakeRankingRepo{
+		volumeFn: func(from, to time.Time) ([]repository.TotalVolumeRow, error) {
+			require.Equal(t, 10, int(from.Month()))
+			require.Equal(t, 11, int(to.Month()))
+			return []repository.TotalVolumeRow{{UserID: 1, Email: "a@example.com", TotalVolume: 12345.678}}, nil
+		},
+	})
+
+	p, today := october2025(t)
+	got, err := svc.TotalVolume(context.Background(), p, today)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, 12345.68, got[0].TotalVolume)
+}
+
+func TestRankingService_ExerciseBest(t *testing.T) {
+	exercises := func(id uint) (*models.Exercise, error) {
+		switch id {
+		case 1:
+			return &models.Exercise{MeasurementKind: models.MeasurementRepsWeight}, nil
+		case 2:
+			return &models.Exercise{MeasurementKind: models.MeasurementDuration}, nil
+		}
+		return nil, repository.ErrNotFound
+	}
+
+	tests := []struct {
+		name       string
+		exerciseID uint
+		wantErr    error
+		wantLen    int
+	}{
+		{
+			name:       "【正常系】重量を記録する種目のランキングを返すこと",
+			exerciseID: 1,
+			wantLen:    2,
+		},
+		{
+			name:       "【異常系】重量を記録しない種目は ErrInvalidRankingExercise を返すこと",
+			exerciseID: 2,
+			wantErr:    ErrInvalidRankingExercise,
+		},
+		{
+			name:       "【異常系】対象外の種目は ErrExerciseNotFound を返すこと",
+			exerciseID: 9,
+			wantErr:    ErrExerciseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewRankingService(&fakeRankingRepo{
+				exerciseFn: exercises,
+				bestFn: func(exerciseID uint, _, _ time.Time) ([]repository.ExerciseBestRow, error) {
+					require.Equal(t, tt.exerciseID, exerciseID)
+					return []repository.ExerciseBestRow{
+						{UserID: 2, BestWeight: 120},
+						{UserID: 1, BestWeight: 100},
+					}, nil
+				},
+			})
+
+			p, today := october2025(t)
+			got, err := svc.ExerciseBest(context.Background(), tt.exerciseID, p, today)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, tt.wantLen)
+			require.Equal(t, 120.0, got[0].BestWeight)
+		})
+	}
+}
+
+func TestRankingService_RelativeStrength(t *testing.T) {
+	lifts := func(userID uint, sex models.Sex, bodyWeight float64, squat, bench, deadlift float64) []repository.BigThreeLiftRow {
+		return []repository.BigThreeLiftRow{
+			{UserID: userID, Sex: sex, Lift: "スクワット", Weight: squat, BodyWeight: bodyWeight},
+			{UserID: userID, Sex: sex, Lift: "ベンチプレス", Weight: bench, BodyWeight: bodyWeight},
+			{UserID: userID, Sex: sex, Lift: "デッドリフト", Weight: deadlift, BodyWeight: bodyWeight + 1},
+		}
+	}
+	var rows []repository.BigThreeLiftRow
+	rows = append(rows, lifts(1, models.SexMale, 100, 200, 140, 240)...)                                                        // 重いが相対的には弱い
+	rows = append(rows, lifts(2, models.SexMale, 74, 180, 120, 220)...)                                                         // 軽くて強い
+	rows = append(rows, lifts(3, models.SexFemale, 62, 120, 70, 150)...)                                                        // 女子63kg級
+	rows = append(rows, repository.BigThreeLiftRow{UserID: 4, Sex: models.SexMale, Lift: "スクワット", Weight: 300, BodyWeight: 90}) // 3種目そろっていない
+
+	svc := NewRankingService(&fakeRankingRepo{
+		bigThreeFn: func(time.Time, time.Time) ([]repository.BigThreeLiftRow, error) { return rows, nil },
+	})
+
+	tests := []struct {
+		name      string
+		query     RelativeStrengthQuery
+		wantUsers []uint
+		wantErr   error
+	}{
+		{
+			name:      "【正常系】DOTS のスコア順に3種目そろったユーザーを返すこと",
+			query:     RelativeStrengthQuery{Formula: models.StrengthDOTS},
+			wantUsers: []uint{2, 3, 1},
+		},
+		{
+			name:      "【正常系】体重階級で絞り込めること",
+			query:     RelativeStrengthQuery{Formula: models.StrengthWilks, WeightClass: "63"},
+			wantUsers: []uint{3},
+		},
+		{
+			name:      "【正常系】記録時の最も重い体重で階級を決めること",
+			query:     RelativeStrengthQuery{Formula: models.StrengthIPFGL, WeightClass: "83"},
+			wantUsers: []uint{2},
+		},
+		{
+			name:    "【異常系】未知の計算式は ErrInvalidRankingQuery を返すこと",
+			query:   RelativeStrengthQuery{Formula: "glossbrenner"},
+			wantErr: ErrInvalidRankingQuery,
+		},
+		{
+			name:    "【異常系】存在しない階級は ErrInvalidRankingQuery を返すこと",
+			query:   RelativeStrengthQuery{Formula: models.StrengthDOTS, WeightClass: "80"},
+			wantErr: ErrInvalidRankingQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, today := october2025(t)
+			got, err := svc.RelativeStrength(context.Background(), tt.query, p, today)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var users []uint
+			for _, r := range got {
+				users = append(users, r.UserID)
+				require.Positive(t, r.Score)
+			}
+			require.Equal(t, tt.wantUsers, users)
+		})
+	}
+
+	t.Run("【正常系】合計と体重を返すこと", func(t *testing.T) {
+		p, today := october2025(t)
+		got, err := svc.RelativeStrength(context.Background(), RelativeStrengthQuery{Formula: models.StrengthDOTS, WeightClass: "105"}, p, today)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, 580.0, got[0].Total)
+		require.Equal(t, 101.0, got[0].BodyWeight)
+		require.Equal(t, "male", got[0].Sex)
+		require.Equal(t, round2(580*models.StrengthDOTS.Coefficient(models.SexMale, 101)), got[0].Score, "係数はトータルに1回だけ掛ける")
+	})
+}
+
+func TestRankingService_Snapshot(t *testing.T) {
+	p, _ := october2025(t)
+	calls := 0
+	repo := &fakeRankingRepo{
+		gymDaysFn: func(time.Time, time.Time) ([]repository.GymDaysRow, error) {
+			calls++
+			return []repository.GymDaysRow{{UserID: 1, TotalTrainingDays: int64(10 + calls)}}, nil
+		},
+	}
+	svc := NewRankingService(repo)
+	ctx := context.Background()
+
+	t.Run("【正常系】進行中の期間は毎回集計して保存しないこと", func(t *testing.T) {
+		got, err := svc.GymDays(ctx, p, time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, int64(11), got[0].TotalTrainingDays)
+		require.Empty(t, repo.snapshots)
+	})
+
+	t.Run("【正常系】締まった期間は初回の結果を保存して以降はそれを返すこと", func(t *testing.T) {
+		nextMonth := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+		got, err := svc.GymDays(ctx, p, nextMonth)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), got[0].TotalTrainingDays)
+
+		again, err := svc.GymDays(ctx, p, nextMonth.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		require.Equal(t, got, again)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("【正常系】任意の期間は保存しないこと", func(t *testing.T) {
+		custom, err := CustomRankingPeriod(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, p.To, custom.To)
+
+		_, err = svc.GymDays(ctx, custom, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, repo.snapshots, 1)
+	})
+}
+
+func TestRankingPeriod(t *testing.T) {
+	day := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC) // 水曜日
+
+	tests := []struct {
+		name     string
+		build    func() (RankingPeriod, error)
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "【正常系】週は月曜始まりの7日間",
+			build:    func() (RankingPeriod, error) { return RankingPeriodContaining(models.RankingWeek, day) },
+			wantFrom: time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "【正常系】年は1月1日から翌年1月1日まで",
+			build:    func() (RankingPeriod, error) { return RankingPeriodContaining(models.RankingYear, day) },
+			wantFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "【異常系】不正な月は ErrInvalidRankingQuery を返すこと",
+			build:   func() (RankingPeriod, error) { return MonthRankingPeriod(2025, 13) },
+			wantErr: true,
+		},
+		{
+			name:    "【異常系】範囲外の年は ErrInvalidRankingQuery を返すこと",
+			build:   func() (RankingPeriod, error) { return YearRankingPeriod(1999) },
+			wantErr: true,
+		},
+		{
+			name: "【異常系】終了日が開始日より前の任意の期間は ErrInvalidRankingQuery を返すこと",
+			build: func() (RankingPeriod, error) {
+				return CustomRankingPeriod(day, day.AddDate(0, 0, -1))
+			},
+			wantErr: true,
+		},
+		{
+			name: "【異常系】366日を超える任意の期間は ErrInvalidRankingQuery を返すこと",
+			build: func() (RankingPeriod, error) {
+				return CustomRankingPeriod(day, day.AddDate(1, 0, 1))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRankingQuery)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFrom, got.From)
+			require.Equal(t, tt.wantTo, got.To)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// PeriodRecap はまとめと、期間が終わって確定（保存）済みかどうか。
+// 進行中の期間のまとめはその時点の集計で、保存しない。
+type PeriodRecap struct {
+	Recap models.Recap
+	Final bool
+}
+
+type RecapService interface {
+	GetRecap(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*PeriodRecap, error)
+	ListRecaps(userID uint) ([]models.Recap, error)
+	PublishRecap(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*models.Recap, error)
+	UnpublishRecap(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error)
+}
+
+type recapService struct {
+	repo repository.RecapRepository
+}
+
+func NewRecapService(repo repository.RecapRepository) RecapService {
+	return &recapService{repo: repo}
+}
+
+// GetRecap は start を含む期間のまとめを返す。終わった期間は初回に集計して保存し、以降は保存した内容を返す。
+func (s *recapService) GetRecap(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*PeriodRecap, error) {
+	if !period.Valid() {
+		return nil, ErrInvalidRecapPeriod
+	}
+	start = period.Start(start)
+	if start.After(today) {
+		return nil, ErrInvalidRecapPeriod
+	}
+
+	final := !today.Before(period.End(start))
+	if final {
+		recap, err := s.repo.FindByPeriod(userID, period, start)
+		if err == nil {
+			return &PeriodRecap{Recap: *recap, Final: true}, nil
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("find recap failed: %w", err)
+		}
+	}
+
+	src, err := s.repo.FindSource(userID, start, period.End(start))
+	if err != nil {
+		return nil, fmt.Errorf("aggregate recap failed: %w", err)
+	}
+	recap := buildRecap(userID, period, start, src)
+	if !final {
+		return &PeriodRecap{Recap: recap}, nil
+	}
+
+	if err := s.repo.Create(&recap); err != nil {
+		if !errors.Is(err, repository.ErrUniqueViolation) {
+			return nil, fmt.Errorf("save recap failed: %w", err)
+		}
+	}
+	// 同時に保存された場合も含め、保存済みの内容を返す
+	saved, err := s.repo.FindByPeriod(userID, period, start)
+	if err != nil {
+		return nil, fmt.Errorf("find recap failed: %w", err)
+	}
+	return &PeriodRecap{Recap: *saved, Final: true}, nil
+}
+
+func (s *recapService) ListRecaps(userID uint) ([]models.Recap, error) {
+	recaps, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("list recaps failed: %w", err)
+	}
+	return recaps, nil
+}
+
+// PublishRecap は確定済みのまとめをタイムラインに公開する。進行中の期間は ErrRecapNotFinal を返す。
+func (s *recapService) PublishRecap(userID uint, period models.RecapPeriod, start time.Time, today time.Time) (*models.Recap, error) {
+	pr, err := s.GetRecap(userID, period, start, today)
+	if err != nil {
+		return nil, err
+	}
+	if !pr.Final {
+		return nil, ErrRecapNotFinal
+	}
+
+	recap := pr.Recap
+	if recap.IsPublic {
+		return &recap, nil
+	}
+	now := time.Now()
+	recap.IsPublic = true
+	recap.PublishedAt = &now
+	if err := s.repo.UpdatePublication(&recap); err != nil {
+		return nil, fmt.Errorf("publish recap failed: %w", err)
+	}
+	return &recap, nil
+}
+
+func (s *recapService) UnpublishRecap(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error) {
+	if !period.Valid() {
+		return nil, ErrInvalidRecapPeriod
+	}
+	recap, err := s.repo.FindByPeriod(userID, period, period.Start(start))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRecapNotFound
+		}
+		return nil, fmt.Errorf("find recap failed: %w", err)
+	}
+
+	recap.IsPublic = false
+	recap.PublishedAt = nil
+	if err := s.repo.UpdatePublication(recap); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRecapNotFound
+		}
+		return nil, fmt.Errorf("unpublish recap failed: %w", err)
+	}
+	return recap, nil
+}
+
+// buildRecap は集計元からまとめを作る。よく行った種目はトレーニング日数が最も多い種目（同じならボリュームの多い方）。
+func buildRecap(userID uint, period models.RecapPeriod, start time.Time, src *repository.RecapSource) models.Recap {
+	recap := models.Recap{
+		UserID:        userID,
+		Period:        period,
+		PeriodStart:   start,
+		TotalSessions: src.Sessions,
+		PRCount:       src.PRCount,
+		LikesReceived: src.LikesReceived,
+	}
+
+	var favorite *repository.RecapExercise
+	var tonnage float64
+	for i := range src.Exercises {
+		ex := &src.Exercises[i]
+		tonnage += ex.Tonnage
+		if favorite == nil || ex.Days > favorite.Days ||
+			(ex.Days == favorite.Days && ex.Tonnage > favorite.Tonnage) {
+			favorite = ex
+		}
+	}
+	recap.TotalTonnage = round2(tonnage)
+	if favorite != nil {
+		id := favorite.ExerciseID
+		recap.FavoriteExerciseID = &id
+		recap.FavoriteExerciseDays = favorite.Days
+		recap.FavoriteExercise = &models.Exercise{Name: favorite.Name}
+	}
+
+	days := map[time.Time]bool{}
+	perMonth := map[time.Time]int{}
+	for _, d := range src.TrainingDates {
+		d = dayOf(d)
+		if days[d] {
+			continue
+		}
+		days[d] = true
+		perMonth[models.RecapMonth.Start(d)]++
+	}
+	recap.TrainingDays = len(days)
+	_, recap.LongestStreak = streakLengths(days, 1, start)
+
+	if period == models.RecapYear {
+		for m := start; m.Before(period.End(start)); m = m.AddDate(0, 1, 0) {
+			if n := perMonth[m]; n > recap.BestMonthDays {
+				month := m
+				recap.BestMonth = &month
+				recap.BestMonthDays = n
+			}
+		}
+	}
+	return recap
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRecapRepo struct {
+	findFn    func(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error)
+	listFn    func(userID uint) ([]models.Recap, error)
+	createFn  func(recap *models.Recap) error
+	publishFn func(recap *models.Recap) error
+	sourceFn  func(userID uint, from, to time.Time) (*repository.RecapSource, error)
+}
+
+func (f *fakeRecapRepo) FindByPeriod(userID uint, period models.RecapPeriod, start time.Time) (*models.Recap, error) {
+	return f.findFn(userID, period, start)
+}
+func (f *fakeRecapRepo) FindByUser(userID uint) ([]models.Recap, error) {
+	return f.listFn(userID)
+}
+func (f *fakeRecapRepo) Create(recap *models.Recap) error { return f.createFn(recap) }
+func (f *fakeRecapRepo) UpdatePublication(recap *models.Recap) error {
+	return f.publishFn(recap)
+}
+func (f *fakeRecapRepo) FindSource(userID uint, from, to time.Time) (*repository.RecapSource, error) {
+	return f.sourceFn(userID, from, to)
+}
+
+func TestBuildRecap(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	src := &repository.RecapSource{
+		Sessions:      5,
+		TrainingDates: []time.Time{day(3, 1), day(3, 2), day(3, 3), day(6, 10), day(6, 12)},
+		Exercises: []repository.RecapExercise{
+			{ExerciseID: 1, Name: "ベンチプレス", Days: 3, Tonnage: 1000.123},
+			{ExerciseID: 2, Name: "スクワット", Days: 3, Tonnage: 1500},
+			{ExerciseID: 3, Name: "懸垂", Days: 1, Tonnage: 0},
+		},
+		PRCount:       4,
+		LikesReceived: 2,
+	}
+
+	got := buildRecap(1, models.RecapYear, day(1, 1), src)
+
+	require.Equal(t, 5, got.TrainingDays)
+	require.Equal(t, 2500.12, got.TotalTonnage)
+	require.Equal(t, uint(2), *got.FavoriteExerciseID) // 日数が同じならボリュームの多い方
+	require.Equal(t, 3, got.FavoriteExerciseDays)
+	require.Equal(t, 3, got.LongestStreak)
+	require.Equal(t, day(3, 1), *got.BestMonth)
+	require.Equal(t, 3, got.BestMonthDays)
+	require.Equal(t, 4, got.PRCount)
+	require.Equal(t, 2, got.LikesReceived)
+
+	t.Run("【正常系】月のまとめでは最も多い月を求めないこと", func(t *testing.T) {
+		got := buildRecap(1, models.RecapMonth, day(3, 1), src)
+		require.Nil(t, got.BestMonth)
+	})
+
+	t.Run("【正常系】記録が無い期間は空のまとめになること", func(t *testing.T) {
+		got := buildRecap(1, models.RecapMonth, day(3, 1), &repository.RecapSource{})
+		require.Nil(t, got.FavoriteExerciseID)
+		require.Zero(t, got.TrainingDays)
+		require.Zero(t, got.LongestStreak)
+	})
+}
+
+func TestRecapService_GetRecap(t *testing.T) {
+	today := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+	source := func(uint, time.Time, time.Time) (*repository.RecapSource, error) {
+		return &repository.RecapSource{Sessions: 3}, nil
+	}
+
+	t.Run("【正常系】終わった期間は初回に保存して確定済みとして返すこと", func(t *testing.T) {
+		var saved *models.Recap
+		svc := NewRecapService(&fakeRecapRepo{
+			findFn: func(_ uint, _ models.RecapPeriod, start time.Time) (*models.Recap, error) {
+				require.Equal(t, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), start)
+				if saved == nil {
+					return nil, repository.ErrNotFound
+				}
+				return saved, nil
+			},
+			createFn: func(r *models.Recap) error {
+				r.ID = 3
+				saved = r
+				return nil
+			},
+			sourceFn: source,
+		})
+
+		got, err := svc.GetRecap(1, models.RecapMonth, time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC), today)
+		require.NoError(t, err)
+		require.True(t, got.Final)
+		require.Equal(t, uint(3), got.Recap.ID)
+		require.Equal(t, 3, got.Recap.TotalSessions)
+	})
+
+	t.Run("【正常系】同時に保存された場合も保存済みの内容を返すこと", func(t *testing.T) {
+		calls := 0
+		svc := NewRecapService(&fakeRecapRepo{
+			findFn: func(uint, models.RecapPeriod, time.Time) (*models.Recap, error) {
+				calls++
+				if calls == 1 {
+					return nil, repository.ErrNotFound
+				}
+				return &models.Recap{ID: 8}, nil
+			},
+			createFn: func(*models.Recap) error { return repository.ErrUniqueViolation },
+			sourceFn: source,
+		})
+
+		got, err := svc.GetRecap(1, models.RecapMonth, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), today)
+		require.NoError(t, err)
+		require.Equal(t, uint(8), got.Recap.ID)
+	})
+
+	t.Run("【正常系】進行中の期間は保存せずに返すこと", func(t *testing.T) {
+		svc := NewRecapService(&fakeRecapRepo{
+			createFn: func(*models.Recap) error {
+				t.Fatal("進行中の期間は保存しない")
+				return nil
+			},
+			sourceFn: source,
+		})
+
+		got, err := svc.GetRecap(1, models.RecapYear, today, today)
+		require.NoError(t, err)
+		require.False(t, got.Final)
+		require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), got.Recap.PeriodStart)
+	})
+
+	t.Run("【異常系】まだ始まっていない期間は ErrInvalidRecapPeriod を返すこと", func(t *testing.T) {
+		svc := NewRecapService(&fakeRecapRepo{})
+		_, err := svc.GetRecap(1, models.RecapMonth, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), today)
+		require.ErrorIs(t, err, ErrInvalidRecapPeriod)
+		_, err = svc.GetRecap(1, "week", today, today)
+		require.ErrorIs(t, err, ErrInvalidRecapPeriod)
+	})
+}
+
+func TestRecapService_Publish(t *testing.T) {
+	today := time.Date(2025, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("【正常系】確定済みのまとめを公開できること", func(t *testing.T) {
+		var published *models.Recap
+		svc := NewRecapService(&fakeRecapRepo{
+			findFn: func(uint, models.RecapPeriod, time.Time) (*models.Recap, error) {
+				return &models.Recap{ID: 2, UserID: 1}, nil
+			},
+			publishFn: func(r *models.Recap) error {
+				published = r
+				return nil
+			},
+		})
+
+		got, err := svc.PublishRecap(1, models.RecapMonth, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), today)
+		require.NoError(t, err)
+		require.True(t, got.IsPublic)
+		require.NotNil(t, published.PublishedAt)
+	})
+
+	t.Run("【異常系】進行中の期間は ErrRecapNotFinal を返すこと", func(t *testing.T) {
+		svc := NewRecapService(&fakeRecapRepo{
+			sourceFn: func(uint, time.Time, time.Time) (*repository.RecapSource, error) {
+				return &repository.RecapSource{}, nil
+			},
+		})
+		_, err := svc.PublishRecap(1, models.RecapMonth, today, today)
+		require.ErrorIs(t, err, ErrRecapNotFinal)
+	})
+
+	t.Run("【異常系】保存されていないまとめの非公開は ErrRecapNotFound を返すこと", func(t *testing.T) {
+		svc := NewRecapService(&fakeRecapRepo{
+			findFn: func(uint, models.RecapPeriod, time.Time) (*models.Recap, error) {
+				return nil, repository.ErrNotFound
+			},
+		})
+		_, err := svc.UnpublishRecap(1, models.RecapMonth, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, ErrRecapNotFound)
+	})
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// VolumeReportQuery はボリュームレポートの取得条件。Bucket は week / month（空なら week）。
+// To を省略すると今日、From を省略すると To を含む defaultReportPeriods 期間分とする。
+type VolumeReportQuery struct {
+	Bucket ProgressionBucket
+	From   *time.Time
+	To     *time.Time
+}
+
+// VolumeStats はボリュームの集計値。ウォームアップセットは含めない。
+// HardSets は回数のあるセットのうち、RIR / RPE が記録されていれば限界に近いもの（RIR 4 以下・RPE 6 以上）の数。
+type VolumeStats struct {
+	Tonnage  float64
+	HardSets int
+	Reps     int
+}
+
+type ExerciseVolume struct {
+	ExerciseID  uint
+	Name        string
+	MuscleGroup models.MuscleGroup
+	VolumeStats
+}
+
+// MuscleVolume は主働筋ごとの集計。部位が未設定の種目は MuscleGroup が空になる。
+type MuscleVolume struct {
+	MuscleGroup models.MuscleGroup
+	VolumeStats
+}
+
+// VolumeSummary は期間内の合計と、種目・部位・プッシュ/プルごとの内訳。
+type VolumeSummary struct {
+	VolumeStats
+	Push       VolumeStats
+	Pull       VolumeStats
+	ByExercise []ExerciseVolume
+	ByMuscle   []MuscleVolume
+}
+
+// VolumePeriod は1期間分の集計。TonnageChangePercent は直前の期間からの増減率（直前が0なら nil）。
+type VolumePeriod struct {
+	PeriodStart time.Time
+	VolumeSummary
+	TonnageChangePercent *float64
+}
+
+// VolumeChange は比較期間からの変化。
+type VolumeChange struct {
+	TonnagePercent *float64
+	HardSets       int
+	Reps           int
+}
+
+// VolumeReport はボリュームレポート。Previous は From の直前の同じ期間数の集計。
+type VolumeReport struct {
+	Bucket       ProgressionBucket
+	From         time.Time
+	To           time.Time
+	Periods      []VolumePeriod
+	Total        VolumeSummary
+	PreviousFrom time.Time
+	PreviousTo   time.Time
+	Previous     VolumeSummary
+	Change       VolumeChange
+}
+
+const (
+	defaultReportPeriods = 4
+	// 1回のレポートで集計する期間数の上限
+	maxReportPeriods = 104
+	hardSetMaxRIR    = 4
+	hardSetMinRPE    = 6
+)
+
+type ReportService interface {
+	GetVolumeReport(userID uint, query VolumeReportQuery, today time.Time) (*VolumeReport, error)
+}
+
+type reportService struct {
+	repo repository.ReportRepository
+}
+
+func NewReportService(repo repository.ReportRepository) ReportService {
+	return &reportService{repo: repo}
+}
+
+// addBuckets は期間の開始日を n 期間ずらす。
+func addBuckets(start time.Time, bucket ProgressionBucket, n int) time.Time {
+	if bucket == BucketMonth {
+		return start.AddDate(0, n, 0)
+	}
+	return start.AddDate(0, 0, 7*n)
+}
+
+func (s *reportService) GetVolumeReport(userID uint, query VolumeReportQuery, today time.Time) (*VolumeReport, error) {
+	if query.Bucket == "" {
+		query.Bucket = BucketWeek
+	}
+	if query.Bucket != BucketWeek && query.Bucket != BucketMonth {
+		return nil, ErrInvalidReportQuery
+	}
+
+	to := bucketStart(today, BucketDay)
+	if query.To != nil {
+		to = bucketStart(*query.To, BucketDay)
+	}
+	from := addBuckets(bucketStart(to, query.Bucket), query.Bucket, -(defaultReportPeriods - 1))
+	if query.From != nil {
+		from = bucketStart(*query.From, BucketDay)
+	}
+	if from.After(to) {
+		return nil, ErrInvalidReportQuery
+	}
+
+	var starts []time.Time
+	for p := bucketStart(from, query.Bucket); !p.After(to); p = addBuckets(p, query.Bucket, 1) {
+		starts = append(starts, p)
+		if len(starts) > maxReportPeriods {
+			return nil, ErrInvalidReportQuery
+		}
+	}
+	prevFrom := addBuckets(from, query.Bucket, -len(starts))
+	prevTo := from.AddDate(0, 0, -1)
+
+	rows, err := s.repo.FindVolumeSets(userID, prevFrom, to)
+	if err != nil {
+		return nil, fmt.Errorf("fetch volume sets failed: %w", err)
+	}
+
+	// periods は From 以降、before は比較期間の記録を期間ごとに集計する
+	total, previous := newVolumeAccumulator(), newVolumeAccumulator()
+	periods := map[time.Time]*volumeAccumulator{}
+	before := map[time.Time]*volumeAccumulator{}
+	for _, r := range rows {
+		day := bucketStart(r.TrainedOn, BucketDay)
+		acc, buckets := total, periods
+		if day.Before(from) {
+			acc, buckets = previous, before
+		}
+		acc.add(r)
+		start := bucketStart(day, query.Bucket)
+		if buckets[start] == nil {
+			buckets[start] = newVolumeAccumulator()
+		}
+		buckets[start].add(r)
+	}
+
+	report := &VolumeReport{
+		Bucket:       query.Bucket,
+		From:         from,
+		To:           to,
+		Periods:      make([]VolumePeriod, 0, len(starts)),
+		Total:        total.summary(),
+		PreviousFrom: prevFrom,
+		PreviousTo:   prevTo,
+		Previous:     previous.summary(),
+	}
+	report.Change = VolumeChange{
+		TonnagePercent: changePercent(report.Previous.Tonnage, report.Total.Tonnage),
+		HardSets:       report.Total.HardSets - report.Previous.HardSets,
+		Reps:           report.Total.Reps - report.Previous.Reps,
+	}
+
+	for i, start := range starts {
+		p := VolumePeriod{PeriodStart: start, VolumeSummary: periods[start].summary()}
+		prevBuckets := periods
+		if i == 0 {
+			prevBuckets = before
+		}
+		if prev := prevBuckets[addBuckets(start, query.Bucket, -1)]; prev != nil {
+			p.TonnageChangePercent = changePercent(prev.stats.Tonnage, p.Tonnage)
+		}
+		report.Periods = append(report.Periods, p)
+	}
+	return report, nil
+}
+
+// changePercent は before から after への増減率(%)を返す。before が0なら nil。
+func changePercent(before, after float64) *float64 {
+	if before == 0 {
+		return nil
+	}
+	v := round2((after - before) / before * 100)
+	return &v
+}
+
+func isHardSet(r repository.VolumeSet) bool {
+	if r.Reps <= 0 {
+		return false
+	}
+	if r.RIR != nil && *r.RIR > hardSetMaxRIR {
+		return false
+	}
+	if r.RPE != nil && *r.RPE < hardSetMinRPE {
+		return false
+	}
+	return true
+}
+
+func (v *VolumeStats) add(r repository.VolumeSet) {
+	v.Tonnage += r.Volume
+	v.Reps += r.Reps
+	if isHardSet(r) {
+		v.HardSets++
+	}
+}
+
+func (v VolumeStats) rounded() VolumeStats {
+	v.Tonnage = round2(v.Tonnage)
+	return v
+}
+
+type volumeAccumulator struct {
+	stats      VolumeStats
+	push, pull VolumeStats
+	exercises  map[uint]*ExerciseVolume
+	muscles    map[models.MuscleGroup]*VolumeStats
+}
+
+func newVolumeAccumulator() *volumeAccumulator {
+	return &volumeAccumulator{
+		exercises: map[uint]*ExerciseVolume{},
+		muscles:   map[models.MuscleGroup]*VolumeStats{},
+	}
+}
+
+func (a *volumeAccumulator) add(r repository.VolumeSet) {
+	if r.SetType == models.SetTypeWarmup {
+		return
+	}
+	a.stats.add(r)
+	switch {
+	case r.MovementPattern.IsPush():
+		a.push.add(r)
+	case r.MovementPattern.IsPull():
+		a.pull.add(r)
+	}
+
+	ex, ok := a.exercises[r.ExerciseID]
+	if !ok {
+		ex = &ExerciseVolume{ExerciseID: r.ExerciseID, Name: r.ExerciseName, MuscleGroup: r.PrimaryMuscle}
+		a.exercises[r.ExerciseID] = ex
+	}
+	ex.VolumeStats.add(r)
+
+	m, ok := a.muscles[r.PrimaryMuscle]
+	if !ok {
+		m = &VolumeStats{}
+		a.muscles[r.PrimaryMuscle] = m
+	}
+	m.add(r)
+}
+
+// summary は集計結果を返す。内訳はトン数の多い順（同じならセット数の多い順）に並べる。
+func (a *volumeAccumulator) summary() VolumeSummary {
+	out := VolumeSummary{
+		ByExercise: []ExerciseVolume{},
+		ByMuscle:   []MuscleVolume{},
+	}
+	if a == nil {
+		return out
+	}
+	out.VolumeStats = a.stats.rounded()
+	out.Push = a.push.rounded()
+	out.Pull = a.pull.rounded()
+
+	for _, ex := range a.exercises {
+		e := *ex
+		e.VolumeStats = e.VolumeStats.rounded()
+		out.ByExercise = append(out.ByExercise, e)
+	}
+	sort.Slice(out.ByExercise, func(i, j int) bool {
+		x, y := out.ByExercise[i], out.ByExercise[j]
+		if x.Tonnage != y.Tonnage {
+			return x.Tonnage > y.Tonnage
+		}
+		if x.HardSets != y.HardSets {
+			return x.HardSets > y.HardSets
+		}
+		return x.ExerciseID < y.ExerciseID
+	})
+
+	for g, v := range a.muscles {
+		out.ByMuscle = append(out.ByMuscle, MuscleVolume{MuscleGroup: g, VolumeStats: v.rounded()})
+	}
+	sort.Slice(out.ByMuscle, func(i, j int) bool {
+		x, y := out.ByMuscle[i], out.ByMuscle[j]
+		if x.Tonnage != y.Tonnage {
+			return x.Tonnage > y.Tonnage
+		}
+		if x.HardSets != y.HardSets {
+			return x.HardSets > y.HardSets
+		}
+		return x.MuscleGroup < y.MuscleGroup
+	})
+	return out
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReportRepo struct {
+	setsFn func(userID uint, from, to time.Time) ([]repository.VolumeSet, error)
+}
+
+func (f *fakeReportRepo) FindVolumeSets(userID uint, from, to time.Time) ([]repository.VolumeSet, error) {
+	return f.setsFn(userID, from, to)
+}
+
+func reportDay(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+func benchSet(day time.Time, reps int, weight float64) repository.VolumeSet {
+	return repository.VolumeSet{
+		TrainedOn: day, ExerciseID: 1, ExerciseName: "ベンチプレス",
+		PrimaryMuscle: models.MuscleChest, MovementPattern: models.MovementHorizontalPush,
+		SetType: models.SetTypeWorking, Reps: reps, Volume: float64(reps) * weight,
+	}
+}
+
+func rowSet(day time.Time, reps int, weight float64) repository.VolumeSet {
+	return repository.VolumeSet{
+		TrainedOn: day, ExerciseID: 2, ExerciseName: "ベントオーバーロー",
+		PrimaryMuscle: models.MuscleBack, MovementPattern: models.MovementHorizontalPull,
+		SetType: models.SetTypeWorking, Reps: reps, Volume: float64(reps) * weight,
+	}
+}
+
+func TestReportService_GetVolumeReport(t *testing.T) {
+	// 2025-10-15 は水曜日
+	today := reportDay(10, 15)
+	warmup := benchSet(reportDay(10, 13), 10, 40)
+	warmup.SetType = models.SetTypeWarmup
+	easy := rowSet(reportDay(10, 14), 12, 50)
+	easy.RIR = ptr(6)
+
+	rows := []repository.VolumeSet{
+		benchSet(reportDay(9, 24), 5, 80), // 比較期間
+		benchSet(reportDay(10, 6), 5, 100),
+		rowSet(reportDay(10, 8), 8, 60),
+		warmup,
+		benchSet(reportDay(10, 13), 5, 100),
+		benchSet(reportDay(10, 13), 5, 100),
+		easy,
+	}
+
+	var gotFrom, gotTo time.Time
+	svc := NewReportService(&fakeReportRepo{
+		setsFn: func(userID uint, from, to time.Time) ([]repository.VolumeSet, error) {
+			gotFrom, gotTo = from, to
+			return rows, nil
+		},
+	})
+
+	t.Run("【正常系】週ごとに種目・部位・プッシュ/プル別の集計と比較を返すこと", func(t *testing.T) {
+		from := reportDay(10, 6)
+		got, err := svc.GetVolumeReport(1, VolumeReportQuery{From: &from}, today)
+		require.NoError(t, err)
+
+		require.Equal(t, BucketWeek, got.Bucket)
+		require.Equal(t, reportDay(9, 22), gotFrom)
+		require.Equal(t, today, gotTo)
+		require.Equal(t, reportDay(9, 22), got.PreviousFrom)
+		require.Equal(t, reportDay(10, 5), got.PreviousTo)
+
+		require.Len(t, got.Periods, 2)
+		w1, w2 := got.Periods[0], got.Periods[1]
+		require.Equal(t, reportDay(10, 6), w1.PeriodStart)
+		require.Equal(t, 980.0, w1.Tonnage)
+		require.Equal(t, 2, w1.HardSets)
+		require.Nil(t, w1.TonnageChangePercent) // 9/29 の週は記録なし
+
+		// ウォームアップは含めず、RIR 6 のセットはハードセットに数えない
+		require.Equal(t, 1600.0, w2.Tonnage)
+		require.Equal(t, 22, w2.Reps)
+		require.Equal(t, 2, w2.HardSets)
+		require.Equal(t, 63.27, *w2.TonnageChangePercent)
+		require.Equal(t, VolumeStats{Tonnage: 1000, HardSets: 2, Reps: 10}, w2.Push)
+		require.Equal(t, VolumeStats{Tonnage: 600, HardSets: 0, Reps: 12}, w2.Pull)
+		require.Equal(t, uint(1), w2.ByExercise[0].ExerciseID)
+		require.Equal(t, models.MuscleChest, w2.ByMuscle[0].MuscleGroup)
+
+		require.Equal(t, 2580.0, got.Total.Tonnage)
+		require.Equal(t, 400.0, got.Previous.Tonnage)
+		require.Equal(t, 545.0, *got.Change.TonnagePercent)
+		require.Equal(t, 3, got.Change.HardSets)
+	})
+
+	t.Run("【正常系】月ごとに省略時は直近4か月を集計すること", func(t *testing.T) {
+		got, err := svc.GetVolumeReport(1, VolumeReportQuery{Bucket: BucketMonth}, today)
+		require.NoError(t, err)
+		require.Equal(t, reportDay(7, 1), got.From)
+		require.Equal(t, reportDay(3, 1), got.PreviousFrom)
+		require.Len(t, got.Periods, 4)
+		require.Equal(t, 400.0, got.Periods[2].Tonnage)
+		require.Equal(t, 2580.0, got.Periods[3].Tonnage)
+		require.Equal(t, 545.0, *got.Periods[3].TonnageChangePercent)
+	})
+
+	t.Run("【異常系】不正な条件は ErrInvalidReportQuery を返すこと", func(t *testing.T) {
+		_, err := svc.GetVolumeReport(1, VolumeReportQuery{Bucket: BucketDay}, today)
+		require.ErrorIs(t, err, ErrInvalidReportQuery)
+
+		from := reportDay(10, 20)
+		_, err = svc.GetVolumeReport(1, VolumeReportQuery{From: &from}, today)
+		require.ErrorIs(t, err, ErrInvalidReportQuery)
+
+		from = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err = svc.GetVolumeReport(1, VolumeReportQuery{From: &from}, today)
+		require.ErrorIs(t, err, ErrInvalidReportQuery)
+	})
+
+	t.Run("【異常系】取得に失敗した場合はエラーを返すこと", func(t *testing.T) {
+		svc := NewReportService(&fakeReportRepo{
+			setsFn: func(uint, time.Time, time.Time) ([]repository.VolumeSet, error) {
+				return nil, errors.New("db down")
+			},
+		})
+		_, err := svc.GetVolumeReport(1, VolumeReportQuery{}, today)
+		require.ErrorContains(t, err, "fetch volume sets failed")
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// TrainingStats はトレーニングの継続状況。
+// CurrentStreak / LongestStreak は daily なら日数、weekly なら週数。
+// 今日（今週）がまだ条件を満たしていなくても、昨日（先週）まで続いていれば途切れたとはみなさない。
+type TrainingStats struct {
+	StreakRule      models.StreakRule
+	WeeklyTarget    int
+	CurrentStreak   int
+	LongestStreak   int
+	WeeklyFrequency []WeekFrequency
+	AveragePerWeek  float64
+	// Weekdays は曜日ごとのトレーニング日数（月曜始まり）
+	Weekdays [7]int
+}
+
+// WeekFrequency は ISO 週（WeekStart は月曜日）ごとのトレーニング日数。
+type WeekFrequency struct {
+	WeekStart time.Time
+	Days      int
+}
+
+const (
+	// 週ごとの頻度を返す週数（今週を含む）
+	statsFrequencyWeeks   = 12
+	maxStreakWeeklyTarget = 7
+)
+
+type StatsService interface {
+	GetStats(userID uint, today time.Time) (*TrainingStats, error)
+	UpdateStreakRule(userID uint, rule models.StreakRule, weeklyTarget int, today time.Time) (*TrainingStats, error)
+}
+
+type statsService struct {
+	repo repository.StatsRepository
+}
+
+func NewStatsService(repo repository.StatsRepository) StatsService {
+	return &statsService{repo: repo}
+}
+
+func (s *statsService) GetStats(userID uint, today time.Time) (*TrainingStats, error) {
+	rule, target, err := s.repo.GetStreakRule(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find streak rule failed: %w", err)
+	}
+	dates, err := s.repo.FindTrainingDates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("find training dates failed: %w", err)
+	}
+	return computeTrainingStats(dates, rule, target, today), nil
+}
+
+// UpdateStreakRule はストリークの数え方を変更し、変更後の継続状況を返す。weekly の目標日数は1〜7日。
+func (s *statsService) UpdateStreakRule(userID uint, rule models.StreakRule, weeklyTarget int, today time.Time) (*TrainingStats, error) {
+	if !rule.Valid() || weeklyTarget < 1 || weeklyTarget > maxStreakWeeklyTarget {
+		return nil, ErrInvalidStreakRule
+	}
+	if err := s.repo.UpdateStreakRule(userID, rule, weeklyTarget); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("update streak rule failed: %w", err)
+	}
+	return s.GetStats(userID, today)
+}
+
+// computeTrainingStats はトレーニングした日（昇順・重複なし）から継続状況を求める。
+func computeTrainingStats(dates []time.Time, rule models.StreakRule, weeklyTarget int, today time.Time) *TrainingStats {
+	if !rule.Valid() {
+		rule = models.StreakDaily
+	}
+	stats := &TrainingStats{StreakRule: rule, WeeklyTarget: weeklyTarget}
+
+	days := map[time.Time]bool{}
+	perWeek := map[time.Time]int{}
+	for _, d := range dates {
+		d = dayOf(d)
+		if days[d] {
+			continue
+		}
+		days[d] = true
+		perWeek[isoWeekStart(d)]++
+		stats.Weekdays[(int(d.Weekday())+6)%7]++
+	}
+
+	thisWeek := isoWeekStart(today)
+	total := 0
+	for i := statsFrequencyWeeks - 1; i >= 0; i-- {
+		ws := thisWeek.AddDate(0, 0, -7*i)
+		stats.WeeklyFrequency = append(stats.WeeklyFrequency, WeekFrequency{WeekStart: ws, Days: perWeek[ws]})
+		total += perWeek[ws]
+	}
+	stats.AveragePerWeek = round2(float64(total) / statsFrequencyWeeks)
+
+	if rule == models.StreakWeekly {
+		qualified := map[time.Time]bool{}
+		for ws, n := range perWeek {
+			if n >= weeklyTarget {
+				qualified[ws] = true
+			}
+		}
+		stats.CurrentStreak, stats.LongestStreak = streakLengths(qualified, 7, thisWeek)
+	} else {
+		stats.CurrentStreak, stats.LongestStreak = streakLengths(days, 1, dayOf(today))
+	}
+	return stats
+}
+
+// streakLengths は stepDays 日おきの期間の開始日の集合から、last で終わる連続数と最長の連続数を返す。
+// last が集合に無い場合は1つ前の期間で終わる連続数を current とする。
+func streakLengths(set map[time.Time]bool, stepDays int, last time.Time) (current, longest int) {
+	for d := range set {
+		if set[d.AddDate(0, 0, -stepDays)] {
+			continue
+		}
+		n := 0
+		for c := d; set[c]; c = c.AddDate(0, 0, stepDays) {
+			n++
+		}
+		if n > longest {
+			longest = n
+		}
+	}
+
+	if !set[last] {
+		last = last.AddDate(0, 0, -stepDays)
+	}
+	for c := last; set[c]; c = c.AddDate(0, 0, -stepDays) {
+		current++
+	}
+	return current, longest
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// isoWeekStart は d を含む ISO 週の月曜日を返す。
+func isoWeekStart(d time.Time) time.Time {
+	d = dayOf(d)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatsRepo struct {
+	datesFn  func(userID uint) ([]time.Time, error)
+	ruleFn   func(userID uint) (models.StreakRule, int, error)
+	updateFn func(userID uint, rule models.StreakRule, weeklyTarget int) error
+}
+
+func (f *fakeStatsRepo) FindTrainingDates(userID uint) ([]time.Time, error) {
+	return f.datesFn(userID)
+}
+func (f *fakeStatsRepo) GetStreakRule(userID uint) (models.StreakRule, int, error) {
+	return f.ruleFn(userID)
+}
+func (f *fakeStatsRepo) UpdateStreakRule(userID uint, rule models.StreakRule, weeklyTarget int) error {
+	return f.updateFn(userID, rule, weeklyTarget)
+}
+
+// 2025-10-15 は水曜日
+func statsDay(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+func TestComputeTrainingStats_Streaks(t *testing.T) {
+	today := statsDay(10, 15)
+
+	tests := []struct {
+		name        string
+		dates       []time.Time
+		rule        models.StreakRule
+		target      int
+		wantCurrent int
+		wantLongest int
+	}{
+		{
+			name:        "【正常系】今日まで続いている連続日数を数えること",
+			dates:       []time.Time{statsDay(10, 1), statsDay(10, 2), statsDay(10, 3), statsDay(10, 14), statsDay(10, 15)},
+			rule:        models.StreakDaily,
+			wantCurrent: 2,
+			wantLongest: 3,
+		},
+		{
+			name:        "【正常系】今日まだトレーニングしていなくても昨日まで続いていれば途切れないこと",
+			dates:       []time.Time{statsDay(10, 12), statsDay(10, 13), statsDay(10, 14)},
+			rule:        models.StreakDaily,
+			wantCurrent: 3,
+			wantLongest: 3,
+		},
+		{
+			name:        "【正常系】一昨日で途切れている場合は0を返すこと",
+			dates:       []time.Time{statsDay(10, 12), statsDay(10, 13)},
+			rule:        models.StreakDaily,
+			wantCurrent: 0,
+			wantLongest: 2,
+		},
+		{
+			name: "【正常系】週の目標日数を満たした週の連続数を数えること",
+			// 9/22週: 2日, 9/29週: 1日（未達）, 10/6週: 2日, 10/13週（今週）: 2日
+			dates: []time.Time{
+				statsDay(9, 22), statsDay(9, 24),
+				statsDay(10, 1),
+				statsDay(10, 6), statsDay(10, 11),
+				statsDay(10, 13), statsDay(10, 15),
+			},
+			rule:        models.StreakWeekly,
+			target:      2,
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+		{
+			name: "【正常系】今週が未達でも先週まで続いていれば途切れないこと",
+			dates: []time.Time{
+				statsDay(9, 29), statsDay(10, 1), statsDay(10, 3),
+				statsDay(10, 6), statsDay(10, 8), statsDay(10, 10),
+				statsDay(10, 13),
+			},
+			rule:        models.StreakWeekly,
+			target:      3,
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+		{
+			name:        "【正常系】記録が無い場合は0を返すこと",
+			rule:        models.StreakWeekly,
+			target:      3,
+			wantCurrent: 0,
+			wantLongest: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeTrainingStats(tt.dates, tt.rule, tt.target, today)
+			require.Equal(t, tt.rule, got.StreakRule)
+			require.Equal(t, tt.wantCurrent, got.CurrentStreak)
+			require.Equal(t, tt.wantLongest, got.LongestStreak)
+		})
+	}
+}
+
+func TestComputeTrainingStats_Frequency(t *testing.T) {
+	today := statsDay(10, 15)
+	dates := []time.Time{
+		statsDay(7, 1), // 12週より前
+		statsDay(10, 6), statsDay(10, 7), statsDay(10, 11),
+		statsDay(10, 13), statsDay(10, 15),
+	}
+
+	got := computeTrainingStats(dates, models.StreakDaily, 3, today)
+
+	require.Len(t, got.WeeklyFrequency, statsFrequencyWeeks)
+	require.Equal(t, statsDay(7, 28), got.WeeklyFrequency[0].WeekStart)
+	require.Equal(t, WeekFrequency{WeekStart: statsDay(10, 6), Days: 3}, got.WeeklyFrequency[10])
+	require.Equal(t, WeekFrequency{WeekStart: statsDay(10, 13), Days: 2}, got.WeeklyFrequency[11])
+	require.Equal(t, 0.42, got.AveragePerWeek)
+	// 月: 10/6, 10/13 / 火: 7/1, 10/7 / 水: 10/15 / 土: 10/11
+	require.Equal(t, [7]int{2, 2, 1, 0, 0, 1, 0}, got.Weekdays)
+}
+
+func TestStatsService_UpdateStreakRule(t *testing.T) {
+	today := statsDay(10, 15)
+
+	tests := []struct {
+		name     string
+		rule     models.StreakRule
+		target   int
+		updateFn func(uint, models.StreakRule, int) error
+		wantErr  error
+	}{
+		{
+			name:   "【正常系】数え方を保存して変更後の状況を返すこと",
+			rule:   models.StreakWeekly,
+			target: 2,
+			updateFn: func(userID uint, rule models.StreakRule, target int) error {
+				require.Equal(t, models.StreakWeekly, rule)
+				require.Equal(t, 2, target)
+				return nil
+			},
+		},
+		{
+			name:    "【異常系】未知の数え方は ErrInvalidStreakRule を返すこと",
+			rule:    "monthly",
+			target:  2,
+			wantErr: ErrInvalidStreakRule,
+		},
+		{
+			name:    "【異常系】週の目標日数が7を超える場合は ErrInvalidStreakRule を返すこと",
+			rule:    models.StreakWeekly,
+			target:  8,
+			wantErr: ErrInvalidStreakRule,
+		},
+		{
+			name:     "【異常系】ユーザーが無い場合は ErrUserNotFound を返すこと",
+			rule:     models.StreakDaily,
+			target:   3,
+			updateFn: func(uint, models.StreakRule, int) error { return repository.ErrNotFound },
+			wantErr:  ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewStatsService(&fakeStatsRepo{
+				updateFn: tt.updateFn,
+				ruleFn: func(uint) (models.StreakRule, int, error) {
+					return tt.rule, tt.target, nil
+				},
+				datesFn: func(uint) ([]time.Time, error) {
+					return []time.Time{statsDay(10, 13), statsDay(10, 14)}, nil
+				},
+			})
+
+			got, err := svc.UpdateStreakRule(1, tt.rule, tt.target, today)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.rule, got.StreakRule)
+			require.Equal(t, 1, got.CurrentStreak)
+		})
+	}
+}
+
+func TestStatsService_GetStats(t *testing.T) {
+	t.Run("【異常系】記録の取得に失敗した場合はエラーを返すこと", func(t *testing.T) {
+		svc := NewStatsService(&fakeStatsRepo{
+			ruleFn:  func(uint) (models.StreakRule, int, error) { return models.StreakDaily, 3, nil },
+			datesFn: func(uint) ([]time.Time, error) { return nil, errors.New("db down") },
+		})
+		_, err := svc.GetStats(1, statsDay(10, 15))
+		require.ErrorContains(t, err, "find training dates failed")
+	})
+}
@@ -1,21 +1,50 @@
 package service
 
 import (
+	"math"
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 )
 
+// HomeSummary はホーム画面の集計。LatestWeightOn は LatestWeight を測った日（体組成ログの計測日かトレーニング日）。
 type HomeSummary struct {
-	TotalTrainingDays int64      `json:"total_training_days"`
-	LatestWeight      *float64   `json:"latest_weight,omitempty"`
-	LatestTrainedOn   *time.Time `json:"trained_on,omitempty"`
-	GoalWeight        *float64   `json:"goal_weight,omitempty"`
-	Height            *float64   `json:"height,omitempty"`
+	TotalTrainingDays int64          `json:"total_training_days"`
+	LatestWeight      *float64       `json:"latest_weight,omitempty"`
+	LatestWeightOn    *time.Time     `json:"trained_on,omitempty"`
+	GoalWeight        *float64       `json:"goal_weight,omitempty"`
+	Height            *float64       `json:"height,omitempty"`
+	BMI               *float64       `json:"bmi,omitempty"`
+	FFMI              *float64       `json:"ffmi,omitempty"`
+	NormalizedFFMI    *float64       `json:"normalized_ffmi,omitempty"`
+	GoalProgress      *GoalProgress  `json:"goal_progress,omitempty"`
+	Stats             *TrainingStats `json:"stats,omitempty"`
 }
 
+// GoalProgress は目標体重までの進み具合。RemainingKG は「目標 - 現在」で、負なら減量が必要。
+// 傾向は直近 goalTrendWeeks 週の体重の線形回帰から求め、データが足りない場合は nil とする。
+type GoalProgress struct {
+	GoalWeight     float64    `json:"goal_weight"`
+	CurrentWeight  float64    `json:"current_weight"`
+	RemainingKG    float64    `json:"remaining_kg"`
+	Reached        bool       `json:"reached"`
+	TrendKGPerWeek *float64   `json:"trend_kg_per_week,omitempty"`
+	EstimatedDate  *time.Time `json:"estimated_date,omitempty"`
+	GoalDate       *time.Time `json:"goal_date,omitempty"`
+	OnTrack        *bool      `json:"on_track,omitempty"`
+}
+
+const (
+	goalTrendWeeks = 4
+	// 目標との差がこの範囲内なら達成とみなす（kg）
+	goalReachedToleranceKG = 0.2
+	// これより先になる予測日は出さない
+	maxGoalForecastDays = 3 * 365
+)
+
 type SummaryService interface {
-	GetHomeSummary(userID uint) (*HomeSummary, error)
+	GetHomeSummary(userID uint, today time.Time) (*HomeSummary, error)
 }
 
 type summaryService struct {
@@ -26,7 +55,7 @@ func NewSummaryService(repo repository.SummaryRepository) SummaryService {
 	return &summaryService{repo: repo}
 }
 
-func (s *summaryService) GetHomeSummary(userID uint) (*HomeSummary, error) {
+func (s *summaryService) GetHomeSummary(userID uint, today time.Time) (*HomeSummary, error) {
 	days, err := s.repo.CountTrainingDays(userID)
 	if err != nil {
 		return nil, err
@@ -42,11 +71,107 @@ func (s *summaryService) GetHomeSummary(userID uint) (*HomeSummary, error) {
 		return nil, err
 	}
 
-	return &HomeSummary{
+	summary := &HomeSummary{
 		TotalTrainingDays: days,
 		LatestWeight:      latestW,
-		LatestTrainedOn:   latestOn,
+		LatestWeightOn:    latestOn,
 		GoalWeight:        goal,
 		Height:            height,
-	}, nil
+	}
+
+	rule, target, err := s.repo.GetStreakRule(userID)
+	if err != nil {
+		return nil, err
+	}
+	dates, err := s.repo.GetTrainingDates(userID)
+	if err != nil {
+		return nil, err
+	}
+	summary.Stats = computeTrainingStats(dates, rule, target, today)
+
+	if height != nil && latestW != nil {
+		bodyFat, err := s.repo.GetLatestBodyFat(userID)
+		if err != nil {
+			return nil, err
+		}
+		bc := models.ComputeBodyComposition(height, latestW, bodyFat)
+		summary.BMI, summary.FFMI, summary.NormalizedFFMI = bc.BMI, bc.FFMI, bc.NormalizedFFMI
+	}
+	if goal == nil || latestW == nil {
+		return summary, nil
+	}
+
+	goalDate, err := s.repo.GetGoalDate(userID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.repo.GetWeightSeries(userID, today.AddDate(0, 0, -7*goalTrendWeeks))
+	if err != nil {
+		return nil, err
+	}
+	summary.GoalProgress = buildGoalProgress(*goal, *latestW, goalDate, series, today)
+	return summary, nil
+}
+
+// weightTrend は体重の推移を最小二乗法で直線に当てはめ、1日あたりの変化量と今日時点の推定体重を返す。
+func weightTrend(series []repository.WeightPoint, today time.Time) (slope, fitted float64, ok bool) {
+	n := float64(len(series))
+	if n < 2 {
+		return 0, 0, false
+	}
+
+	var sumX, sumY, sumXX, sumXY float64
+	for _, p := range series {
+		x := p.Date.Sub(today).Hours() / 24
+		sumX += x
+		sumY += p.Weight
+		sumXX += x * x
+		sumXY += x * p.Weight
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / denom
+	fitted = (sumY - slope*sumX) / n
+	return slope, fitted, true
+}
+
+func buildGoalProgress(goal, current float64, goalDate *time.Time, series []repository.WeightPoint, today time.Time) *GoalProgress {
+	p := &GoalProgress{
+		GoalWeight:    goal,
+		CurrentWeight: current,
+		RemainingKG:   round2(goal - current),
+		GoalDate:      goalDate,
+	}
+	if math.Abs(goal-current) <= goalReachedToleranceKG {
+		p.Reached = true
+		onTrack := true
+		p.OnTrack = &onTrack
+		return p
+	}
+
+	slope, fitted, ok := weightTrend(series, today)
+	if !ok {
+		return p
+	}
+	perWeek := round2(slope * 7)
+	p.TrendKGPerWeek = &perWeek
+
+	// 目標に向かって変化しているときだけ到達日を予測する
+	towardGoal := slope*(goal-current) > 0
+	if towardGoal {
+		days := math.Ceil(math.Max(0, (goal-fitted)/slope))
+		if days <= maxGoalForecastDays {
+			eta := today.AddDate(0, 0, int(days))
+			p.EstimatedDate = &eta
+		}
+	}
+
+	onTrack := towardGoal
+	if goalDate != nil {
+		onTrack = p.EstimatedDate != nil && !p.EstimatedDate.After(*goalDate)
+	}
+	p.OnTrack = &onTrack
+	return p
 }
@@ -5,14 +5,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 	"github.com/RintaroNasu/muscle_diary_app/utils"
 	"github.com/stretchr/testify/require"
 )
 
 type fakeSummaryRepo struct {
-	countFn  func(userID uint) (int64, error)
-	latestFn func(userID uint) (*float64, *time.Time, error)
-	basicsFn func(userID uint) (*float64, *float64, error)
+	countFn    func(userID uint) (int64, error)
+	latestFn   func(userID uint) (*float64, *time.Time, error)
+	basicsFn   func(userID uint) (*float64, *float64, error)
+	goalDateFn func(userID uint) (*time.Time, error)
+	seriesFn   func(userID uint, from time.Time) ([]repository.WeightPoint, error)
+	bodyFatFn  func(userID uint) (*float64, error)
+	datesFn    func(userID uint) ([]time.Time, error)
 }
 
 func (f *fakeSummaryRepo) CountTrainingDays(userID uint) (int64, error) {
@@ -24,6 +30,24 @@ func (f *fakeSummaryRepo) GetLatestWeight(userID uint) (*float64, *time.Time, er
 func (f *fakeSummaryRepo) GetProfileBasics(userID uint) (*float64, *float64, error) {
 	return f.basicsFn(userID)
 }
+func (f *fakeSummaryRepo) GetGoalDate(userID uint) (*time.Time, error) {
+	return f.goalDateFn(userID)
+}
+func (f *fakeSummaryRepo) GetTrainingDates(userID uint) ([]time.Time, error) {
+	if f.datesFn == nil {
+		return nil, nil
+	}
+	return f.datesFn(userID)
+}
+func (f *fakeSummaryRepo) GetStreakRule(userID uint) (models.StreakRule, int, error) {
+	return models.StreakDaily, 3, nil
+}
+func (f *fakeSummaryRepo) GetLatestBodyFat(userID uint) (*float64, error) {
+	return f.bodyFatFn(userID)
+}
+func (f *fakeSummaryRepo) GetWeightSeries(userID uint, from time.Time) ([]repository.WeightPoint, error) {
+	return f.seriesFn(userID, from)
+}
 
 func TestNewSummaryService(t *testing.T) {
 	svc := NewSummaryService(&fakeSummaryRepo{
@@ -48,6 +72,9 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 		wantTrained *time.Time
 		wantHeight  *float64
 		wantGoal    *float64
+		wantBMI     *float64
+		wantFFMI    *float64
+		wantStreak  int
 		wantErr     string
 	}{
 		{
@@ -60,6 +87,14 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 				basicsFn: func(uint) (*float64, *float64, error) {
 					return utils.Ptr(175.0), utils.Ptr(65.0), nil
 				},
+				goalDateFn: func(uint) (*time.Time, error) { return nil, nil },
+				seriesFn: func(uint, time.Time) ([]repository.WeightPoint, error) {
+					return nil, nil
+				},
+				bodyFatFn: func(uint) (*float64, error) { return utils.Ptr(12.0), nil },
+				datesFn: func(uint) ([]time.Time, error) {
+					return []time.Time{dayOf(now).AddDate(0, 0, -2), dayOf(now).AddDate(0, 0, -1)}, nil
+				},
 			},
 			userID:      1,
 			wantDays:    12,
@@ -67,6 +102,9 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 			wantTrained: &now,
 			wantHeight:  utils.Ptr(175.0),
 			wantGoal:    utils.Ptr(65.0),
+			wantBMI:     utils.Ptr(20.3),
+			wantFFMI:    utils.Ptr(17.9),
+			wantStreak:  2,
 		},
 		{
 			name: "【正常系】最新体重が存在しない場合は nil が返ること",
@@ -125,7 +163,7 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := NewSummaryService(&tt.repo)
 
-			got, err := svc.GetHomeSummary(tt.userID)
+			got, err := svc.GetHomeSummary(tt.userID, now)
 
 			if tt.wantErr != "" {
 				require.Error(t, err)
@@ -146,10 +184,10 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 			}
 
 			if tt.wantTrained == nil {
-				require.Nil(t, got.LatestTrainedOn)
+				require.Nil(t, got.LatestWeightOn)
 			} else {
-				require.NotNil(t, got.LatestTrainedOn)
-				require.WithinDuration(t, *tt.wantTrained, *got.LatestTrainedOn, time.Second)
+				require.NotNil(t, got.LatestWeightOn)
+				require.WithinDuration(t, *tt.wantTrained, *got.LatestWeightOn, time.Second)
 			}
 
 			if tt.wantHeight == nil {
@@ -164,6 +202,107 @@ func TestSummaryService_GetHomeSummary(t *testing.T) {
 				require.NotNil(t, got.GoalWeight)
 				require.InDelta(t, *tt.wantGoal, *got.GoalWeight, 1e-6)
 			}
+			require.Equal(t, tt.wantBMI, got.BMI)
+			require.Equal(t, tt.wantFFMI, got.FFMI)
+			require.NotNil(t, got.Stats)
+			require.Equal(t, tt.wantStreak, got.Stats.CurrentStreak)
+		})
+	}
+}
+
+func TestSummaryService_GoalProgress(t *testing.T) {
+	today := time.Date(2025, 10, 29, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return today.AddDate(0, 0, -n) }
+	// 4週間で 0.5kg/週 ずつ減っている体重
+	losing := []repository.WeightPoint{
+		{Date: daysAgo(28), Weight: 72.0},
+		{Date: daysAgo(21), Weight: 71.5},
+		{Date: daysAgo(14), Weight: 71.0},
+		{Date: daysAgo(7), Weight: 70.5},
+		{Date: today, Weight: 70.0},
+	}
+
+	tests := []struct {
+		name        string
+		goal        float64
+		current     float64
+		goalDate    *time.Time
+		series      []repository.WeightPoint
+		wantTrend   *float64
+		wantETA     *time.Time
+		wantOnTrack *bool
+		wantReached bool
+	}{
+		{
+			name:        "【正常系】減量の傾向から到達予定日を求めること",
+			goal:        68.0,
+			current:     70.0,
+			series:      losing,
+			wantTrend:   utils.Ptr(-0.5),
+			wantETA:     utils.Ptr(today.AddDate(0, 0, 28)),
+			wantOnTrack: utils.Ptr(true),
+		},
+		{
+			name:        "【正常系】目標日までに間に合わない場合は on_track が false になること",
+			goal:        68.0,
+			current:     70.0,
+			goalDate:    utils.Ptr(today.AddDate(0, 0, 14)),
+			series:      losing,
+			wantTrend:   utils.Ptr(-0.5),
+			wantETA:     utils.Ptr(today.AddDate(0, 0, 28)),
+			wantOnTrack: utils.Ptr(false),
+		},
+		{
+			name:        "【正常系】目標と逆方向の傾向では到達予定日を返さないこと",
+			goal:        75.0,
+			current:     70.0,
+			series:      losing,
+			wantTrend:   utils.Ptr(-0.5),
+			wantOnTrack: utils.Ptr(false),
+		},
+		{
+			name:        "【正常系】目標との差が許容範囲内なら達成とみなすこと",
+			goal:        70.1,
+			current:     70.0,
+			series:      losing,
+			wantOnTrack: utils.Ptr(true),
+			wantReached: true,
+		},
+		{
+			name:    "【正常系】体重の記録が1日分しか無い場合は傾向を返さないこと",
+			goal:    68.0,
+			current: 70.0,
+			series:  losing[:1],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewSummaryService(&fakeSummaryRepo{
+				countFn: func(uint) (int64, error) { return 5, nil },
+				latestFn: func(uint) (*float64, *time.Time, error) {
+					return utils.Ptr(tt.current), &today, nil
+				},
+				basicsFn: func(uint) (*float64, *float64, error) {
+					return nil, utils.Ptr(tt.goal), nil
+				},
+				goalDateFn: func(uint) (*time.Time, error) { return tt.goalDate, nil },
+				seriesFn: func(_ uint, from time.Time) ([]repository.WeightPoint, error) {
+					require.Equal(t, daysAgo(7*goalTrendWeeks), from)
+					return tt.series, nil
+				},
+			})
+
+			got, err := svc.GetHomeSummary(1, today)
+			require.NoError(t, err)
+
+			gp := got.GoalProgress
+			require.NotNil(t, gp)
+			require.InDelta(t, tt.goal-tt.current, gp.RemainingKG, 1e-6)
+			require.Equal(t, tt.wantReached, gp.Reached)
+			require.Equal(t, tt.wantTrend, gp.TrendKGPerWeek)
+			require.Equal(t, tt.wantETA, gp.EstimatedDate)
+			require.Equal(t, tt.wantOnTrack, gp.OnTrack)
 		})
 	}
 }
@@ -1,24 +1,88 @@
 package service
 
 import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 )
 
 type TimelineService interface {
-	GetTimeline(userID uint) ([]TimelineItem, error)
+	GetTimeline(userID uint, q TimelineQuery) (*TimelinePage, error)
 }
 
+const (
+	defaultTimelineLimit = 20
+	maxTimelineLimit     = 100
+)
+
+// TimelineQuery はタイムラインの取得条件。Scope の省略時は global、Limit の省略時は20件（上限100件）。
+// ExerciseID・AuthorID・From・To（日付、To を含む）は任意の絞り込みで、Cursor は前のページの NextCursor。
+type TimelineQuery struct {
+	Scope      models.TimelineScope
+	ExerciseID *uint
+	AuthorID   *uint
+	From       *time.Time
+	To         *time.Time
+	Cursor     string
+	Limit      int
+}
+
+// TimelinePage はタイムラインの1ページ。続きが無ければ NextCursor は空。
+type TimelinePage struct {
+	Items      []TimelineItem
+	NextCursor string
+}
+
+// TimelineKind はタイムラインの投稿の種類
+type TimelineKind string
+
+const (
+	TimelineSession TimelineKind = "session"
+	TimelineRecap   TimelineKind = "recap"
+)
+
+// TimelineItem はタイムラインの1投稿。Kind が recap の場合は Recap を持ち、TrainedOn は公開日（日本時間）。
 type TimelineItem struct {
+	Kind          TimelineKind
+	SessionID     uint
+	UserID        uint
+	UserEmail     string
+	ExerciseNames []string
+	Exercises     []TimelineExercise
+	BodyWeight    float64
+	TrainedOn     time.Time
+	Comment       string
+	LikedByMe     bool
+	LikeCount     int
+	CommentCount  int
+	Recap         *TimelineRecapItem
+}
+
+// TimelineExercise はセッション内の1種目の記録とセット。
+type TimelineExercise struct {
 	RecordID     uint
-	UserID       uint
-	UserEmail    string
+	ExerciseID   uint
 	ExerciseName string
-	BodyWeight   float64
-	TrainedOn    time.Time
-	Comment      string
-	LikedByMe    bool
+	Sets         []models.WorkoutSet
+}
+
+type TimelineRecapItem struct {
+	RecapID              uint
+	Period               models.RecapPeriod
+	PeriodStart          time.Time
+	TotalSessions        int
+	TrainingDays         int
+	TotalTonnage         float64
+	FavoriteExerciseName *string
+	PRCount              int
+	LongestStreak        int
+	PublishedAt          time.Time
 }
 
 type timelineService struct {
@@ -29,24 +93,174 @@ func NewTimelineService(repo repository.TimelineRepository) TimelineService {
 	return &timelineService{repo: repo}
 }
 
-func (s *timelineService) GetTimeline(userID uint) ([]TimelineItem, error) {
-	rows, err := s.repo.FindPublicRecords(userID)
+// GetTimeline は q の公開セッションと公開されたまとめを日付の新しい順に1ページ分返す。
+// 同じ日ならまとめを先にし、まとめは公開日時、セッションは ID の新しい順に並べる。
+func (s *timelineService) GetTimeline(userID uint, q TimelineQuery) (*TimelinePage, error) {
+	if q.Scope == "" {
+		q.Scope = models.TimelineGlobal
+	}
+	if !q.Scope.Valid() {
+		return nil, ErrInvalidTimelineScope
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultTimelineLimit
+	}
+	if q.Limit < 1 || q.Limit > maxTimelineLimit {
+		return nil, ErrInvalidTimelineQuery
+	}
+	if q.From != nil && q.To != nil && q.To.Before(*q.From) {
+		return nil, ErrInvalidTimelineQuery
+	}
+	cursor, err := decodeTimelineCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// 続きの有無を知るため、それぞれ1件多く取得する
+	rq := repository.TimelineQuery{
+		Scope:      q.Scope,
+		ExerciseID: q.ExerciseID,
+		AuthorID:   q.AuthorID,
+		From:       q.From,
+		To:         q.To,
+		Cursor:     cursor,
+		Limit:      q.Limit + 1,
+	}
+	rows, err := s.repo.FindPublicSessions(userID, rq)
+	if err != nil {
+		return nil, err
+	}
+	recaps, err := s.repo.FindPublicRecaps(userID, rq)
 	if err != nil {
 		return nil, err
 	}
 
-	out := make([]TimelineItem, 0, len(rows))
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+
+	out := make([]TimelineItem, 0, len(recaps)+len(rows))
+	for _, rc := range recaps {
+		published := rc.PublishedAt.In(loc)
+		out = append(out, TimelineItem{
+			Kind:      TimelineRecap,
+			UserID:    rc.UserID,
+			UserEmail: rc.UserEmail,
+			TrainedOn: time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, time.UTC),
+			Recap: &TimelineRecapItem{
+				RecapID:              rc.RecapID,
+				Period:               rc.Period,
+				PeriodStart:          rc.PeriodStart,
+				TotalSessions:        rc.TotalSessions,
+				TrainingDays:         rc.TrainingDays,
+				TotalTonnage:         rc.TotalTonnage,
+				FavoriteExerciseName: rc.FavoriteExerciseName,
+				PRCount:              rc.PRCount,
+				LongestStreak:        rc.LongestStreak,
+				PublishedAt:          rc.PublishedAt,
+			},
+		})
+	}
 	for _, it := range rows {
+		exercises := make([]TimelineExercise, 0, len(it.Exercises))
+		for _, ex := range it.Exercises {
+			exercises = append(exercises, TimelineExercise{
+				RecordID:     ex.RecordID,
+				ExerciseID:   ex.ExerciseID,
+				ExerciseName: ex.ExerciseName,
+				Sets:         ex.Sets,
+			})
+		}
 		out = append(out, TimelineItem{
-			RecordID:     it.RecordID,
-			UserID:       it.UserID,
-			UserEmail:    it.UserEmail,
-			ExerciseName: it.ExerciseName,
-			BodyWeight:   it.BodyWeight,
-			TrainedOn:    it.TrainedOn,
-			Comment:      it.Comment,
-			LikedByMe:    it.LikedByMe,
+			Kind:          TimelineSession,
+			SessionID:     it.SessionID,
+			UserID:        it.UserID,
+			UserEmail:     it.UserEmail,
+			ExerciseNames: it.ExerciseNames,
+			Exercises:     exercises,
+			BodyWeight:    it.BodyWeight,
+			TrainedOn:     it.TrainedOn,
+			Comment:       it.Comment,
+			LikedByMe:     it.LikedByMe,
+			LikeCount:     it.LikeCount,
+			CommentCount:  it.CommentCount,
 		})
 	}
-	return out, nil
+	sort.SliceStable(out, func(i, j int) bool {
+		return timelineBefore(out[i], out[j])
+	})
+
+	page := &TimelinePage{Items: out}
+	if len(out) > q.Limit {
+		page.Items = out[:q.Limit]
+		page.NextCursor = encodeTimelineCursor(page.Items[q.Limit-1])
+	}
+	return page, nil
+}
+
+// timelineBefore は a が b より前（新しい側）に並ぶか。repository.TimelineCursor の並びと一致させる。
+func timelineBefore(a, b TimelineItem) bool {
+	da, db := dayOf(a.TrainedOn), dayOf(b.TrainedOn)
+	if !da.Equal(db) {
+		return da.After(db)
+	}
+	if (a.Kind == TimelineRecap) != (b.Kind == TimelineRecap) {
+		return a.Kind == TimelineRecap
+	}
+	if a.Kind == TimelineRecap {
+		if !a.Recap.PublishedAt.Equal(b.Recap.PublishedAt) {
+			return a.Recap.PublishedAt.After(b.Recap.PublishedAt)
+		}
+		return a.Recap.RecapID > b.Recap.RecapID
+	}
+	return a.SessionID > b.SessionID
+}
+
+// encodeTimelineCursor は投稿の位置を不透明なカーソル文字列にする。
+// 形式は "s:日付:セッションID" または "r:日付:公開日時(UnixNano):まとめID" の base64url。
+func encodeTimelineCursor(it TimelineItem) string {
+	day := dayOf(it.TrainedOn).Format("2006-01-02")
+	var raw string
+	if it.Kind == TimelineRecap {
+		raw = fmt.Sprintf("r:%s:%d:%d", day, it.Recap.PublishedAt.UnixNano(), it.Recap.RecapID)
+	} else {
+		raw = fmt.Sprintf("s:%s:%d", day, it.SessionID)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTimelineCursor(cursor string) (*repository.TimelineCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidTimelineCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) < 3 {
+		return nil, ErrInvalidTimelineCursor
+	}
+	day, err := time.Parse("2006-01-02", parts[1])
+	if err != nil {
+		return nil, ErrInvalidTimelineCursor
+	}
+	id, err := strconv.ParseUint(parts[len(parts)-1], 10, 32)
+	if err != nil || id == 0 {
+		return nil, ErrInvalidTimelineCursor
+	}
+
+	c := &repository.TimelineCursor{Day: day, ID: uint(id)}
+	switch {
+	case parts[0] == "s" && len(parts) == 3:
+	case parts[0] == "r" && len(parts) == 4:
+		nanos, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, ErrInvalidTimelineCursor
+		}
+		c.IsRecap = true
+		c.PublishedAt = time.Unix(0, nanos).UTC()
+	default:
+		return nil, ErrInvalidTimelineCursor
+	}
+	return c, nil
 }
@@ -5,17 +5,27 @@ import (
 	"testing"
 	"time"
 
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 	"github.com/stretchr/testify/require"
 )
 
 type fakeTimelineRepo struct {
-	findFn func(userID uint) ([]repository.TimelineItem, error)
+	findFn   func(userID uint) ([]repository.TimelineItem, error)
+	recapsFn func() ([]repository.TimelineRecap, error)
+	gotQuery repository.TimelineQuery
 }
 
-func (f *fakeTimelineRepo) FindPublicRecords(userID uint) ([]repository.TimelineItem, error) {
+func (f *fakeTimelineRepo) FindPublicSessions(userID uint, q repository.TimelineQuery) ([]repository.TimelineItem, error) {
+	f.gotQuery = q
 	return f.findFn(userID)
 }
+func (f *fakeTimelineRepo) FindPublicRecaps(userID uint, q repository.TimelineQuery) ([]repository.TimelineRecap, error) {
+	if f.recapsFn == nil {
+		return nil, nil
+	}
+	return f.recapsFn()
+}
 func TestNewTimelineService(t *testing.T) {
 	repo := &fakeTimelineRepo{
 		findFn: func(userID uint) ([]repository.TimelineItem, error) {
@@ -40,36 +50,36 @@ func TestTimelineService_GetTimeline(t *testing.T) {
 		wantErr   string
 	}{
 		{
-			name: "【正常系】公開記録が1件以上返ってくる場合に値をマッピングできること",
+			name: "【正常系】公開セッションが1件以上返ってくる場合に値をマッピングできること",
 			repo: fakeTimelineRepo{
 				findFn: func(userID uint) ([]repository.TimelineItem, error) {
 					return []repository.TimelineItem{
 						{
-							RecordID:     1,
-							UserID:       10,
-							UserEmail:    "test@example.com",
-							ExerciseName: "Bench Press",
-							BodyWeight:   70.5,
-							TrainedOn:    now,
-							Comment:      "がんばった",
-							LikedByMe:    false,
+							SessionID:     1,
+							UserID:        10,
+							UserEmail:     "test@example.com",
+							ExerciseNames: []string{"Bench Press", "Squat"},
+							BodyWeight:    70.5,
+							TrainedOn:     now,
+							Comment:       "がんばった",
+							LikedByMe:     false,
 						},
 					}, nil
 				},
 			},
 			wantLen: 1,
 			wantFirst: &TimelineItem{
-				RecordID:     1,
-				UserID:       10,
-				UserEmail:    "test@example.com",
-				ExerciseName: "Bench Press",
-				BodyWeight:   70.5,
-				TrainedOn:    now,
-				Comment:      "がんばった",
+				SessionID:     1,
+				UserID:        10,
+				UserEmail:     "test@example.com",
+				ExerciseNames: []string{"Bench Press", "Squat"},
+				BodyWeight:    70.5,
+				TrainedOn:     now,
+				Comment:       "がんばった",
 			},
 		},
 		{
-			name: "【正常系】公開記録が0件の場合は空スライスを返すこと",
+			name: "【正常系】公開セッションが0件の場合は空スライスを返すこと",
 			repo: fakeTimelineRepo{
 				findFn: func(userID uint) ([]repository.TimelineItem, error) {
 					return []repository.TimelineItem{}, nil
@@ -94,7 +104,7 @@ func TestTimelineService_GetTimeline(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := NewTimelineService(&tt.repo)
 
-			got, err := svc.GetTimeline(1)
+			page, err := svc.GetTimeline(1, TimelineQuery{})
 
 			if tt.wantErr != "" {
 				require.Error(t, err)
@@ -103,13 +113,14 @@ func TestTimelineService_GetTimeline(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			got := page.Items
 			require.Len(t, got, tt.wantLen)
 
 			if tt.wantFirst != nil {
-				require.Equal(t, tt.wantFirst.RecordID, got[0].RecordID)
+				require.Equal(t, tt.wantFirst.SessionID, got[0].SessionID)
 				require.Equal(t, tt.wantFirst.UserID, got[0].UserID)
 				require.Equal(t, tt.wantFirst.UserEmail, got[0].UserEmail)
-				require.Equal(t, tt.wantFirst.ExerciseName, got[0].ExerciseName)
+				require.Equal(t, tt.wantFirst.ExerciseNames, got[0].ExerciseNames)
 				require.InDelta(t, tt.wantFirst.BodyWeight, got[0].BodyWeight, 1e-6)
 				require.WithinDuration(t, tt.wantFirst.TrainedOn, got[0].TrainedOn, time.Second)
 				require.Equal(t, tt.wantFirst.Comment, got[0].Comment)
@@ -117,3 +128,116 @@ func TestTimelineService_GetTimeline(t *testing.T) {
 		})
 	}
 }
+
+func TestTimelineService_GetTimeline_Recaps(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	svc := NewTimelineService(&fakeTimelineRepo{
+		findFn: func(uint) ([]repository.TimelineItem, error) {
+			return []repository.TimelineItem{
+				{SessionID: 2, TrainedOn: day(3)},
+				{SessionID: 1, TrainedOn: day(1)},
+			}, nil
+		},
+		recapsFn: func() ([]repository.TimelineRecap, error) {
+			// 日本時間では 10/3 の公開
+			return []repository.TimelineRecap{{
+				RecapID:     7,
+				UserID:      10,
+				Period:      models.RecapMonth,
+				PeriodStart: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				PublishedAt: time.Date(2025, 10, 2, 16, 0, 0, 0, time.UTC),
+			}}, nil
+		},
+	})
+
+	page, err := svc.GetTimeline(1, TimelineQuery{})
+	require.NoError(t, err)
+	got := page.Items
+	require.Len(t, got, 3)
+	require.Equal(t, TimelineRecap, got[0].Kind)
+	require.Equal(t, day(3), got[0].TrainedOn)
+	require.Equal(t, uint(7), got[0].Recap.RecapID)
+	require.Equal(t, TimelineSession, got[1].Kind)
+	require.Equal(t, uint(2), got[1].SessionID)
+	require.Equal(t, uint(1), got[2].SessionID)
+}
+
+func TestTimelineService_GetTimeline_Scope(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     models.TimelineScope
+		wantScope models.TimelineScope
+		wantErr   error
+	}{
+		{name: "【正常系】省略時は global で取得すること", scope: "", wantScope: models.TimelineGlobal},
+		{name: "【正常系】following を指定できること", scope: models.TimelineFollowing, wantScope: models.TimelineFollowing},
+		{name: "【正常系】me を指定できること", scope: models.TimelineMe, wantScope: models.TimelineMe},
+		{name: "【異常系】未知の範囲は ErrInvalidTimelineScope を返すこと", scope: "friends", wantErr: ErrInvalidTimelineScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTimelineRepo{
+				findFn: func(uint) ([]repository.TimelineItem, error) { return nil, nil },
+			}
+			_, err := NewTimelineService(repo).GetTimeline(1, TimelineQuery{Scope: tt.scope})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantScope, repo.gotQuery.Scope)
+		})
+	}
+}
+
+func TestTimelineService_GetTimeline_Page(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	repo := &fakeTimelineRepo{
+		findFn: func(uint) ([]repository.TimelineItem, error) {
+			return []repository.TimelineItem{
+				{SessionID: 3, TrainedOn: day(3)},
+				{SessionID: 2, TrainedOn: day(2)},
+				{SessionID: 1, TrainedOn: day(1)},
+			}, nil
+		},
+	}
+	svc := NewTimelineService(repo)
+
+	t.Run("【正常系】limit を超える分があれば NextCursor を返し、次のページの条件に使えること", func(t *testing.T) {
+		page, err := svc.GetTimeline(1, TimelineQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		require.Equal(t, 3, repo.gotQuery.Limit)
+		require.NotEmpty(t, page.NextCursor)
+
+		_, err = svc.GetTimeline(1, TimelineQuery{Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.Equal(t, &repository.TimelineCursor{Day: day(2), ID: 2}, repo.gotQuery.Cursor)
+	})
+
+	t.Run("【正常系】limit 以内なら NextCursor は空であること", func(t *testing.T) {
+		page, err := svc.GetTimeline(1, TimelineQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 3)
+		require.Equal(t, 21, repo.gotQuery.Limit)
+		require.Empty(t, page.NextCursor)
+	})
+
+	tests := []struct {
+		name    string
+		q       TimelineQuery
+		wantErr error
+	}{
+		{name: "【異常系】limit が上限を超える場合は ErrInvalidTimelineQuery を返すこと", q: TimelineQuery{Limit: 101}, wantErr: ErrInvalidTimelineQuery},
+		{name: "【異常系】limit が負の場合は ErrInvalidTimelineQuery を返すこと", q: TimelineQuery{Limit: -1}, wantErr: ErrInvalidTimelineQuery},
+		{name: "【異常系】from が to より後の場合は ErrInvalidTimelineQuery を返すこと", q: TimelineQuery{From: ptr(day(5)), To: ptr(day(4))}, wantErr: ErrInvalidTimelineQuery},
+		{name: "【異常系】不正なカーソルは ErrInvalidTimelineCursor を返すこと", q: TimelineQuery{Cursor: "not-a-cursor"}, wantErr: ErrInvalidTimelineCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetTimeline(1, tt.q)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+const (
+	maxCommentLength     = 500
+	defaultCommentsLimit = 20
+	maxCommentsLimit     = 100
+)
+
+type WorkoutCommentService interface {
+	ListComments(userID uint, sessionID uint, q CommentQuery) (*CommentPage, error)
+	CreateComment(userID uint, sessionID uint, data CommentData) (*Comment, error)
+	UpdateComment(userID uint, sessionID uint, commentID uint, body string) (*Comment, error)
+	DeleteComment(userID uint, sessionID uint, commentID uint) error
+}
+
+// CommentData はコメントの入力。ParentID を指定すると返信になる。
+type CommentData struct {
+	Body     string
+	ParentID *uint
+}
+
+// CommentQuery はコメント一覧の取得位置。Limit の省略時は20件（上限100件）、Cursor は前のページの NextCursor。
+type CommentQuery struct {
+	Cursor string
+	Limit  int
+}
+
+// Comment はコメント1件。返信ではないコメントは Replies に返信を古い順に持つ。
+type Comment struct {
+	ID        uint
+	SessionID uint
+	UserID    uint
+	UserEmail string
+	ParentID  *uint
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Replies   []Comment
+}
+
+// CommentPage はコメント一覧の1ページ。続きが無ければ NextCursor は空。
+type CommentPage struct {
+	Items      []Comment
+	NextCursor string
+}
+
+type workoutCommentService struct {
+	repo repository.WorkoutCommentRepository
+}
+
+func NewWorkoutCommentService(repo repository.WorkoutCommentRepository) WorkoutCommentService {
+	return &workoutCommentService{repo: repo}
+}
+
+func toComment(r repository.CommentRow) Comment {
+	return Comment{
+		ID:        r.ID,
+		SessionID: r.SessionID,
+		UserID:    r.UserID,
+		UserEmail: r.UserEmail,
+		ParentID:  r.ParentID,
+		Body:      r.Body,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// normalizeCommentBody は前後の空白を除いた本文を返す。空または長すぎる場合は ErrInvalidComment。
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return body, nil
+}
+
+func (s *workoutCommentService) findSession(userID uint, sessionID uint) (*repository.SessionAccess, error) {
+	access, err := s.repo.FindSessionAccess(userID, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("find session failed: %w", err)
+	}
+	return access, nil
+}
+
+// findPublicSession はいいねと同じく、userID から見える公開セッションだけをコメントの対象にする。
+func (s *workoutCommentService) findPublicSession(userID uint, sessionID uint) (*repository.SessionAccess, error) {
+	return checkSessionAccess(s.repo.FindSessionAccess, userID, sessionID)
+}
+
+func (s *workoutCommentService) findComment(commentID uint, sessionID uint) (*repository.CommentRow, error) {
+	comment, err := s.repo.FindByID(commentID, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("find comment failed: %w", err)
+	}
+	return comment, nil
+}
+
+// ListComments は返信ではないコメントを古い順に1ページ分、それぞれの返信を付けて返す。
+func (s *workoutCommentService) ListComments(userID uint, sessionID uint, q CommentQuery) (*CommentPage, error) {
+	if q.Limit == 0 {
+		q.Limit = defaultCommentsLimit
+	}
+	if q.Limit < 1 || q.Limit > maxCommentsLimit {
+		return nil, ErrInvalidCommentQuery
+	}
+	var afterID uint64
+	if q.Cursor != "" {
+		id, err := strconv.ParseUint(q.Cursor, 10, 32)
+		if err != nil || id == 0 {
+			return nil, ErrInvalidCommentQuery
+		}
+		afterID = id
+	}
+
+	if _, err := s.findPublicSession(userID, sessionID); err != nil {
+		return nil, err
+	}
+
+	// 続きの有無を知るため1件多く取得する
+	rows, err := s.repo.FindTopLevel(sessionID, uint(afterID), q.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("list comments failed: %w", err)
+	}
+	page := &CommentPage{}
+	if len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		page.NextCursor = strconv.FormatUint(uint64(rows[len(rows)-1].ID), 10)
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.ID)
+	}
+	replies, err := s.repo.FindReplies(ids)
+	if err != nil {
+		return nil, fmt.Errorf("list replies failed: %w", err)
+	}
+	byParent := make(map[uint][]Comment, len(rows))
+	for _, r := range replies {
+		byParent[*r.ParentID] = append(byParent[*r.ParentID], toComment(r))
+	}
+
+	page.Items = make([]Comment, 0, len(rows))
+	for _, r := range rows {
+		c := toComment(r)
+		c.Replies = byParent[r.ID]
+		page.Items = append(page.Items, c)
+	}
+	return page, nil
+}
+
+// CreateComment は公開セッションにコメントする。返信先は同じセッションの返信ではないコメントに限る。
+func (s *workoutCommentService) CreateComment(userID uint, sessionID uint, data CommentData) (*Comment, error) {
+	body, err := normalizeCommentBody(data.Body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.findPublicSession(userID, sessionID); err != nil {
+		return nil, err
+	}
+	if data.ParentID != nil {
+		parent, err := s.findComment(*data.ParentID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ParentID != nil {
+			return nil, ErrInvalidComment
+		}
+	}
+
+	comment := &models.WorkoutComment{
+		SessionID: sessionID,
+		UserID:    userID,
+		ParentID:  data.ParentID,
+		Body:      body,
+	}
+	if err := s.repo.Create(comment); err != nil {
+		return nil, fmt.Errorf("create comment failed: %w", err)
+	}
+
+	created, err := s.findComment(comment.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	out := toComment(*created)
+	return &out, nil
+}
+
+// UpdateComment はコメントの本文を変更する。変更できるのはコメントした本人のみ。
+func (s *workoutCommentService) UpdateComment(userID uint, sessionID uint, commentID uint, body string) (*Comment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.findPublicSession(userID, sessionID); err != nil {
+		return nil, err
+	}
+	comment, err := s.findComment(commentID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, ErrForbiddenComment
+	}
+
+	if err := s.repo.UpdateBody(commentID, body); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("update comment failed: %w", err)
+	}
+
+	updated, err := s.findComment(commentID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	out := toComment(*updated)
+	return &out, nil
+}
+
+// DeleteComment はコメントを返信ごと削除する。削除できるのはコメントした本人とセッションの投稿者。
+// 後から非公開にしたセッションや、フォローを外された非公開アカウントのセッションでも削除できるよう、公開状態は問わない。
+func (s *workoutCommentService) DeleteComment(userID uint, sessionID uint, commentID uint) error {
+	session, err := s.findSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+	comment, err := s.findComment(commentID, sessionID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID && session.OwnerID != userID {
+		return ErrForbiddenComment
+	}
+
+	if err := s.repo.Delete(commentID, sessionID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCommentNotFound
+		}
+		return fmt.Errorf("delete comment failed: %w", err)
+	}
+	return nil
+}
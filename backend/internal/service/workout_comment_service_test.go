@@ -0,0 +1,243 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWorkoutCommentRepo struct {
+	session  *models.WorkoutSession
+	comments map[uint]repository.CommentRow
+	nextID   uint
+	// hiddenFrom は投稿者の非公開アカウントを見られない（承認済みのフォロワーでない）ユーザー
+	hiddenFrom map[uint]bool
+
+	gotAfterID uint
+	gotLimit   int
+	deleted    []uint
+}
+
+func newFakeWorkoutCommentRepo(session *models.WorkoutSession, comments ...repository.CommentRow) *fakeWorkoutCommentRepo {
+	f := &fakeWorkoutCommentRepo{session: session, comments: map[uint]repository.CommentRow{}, nextID: 100}
+	for _, c := range comments {
+		f.comments[c.ID] = c
+	}
+	return f
+}
+
+func (f *fakeWorkoutCommentRepo) FindSessionAccess(viewerID uint, sessionID uint) (*repository.SessionAccess, error) {
+	if f.session == nil || f.session.ID != sessionID {
+		return nil, repository.ErrNotFound
+	}
+	return &repository.SessionAccess{
+		OwnerID:       f.session.UserID,
+		IsPublic:      f.session.IsPublic,
+		AuthorVisible: !f.hiddenFrom[viewerID],
+	}, nil
+}
+func (f *fakeWorkoutCommentRepo) FindByID(id uint, sessionID uint) (*repository.CommentRow, error) {
+	c, ok := f.comments[id]
+	if !ok || c.SessionID != sessionID {
+		return nil, repository.ErrNotFound
+	}
+	return &c, nil
+}
+func (f *fakeWorkoutCommentRepo) Create(comment *models.WorkoutComment) error {
+	f.nextID++
+	comment.ID = f.nextID
+	f.comments[comment.ID] = repository.CommentRow{
+		ID: comment.ID, SessionID: comment.SessionID, UserID: comment.UserID, ParentID: comment.ParentID, Body: comment.Body,
+	}
+	return nil
+}
+func (f *fakeWorkoutCommentRepo) UpdateBody(id uint, body string) error {
+	c := f.comments[id]
+	c.Body = body
+	f.comments[id] = c
+	return nil
+}
+func (f *fakeWorkoutCommentRepo) Delete(id uint, sessionID uint) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+func (f *fakeWorkoutCommentRepo) FindTopLevel(sessionID uint, afterID uint, limit int) ([]repository.CommentRow, error) {
+	f.gotAfterID, f.gotLimit = afterID, limit
+	var out []repository.CommentRow
+	for id := afterID + 1; id <= f.nextID && len(out) < limit; id++ {
+		if c, ok := f.comments[id]; ok && c.ParentID == nil {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+func (f *fakeWorkoutCommentRepo) FindReplies(parentIDs []uint) ([]repository.CommentRow, error) {
+	var out []repository.CommentRow
+	for id := uint(1); id <= f.nextID; id++ {
+		c, ok := f.comments[id]
+		if !ok || c.ParentID == nil {
+			continue
+		}
+		for _, p := range parentIDs {
+			if *c.ParentID == p {
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// ownerID=1 の公開セッション 10 に、ユーザー2のコメント1・2と、ユーザー1からコメント1への返信3がある状態
+func seedCommentRepo(isPublic bool) *fakeWorkoutCommentRepo {
+	session := &models.WorkoutSession{UserID: 1, IsPublic: isPublic}
+	session.ID = 10
+	repo := newFakeWorkoutCommentRepo(session,
+		repository.CommentRow{ID: 1, SessionID: 10, UserID: 2, Body: "ナイス"},
+		repository.CommentRow{ID: 2, SessionID: 10, UserID: 2, Body: "すごい"},
+		repository.CommentRow{ID: 3, SessionID: 10, UserID: 1, ParentID: ptr(uint(1)), Body: "ありがとう"},
+	)
+	repo.nextID = 3
+	return repo
+}
+
+func TestWorkoutCommentService_ListComments(t *testing.T) {
+	t.Run("【正常系】返信を付けて1ページ分返し、続きがあれば NextCursor を返すこと", func(t *testing.T) {
+		repo := seedCommentRepo(true)
+		svc := NewWorkoutCommentService(repo)
+
+		page, err := svc.ListComments(2, 10, CommentQuery{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, 2, repo.gotLimit)
+		require.Len(t, page.Items, 1)
+		require.Equal(t, uint(1), page.Items[0].ID)
+		require.Len(t, page.Items[0].Replies, 1)
+		require.Equal(t, uint(3), page.Items[0].Replies[0].ID)
+		require.Equal(t, "1", page.NextCursor)
+
+		page, err = svc.ListComments(2, 10, CommentQuery{Limit: 1, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		require.Equal(t, uint(1), repo.gotAfterID)
+		require.Len(t, page.Items, 1)
+		require.Equal(t, uint(2), page.Items[0].ID)
+		require.Empty(t, page.Items[0].Replies)
+		require.Empty(t, page.NextCursor)
+	})
+
+	tests := []struct {
+		name     string
+		isPublic bool
+		q        CommentQuery
+		wantErr  error
+	}{
+		{name: "【異常系】非公開のセッションは ErrForbiddenPrivateRecord を返すこと", isPublic: false, wantErr: ErrForbiddenPrivateRecord},
+		{name: "【異常系】limit が上限を超える場合は ErrInvalidCommentQuery を返すこと", isPublic: true, q: CommentQuery{Limit: 101}, wantErr: ErrInvalidCommentQuery},
+		{name: "【異常系】不正なカーソルは ErrInvalidCommentQuery を返すこと", isPublic: true, q: CommentQuery{Cursor: "abc"}, wantErr: ErrInvalidCommentQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWorkoutCommentService(seedCommentRepo(tt.isPublic)).ListComments(2, 10, tt.q)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("【異常系】非公開アカウントのセッションは承認済みのフォロワー以外に ErrPrivateAccount を返すこと", func(t *testing.T) {
+		repo := seedCommentRepo(true)
+		repo.hiddenFrom = map[uint]bool{4: true}
+		_, err := NewWorkoutCommentService(repo).ListComments(4, 10, CommentQuery{})
+		require.ErrorIs(t, err, ErrPrivateAccount)
+	})
+}
+
+func TestWorkoutCommentService_CreateComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		isPublic  bool
+		sessionID uint
+		data      CommentData
+		wantErr   error
+	}{
+		{name: "【正常系】前後の空白を除いてコメントできること", isPublic: true, sessionID: 10, data: CommentData{Body: "  いい重量！ "}},
+		{name: "【正常系】返信ではないコメントに返信できること", isPublic: true, sessionID: 10, data: CommentData{Body: "同感", ParentID: ptr(uint(2))}},
+		{name: "【異常系】返信への返信は ErrInvalidComment を返すこと", isPublic: true, sessionID: 10, data: CommentData{Body: "返信", ParentID: ptr(uint(3))}, wantErr: ErrInvalidComment},
+		{name: "【異常系】存在しない返信先は ErrCommentNotFound を返すこと", isPublic: true, sessionID: 10, data: CommentData{Body: "返信", ParentID: ptr(uint(99))}, wantErr: ErrCommentNotFound},
+		{name: "【異常系】空の本文は ErrInvalidComment を返すこと", isPublic: true, sessionID: 10, data: CommentData{Body: "   "}, wantErr: ErrInvalidComment},
+		{name: "【異常系】非公開のセッションは ErrForbiddenPrivateRecord を返すこと", isPublic: false, sessionID: 10, data: CommentData{Body: "こんにちは"}, wantErr: ErrForbiddenPrivateRecord},
+		{name: "【異常系】存在しないセッションは ErrSessionNotFound を返すこと", isPublic: true, sessionID: 11, data: CommentData{Body: "こんにちは"}, wantErr: ErrSessionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWorkoutCommentService(seedCommentRepo(tt.isPublic)).CreateComment(2, tt.sessionID, tt.data)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint(2), got.UserID)
+			require.Equal(t, tt.data.ParentID, got.ParentID)
+			require.Equal(t, strings.TrimSpace(tt.data.Body), got.Body)
+		})
+	}
+
+	t.Run("【異常系】非公開アカウントのセッションに承認済みのフォロワー以外はコメントできないこと", func(t *testing.T) {
+		repo := seedCommentRepo(true)
+		repo.hiddenFrom = map[uint]bool{4: true}
+		_, err := NewWorkoutCommentService(repo).CreateComment(4, 10, CommentData{Body: "こんにちは"})
+		require.ErrorIs(t, err, ErrPrivateAccount)
+		require.Len(t, repo.comments, 3)
+	})
+}
+
+func TestWorkoutCommentService_UpdateComment(t *testing.T) {
+	t.Run("【正常系】コメントした本人は本文を変更できること", func(t *testing.T) {
+		got, err := NewWorkoutCommentService(seedCommentRepo(true)).UpdateComment(2, 10, 1, "ナイス！")
+		require.NoError(t, err)
+		require.Equal(t, "ナイス！", got.Body)
+	})
+
+	t.Run("【異常系】セッションの投稿者でも他人のコメントは変更できないこと", func(t *testing.T) {
+		_, err := NewWorkoutCommentService(seedCommentRepo(true)).UpdateComment(1, 10, 1, "書き換え")
+		require.ErrorIs(t, err, ErrForbiddenComment)
+	})
+
+	t.Run("【異常系】フォローを外された非公開アカウントのセッションではコメントを変更できないこと", func(t *testing.T) {
+		repo := seedCommentRepo(true)
+		repo.hiddenFrom = map[uint]bool{2: true}
+		_, err := NewWorkoutCommentService(repo).UpdateComment(2, 10, 1, "ナイス！")
+		require.ErrorIs(t, err, ErrPrivateAccount)
+	})
+}
+
+func TestWorkoutCommentService_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uint
+		isPublic bool
+		wantErr  error
+	}{
+		{name: "【正常系】コメントした本人は削除できること", userID: 2, isPublic: true},
+		{name: "【正常系】セッションの投稿者は他人のコメントを削除できること", userID: 1, isPublic: true},
+		{name: "【正常系】非公開にしたセッションでも削除できること", userID: 1, isPublic: false},
+		{name: "【異常系】第三者は ErrForbiddenComment を返すこと", userID: 3, isPublic: true, wantErr: ErrForbiddenComment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := seedCommentRepo(tt.isPublic)
+			err := NewWorkoutCommentService(repo).DeleteComment(tt.userID, 10, 1)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Empty(t, repo.deleted)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []uint{1}, repo.deleted)
+		})
+	}
+
+	t.Run("【異常系】存在しないコメントは ErrCommentNotFound を返すこと", func(t *testing.T) {
+		err := NewWorkoutCommentService(seedCommentRepo(true)).DeleteComment(2, 10, 99)
+		require.ErrorIs(t, err, ErrCommentNotFound)
+	})
+}
@@ -2,13 +2,28 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 )
 
 type WorkoutLikeService interface {
-	Like(userID uint, recordID uint) error
-	Unlike(userID uint, recordID uint) error
+	Like(userID uint, sessionID uint) error
+	Unlike(userID uint, sessionID uint) error
+	ListLikers(userID uint, sessionID uint) (*SessionLikes, error)
+}
+
+// SessionLikes はセッションのいいね数といいねしたユーザー（新しい順）。
+type SessionLikes struct {
+	SessionID uint
+	LikeCount int
+	Users     []LikeUser
+}
+
+type LikeUser struct {
+	UserID  uint
+	Email   string
+	LikedAt time.Time
 }
 
 type workoutLikeService struct {
@@ -19,32 +34,65 @@ func NewWorkoutLikeService(repo repository.WorkoutLikeRepository) WorkoutLikeSer
 	return &workoutLikeService{repo: repo}
 }
 
-func (s *workoutLikeService) Like(userID uint, recordID uint) error {
-	public, err := s.repo.IsRecordPublic(recordID)
+// checkSessionAccess は sessionID のセッションが存在し、viewerID から見られる公開セッションかを確かめる。
+// 非公開アカウントの投稿は本人と承認済みのフォロワーにだけ見せる。
+func checkSessionAccess(find func(viewerID uint, sessionID uint) (*repository.SessionAccess, error), viewerID uint, sessionID uint) (*repository.SessionAccess, error) {
+	access, err := find(viewerID, sessionID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return ErrRecordNotFound
+			return nil, ErrSessionNotFound
 		}
-		return err
+		return nil, err
 	}
-	if !public {
-		return ErrForbiddenPrivateRecord
+	if !access.AuthorVisible {
+		return nil, ErrPrivateAccount
+	}
+	if !access.IsPublic {
+		return nil, ErrForbiddenPrivateRecord
+	}
+	return access, nil
+}
+
+func (s *workoutLikeService) checkPublic(userID uint, sessionID uint) error {
+	_, err := checkSessionAccess(s.repo.FindSessionAccess, userID, sessionID)
+	return err
+}
+
+func (s *workoutLikeService) Like(userID uint, sessionID uint) error {
+	if err := s.checkPublic(userID, sessionID); err != nil {
+		return err
 	}
+	return s.repo.CreateLike(userID, sessionID)
+}
 
-	return s.repo.CreateLike(userID, recordID)
+func (s *workoutLikeService) Unlike(userID uint, sessionID uint) error {
+	if err := s.checkPublic(userID, sessionID); err != nil {
+		return err
+	}
+	return s.repo.DeleteLike(userID, sessionID)
 }
 
-func (s *workoutLikeService) Unlike(userID uint, recordID uint) error {
-	public, err := s.repo.IsRecordPublic(recordID)
+// ListLikers は公開セッションにいいねしたユーザーを返す。
+func (s *workoutLikeService) ListLikers(userID uint, sessionID uint) (*SessionLikes, error) {
+	if err := s.checkPublic(userID, sessionID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountLikes(sessionID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return ErrRecordNotFound
+			return nil, ErrSessionNotFound
 		}
-		return err
+		return nil, err
 	}
-	if !public {
-		return ErrForbiddenPrivateRecord
+	rows, err := s.repo.FindLikers(sessionID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.DeleteLike(userID, recordID)
+	users := make([]LikeUser, 0, len(rows))
+	for _, r := range rows {
+		users = append(users, LikeUser{UserID: r.UserID, Email: r.Email, LikedAt: r.LikedAt})
+	}
+	return &SessionLikes{SessionID: sessionID, LikeCount: count, Users: users}, nil
 }
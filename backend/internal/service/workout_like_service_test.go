@@ -3,49 +3,66 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
 	"github.com/stretchr/testify/require"
 )
 
 type fakeWorkoutLikeRepo struct {
-	isRecordPublicFunc func(recordID uint) (bool, error)
-	createLikeFunc     func(userID uint, recordID uint) error
-	deleteLikeFunc     func(userID uint, recordID uint) error
-	isLikedByMeFunc    func(userID uint, recordID uint) (bool, error)
+	findAccessFunc  func(viewerID uint, sessionID uint) (*repository.SessionAccess, error)
+	createLikeFunc  func(userID uint, sessionID uint) error
+	deleteLikeFunc  func(userID uint, sessionID uint) error
+	isLikedByMeFunc func(userID uint, sessionID uint) (bool, error)
+	countLikesFunc  func(sessionID uint) (int, error)
+	findLikersFunc  func(sessionID uint) ([]repository.LikeUser, error)
 
 	createCalled int
 	deleteCalled int
 }
 
-func (f *fakeWorkoutLikeRepo) CreateLike(userID uint, recordID uint) error {
+func (f *fakeWorkoutLikeRepo) CreateLike(userID uint, sessionID uint) error {
 	f.createCalled++
 	if f.createLikeFunc == nil {
 		return nil
 	}
-	return f.createLikeFunc(userID, recordID)
+	return f.createLikeFunc(userID, sessionID)
 }
 
-func (f *fakeWorkoutLikeRepo) DeleteLike(userID uint, recordID uint) error {
+func (f *fakeWorkoutLikeRepo) DeleteLike(userID uint, sessionID uint) error {
 	f.deleteCalled++
 	if f.deleteLikeFunc == nil {
 		return nil
 	}
-	return f.deleteLikeFunc(userID, recordID)
+	return f.deleteLikeFunc(userID, sessionID)
 }
 
-func (f *fakeWorkoutLikeRepo) IsRecordPublic(recordID uint) (bool, error) {
-	if f.isRecordPublicFunc == nil {
-		return true, nil
+func (f *fakeWorkoutLikeRepo) FindSessionAccess(viewerID uint, sessionID uint) (*repository.SessionAccess, error) {
+	if f.findAccessFunc == nil {
+		return &repository.SessionAccess{IsPublic: true, AuthorVisible: true}, nil
 	}
-	return f.isRecordPublicFunc(recordID)
+	return f.findAccessFunc(viewerID, sessionID)
 }
 
-func (f *fakeWorkoutLikeRepo) IsLikedByMe(userID uint, recordID uint) (bool, error) {
+func (f *fakeWorkoutLikeRepo) IsLikedByMe(userID uint, sessionID uint) (bool, error) {
 	if f.isLikedByMeFunc == nil {
 		return false, nil
 	}
-	return f.isLikedByMeFunc(userID, recordID)
+	return f.isLikedByMeFunc(userID, sessionID)
+}
+
+func (f *fakeWorkoutLikeRepo) CountLikes(sessionID uint) (int, error) {
+	if f.countLikesFunc == nil {
+		return 0, nil
+	}
+	return f.countLikesFunc(sessionID)
+}
+
+func (f *fakeWorkoutLikeRepo) FindLikers(sessionID uint) ([]repository.LikeUser, error) {
+	if f.findLikersFunc == nil {
+		return nil, nil
+	}
+	return f.findLikersFunc(sessionID)
 }
 
 func TestWorkoutLikeService_Like(t *testing.T) {
@@ -53,56 +70,68 @@ func TestWorkoutLikeService_Like(t *testing.T) {
 		name        string
 		repo        fakeWorkoutLikeRepo
 		userID      uint
-		recordID    uint
+		sessionID   uint
 		wantErr     error
 		errContains string
 		wantCreate  int
 		wantDelete  int
 	}{
 		{
-			name:   "【正常系】公開レコードならCreateLikeが呼ばれて成功する",
-			userID: 1, recordID: 10,
+			name:   "【正常系】公開セッションならCreateLikeが呼ばれて成功する",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					require.Equal(t, uint(10), recordID)
-					return true, nil
+				findAccessFunc: func(viewerID uint, sessionID uint) (*repository.SessionAccess, error) {
+					require.Equal(t, uint(1), viewerID)
+					require.Equal(t, uint(10), sessionID)
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: true}, nil
 				},
-				createLikeFunc: func(userID uint, recordID uint) error {
+				createLikeFunc: func(userID uint, sessionID uint) error {
 					require.Equal(t, uint(1), userID)
-					require.Equal(t, uint(10), recordID)
+					require.Equal(t, uint(10), sessionID)
 					return nil
 				},
 			},
 			wantCreate: 1,
 		},
 		{
-			name:   "【正常系】非公開レコードならErrForbiddenPrivateRecordを返しCreateLikeは呼ばれない",
-			userID: 1, recordID: 10,
+			name:   "【正常系】非公開セッションならErrForbiddenPrivateRecordを返しCreateLikeは呼ばれない",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, nil
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: false, AuthorVisible: true}, nil
 				},
 			},
 			wantErr:    ErrForbiddenPrivateRecord,
 			wantCreate: 0,
 		},
 		{
-			name:   "【正常系】存在しないレコードならErrRecordNotFoundに変換して返す",
-			userID: 1, recordID: 999,
+			name:   "【正常系】承認されたフォロワーでなければ非公開アカウントのセッションにErrPrivateAccountを返しCreateLikeは呼ばれない",
+			userID: 1, sessionID: 10,
+			repo: fakeWorkoutLikeRepo{
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: false}, nil
+				},
+			},
+			wantErr:    ErrPrivateAccount,
+			wantCreate: 0,
+		},
+		{
+			name:   "【正常系】存在しないセッションならErrSessionNotFoundに変換して返す",
+			userID: 1, sessionID: 999,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, repository.ErrNotFound
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return nil, repository.ErrNotFound
 				},
 			},
-			wantErr:    ErrRecordNotFound,
+			wantErr:    ErrSessionNotFound,
 			wantCreate: 0,
 		},
 		{
-			name:   "【異常系】IsRecordPublicが想定外エラーならそのまま返す",
-			userID: 1, recordID: 10,
+			name:   "【異常系】FindSessionAccessが想定外エラーならそのまま返す",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, errors.New("db down")
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return nil, errors.New("db down")
 				},
 			},
 			errContains: "db down",
@@ -110,12 +139,12 @@ func TestWorkoutLikeService_Like(t *testing.T) {
 		},
 		{
 			name:   "【異常系】CreateLikeがエラーならそのまま返す",
-			userID: 1, recordID: 10,
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return true, nil
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: true}, nil
 				},
-				createLikeFunc: func(userID uint, recordID uint) error {
+				createLikeFunc: func(userID uint, sessionID uint) error {
 					return errors.New("insert failed")
 				},
 			},
@@ -130,7 +159,7 @@ func TestWorkoutLikeService_Like(t *testing.T) {
 			repo := tt.repo
 			svc := NewWorkoutLikeService(&repo)
 
-			err := svc.Like(tt.userID, tt.recordID)
+			err := svc.Like(tt.userID, tt.sessionID)
 
 			switch {
 			case tt.wantErr != nil:
@@ -153,55 +182,66 @@ func TestWorkoutLikeService_Unlike(t *testing.T) {
 		name        string
 		repo        fakeWorkoutLikeRepo
 		userID      uint
-		recordID    uint
+		sessionID   uint
 		wantErr     error
 		errContains string
 		wantCreate  int
 		wantDelete  int
 	}{
 		{
-			name:   "【正常系】公開レコードならDeleteLikeが呼ばれて成功する（冪等）",
-			userID: 1, recordID: 10,
+			name:   "【正常系】公開セッションならDeleteLikeが呼ばれて成功する（冪等）",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return true, nil
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: true}, nil
 				},
-				deleteLikeFunc: func(userID uint, recordID uint) error {
+				deleteLikeFunc: func(userID uint, sessionID uint) error {
 					require.Equal(t, uint(1), userID)
-					require.Equal(t, uint(10), recordID)
+					require.Equal(t, uint(10), sessionID)
 					return nil
 				},
 			},
 			wantDelete: 1,
 		},
 		{
-			name:   "【正常系】非公開レコードならErrForbiddenPrivateRecordを返しDeleteLikeは呼ばれない",
-			userID: 1, recordID: 10,
+			name:   "【正常系】非公開セッションならErrForbiddenPrivateRecordを返しDeleteLikeは呼ばれない",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, nil
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: false, AuthorVisible: true}, nil
 				},
 			},
 			wantErr:    ErrForbiddenPrivateRecord,
 			wantDelete: 0,
 		},
 		{
-			name:   "【正常系】存在しないレコードならErrRecordNotFoundに変換して返す",
-			userID: 1, recordID: 999,
+			name:   "【正常系】承認されたフォロワーでなければ非公開アカウントのセッションにErrPrivateAccountを返しDeleteLikeは呼ばれない",
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, repository.ErrNotFound
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: false}, nil
 				},
 			},
-			wantErr:    ErrRecordNotFound,
+			wantErr:    ErrPrivateAccount,
 			wantDelete: 0,
 		},
 		{
-			name:   "【異常系】IsRecordPublicが想定外エラーならそのまま返す",
-			userID: 1, recordID: 10,
+			name:   "【正常系】存在しないセッションならErrSessionNotFoundに変換して返す",
+			userID: 1, sessionID: 999,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return false, errors.New("db down")
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return nil, repository.ErrNotFound
+				},
+			},
+			wantErr:    ErrSessionNotFound,
+			wantDelete: 0,
+		},
+		{
+			name:   "【異常系】FindSessionAccessが想定外エラーならそのまま返す",
+			userID: 1, sessionID: 10,
+			repo: fakeWorkoutLikeRepo{
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return nil, errors.New("db down")
 				},
 			},
 			errContains: "db down",
@@ -209,12 +249,12 @@ func TestWorkoutLikeService_Unlike(t *testing.T) {
 		},
 		{
 			name:   "【異常系】DeleteLikeがエラーならそのまま返す",
-			userID: 1, recordID: 10,
+			userID: 1, sessionID: 10,
 			repo: fakeWorkoutLikeRepo{
-				isRecordPublicFunc: func(recordID uint) (bool, error) {
-					return true, nil
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: true}, nil
 				},
-				deleteLikeFunc: func(userID uint, recordID uint) error {
+				deleteLikeFunc: func(userID uint, sessionID uint) error {
 					return errors.New("delete failed")
 				},
 			},
@@ -229,7 +269,7 @@ func TestWorkoutLikeService_Unlike(t *testing.T) {
 			repo := tt.repo
 			svc := NewWorkoutLikeService(&repo)
 
-			err := svc.Unlike(tt.userID, tt.recordID)
+			err := svc.Unlike(tt.userID, tt.sessionID)
 
 			switch {
 			case tt.wantErr != nil:
@@ -246,3 +286,80 @@ func TestWorkoutLikeService_Unlike(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutLikeService_ListLikers(t *testing.T) {
+	likedAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		repo      fakeWorkoutLikeRepo
+		wantErr   error
+		wantCount int
+		wantUsers []LikeUser
+	}{
+		{
+			name: "【正常系】いいね数といいねしたユーザーを返すこと",
+			repo: fakeWorkoutLikeRepo{
+				countLikesFunc: func(uint) (int, error) { return 2, nil },
+				findLikersFunc: func(uint) ([]repository.LikeUser, error) {
+					return []repository.LikeUser{
+						{UserID: 3, Email: "c@example.com", LikedAt: likedAt},
+						{UserID: 2, Email: "b@example.com", LikedAt: likedAt.Add(-time.Hour)},
+					}, nil
+				},
+			},
+			wantCount: 2,
+			wantUsers: []LikeUser{
+				{UserID: 3, Email: "c@example.com", LikedAt: likedAt},
+				{UserID: 2, Email: "b@example.com", LikedAt: likedAt.Add(-time.Hour)},
+			},
+		},
+		{
+			name:      "【正常系】いいねが無い場合は空の一覧を返すこと",
+			repo:      fakeWorkoutLikeRepo{},
+			wantUsers: []LikeUser{},
+		},
+		{
+			name: "【異常系】非公開のセッションは ErrForbiddenPrivateRecord を返すこと",
+			repo: fakeWorkoutLikeRepo{
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: false, AuthorVisible: true}, nil
+				},
+			},
+			wantErr: ErrForbiddenPrivateRecord,
+		},
+		{
+			name: "【異常系】非公開アカウントのセッションは承認されたフォロワー以外に ErrPrivateAccount を返し、いいねしたユーザーを取得しないこと",
+			repo: fakeWorkoutLikeRepo{
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) {
+					return &repository.SessionAccess{IsPublic: true, AuthorVisible: false}, nil
+				},
+				countLikesFunc: func(uint) (int, error) { panic("CountLikes must not be called") },
+				findLikersFunc: func(uint) ([]repository.LikeUser, error) { panic("FindLikers must not be called") },
+			},
+			wantErr: ErrPrivateAccount,
+		},
+		{
+			name: "【異常系】存在しないセッションは ErrSessionNotFound を返すこと",
+			repo: fakeWorkoutLikeRepo{
+				findAccessFunc: func(uint, uint) (*repository.SessionAccess, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.repo
+			got, err := NewWorkoutLikeService(&repo).ListLikers(1, 10)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint(10), got.SessionID)
+			require.Equal(t, tt.wantCount, got.LikeCount)
+			require.Equal(t, tt.wantUsers, got.Users)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+// ProgressionBucket は推移を集計する期間の単位
+type ProgressionBucket string
+
+const (
+	BucketDay   ProgressionBucket = "day"
+	BucketWeek  ProgressionBucket = "week"
+	BucketMonth ProgressionBucket = "month"
+)
+
+func (b ProgressionBucket) Valid() bool {
+	switch b {
+	case BucketDay, BucketWeek, BucketMonth:
+		return true
+	}
+	return false
+}
+
+// ProgressionQuery は種目の推移の取得条件。空の Formula / Bucket は epley / day として扱う。
+type ProgressionQuery struct {
+	Formula models.OneRepMaxFormula
+	Bucket  ProgressionBucket
+	From    *time.Time
+	To      *time.Time
+}
+
+type TopSet struct {
+	Weight    float64
+	Reps      int
+	TrainedOn time.Time
+}
+
+// ProgressionPoint は1期間分の集計。PeriodStart は日・週（月曜始まり）・月の初日。
+type ProgressionPoint struct {
+	PeriodStart  time.Time
+	BestE1RM     float64
+	TopSet       *TopSet
+	TotalVolume  float64
+	TotalReps    int
+	TrainingDays int
+}
+
+// ExerciseProgression は推移の集計結果。Formula / Bucket は既定値を補った実際の条件。
+type ExerciseProgression struct {
+	Formula models.OneRepMaxFormula
+	Bucket  ProgressionBucket
+	Points  []ProgressionPoint
+}
+
+func bucketStart(day time.Time, bucket ProgressionBucket) time.Time {
+	d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case BucketWeek:
+		offset := (int(d.Weekday()) + 6) % 7
+		return d.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return d
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// setLoad は推定1RMに使う負荷を返す。自重種目は記録時の体重を含める。
+func setLoad(r repository.FlatWorkoutSet) float64 {
+	if r.MeasurementKind == models.MeasurementBodyweightLoad {
+		return r.BodyWeight + r.ExerciseWeight
+	}
+	return r.ExerciseWeight
+}
+
+// GetExerciseProgression は種目の推定1RM・トップセット・ボリューム・回数を期間ごとに集計する。
+// トップセットは最も重い加重のセット（同重量なら回数の多いもの）。ウォームアップセットは集計しない。
+func (s *workoutService) GetExerciseProgression(userID uint, exerciseID uint, query ProgressionQuery) (*ExerciseProgression, error) {
+	if query.Formula == "" {
+		query.Formula = models.OneRepMaxEpley
+	}
+	if query.Bucket == "" {
+		query.Bucket = BucketDay
+	}
+	if !query.Formula.Valid() || !query.Bucket.Valid() {
+		return nil, ErrInvalidProgressionQuery
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, ErrInvalidProgressionQuery
+	}
+
+	rows, err := s.repo.FindSetsByUserAndExerciseInRange(userID, exerciseID, query.From, query.To)
+	if err != nil {
+		return nil, fmt.Errorf("fetch exercise progression failed: %w", err)
+	}
+
+	points := make(map[time.Time]*ProgressionPoint)
+	days := make(map[time.Time]map[time.Time]bool)
+	for _, r := range rows {
+		if r.SetType == models.SetTypeWarmup {
+			continue
+		}
+		start := bucketStart(r.TrainedOn, query.Bucket)
+		p, ok := points[start]
+		if !ok {
+			p = &ProgressionPoint{PeriodStart: start}
+			points[start] = p
+			days[start] = make(map[time.Time]bool)
+		}
+		days[start][bucketStart(r.TrainedOn, BucketDay)] = true
+
+		p.TotalVolume += r.Volume
+		p.TotalReps += r.Reps
+
+		weighted := r.MeasurementKind == models.MeasurementRepsWeight ||
+			r.MeasurementKind == models.MeasurementBodyweightLoad
+		if !weighted || r.Reps <= 0 {
+			continue
+		}
+		if r.Reps <= models.MaxRepsForEstimated1RM {
+			if e := query.Formula.Estimate(setLoad(r), r.Reps); e > p.BestE1RM {
+				p.BestE1RM = e
+			}
+		}
+		if p.TopSet == nil || r.ExerciseWeight > p.TopSet.Weight ||
+			(r.ExerciseWeight == p.TopSet.Weight && r.Reps > p.TopSet.Reps) {
+			p.TopSet = &TopSet{Weight: r.ExerciseWeight, Reps: r.Reps, TrainedOn: r.TrainedOn}
+		}
+	}
+
+	out := make([]ProgressionPoint, 0, len(points))
+	for start, p := range points {
+		p.BestE1RM = round2(p.BestE1RM)
+		p.TotalVolume = round2(p.TotalVolume)
+		p.TrainingDays = len(days[start])
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].PeriodStart.Before(out[j].PeriodStart)
+	})
+	return &ExerciseProgression{Formula: query.Formula, Bucket: query.Bucket, Points: out}, nil
+}
@@ -3,7 +3,11 @@ package service
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/models"
 	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
@@ -16,41 +20,194 @@ type WorkoutService interface {
 	UpdateWorkoutRecord(userID uint, recordID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []WorkoutSetData) (*models.WorkoutRecord, error)
 	DeleteWorkoutRecord(userID uint, recordID uint) error
 	GetWorkoutRecordsByExercise(userID uint, exerciseID uint) ([]FlatSet, error)
+	GetExerciseProgression(userID uint, exerciseID uint, query ProgressionQuery) (*ExerciseProgression, error)
 }
 
 type WorkoutSetData struct {
-	SetNo          int
-	Reps           int
-	ExerciseWeight float64
-	IsPublic       bool
+	SetNo           int
+	Reps            int
+	ExerciseWeight  float64
+	IsPublic        bool
+	SetType         models.SetType
+	RPE             *float64
+	RIR             *int
+	RestSeconds     *int
+	Tempo           string
+	Note            string
+	DurationSeconds *int
+	DistanceMeters  *float64
 }
 
+// セットの付加情報の許容範囲
+const (
+	minRPE           = 1.0
+	maxRPE           = 10.0
+	maxRIR           = 10
+	maxRestSeconds   = 60 * 60
+	maxSetNoteLength = 500
+)
+
+// 時間・距離の上限（1セットあたり24時間・1000km）
+const (
+	maxDurationSeconds = 24 * 60 * 60
+	maxDistanceMeters  = 1000 * 1000
+)
+
+// テンポは「伸張-停止-短縮-停止」の4桁（例: 3-1-X-0, 31X0）
+var tempoPattern = regexp.MustCompile(`^[0-9X](-?[0-9X]){3}$`)
+
 type workoutService struct {
-	repo repository.WorkoutRepository
+	repo     repository.WorkoutRepository
+	rankings RankingInvalidator
 }
 
 type FlatSet struct {
-	RecordID       uint
-	TrainedOn      time.Time
-	SetNo          int
-	Reps           int
-	ExerciseWeight float64
-	BodyWeight     float64
+	RecordID        uint
+	TrainedOn       time.Time
+	SetNo           int
+	Reps            int
+	ExerciseWeight  float64
+	BodyWeight      float64
+	SetType         models.SetType
+	RPE             *float64
+	RIR             *int
+	RestSeconds     *int
+	Tempo           string
+	Note            string
+	MeasurementKind models.MeasurementKind
+	DurationSeconds *int
+	DistanceMeters  *float64
+	Volume          float64
 }
 
-func NewWorkoutService(repo repository.WorkoutRepository) WorkoutService {
-	return &workoutService{repo: repo}
+// NewWorkoutService の rankings には記録の変更を伝えるランキングのキャッシュを渡す（nil なら伝えない）。
+func NewWorkoutService(repo repository.WorkoutRepository, rankings RankingInvalidator) WorkoutService {
+	return &workoutService{repo: repo, rankings: rankings}
 }
 
-func (s *workoutService) CreateWorkoutRecord(userID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []WorkoutSetData, isPublic bool, comment string) (*models.WorkoutRecord, error) {
+func validateSets(sets []WorkoutSetData, kind models.MeasurementKind) error {
 	if len(sets) == 0 {
-		return nil, ErrNoSets
+		return ErrNoSets
 	}
 
 	for _, st := range sets {
-		if st.SetNo <= 0 || st.Reps <= 0 || st.ExerciseWeight < 0 {
-			return nil, ErrInvalidSetValue
+		if st.SetNo <= 0 {
+			return ErrInvalidSetValue
+		}
+		if err := validateSetMeasurement(st, kind); err != nil {
+			return err
+		}
+		if err := validateSetMetadata(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSetMeasurement は種目の計測方法に応じて必要な値だけが入力されているかを検証する。
+func validateSetMeasurement(st WorkoutSetData, kind models.MeasurementKind) error {
+	hasDuration := st.DurationSeconds != nil
+	hasDistance := st.DistanceMeters != nil
+	if hasDuration && (*st.DurationSeconds <= 0 || *st.DurationSeconds > maxDurationSeconds) {
+		return ErrInvalidSetValue
+	}
+	if hasDistance && (*st.DistanceMeters <= 0 || *st.DistanceMeters > maxDistanceMeters) {
+		return ErrInvalidSetValue
+	}
+
+	var ok bool
+	switch kind {
+	case models.MeasurementRepsWeight, models.MeasurementBodyweightLoad:
+		// 自重種目の ExerciseWeight は加重分
+		ok = st.Reps > 0 && st.ExerciseWeight >= 0 && !hasDuration && !hasDistance
+	case models.MeasurementRepsOnly:
+		ok = st.Reps > 0 && st.ExerciseWeight == 0 && !hasDuration && !hasDistance
+	case models.MeasurementDuration:
+		ok = hasDuration && st.Reps == 0 && st.ExerciseWeight >= 0 && !hasDistance
+	case models.MeasurementDistanceDuration:
+		ok = hasDistance && hasDuration && st.Reps == 0 && st.ExerciseWeight == 0
+	}
+	if !ok {
+		return ErrInvalidSetValue
+	}
+	return nil
+}
+
+func validateSetMetadata(st WorkoutSetData) error {
+	if st.SetType != "" && !st.SetType.Valid() {
+		return ErrInvalidSetValue
+	}
+	if st.RPE != nil && st.RIR != nil {
+		return ErrInvalidSetValue
+	}
+	if st.RPE != nil {
+		if *st.RPE < minRPE || *st.RPE > maxRPE || math.Mod(*st.RPE*2, 1) != 0 {
+			return ErrInvalidSetValue
+		}
+	}
+	if st.RIR != nil && (*st.RIR < 0 || *st.RIR > maxRIR) {
+		return ErrInvalidSetValue
+	}
+	if st.RestSeconds != nil && (*st.RestSeconds < 0 || *st.RestSeconds > maxRestSeconds) {
+		return ErrInvalidSetValue
+	}
+	if st.Tempo != "" && !tempoPattern.MatchString(strings.ToUpper(st.Tempo)) {
+		return ErrInvalidSetValue
+	}
+	if utf8.RuneCountInString(st.Note) > maxSetNoteLength {
+		return ErrInvalidSetValue
+	}
+	return nil
+}
+
+func toWorkoutSets(sets []WorkoutSetData) []models.WorkoutSet {
+	out := make([]models.WorkoutSet, 0, len(sets))
+	for _, setData := range sets {
+		setType := setData.SetType
+		if setType == "" {
+			setType = models.SetTypeWorking
+		}
+		out = append(out, models.WorkoutSet{
+			SetNo:           setData.SetNo,
+			Reps:            setData.Reps,
+			ExerciseWeight:  setData.ExerciseWeight,
+			SetType:         setType,
+			RPE:             setData.RPE,
+			RIR:             setData.RIR,
+			RestSeconds:     setData.RestSeconds,
+			Tempo:           strings.ToUpper(setData.Tempo),
+			Note:            setData.Note,
+			DurationSeconds: setData.DurationSeconds,
+			DistanceMeters:  setData.DistanceMeters,
+		})
+	}
+	return out
+}
+
+func (s *workoutService) findUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error) {
+	ex, err := s.repo.FindUsableExercise(userID, exerciseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExerciseNotFound
 		}
+		return nil, fmt.Errorf("find exercise failed: %w", err)
+	}
+	return ex, nil
+}
+
+func (s *workoutService) CreateWorkoutRecord(userID uint, bodyWeight float64, exerciseID uint, trainedOn time.Time, sets []WorkoutSetData, isPublic bool, comment string) (*models.WorkoutRecord, error) {
+	if len(sets) == 0 {
+		return nil, ErrNoSets
+	}
+	exercise, err := s.findUsableExercise(userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSets(sets, exercise.MeasurementKind); err != nil {
+		return nil, err
+	}
+	if exercise.IsArchived() {
+		return nil, ErrExerciseArchived
 	}
 
 	record := &models.WorkoutRecord{
@@ -62,14 +219,7 @@ func (s *workoutService) CreateWorkoutRecord(userID uint, bodyWeight float64, ex
 		Comment:    comment,
 	}
 
-	for _, setData := range sets {
-		set := models.WorkoutSet{
-			SetNo:          setData.SetNo,
-			Reps:           setData.Reps,
-			ExerciseWeight: setData.ExerciseWeight,
-		}
-		record.Sets = append(record.Sets, set)
-	}
+	record.Sets = toWorkoutSets(sets)
 
 	if err := s.repo.Create(record); err != nil {
 		if errors.Is(err, repository.ErrFKViolation) {
@@ -77,6 +227,7 @@ func (s *workoutService) CreateWorkoutRecord(userID uint, bodyWeight float64, ex
 		}
 		return nil, fmt.Errorf("create workout record failed: %w", err)
 	}
+	invalidateRankings(s.rankings, record.TrainedOn)
 
 	return record, nil
 }
@@ -105,11 +256,12 @@ func (s *workoutService) UpdateWorkoutRecord(userID uint, recordID uint, bodyWei
 	if len(sets) == 0 {
 		return nil, ErrNoSets
 	}
-
-	for _, st := range sets {
-		if st.SetNo <= 0 || st.Reps <= 0 || st.ExerciseWeight < 0 {
-			return nil, ErrInvalidSetValue
-		}
+	exercise, err := s.findUsableExercise(userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSets(sets, exercise.MeasurementKind); err != nil {
+		return nil, err
 	}
 
 	existingRecord, err := s.repo.FindByIDAndUserID(recordID, userID)
@@ -119,20 +271,17 @@ func (s *workoutService) UpdateWorkoutRecord(userID uint, recordID uint, bodyWei
 		}
 		return nil, fmt.Errorf("find workout record failed: %w", err)
 	}
+	// アーカイブ済みの種目は、既にその種目の記録を編集する場合のみ許可する
+	if exercise.IsArchived() && existingRecord.ExerciseID != exerciseID {
+		return nil, ErrExerciseArchived
+	}
 
+	previousDay := existingRecord.TrainedOn
 	existingRecord.BodyWeight = bodyWeight
 	existingRecord.ExerciseID = exerciseID
 	existingRecord.TrainedOn = trainedOn
 
-	existingRecord.Sets = make([]models.WorkoutSet, 0, len(sets))
-	for _, setData := range sets {
-		set := models.WorkoutSet{
-			SetNo:          setData.SetNo,
-			Reps:           setData.Reps,
-			ExerciseWeight: setData.ExerciseWeight,
-		}
-		existingRecord.Sets = append(existingRecord.Sets, set)
-	}
+	existingRecord.Sets = toWorkoutSets(sets)
 
 	if err := s.repo.Update(existingRecord); err != nil {
 		if errors.Is(err, repository.ErrFKViolation) {
@@ -140,12 +289,13 @@ func (s *workoutService) UpdateWorkoutRecord(userID uint, recordID uint, bodyWei
 		}
 		return nil, fmt.Errorf("update workout record failed: %w", err)
 	}
+	invalidateRankings(s.rankings, previousDay, existingRecord.TrainedOn)
 
 	return existingRecord, nil
 }
 
 func (s *workoutService) DeleteWorkoutRecord(userID uint, recordID uint) error {
-	_, err := s.repo.FindByIDAndUserID(recordID, userID)
+	record, err := s.repo.FindByIDAndUserID(recordID, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return ErrRecordNotFound
@@ -156,6 +306,7 @@ func (s *workoutService) DeleteWorkoutRecord(userID uint, recordID uint) error {
 	if err := s.repo.Delete(recordID, userID); err != nil {
 		return fmt.Errorf("delete workout record failed: %w", err)
 	}
+	invalidateRankings(s.rankings, record.TrainedOn)
 
 	return nil
 }
@@ -168,12 +319,22 @@ func (s *workoutService) GetWorkoutRecordsByExercise(userID uint, exerciseID uin
 	out := make([]FlatSet, 0, len(rows))
 	for _, r := range rows {
 		out = append(out, FlatSet{
-			RecordID:       r.RecordID,
-			TrainedOn:      r.TrainedOn,
-			SetNo:          r.SetNo,
-			Reps:           r.Reps,
-			ExerciseWeight: r.ExerciseWeight,
-			BodyWeight:     r.BodyWeight,
+			RecordID:        r.RecordID,
+			TrainedOn:       r.TrainedOn,
+			SetNo:           r.SetNo,
+			Reps:            r.Reps,
+			ExerciseWeight:  r.ExerciseWeight,
+			BodyWeight:      r.BodyWeight,
+			SetType:         r.SetType,
+			RPE:             r.RPE,
+			RIR:             r.RIR,
+			RestSeconds:     r.RestSeconds,
+			Tempo:           r.Tempo,
+			Note:            r.Note,
+			MeasurementKind: r.MeasurementKind,
+			DurationSeconds: r.DurationSeconds,
+			DistanceMeters:  r.DistanceMeters,
+			Volume:          r.Volume,
 		})
 	}
 	return out, nil
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,13 +13,15 @@ import (
 )
 
 type fakeWorkoutRepo struct {
-	createFn   func(rec *models.WorkoutRecord) error
-	findDayFn  func(userID uint, day time.Time) ([]models.WorkoutRecord, error)
-	findMonFn  func(userID uint, year, month int) ([]time.Time, error)
-	findOneFn  func(id uint, userID uint) (*models.WorkoutRecord, error)
-	updateFn   func(rec *models.WorkoutRecord) error
-	deleteFn   func(id uint, userID uint) error
-	findSetsFn func(userID uint, exerciseID uint) ([]repository.FlatWorkoutSet, error)
+	createFn    func(rec *models.WorkoutRecord) error
+	findDayFn   func(userID uint, day time.Time) ([]models.WorkoutRecord, error)
+	findMonFn   func(userID uint, year, month int) ([]time.Time, error)
+	findOneFn   func(id uint, userID uint) (*models.WorkoutRecord, error)
+	updateFn    func(rec *models.WorkoutRecord) error
+	deleteFn    func(id uint, userID uint) error
+	findSetsFn  func(userID uint, exerciseID uint) ([]repository.FlatWorkoutSet, error)
+	findRangeFn func(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error)
+	findExFn    func(userID uint, exerciseID uint) (*models.Exercise, error)
 }
 
 func (f *fakeWorkoutRepo) Create(rec *models.WorkoutRecord) error {
@@ -43,6 +46,30 @@ func (f *fakeWorkoutRepo) FindSetsByUserAndExercise(userID uint, exerciseID uint
 	return f.findSetsFn(userID, exerciseID)
 }
 
+func (f *fakeWorkoutRepo) FindSetsByUserAndExerciseInRange(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error) {
+	return f.findRangeFn(userID, exerciseID, from, to)
+}
+
+func (f *fakeWorkoutRepo) FindUsableExercise(userID uint, exerciseID uint) (*models.Exercise, error) {
+	if f.findExFn == nil {
+		return &models.Exercise{MeasurementKind: models.MeasurementRepsWeight}, nil
+	}
+	return f.findExFn(userID, exerciseID)
+}
+
+func archivedExercise(uint, uint) (*models.Exercise, error) {
+	archivedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	return &models.Exercise{MeasurementKind: models.MeasurementRepsWeight, ArchivedAt: &archivedAt}, nil
+}
+
+func exerciseOfKind(kind models.MeasurementKind) func(uint, uint) (*models.Exercise, error) {
+	return func(uint, uint) (*models.Exercise, error) {
+		return &models.Exercise{MeasurementKind: kind}, nil
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
 func TestNewWorkoutService(t *testing.T) {
 	svc := NewWorkoutService(&fakeWorkoutRepo{
 		createFn:   func(*models.WorkoutRecord) error { return nil },
@@ -52,7 +79,7 @@ func TestNewWorkoutService(t *testing.T) {
 		updateFn:   func(*models.WorkoutRecord) error { return nil },
 		deleteFn:   func(uint, uint) error { return nil },
 		findSetsFn: func(uint, uint) ([]repository.FlatWorkoutSet, error) { return nil, nil },
-	})
+	}, nil)
 	require.NotNil(t, svc)
 	_, ok := svc.(WorkoutService)
 	require.True(t, ok)
@@ -109,6 +136,175 @@ func TestWorkoutService_CreateWorkoutRecord(t *testing.T) {
 			},
 			wantErr: ErrInvalidSetValue,
 		},
+		{
+			name: "【正常系】セットのメタ情報（種別・RPE・レスト・テンポ・メモ）を保存できること",
+			repo: fakeWorkoutRepo{
+				createFn: func(rec *models.WorkoutRecord) error {
+					require.Equal(t, models.SetTypeWarmup, rec.Sets[0].SetType)
+					require.Equal(t, models.SetTypeWorking, rec.Sets[1].SetType)
+					require.Equal(t, 8.5, *rec.Sets[1].RPE)
+					require.Equal(t, 180, *rec.Sets[1].RestSeconds)
+					require.Equal(t, "3-1-X-0", rec.Sets[1].Tempo)
+					require.Equal(t, "フォーム良好", rec.Sets[1].Note)
+					return nil
+				},
+			},
+			userID:     1,
+			exerciseID: 2,
+			trainedOn:  day,
+			sets: []WorkoutSetData{
+				{SetNo: 1, Reps: 10, ExerciseWeight: 40, SetType: models.SetTypeWarmup},
+				{SetNo: 2, Reps: 5, ExerciseWeight: 80, RPE: ptr(8.5), RestSeconds: ptr(180), Tempo: "3-1-x-0", Note: "フォーム良好"},
+			},
+			wantSetLen: 2,
+		},
+		{
+			name:    "【異常系】不明なセット種別の場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, SetType: "superset"}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】RPE が範囲外の場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, RPE: ptr(10.5)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】RPE が0.5刻みでない場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, RPE: ptr(7.3)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】RPE と RIR を同時に指定した場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, RPE: ptr(8.0), RIR: ptr(2)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】RIR が負の場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, RIR: ptr(-1)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】レスト秒数が上限を超える場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, RestSeconds: ptr(3601)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】テンポの形式が不正な場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, Tempo: "slow"}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】メモが長すぎる場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, Note: strings.Repeat("あ", 501)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【正常系】時間種目は回数なしで秒数を記録できること",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementDuration),
+				createFn: func(*models.WorkoutRecord) error { return nil },
+			},
+			userID:     1,
+			exerciseID: 3,
+			trainedOn:  day,
+			sets:       []WorkoutSetData{{SetNo: 1, DurationSeconds: ptr(60)}},
+			wantSetLen: 1,
+		},
+		{
+			name: "【正常系】距離＋時間種目は距離と時間を記録できること",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementDistanceDuration),
+				createFn: func(*models.WorkoutRecord) error { return nil },
+			},
+			userID:     1,
+			exerciseID: 4,
+			trainedOn:  day,
+			sets:       []WorkoutSetData{{SetNo: 1, DistanceMeters: ptr(5000.0), DurationSeconds: ptr(1500)}},
+			wantSetLen: 1,
+		},
+		{
+			name: "【正常系】自重種目は加重なしでも記録できること",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementBodyweightLoad),
+				createFn: func(*models.WorkoutRecord) error { return nil },
+			},
+			userID:     1,
+			bodyWeight: 70,
+			exerciseID: 5,
+			trainedOn:  day,
+			sets:       []WorkoutSetData{{SetNo: 1, Reps: 8}, {SetNo: 2, Reps: 5, ExerciseWeight: 10}},
+			wantSetLen: 2,
+		},
+		{
+			name: "【異常系】時間種目で秒数が無い場合は ErrInvalidSetValue を返すこと",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementDuration),
+			},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】距離＋時間種目で時間が無い場合は ErrInvalidSetValue を返すこと",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementDistanceDuration),
+			},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, DistanceMeters: ptr(5000.0)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】回数のみの種目で重量を指定した場合は ErrInvalidSetValue を返すこと",
+			repo: fakeWorkoutRepo{
+				findExFn: exerciseOfKind(models.MeasurementRepsOnly),
+			},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 20, ExerciseWeight: 5}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name:    "【異常系】重量種目で時間を指定した場合は ErrInvalidSetValue を返すこと",
+			repo:    fakeWorkoutRepo{},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50, DurationSeconds: ptr(30)}},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】種目が存在しない・他人の非公開種目の場合は ErrExerciseNotFound を返すこと",
+			repo: fakeWorkoutRepo{
+				findExFn: func(uint, uint) (*models.Exercise, error) {
+					return nil, repository.ErrNotFound
+				},
+			},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50}},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目は ErrExerciseArchived を返すこと",
+			repo: fakeWorkoutRepo{
+				findExFn: archivedExercise,
+			},
+			userID:  1,
+			sets:    []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50}},
+			wantErr: ErrExerciseArchived,
+		},
 		{
 			name: "【異常系】repo が FK 違反を返した場合は ErrExerciseNotFound を返すこと",
 			repo: fakeWorkoutRepo{
@@ -138,7 +334,7 @@ func TestWorkoutService_CreateWorkoutRecord(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewWorkoutService(&tt.repo)
+			svc := NewWorkoutService(&tt.repo, nil)
 			got, err := svc.CreateWorkoutRecord(tt.userID, tt.bodyWeight, tt.exerciseID, tt.trainedOn, tt.sets, tt.isPublic, tt.comment)
 
 			if tt.wantErr != nil || tt.wantErrSub != "" {
@@ -198,7 +394,7 @@ func TestWorkoutService_GetDailyRecords(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewWorkoutService(&tt.repo)
+			svc := NewWorkoutService(&tt.repo, nil)
 			got, err := svc.GetDailyRecords(tt.userID, tt.day)
 
 			if tt.wantErrSub != "" {
@@ -260,7 +456,7 @@ func TestWorkoutService_GetMonthRecordDays(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewWorkoutService(&tt.repo)
+			svc := NewWorkoutService(&tt.repo, nil)
 			got, err := svc.GetMonthRecordDays(tt.userID, tt.year, tt.month)
 
 			if tt.wantErrSub != "" {
@@ -364,6 +560,40 @@ func TestWorkoutService_UpdateWorkoutRecord(t *testing.T) {
 			sets:       []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 40}},
 			wantErr:    ErrExerciseNotFound,
 		},
+		{
+			name: "【正常系】アーカイブ済みの種目でも同じ種目の記録は編集できること",
+			repo: fakeWorkoutRepo{
+				findExFn: archivedExercise,
+				findOneFn: func(id uint, userID uint) (*models.WorkoutRecord, error) {
+					c := *baseRecord
+					return &c, nil
+				},
+				updateFn: func(rec *models.WorkoutRecord) error { return nil },
+			},
+			userID:     1,
+			recordID:   100,
+			bodyWeight: 61,
+			exerciseID: 2,
+			trainedOn:  fixed,
+			sets:       []WorkoutSetData{{SetNo: 1, Reps: 8, ExerciseWeight: 50}},
+			wantSetLen: 1,
+		},
+		{
+			name: "【異常系】別の種目からアーカイブ済みの種目へは変更できないこと",
+			repo: fakeWorkoutRepo{
+				findExFn: archivedExercise,
+				findOneFn: func(id uint, userID uint) (*models.WorkoutRecord, error) {
+					c := *baseRecord
+					return &c, nil
+				},
+			},
+			userID:     1,
+			recordID:   100,
+			exerciseID: 3,
+			trainedOn:  fixed,
+			sets:       []WorkoutSetData{{SetNo: 1, Reps: 8, ExerciseWeight: 50}},
+			wantErr:    ErrExerciseArchived,
+		},
 		{
 			name: "【異常系】Update その他のエラーは wrap されて返すこと",
 			repo: fakeWorkoutRepo{
@@ -383,7 +613,7 @@ func TestWorkoutService_UpdateWorkoutRecord(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewWorkoutService(&tt.repo)
+			svc := NewWorkoutService(&tt.repo, nil)
 			got, err := svc.UpdateWorkoutRecord(tt.userID, tt.recordID, tt.bodyWeight, tt.exerciseID, tt.trainedOn, tt.sets)
 
 			if tt.wantErr != nil || tt.wantErrSub != "" {
@@ -462,7 +692,7 @@ func TestWorkoutService_DeleteWorkoutRecord(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewWorkoutService(&tt.repo)
+			svc := NewWorkoutService(&tt.repo, nil)
 			err := svc.DeleteWorkoutRecord(tt.userID, tt.recordID)
 
 			if tt.wantErr != nil || tt.wantErrSub != "" {
@@ -479,3 +709,182 @@ func TestWorkoutService_DeleteWorkoutRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutService_GetExerciseProgression(t *testing.T) {
+	// 2025-10-06(月), 10-08(水), 10-13(月)
+	mon := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	wed := mon.AddDate(0, 0, 2)
+	nextMon := mon.AddDate(0, 0, 7)
+	rows := []repository.FlatWorkoutSet{
+		{TrainedOn: mon, Reps: 10, ExerciseWeight: 60, MeasurementKind: models.MeasurementRepsWeight, Volume: 600},
+		{TrainedOn: mon, Reps: 5, ExerciseWeight: 80, MeasurementKind: models.MeasurementRepsWeight, Volume: 400},
+		{TrainedOn: wed, Reps: 1, ExerciseWeight: 90, MeasurementKind: models.MeasurementRepsWeight, Volume: 90},
+		{TrainedOn: wed, Reps: 20, ExerciseWeight: 40, MeasurementKind: models.MeasurementRepsWeight, Volume: 800},
+		{TrainedOn: nextMon, Reps: 3, ExerciseWeight: 85, MeasurementKind: models.MeasurementRepsWeight, Volume: 255},
+		{TrainedOn: nextMon, Reps: 1, ExerciseWeight: 100, SetType: models.SetTypeWarmup, MeasurementKind: models.MeasurementRepsWeight, Volume: 100},
+	}
+	fromRepo := func(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error) {
+		return rows, nil
+	}
+
+	tests := []struct {
+		name      string
+		query     ProgressionQuery
+		repoFn    func(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error)
+		want      *ExerciseProgression
+		wantErrIs error
+		wantSub   string
+	}{
+		{
+			name:   "【正常系】既定では Epley 式で日ごとに集計すること（20回のセットは推定1RMの対象外、ウォームアップは集計しない）",
+			repoFn: fromRepo,
+			want: &ExerciseProgression{
+				Formula: models.OneRepMaxEpley,
+				Bucket:  BucketDay,
+				Points: []ProgressionPoint{
+					{PeriodStart: mon, BestE1RM: 93.33, TopSet: &TopSet{Weight: 80, Reps: 5, TrainedOn: mon}, TotalVolume: 1000, TotalReps: 15, TrainingDays: 1},
+					{PeriodStart: wed, BestE1RM: 90, TopSet: &TopSet{Weight: 90, Reps: 1, TrainedOn: wed}, TotalVolume: 890, TotalReps: 21, TrainingDays: 1},
+					{PeriodStart: nextMon, BestE1RM: 93.5, TopSet: &TopSet{Weight: 85, Reps: 3, TrainedOn: nextMon}, TotalVolume: 255, TotalReps: 3, TrainingDays: 1},
+				},
+			},
+		},
+		{
+			name:   "【正常系】週単位（月曜始まり）で集計し、計算式を選択できること",
+			query:  ProgressionQuery{Formula: models.OneRepMaxBrzycki, Bucket: BucketWeek},
+			repoFn: fromRepo,
+			want: &ExerciseProgression{
+				Formula: models.OneRepMaxBrzycki,
+				Bucket:  BucketWeek,
+				Points: []ProgressionPoint{
+					// Brzycki: 80×36/32 = 90
+					{PeriodStart: mon, BestE1RM: 90, TopSet: &TopSet{Weight: 90, Reps: 1, TrainedOn: wed}, TotalVolume: 1890, TotalReps: 36, TrainingDays: 2},
+					// 85×36/34 ≒ 90.0
+					{PeriodStart: nextMon, BestE1RM: 90, TopSet: &TopSet{Weight: 85, Reps: 3, TrainedOn: nextMon}, TotalVolume: 255, TotalReps: 3, TrainingDays: 1},
+				},
+			},
+		},
+		{
+			name:  "【正常系】月単位で集計し、期間がリポジトリに渡されること",
+			query: ProgressionQuery{Formula: models.OneRepMaxLombardi, Bucket: BucketMonth, From: &mon, To: &nextMon},
+			repoFn: func(userID uint, exerciseID uint, from, to *time.Time) ([]repository.FlatWorkoutSet, error) {
+				require.Equal(t, uint(2), exerciseID)
+				require.Equal(t, mon, *from)
+				require.Equal(t, nextMon, *to)
+				return rows[:1], nil
+			},
+			want: &ExerciseProgression{
+				Formula: models.OneRepMaxLombardi,
+				Bucket:  BucketMonth,
+				Points: []ProgressionPoint{
+					// Lombardi: 60×10^0.1 ≒ 75.54
+					{PeriodStart: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), BestE1RM: 75.54, TopSet: &TopSet{Weight: 60, Reps: 10, TrainedOn: mon}, TotalVolume: 600, TotalReps: 10, TrainingDays: 1},
+				},
+			},
+		},
+		{
+			name:  "【正常系】自重種目は体重を含めて推定1RMを計算し、トップセットは加重で判定すること",
+			query: ProgressionQuery{},
+			repoFn: func(uint, uint, *time.Time, *time.Time) ([]repository.FlatWorkoutSet, error) {
+				return []repository.FlatWorkoutSet{
+					{TrainedOn: mon, Reps: 1, ExerciseWeight: 10, BodyWeight: 70, MeasurementKind: models.MeasurementBodyweightLoad, Volume: 80},
+				}, nil
+			},
+			want: &ExerciseProgression{
+				Formula: models.OneRepMaxEpley,
+				Bucket:  BucketDay,
+				Points: []ProgressionPoint{
+					{PeriodStart: mon, BestE1RM: 80, TopSet: &TopSet{Weight: 10, Reps: 1, TrainedOn: mon}, TotalVolume: 80, TotalReps: 1, TrainingDays: 1},
+				},
+			},
+		},
+		{
+			name:      "【異常系】未知の計算式は ErrInvalidProgressionQuery を返すこと",
+			query:     ProgressionQuery{Formula: "wathan"},
+			wantErrIs: ErrInvalidProgressionQuery,
+		},
+		{
+			name:      "【異常系】未知の集計単位は ErrInvalidProgressionQuery を返すこと",
+			query:     ProgressionQuery{Bucket: "year"},
+			wantErrIs: ErrInvalidProgressionQuery,
+		},
+		{
+			name:      "【異常系】from が to より後の場合は ErrInvalidProgressionQuery を返すこと",
+			query:     ProgressionQuery{From: &nextMon, To: &mon},
+			wantErrIs: ErrInvalidProgressionQuery,
+		},
+		{
+			name: "【異常系】リポジトリのエラーは wrap されて返ること",
+			repoFn: func(uint, uint, *time.Time, *time.Time) ([]repository.FlatWorkoutSet, error) {
+				return nil, errors.New("db down")
+			},
+			wantSub: "fetch exercise progression failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutService(&fakeWorkoutRepo{findRangeFn: tt.repoFn}, nil)
+			got, err := svc.GetExerciseProgression(1, 2, tt.query)
+
+			switch {
+			case tt.wantErrIs != nil:
+				require.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantSub)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+type fakeRankingInvalidator struct {
+	days []time.Time
+}
+
+func (f *fakeRankingInvalidator) InvalidateDay(day time.Time) { f.days = append(f.days, day) }
+
+func TestWorkoutService_InvalidatesRankings(t *testing.T) {
+	oldDay := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+	newDay := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	sets := []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 40}}
+	repo := &fakeWorkoutRepo{
+		createFn: func(*models.WorkoutRecord) error { return nil },
+		findOneFn: func(uint, uint) (*models.WorkoutRecord, error) {
+			return &models.WorkoutRecord{UserID: 1, ExerciseID: 2, TrainedOn: oldDay}, nil
+		},
+		updateFn: func(*models.WorkoutRecord) error { return nil },
+		deleteFn: func(uint, uint) error { return nil },
+	}
+
+	t.Run("【正常系】作成した記録の日を含むランキングを破棄すること", func(t *testing.T) {
+		inv := &fakeRankingInvalidator{}
+		_, err := NewWorkoutService(repo, inv).CreateWorkoutRecord(1, 60, 2, newDay, sets, false, "")
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{newDay}, inv.days)
+	})
+
+	t.Run("【正常系】日付を変えて更新した場合は変更前後の日を破棄すること", func(t *testing.T) {
+		inv := &fakeRankingInvalidator{}
+		_, err := NewWorkoutService(repo, inv).UpdateWorkoutRecord(1, 100, 60, 2, newDay, sets)
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{oldDay, newDay}, inv.days)
+	})
+
+	t.Run("【正常系】削除した記録の日を破棄すること", func(t *testing.T) {
+		inv := &fakeRankingInvalidator{}
+		require.NoError(t, NewWorkoutService(repo, inv).DeleteWorkoutRecord(1, 100))
+		require.Equal(t, []time.Time{oldDay}, inv.days)
+	})
+
+	t.Run("【異常系】保存に失敗した場合は破棄しないこと", func(t *testing.T) {
+		inv := &fakeRankingInvalidator{}
+		failing := *repo
+		failing.createFn = func(*models.WorkoutRecord) error { return errors.New("boom") }
+		_, err := NewWorkoutService(&failing, inv).CreateWorkoutRecord(1, 60, 2, newDay, sets, false, "")
+		require.Error(t, err)
+		require.Empty(t, inv.days)
+	})
+}
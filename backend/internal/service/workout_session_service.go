@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type WorkoutSessionService interface {
+	CreateSession(userID uint, data WorkoutSessionData) (*models.WorkoutSession, error)
+	GetSession(userID uint, sessionID uint) (*models.WorkoutSession, error)
+	GetDailySessions(userID uint, day time.Time) ([]models.WorkoutSession, error)
+	UpdateSession(userID uint, sessionID uint, data WorkoutSessionData) (*models.WorkoutSession, error)
+	DeleteSession(userID uint, sessionID uint) error
+}
+
+type WorkoutSessionData struct {
+	TrainedOn  time.Time
+	StartedAt  *time.Time
+	EndedAt    *time.Time
+	BodyWeight float64
+	IsPublic   bool
+	Comment    string
+	TemplateID *uint
+	Exercises  []WorkoutExerciseData
+}
+
+type WorkoutExerciseData struct {
+	ExerciseID uint
+	Sets       []WorkoutSetData
+}
+
+type workoutSessionService struct {
+	repo     repository.WorkoutSessionRepository
+	rankings RankingInvalidator
+}
+
+// NewWorkoutSessionService の rankings には記録の変更を伝えるランキングのキャッシュを渡す（nil なら伝えない）。
+func NewWorkoutSessionService(repo repository.WorkoutSessionRepository, rankings RankingInvalidator) WorkoutSessionService {
+	return &workoutSessionService{repo: repo, rankings: rankings}
+}
+
+// validateSessionData は入力内容を検証し、使用する種目を種目IDごとに返す。
+func (s *workoutSessionService) validateSessionData(userID uint, data WorkoutSessionData) (map[uint]models.Exercise, error) {
+	if len(data.Exercises) == 0 {
+		return nil, ErrNoExercises
+	}
+	if data.StartedAt != nil && data.EndedAt != nil && data.EndedAt.Before(*data.StartedAt) {
+		return nil, ErrInvalidSessionTime
+	}
+	return validateExerciseBlocks(s.repo.FindUsableExercises, userID, data.Exercises)
+}
+
+// validateExerciseBlocks は種目ブロックごとのセットを種目の計測方法に沿って検証し、使用する種目を種目IDごとに返す。
+// セッションとテンプレートで同じ規則を使う。
+func validateExerciseBlocks(
+	findExercises func(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error),
+	userID uint,
+	blocks []WorkoutExerciseData,
+) (map[uint]models.Exercise, error) {
+	if len(blocks) == 0 {
+		return nil, ErrNoExercises
+	}
+
+	exerciseIDs := make([]uint, 0, len(blocks))
+	for _, ex := range blocks {
+		if ex.ExerciseID == 0 {
+			return nil, ErrExerciseNotFound
+		}
+		if len(ex.Sets) == 0 {
+			return nil, ErrNoSets
+		}
+		exerciseIDs = append(exerciseIDs, ex.ExerciseID)
+	}
+
+	exercises, err := findExercises(userID, exerciseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find exercises failed: %w", err)
+	}
+	for _, ex := range blocks {
+		exercise, ok := exercises[ex.ExerciseID]
+		if !ok {
+			return nil, ErrExerciseNotFound
+		}
+		if err := validateSets(ex.Sets, exercise.MeasurementKind); err != nil {
+			return nil, err
+		}
+	}
+	return exercises, nil
+}
+
+// rejectArchivedExercises はアーカイブ済みの種目が含まれていればエラーを返す（currentIDs に既に含まれる種目は許可する）。
+func rejectArchivedExercises(exercises map[uint]models.Exercise, currentIDs []uint) error {
+	inUse := make(map[uint]bool, len(currentIDs))
+	for _, id := range currentIDs {
+		inUse[id] = true
+	}
+	for id, ex := range exercises {
+		if ex.IsArchived() && !inUse[id] {
+			return ErrExerciseArchived
+		}
+	}
+	return nil
+}
+
+func recordExerciseIDs(records []models.WorkoutRecord) []uint {
+	ids := make([]uint, 0, len(records))
+	for _, rec := range records {
+		ids = append(ids, rec.ExerciseID)
+	}
+	return ids
+}
+
+func toSessionRecords(exercises []WorkoutExerciseData) []models.WorkoutRecord {
+	records := make([]models.WorkoutRecord, 0, len(exercises))
+	for _, ex := range exercises {
+		records = append(records, models.WorkoutRecord{
+			ExerciseID: ex.ExerciseID,
+			Sets:       toWorkoutSets(ex.Sets),
+		})
+	}
+	return records
+}
+
+func (s *workoutSessionService) CreateSession(userID uint, data WorkoutSessionData) (*models.WorkoutSession, error) {
+	exercises, err := s.validateSessionData(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectArchivedExercises(exercises, nil); err != nil {
+		return nil, err
+	}
+
+	session := &models.WorkoutSession{
+		UserID:     userID,
+		TrainedOn:  data.TrainedOn,
+		StartedAt:  data.StartedAt,
+		EndedAt:    data.EndedAt,
+		BodyWeight: data.BodyWeight,
+		IsPublic:   data.IsPublic,
+		Comment:    data.Comment,
+		TemplateID: data.TemplateID,
+		Records:    toSessionRecords(data.Exercises),
+	}
+
+	if err := s.repo.Create(session); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		var ce *repository.ConstraintError
+		if errors.Is(err, repository.ErrFKViolation) || errors.As(err, &ce) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("create workout session failed: %w", err)
+	}
+	invalidateRankings(s.rankings, session.TrainedOn)
+
+	return session, nil
+}
+
+func (s *workoutSessionService) GetSession(userID uint, sessionID uint) (*models.WorkoutSession, error) {
+	session, err := s.repo.FindByIDAndUserID(sessionID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("find workout session failed: %w", err)
+	}
+	return session, nil
+}
+
+func (s *workoutSessionService) GetDailySessions(userID uint, day time.Time) ([]models.WorkoutSession, error) {
+	sessions, err := s.repo.FindByUserAndDay(userID, day)
+	if err != nil {
+		return nil, fmt.Errorf("fetch daily sessions failed: %w", err)
+	}
+	return sessions, nil
+}
+
+func (s *workoutSessionService) UpdateSession(userID uint, sessionID uint, data WorkoutSessionData) (*models.WorkoutSession, error) {
+	exercises, err := s.validateSessionData(userID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.GetSession(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectArchivedExercises(exercises, recordExerciseIDs(session.Records)); err != nil {
+		return nil, err
+	}
+
+	previousDay := session.TrainedOn
+	session.TrainedOn = data.TrainedOn
+	session.StartedAt = data.StartedAt
+	session.EndedAt = data.EndedAt
+	session.BodyWeight = data.BodyWeight
+	session.IsPublic = data.IsPublic
+	session.Comment = data.Comment
+	session.Records = toSessionRecords(data.Exercises)
+
+	if err := s.repo.Update(session); err != nil {
+		var ce *repository.ConstraintError
+		if errors.Is(err, repository.ErrFKViolation) || errors.As(err, &ce) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("update workout session failed: %w", err)
+	}
+	invalidateRankings(s.rankings, previousDay, session.TrainedOn)
+
+	return session, nil
+}
+
+func (s *workoutSessionService) DeleteSession(userID uint, sessionID uint) error {
+	session, err := s.GetSession(userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(sessionID, userID); err != nil {
+		return fmt.Errorf("delete workout session failed: %w", err)
+	}
+	invalidateRankings(s.rankings, session.TrainedOn)
+	return nil
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeWorkoutSessionRepo struct {
+	createFn  func(session *models.WorkoutSession) error
+	findOneFn func(id uint, userID uint) (*models.WorkoutSession, error)
+	findDayFn func(userID uint, day time.Time) ([]models.WorkoutSession, error)
+	updateFn  func(session *models.WorkoutSession) error
+	deleteFn  func(id uint, userID uint) error
+	findExFn  func(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+}
+
+func (f *fakeWorkoutSessionRepo) Create(session *models.WorkoutSession) error {
+	return f.createFn(session)
+}
+func (f *fakeWorkoutSessionRepo) FindByIDAndUserID(id uint, userID uint) (*models.WorkoutSession, error) {
+	return f.findOneFn(id, userID)
+}
+func (f *fakeWorkoutSessionRepo) FindByUserAndDay(userID uint, day time.Time) ([]models.WorkoutSession, error) {
+	return f.findDayFn(userID, day)
+}
+func (f *fakeWorkoutSessionRepo) Update(session *models.WorkoutSession) error {
+	return f.updateFn(session)
+}
+func (f *fakeWorkoutSessionRepo) Delete(id uint, userID uint) error {
+	return f.deleteFn(id, userID)
+}
+
+func (f *fakeWorkoutSessionRepo) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	if f.findExFn != nil {
+		return f.findExFn(userID, exerciseIDs)
+	}
+	exercises := make(map[uint]models.Exercise, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		exercises[id] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+	}
+	return exercises, nil
+}
+
+func validSessionData() WorkoutSessionData {
+	return WorkoutSessionData{
+		TrainedOn:  time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		BodyWeight: 70,
+		Exercises: []WorkoutExerciseData{
+			{ExerciseID: 1, Sets: []WorkoutSetData{{SetNo: 1, Reps: 10, ExerciseWeight: 50}}},
+			{ExerciseID: 2, Sets: []WorkoutSetData{{SetNo: 1, Reps: 5, ExerciseWeight: 100}}},
+		},
+	}
+}
+
+func TestWorkoutSessionService_CreateSession(t *testing.T) {
+	start := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		repo       fakeWorkoutSessionRepo
+		data       func() WorkoutSessionData
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】種目ブロックを含むセッションを作成できること",
+			repo: fakeWorkoutSessionRepo{
+				createFn: func(s *models.WorkoutSession) error {
+					s.ID = 5
+					return nil
+				},
+			},
+			data: validSessionData,
+		},
+		{
+			name: "【正常系】作成元のテンプレートIDを引き継ぐこと",
+			repo: fakeWorkoutSessionRepo{
+				createFn: func(s *models.WorkoutSession) error {
+					require.NotNil(t, s.TemplateID)
+					require.Equal(t, uint(3), *s.TemplateID)
+					s.ID = 5
+					return nil
+				},
+			},
+			data: func() WorkoutSessionData {
+				d := validSessionData()
+				d.TemplateID = ptr(uint(3))
+				return d
+			},
+		},
+		{
+			name: "【異常系】作成元のテンプレートが見つからない場合は ErrTemplateNotFound を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				createFn: func(*models.WorkoutSession) error { return repository.ErrNotFound },
+			},
+			data: func() WorkoutSessionData {
+				d := validSessionData()
+				d.TemplateID = ptr(uint(3))
+				return d
+			},
+			wantErr: ErrTemplateNotFound,
+		},
+		{
+			name: "【異常系】種目が空の場合は ErrNoExercises を返すこと",
+			repo: fakeWorkoutSessionRepo{},
+			data: func() WorkoutSessionData {
+				d := validSessionData()
+				d.Exercises = nil
+				return d
+			},
+			wantErr: ErrNoExercises,
+		},
+		{
+			name: "【異常系】終了時刻が開始時刻より前の場合は ErrInvalidSessionTime を返すこと",
+			repo: fakeWorkoutSessionRepo{},
+			data: func() WorkoutSessionData {
+				d := validSessionData()
+				d.StartedAt, d.EndedAt = &start, &end
+				return d
+			},
+			wantErr: ErrInvalidSessionTime,
+		},
+		{
+			name: "【異常系】セット値が不正な場合は ErrInvalidSetValue を返すこと",
+			repo: fakeWorkoutSessionRepo{},
+			data: func() WorkoutSessionData {
+				d := validSessionData()
+				d.Exercises[1].Sets[0].Reps = 0
+				return d
+			},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】種目の計測方法に合わないセットは ErrInvalidSetValue を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findExFn: func(userID uint, ids []uint) (map[uint]models.Exercise, error) {
+					return map[uint]models.Exercise{
+						1: {MeasurementKind: models.MeasurementRepsWeight},
+						2: {MeasurementKind: models.MeasurementDuration},
+					}, nil
+				},
+			},
+			data:    validSessionData,
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】存在しない・他人の非公開種目が含まれる場合は ErrExerciseNotFound を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findExFn: func(userID uint, ids []uint) (map[uint]models.Exercise, error) {
+					return map[uint]models.Exercise{1: {MeasurementKind: models.MeasurementRepsWeight}}, nil
+				},
+			},
+			data:    validSessionData,
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目が含まれる場合は ErrExerciseArchived を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findExFn: func(userID uint, ids []uint) (map[uint]models.Exercise, error) {
+					archivedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+					return map[uint]models.Exercise{
+						1: {MeasurementKind: models.MeasurementRepsWeight},
+						2: {MeasurementKind: models.MeasurementRepsWeight, ArchivedAt: &archivedAt},
+					}, nil
+				},
+			},
+			data:    validSessionData,
+			wantErr: ErrExerciseArchived,
+		},
+		{
+			name: "【異常系】FK 違反は ErrExerciseNotFound に変換されること",
+			repo: fakeWorkoutSessionRepo{
+				createFn: func(*models.WorkoutSession) error {
+					return &repository.ConstraintError{Constraint: "foreign_key"}
+				},
+			},
+			data:    validSessionData,
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "【異常系】その他の repo エラーは wrap されて返すこと",
+			repo: fakeWorkoutSessionRepo{
+				createFn: func(*models.WorkoutSession) error { return errors.New("db down") },
+			},
+			data:       validSessionData,
+			wantErrSub: "create workout session failed: db down",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutSessionService(&tt.repo, nil)
+			got, err := svc.CreateSession(1, tt.data())
+
+			if tt.wantErr != nil || tt.wantErrSub != "" {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+				}
+				if tt.wantErrSub != "" {
+					require.Contains(t, err.Error(), tt.wantErrSub)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, uint(5), got.ID)
+			require.Equal(t, uint(1), got.UserID)
+			require.Len(t, got.Records, 2)
+			require.Equal(t, uint(2), got.Records[1].ExerciseID)
+		})
+	}
+}
+
+func TestWorkoutSessionService_GetSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       fakeWorkoutSessionRepo
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】セッションを取得できること",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(id, userID uint) (*models.WorkoutSession, error) {
+					return &models.WorkoutSession{Model: gorm.Model{ID: id}, UserID: userID}, nil
+				},
+			},
+		},
+		{
+			name: "【異常系】存在しない場合は ErrSessionNotFound を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutSession, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name: "【異常系】repo エラーは wrap されて返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutSession, error) { return nil, errors.New("boom") },
+			},
+			wantErrSub: "find workout session failed: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutSessionService(&tt.repo, nil)
+			got, err := svc.GetSession(1, 3)
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, uint(3), got.ID)
+			}
+		})
+	}
+}
+
+func TestWorkoutSessionService_UpdateSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    fakeWorkoutSessionRepo
+		wantErr error
+	}{
+		{
+			name: "【正常系】セッションを更新できること",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(id, userID uint) (*models.WorkoutSession, error) {
+					return &models.WorkoutSession{Model: gorm.Model{ID: id}, UserID: userID}, nil
+				},
+				updateFn: func(s *models.WorkoutSession) error {
+					require.Len(t, s.Records, 2)
+					require.InDelta(t, 70.0, s.BodyWeight, 1e-6)
+					return nil
+				},
+			},
+		},
+		{
+			name: "【異常系】存在しないセッションは ErrSessionNotFound を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutSession, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name: "【異常系】FK 違反は ErrExerciseNotFound に変換されること",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(id, userID uint) (*models.WorkoutSession, error) {
+					return &models.WorkoutSession{Model: gorm.Model{ID: id}, UserID: userID}, nil
+				},
+				updateFn: func(*models.WorkoutSession) error { return repository.ErrFKViolation },
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutSessionService(&tt.repo, nil)
+			got, err := svc.UpdateSession(1, 3, validSessionData())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint(3), got.ID)
+		})
+	}
+}
+
+func TestWorkoutSessionService_DeleteSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       fakeWorkoutSessionRepo
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】セッションを削除できること",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(id, userID uint) (*models.WorkoutSession, error) { return &models.WorkoutSession{}, nil },
+				deleteFn:  func(uint, uint) error { return nil },
+			},
+		},
+		{
+			name: "【異常系】存在しないセッションは ErrSessionNotFound を返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutSession, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrSessionNotFound,
+		},
+		{
+			name: "【異常系】削除失敗は wrap されて返すこと",
+			repo: fakeWorkoutSessionRepo{
+				findOneFn: func(id, userID uint) (*models.WorkoutSession, error) { return &models.WorkoutSession{}, nil },
+				deleteFn:  func(uint, uint) error { return errors.New("boom") },
+			},
+			wantErrSub: "delete workout session failed: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutSessionService(&tt.repo, nil)
+			err := svc.DeleteSession(1, 3)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+)
+
+type WorkoutTemplateService interface {
+	CreateTemplate(userID uint, data WorkoutTemplateData) (*models.WorkoutTemplate, error)
+	ListTemplates(userID uint) ([]models.WorkoutTemplate, error)
+	GetTemplate(userID uint, templateID uint) (*models.WorkoutTemplate, error)
+	UpdateTemplate(userID uint, templateID uint, data WorkoutTemplateData) (*models.WorkoutTemplate, error)
+	DeleteTemplate(userID uint, templateID uint) error
+	StartTemplate(userID uint, templateID uint, trainedOn time.Time) (*models.WorkoutSession, error)
+}
+
+// WorkoutTemplateData の Exercises のセットは目標値として扱う。
+type WorkoutTemplateData struct {
+	Name      string
+	Note      string
+	Exercises []WorkoutExerciseData
+}
+
+const maxTemplateNameLength = 100
+
+type workoutTemplateService struct {
+	repo repository.WorkoutTemplateRepository
+}
+
+func NewWorkoutTemplateService(repo repository.WorkoutTemplateRepository) WorkoutTemplateService {
+	return &workoutTemplateService{repo: repo}
+}
+
+func (s *workoutTemplateService) validateTemplateData(userID uint, data WorkoutTemplateData) (map[uint]models.Exercise, error) {
+	name := strings.TrimSpace(data.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxTemplateNameLength {
+		return nil, ErrInvalidTemplateName
+	}
+	return validateExerciseBlocks(s.repo.FindUsableExercises, userID, data.Exercises)
+}
+
+func toTemplateExercises(exercises []WorkoutExerciseData) []models.WorkoutTemplateExercise {
+	out := make([]models.WorkoutTemplateExercise, 0, len(exercises))
+	for _, ex := range exercises {
+		sets := make([]models.WorkoutTemplateSet, 0, len(ex.Sets))
+		for _, st := range toWorkoutSets(ex.Sets) {
+			sets = append(sets, models.WorkoutTemplateSet{
+				SetNo:           st.SetNo,
+				SetType:         st.SetType,
+				Reps:            st.Reps,
+				ExerciseWeight:  st.ExerciseWeight,
+				RestSeconds:     st.RestSeconds,
+				Tempo:           st.Tempo,
+				Note:            st.Note,
+				DurationSeconds: st.DurationSeconds,
+				DistanceMeters:  st.DistanceMeters,
+			})
+		}
+		out = append(out, models.WorkoutTemplateExercise{
+			ExerciseID: ex.ExerciseID,
+			Sets:       sets,
+		})
+	}
+	return out
+}
+
+func templateExerciseIDs(template *models.WorkoutTemplate) []uint {
+	ids := make([]uint, 0, len(template.Exercises))
+	for _, ex := range template.Exercises {
+		ids = append(ids, ex.ExerciseID)
+	}
+	return ids
+}
+
+func (s *workoutTemplateService) CreateTemplate(userID uint, data WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+	exercises, err := s.validateTemplateData(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectArchivedExercises(exercises, nil); err != nil {
+		return nil, err
+	}
+
+	template := &models.WorkoutTemplate{
+		UserID:    userID,
+		Name:      strings.TrimSpace(data.Name),
+		Note:      data.Note,
+		Exercises: toTemplateExercises(data.Exercises),
+	}
+	if err := s.repo.Create(template); err != nil {
+		var ce *repository.ConstraintError
+		if errors.As(err, &ce) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("create workout template failed: %w", err)
+	}
+	return template, nil
+}
+
+func (s *workoutTemplateService) ListTemplates(userID uint) ([]models.WorkoutTemplate, error) {
+	templates, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch workout templates failed: %w", err)
+	}
+	return templates, nil
+}
+
+func (s *workoutTemplateService) GetTemplate(userID uint, templateID uint) (*models.WorkoutTemplate, error) {
+	template, err := s.repo.FindByIDAndUserID(templateID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("find workout template failed: %w", err)
+	}
+	return template, nil
+}
+
+func (s *workoutTemplateService) UpdateTemplate(userID uint, templateID uint, data WorkoutTemplateData) (*models.WorkoutTemplate, error) {
+	exercises, err := s.validateTemplateData(userID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.GetTemplate(userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectArchivedExercises(exercises, templateExerciseIDs(template)); err != nil {
+		return nil, err
+	}
+
+	template.Name = strings.TrimSpace(data.Name)
+	template.Note = data.Note
+	template.Exercises = toTemplateExercises(data.Exercises)
+
+	if err := s.repo.Update(template); err != nil {
+		var ce *repository.ConstraintError
+		if errors.As(err, &ce) {
+			return nil, ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("update workout template failed: %w", err)
+	}
+	return template, nil
+}
+
+func (s *workoutTemplateService) DeleteTemplate(userID uint, templateID uint) error {
+	if err := s.repo.Delete(templateID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTemplateNotFound
+		}
+		return fmt.Errorf("delete workout template failed: %w", err)
+	}
+	return nil
+}
+
+// StartTemplate はテンプレートから trainedOn のトレーニングの入力内容を組み立てる。保存はしない。
+// 各セットは目標値で埋め、重量は前回このテンプレートで使った重量を優先する。
+func (s *workoutTemplateService) StartTemplate(userID uint, templateID uint, trainedOn time.Time) (*models.WorkoutSession, error) {
+	template, err := s.GetTemplate(userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := s.repo.FindUsableExercises(userID, templateExerciseIDs(template))
+	if err != nil {
+		return nil, fmt.Errorf("find exercises failed: %w", err)
+	}
+	for _, ex := range template.Exercises {
+		if _, ok := exercises[ex.ExerciseID]; !ok {
+			return nil, ErrExerciseNotFound
+		}
+	}
+	if err := rejectArchivedExercises(exercises, nil); err != nil {
+		return nil, err
+	}
+
+	session := &models.WorkoutSession{
+		UserID:     userID,
+		TrainedOn:  trainedOn,
+		TemplateID: &template.ID,
+		Records:    make([]models.WorkoutRecord, 0, len(template.Exercises)),
+	}
+	for _, ex := range template.Exercises {
+		sets := make([]models.WorkoutSet, 0, len(ex.Sets))
+		for _, st := range ex.Sets {
+			sets = append(sets, models.WorkoutSet{
+				SetNo:           st.SetNo,
+				Reps:            st.Reps,
+				ExerciseWeight:  st.PrefillWeight(),
+				SetType:         st.SetType,
+				RestSeconds:     st.RestSeconds,
+				Tempo:           st.Tempo,
+				Note:            st.Note,
+				DurationSeconds: st.DurationSeconds,
+				DistanceMeters:  st.DistanceMeters,
+			})
+		}
+		session.Records = append(session.Records, models.WorkoutRecord{
+			ExerciseID: ex.ExerciseID,
+			Exercise:   exercises[ex.ExerciseID],
+			Sets:       sets,
+		})
+	}
+	return session, nil
+}
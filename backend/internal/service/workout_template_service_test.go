@@ -0,0 +1,356 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeWorkoutTemplateRepo struct {
+	createFn   func(template *models.WorkoutTemplate) error
+	findUserFn func(userID uint) ([]models.WorkoutTemplate, error)
+	findOneFn  func(id uint, userID uint) (*models.WorkoutTemplate, error)
+	updateFn   func(template *models.WorkoutTemplate) error
+	deleteFn   func(id uint, userID uint) error
+	findExFn   func(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error)
+}
+
+func (f *fakeWorkoutTemplateRepo) Create(template *models.WorkoutTemplate) error {
+	return f.createFn(template)
+}
+func (f *fakeWorkoutTemplateRepo) FindByUser(userID uint) ([]models.WorkoutTemplate, error) {
+	return f.findUserFn(userID)
+}
+func (f *fakeWorkoutTemplateRepo) FindByIDAndUserID(id uint, userID uint) (*models.WorkoutTemplate, error) {
+	return f.findOneFn(id, userID)
+}
+func (f *fakeWorkoutTemplateRepo) Update(template *models.WorkoutTemplate) error {
+	return f.updateFn(template)
+}
+func (f *fakeWorkoutTemplateRepo) Delete(id uint, userID uint) error {
+	return f.deleteFn(id, userID)
+}
+
+func (f *fakeWorkoutTemplateRepo) FindUsableExercises(userID uint, exerciseIDs []uint) (map[uint]models.Exercise, error) {
+	if f.findExFn != nil {
+		return f.findExFn(userID, exerciseIDs)
+	}
+	exercises := make(map[uint]models.Exercise, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		exercises[id] = models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+	}
+	return exercises, nil
+}
+
+func validTemplateData() WorkoutTemplateData {
+	return WorkoutTemplateData{
+		Name: " Push day ",
+		Exercises: []WorkoutExerciseData{
+			{ExerciseID: 1, Sets: []WorkoutSetData{{SetNo: 1, Reps: 8, ExerciseWeight: 60}, {SetNo: 2, Reps: 8, ExerciseWeight: 60}}},
+			{ExerciseID: 2, Sets: []WorkoutSetData{{SetNo: 1, Reps: 12, ExerciseWeight: 20}}},
+		},
+	}
+}
+
+func archivedExercises(archivedID uint) func(uint, []uint) (map[uint]models.Exercise, error) {
+	return func(_ uint, ids []uint) (map[uint]models.Exercise, error) {
+		now := time.Now()
+		out := make(map[uint]models.Exercise, len(ids))
+		for _, id := range ids {
+			ex := models.Exercise{MeasurementKind: models.MeasurementRepsWeight}
+			if id == archivedID {
+				ex.ArchivedAt = &now
+			}
+			out[id] = ex
+		}
+		return out, nil
+	}
+}
+
+func TestWorkoutTemplateService_CreateTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       fakeWorkoutTemplateRepo
+		data       func() WorkoutTemplateData
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】種目と目標セットを持つテンプレートを作成できること",
+			repo: fakeWorkoutTemplateRepo{
+				createFn: func(tpl *models.WorkoutTemplate) error {
+					require.Equal(t, "Push day", tpl.Name)
+					require.Len(t, tpl.Exercises, 2)
+					require.Equal(t, models.SetTypeWorking, tpl.Exercises[0].Sets[0].SetType)
+					tpl.ID = 3
+					return nil
+				},
+			},
+			data: validTemplateData,
+		},
+		{
+			name: "【異常系】名前が空の場合は ErrInvalidTemplateName を返すこと",
+			data: func() WorkoutTemplateData {
+				d := validTemplateData()
+				d.Name = "  "
+				return d
+			},
+			wantErr: ErrInvalidTemplateName,
+		},
+		{
+			name: "【異常系】名前が長すぎる場合は ErrInvalidTemplateName を返すこと",
+			data: func() WorkoutTemplateData {
+				d := validTemplateData()
+				d.Name = strings.Repeat("あ", maxTemplateNameLength+1)
+				return d
+			},
+			wantErr: ErrInvalidTemplateName,
+		},
+		{
+			name: "【異常系】種目がない場合は ErrNoExercises を返すこと",
+			data: func() WorkoutTemplateData {
+				d := validTemplateData()
+				d.Exercises = nil
+				return d
+			},
+			wantErr: ErrNoExercises,
+		},
+		{
+			name: "【異常系】計測方法に合わない目標セットは ErrInvalidSetValue を返すこと",
+			data: func() WorkoutTemplateData {
+				d := validTemplateData()
+				d.Exercises[0].Sets[0].Reps = 0
+				return d
+			},
+			wantErr: ErrInvalidSetValue,
+		},
+		{
+			name: "【異常系】利用できない種目は ErrExerciseNotFound を返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findExFn: func(uint, []uint) (map[uint]models.Exercise, error) {
+					return map[uint]models.Exercise{1: {MeasurementKind: models.MeasurementRepsWeight}}, nil
+				},
+			},
+			data:    validTemplateData,
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name:    "【異常系】アーカイブ済みの種目は ErrExerciseArchived を返すこと",
+			repo:    fakeWorkoutTemplateRepo{findExFn: archivedExercises(2)},
+			data:    validTemplateData,
+			wantErr: ErrExerciseArchived,
+		},
+		{
+			name: "【異常系】保存に失敗した場合は wrap されたエラーを返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				createFn: func(*models.WorkoutTemplate) error { return errors.New("db down") },
+			},
+			data:       validTemplateData,
+			wantErrSub: "create workout template failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutTemplateService(&tt.repo)
+			got, err := svc.CreateTemplate(1, tt.data())
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, uint(3), got.ID)
+			}
+		})
+	}
+}
+
+func TestWorkoutTemplateService_UpdateTemplate(t *testing.T) {
+	existing := func(uint, uint) (*models.WorkoutTemplate, error) {
+		return &models.WorkoutTemplate{
+			Model:     gorm.Model{ID: 3},
+			UserID:    1,
+			Exercises: []models.WorkoutTemplateExercise{{ExerciseID: 2}},
+		}, nil
+	}
+
+	tests := []struct {
+		name    string
+		repo    fakeWorkoutTemplateRepo
+		wantErr error
+	}{
+		{
+			name: "【正常系】既にテンプレートに含まれるアーカイブ済み種目は残せること",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: existing,
+				findExFn:  archivedExercises(2),
+				updateFn: func(tpl *models.WorkoutTemplate) error {
+					require.Equal(t, "Push day", tpl.Name)
+					require.Len(t, tpl.Exercises, 2)
+					return nil
+				},
+			},
+		},
+		{
+			name: "【異常系】アーカイブ済みの種目を新たに追加すると ErrExerciseArchived を返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: existing,
+				findExFn:  archivedExercises(1),
+			},
+			wantErr: ErrExerciseArchived,
+		},
+		{
+			name: "【異常系】存在しない場合は ErrTemplateNotFound を返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutTemplate, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrTemplateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutTemplateService(&tt.repo)
+			_, err := svc.UpdateTemplate(1, 3, validTemplateData())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWorkoutTemplateService_DeleteTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteErr  error
+		wantErr    error
+		wantErrSub string
+	}{
+		{name: "【正常系】テンプレートを削除できること"},
+		{name: "【異常系】存在しない場合は ErrTemplateNotFound を返すこと", deleteErr: repository.ErrNotFound, wantErr: ErrTemplateNotFound},
+		{name: "【異常系】削除に失敗した場合は wrap されたエラーを返すこと", deleteErr: errors.New("db down"), wantErrSub: "delete workout template failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWorkoutTemplateRepo{
+				deleteFn: func(uint, uint) error { return tt.deleteErr },
+			}
+			err := NewWorkoutTemplateService(repo).DeleteTemplate(1, 3)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkoutTemplateService_StartTemplate(t *testing.T) {
+	today := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+	last := 62.5
+	rest := 90
+	template := func(uint, uint) (*models.WorkoutTemplate, error) {
+		return &models.WorkoutTemplate{
+			Model:  gorm.Model{ID: 3},
+			UserID: 1,
+			Exercises: []models.WorkoutTemplateExercise{
+				{ExerciseID: 1, Sets: []models.WorkoutTemplateSet{
+					{SetNo: 1, SetType: models.SetTypeWorking, Reps: 8, ExerciseWeight: 60, LastWeight: &last, RestSeconds: &rest},
+					{SetNo: 2, SetType: models.SetTypeWorking, Reps: 8, ExerciseWeight: 60},
+				}},
+				{ExerciseID: 2, Sets: []models.WorkoutTemplateSet{{SetNo: 1, SetType: models.SetTypeWarmup, Reps: 12, ExerciseWeight: 20}}},
+			},
+		}, nil
+	}
+
+	tests := []struct {
+		name       string
+		repo       fakeWorkoutTemplateRepo
+		wantErr    error
+		wantErrSub string
+	}{
+		{
+			name: "【正常系】前回の重量を優先して今日のトレーニングを保存せずに組み立てること",
+			repo: fakeWorkoutTemplateRepo{findOneFn: template},
+		},
+		{
+			name: "【異常系】存在しない場合は ErrTemplateNotFound を返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: func(uint, uint) (*models.WorkoutTemplate, error) { return nil, repository.ErrNotFound },
+			},
+			wantErr: ErrTemplateNotFound,
+		},
+		{
+			name: "【異常系】利用できなくなった種目を含む場合は ErrExerciseNotFound を返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: template,
+				findExFn: func(uint, []uint) (map[uint]models.Exercise, error) {
+					return map[uint]models.Exercise{1: {MeasurementKind: models.MeasurementRepsWeight}}, nil
+				},
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name:    "【異常系】アーカイブ済みの種目を含む場合は ErrExerciseArchived を返すこと",
+			repo:    fakeWorkoutTemplateRepo{findOneFn: template, findExFn: archivedExercises(2)},
+			wantErr: ErrExerciseArchived,
+		},
+		{
+			name: "【異常系】種目の取得に失敗した場合は wrap されたエラーを返すこと",
+			repo: fakeWorkoutTemplateRepo{
+				findOneFn: template,
+				findExFn: func(uint, []uint) (map[uint]models.Exercise, error) {
+					return nil, errors.New("db down")
+				},
+			},
+			wantErrSub: "find exercises failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkoutTemplateService(&tt.repo)
+			got, err := svc.StartTemplate(1, 3, today)
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantErrSub != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErrSub)
+			default:
+				require.NoError(t, err)
+				require.Zero(t, got.ID)
+				require.Equal(t, uint(1), got.UserID)
+				require.Equal(t, today, got.TrainedOn)
+				require.NotNil(t, got.TemplateID)
+				require.Equal(t, uint(3), *got.TemplateID)
+				require.Len(t, got.Records, 2)
+
+				bench := got.Records[0]
+				require.Equal(t, uint(1), bench.ExerciseID)
+				require.Equal(t, 62.5, bench.Sets[0].ExerciseWeight)
+				require.Equal(t, &rest, bench.Sets[0].RestSeconds)
+				require.Equal(t, 60.0, bench.Sets[1].ExerciseWeight)
+				require.Equal(t, models.SetTypeWarmup, got.Records[1].Sets[0].SetType)
+			}
+		})
+	}
+}
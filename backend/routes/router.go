@@ -24,10 +24,29 @@ func Register(e *echo.Echo, conn *gorm.DB) {
 
 	authRequired := e.Group("", middleware.JWTMiddleware())
 
+	// 記録の変更でランキングのキャッシュを破棄するため、記録を書き込むサービスより先に作る
+	rankingCache := service.NewRankingCache()
+
 	workoutRepo := repository.NewWorkoutRepository(conn)
-	workoutSvc := service.NewWorkoutService(workoutRepo)
+	workoutSvc := service.NewWorkoutService(workoutRepo, rankingCache)
 	workoutHandler := handler.NewWorkoutHandler(workoutSvc)
 
+	workoutSessionRepo := repository.NewWorkoutSessionRepository(conn)
+	workoutSessionSvc := service.NewWorkoutSessionService(workoutSessionRepo, rankingCache)
+	workoutSessionHandler := handler.NewWorkoutSessionHandler(workoutSessionSvc)
+
+	workoutTemplateRepo := repository.NewWorkoutTemplateRepository(conn)
+	workoutTemplateSvc := service.NewWorkoutTemplateService(workoutTemplateRepo)
+	workoutTemplateHandler := handler.NewWorkoutTemplateHandler(workoutTemplateSvc)
+
+	programRepo := repository.NewProgramRepository(conn)
+	programSvc := service.NewProgramService(programRepo)
+	programHandler := handler.NewProgramHandler(programSvc)
+
+	prRepo := repository.NewPersonalRecordRepository(conn)
+	prSvc := service.NewPersonalRecordService(prRepo)
+	prHandler := handler.NewPersonalRecordHandler(prSvc)
+
 	exRepo := repository.NewExerciseRepository(conn)
 	exSvc := service.NewExerciseService(exRepo)
 	exHandler := handler.NewExerciseHandler(exSvc)
@@ -36,13 +55,32 @@ func Register(e *echo.Echo, conn *gorm.DB) {
 	profileSvc := service.NewProfileService(profileRepo)
 	profileHandler := handler.NewProfileHandler(profileSvc)
 
+	bodyMetricRepo := repository.NewBodyMetricRepository(conn)
+	bodyMetricSvc := service.NewBodyMetricService(bodyMetricRepo)
+	bodyMetricHandler := handler.NewBodyMetricHandler(bodyMetricSvc)
+
+	goalRepo := repository.NewGoalRepository(conn)
+	goalSvc := service.NewGoalService(goalRepo)
+	goalHandler := handler.NewGoalHandler(goalSvc)
+
+	statsRepo := repository.NewStatsRepository(conn)
+	statsSvc := service.NewStatsService(statsRepo)
+	statsHandler := handler.NewStatsHandler(statsSvc)
+
+	reportRepo := repository.NewReportRepository(conn)
+	reportSvc := service.NewReportService(reportRepo)
+	reportHandler := handler.NewReportHandler(reportSvc)
+
+	recapRepo := repository.NewRecapRepository(conn)
+	recapSvc := service.NewRecapService(recapRepo)
+	recapHandler := handler.NewRecapHandler(recapSvc)
+
 	summaryRepo := repository.NewSummaryRepository(conn)
 	summarySvc := service.NewSummaryService(summaryRepo)
 	summaryHandler := handler.NewSummaryHandler(summarySvc)
 
 	rankingRepo := repository.NewRankingRepository(conn)
 	rankingSvc := service.NewRankingService(rankingRepo)
-	rankingCache := service.NewRankingCache()
 	rankingHandler := handler.NewRankingHandler(rankingSvc, rankingCache)
 
 	timelineRepo := repository.NewTimelineRepository(conn)
@@ -53,18 +91,94 @@ func Register(e *echo.Echo, conn *gorm.DB) {
 	workoutLikeSvc := service.NewWorkoutLikeService(workoutLikeRepo)
 	workoutLikeHandler := handler.NewWorkoutLikeHandler(workoutLikeSvc)
 
+	workoutCommentRepo := repository.NewWorkoutCommentRepository(conn)
+	workoutCommentSvc := service.NewWorkoutCommentService(workoutCommentRepo)
+	workoutCommentHandler := handler.NewWorkoutCommentHandler(workoutCommentSvc)
+
+	followRepo := repository.NewFollowRepository(conn)
+	followSvc := service.NewFollowService(followRepo)
+	followHandler := handler.NewFollowHandler(followSvc)
+
+	notificationRepo := repository.NewNotificationRepository(conn)
+	notificationSvc := service.NewNotificationService(notificationRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationSvc)
+
 	authRequired.GET("/exercises", exHandler.List)
+	authRequired.POST("/exercises", exHandler.Create)
+	authRequired.PUT("/exercises/:id", exHandler.Update)
+	authRequired.DELETE("/exercises/:id", exHandler.Delete)
+	authRequired.POST("/exercises/:id/archive", exHandler.Archive)
+	authRequired.DELETE("/exercises/:id/archive", exHandler.Unarchive)
 	authRequired.POST("/training_records", workoutHandler.CreateWorkoutRecord)
 	authRequired.GET("/training_records/date", workoutHandler.GetWorkoutRecordsByDate)
 	authRequired.GET("/training_records/monthly_days", workoutHandler.GetMonthRecordDays)
 	authRequired.PUT("/training_records/:id", workoutHandler.UpdateWorkoutRecord)
 	authRequired.DELETE("/training_records/:id", workoutHandler.DeleteWorkoutRecord)
 	authRequired.GET("/training_records/exercises/:exerciseId", workoutHandler.GetWorkoutRecordsByExercise)
+	authRequired.GET("/training_records/exercises/:exerciseId/progression", workoutHandler.GetExerciseProgression)
+	authRequired.GET("/records/prs", prHandler.GetPersonalRecords)
+	authRequired.GET("/records/prs/:exerciseId", prHandler.GetExercisePersonalRecords)
+	authRequired.POST("/training_sessions", workoutSessionHandler.CreateSession)
+	authRequired.GET("/training_sessions/date", workoutSessionHandler.GetSessionsByDate)
+	authRequired.GET("/training_sessions/:id", workoutSessionHandler.GetSession)
+	authRequired.PUT("/training_sessions/:id", workoutSessionHandler.UpdateSession)
+	authRequired.DELETE("/training_sessions/:id", workoutSessionHandler.DeleteSession)
+	authRequired.GET("/templates", workoutTemplateHandler.ListTemplates)
+	authRequired.POST("/templates", workoutTemplateHandler.CreateTemplate)
+	authRequired.GET("/templates/:id", workoutTemplateHandler.GetTemplate)
+	authRequired.PUT("/templates/:id", workoutTemplateHandler.UpdateTemplate)
+	authRequired.DELETE("/templates/:id", workoutTemplateHandler.DeleteTemplate)
+	authRequired.POST("/templates/:id/start", workoutTemplateHandler.StartTemplate)
+	authRequired.GET("/programs", programHandler.ListPrograms)
+	authRequired.PUT("/program", programHandler.Enroll)
+	authRequired.GET("/program", programHandler.GetProgram)
+	authRequired.GET("/program/today", programHandler.GetToday)
+	authRequired.DELETE("/program", programHandler.Quit)
 	authRequired.GET("/profile", profileHandler.GetProfile)
 	authRequired.PUT("/profile", profileHandler.UpdateProfile)
+	authRequired.PUT("/profile/privacy", followHandler.UpdatePrivacy)
+	authRequired.GET("/body_metrics", bodyMetricHandler.ListMetrics)
+	authRequired.POST("/body_metrics", bodyMetricHandler.CreateMetric)
+	authRequired.GET("/body_metrics/composition", bodyMetricHandler.ListBodyComposition)
+	authRequired.GET("/body_metrics/:id", bodyMetricHandler.GetMetric)
+	authRequired.PUT("/body_metrics/:id", bodyMetricHandler.UpdateMetric)
+	authRequired.DELETE("/body_metrics/:id", bodyMetricHandler.DeleteMetric)
+	authRequired.GET("/goals", goalHandler.ListGoals)
+	authRequired.POST("/goals", goalHandler.CreateGoal)
+	authRequired.GET("/goals/:id", goalHandler.GetGoal)
+	authRequired.PUT("/goals/:id", goalHandler.UpdateGoal)
+	authRequired.DELETE("/goals/:id", goalHandler.DeleteGoal)
 	authRequired.GET("/home/summary", summaryHandler.GetHomeSummary)
-	authRequired.GET("/ranking/monthly_gym_days", rankingHandler.MonthlyGymDays)
+	authRequired.GET("/stats", statsHandler.GetStats)
+	authRequired.PUT("/stats/streak_rule", statsHandler.UpdateStreakRule)
+	authRequired.GET("/reports/volume", reportHandler.GetVolumeReport)
+	authRequired.GET("/recaps", recapHandler.ListRecaps)
+	authRequired.GET("/recaps/:period/:key", recapHandler.GetRecap)
+	authRequired.PUT("/recaps/:period/:key/publish", recapHandler.PublishRecap)
+	authRequired.DELETE("/recaps/:period/:key/publish", recapHandler.UnpublishRecap)
+	authRequired.GET("/ranking/monthly_gym_days", rankingHandler.GymDays)
+	authRequired.GET("/ranking/gym_days", rankingHandler.GymDays)
+	authRequired.GET("/ranking/total_volume", rankingHandler.TotalVolume)
+	authRequired.GET("/ranking/exercise_best/:exercise_id", rankingHandler.ExerciseBest)
+	authRequired.GET("/ranking/relative_strength", rankingHandler.RelativeStrength)
+	authRequired.GET("/ranking/cache_stats", rankingHandler.CacheStats)
 	authRequired.GET("/timeline", timelineHandler.GetTimeline)
-	authRequired.POST("/timeline/:recordId/like", workoutLikeHandler.Like)
-	authRequired.DELETE("/timeline/:recordId/like", workoutLikeHandler.Unlike)
+	authRequired.POST("/timeline/:sessionId/like", workoutLikeHandler.Like)
+	authRequired.DELETE("/timeline/:sessionId/like", workoutLikeHandler.Unlike)
+	authRequired.GET("/timeline/:sessionId/likes", workoutLikeHandler.ListLikers)
+	authRequired.GET("/timeline/:sessionId/comments", workoutCommentHandler.ListComments)
+	authRequired.POST("/timeline/:sessionId/comments", workoutCommentHandler.CreateComment)
+	authRequired.PUT("/timeline/:sessionId/comments/:commentId", workoutCommentHandler.UpdateComment)
+	authRequired.DELETE("/timeline/:sessionId/comments/:commentId", workoutCommentHandler.DeleteComment)
+	authRequired.POST("/users/:id/follow", followHandler.Follow)
+	authRequired.DELETE("/users/:id/follow", followHandler.Unfollow)
+	authRequired.GET("/users/:id/followers", followHandler.ListFollowers)
+	authRequired.GET("/users/:id/following", followHandler.ListFollowing)
+	authRequired.GET("/users/:id/follow_summary", followHandler.GetFollowSummary)
+	authRequired.GET("/follow_requests", followHandler.ListFollowRequests)
+	authRequired.POST("/follow_requests/:id/approve", followHandler.ApproveFollowRequest)
+	authRequired.DELETE("/follow_requests/:id", followHandler.RejectFollowRequest)
+	authRequired.GET("/notifications", notificationHandler.ListNotifications)
+	authRequired.POST("/notifications/read_all", notificationHandler.MarkAllRead)
+	authRequired.POST("/notifications/:id/read", notificationHandler.MarkRead)
 }
@@ -22,7 +22,7 @@ func newIntegrationDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.Exercise{}))
+	require.NoError(t, db.AutoMigrate(&models.Exercise{}, &models.ExerciseMuscle{}, &models.ExerciseAlias{}))
 	return db
 }
 
@@ -47,6 +47,7 @@ func TestExerciseIntegration_List(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
+		c.Set("user_id", uint(1))
 
 		err := h.List(c)
 		if err != nil {
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/RintaroNasu/muscle_diary_app/internal/handler"
 	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
@@ -25,7 +26,7 @@ func newProfileIntegrationDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.User{}))
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}, &models.BodyMetric{}))
 	return db
 }
 
@@ -109,4 +110,48 @@ func TestProfileIntegration_GetAndUpdate(t *testing.T) {
 		require.InDelta(t, 65.0, *updated.GoalWeight, 0.01)
 	})
 
+	t.Run("【正常系】goal_date・sexを含まない更新では既存の目標日・性別が保持されること", func(t *testing.T) {
+		db := newProfileIntegrationDB(t)
+		user := models.User{
+			Email:      "user3@example.com",
+			Height:     utils.Ptr(160.0),
+			GoalWeight: utils.Ptr(55.0),
+			GoalDate:   utils.Ptr(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)),
+			Sex:        utils.Ptr(models.SexFemale),
+		}
+		require.NoError(t, db.Create(&user).Error)
+
+		repo := repository.NewProfileRepository(db)
+		svc := service.NewProfileService(repo)
+		h := handler.NewProfileHandler(svc)
+
+		body := `{"height_cm":175.0,"goal_weight_kg":65.0}`
+		req := httptest.NewRequest(http.MethodPut, "/profile", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		setUserID(c, user.ID)
+
+		err := h.UpdateProfile(c)
+		if err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var res handler.ProfileResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.NotNil(t, res.GoalDate)
+		require.Equal(t, "2026-12-31", *res.GoalDate)
+		require.NotNil(t, res.Sex)
+		require.Equal(t, "female", *res.Sex)
+
+		var updated models.User
+		require.NoError(t, db.First(&updated, user.ID).Error)
+		require.InDelta(t, 175.0, *updated.Height, 0.01)
+		require.NotNil(t, updated.GoalDate)
+		require.Equal(t, "2026-12-31", updated.GoalDate.Format("2006-01-02"))
+		require.NotNil(t, updated.Sex)
+		require.Equal(t, models.SexFemale, *updated.Sex)
+	})
 }
@@ -25,7 +25,7 @@ import (
 type SummaryResponseDTO struct {
 	TotalTrainingDays int      `json:"total_training_days"`
 	LatestWeight      *float64 `json:"latest_weight"`
-	LatestTrainedOn   string   `json:"latest_trained_on"`
+	LatestWeightOn    string   `json:"latest_trained_on"`
 	GoalWeight        *float64 `json:"goal_weight"`
 	Height            *float64 `json:"height"`
 }
@@ -35,7 +35,7 @@ func newSummaryIntegrationDB(t *testing.T) *gorm.DB {
 	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
 	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	require.NoError(t, err)
-	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}))
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Exercise{}, &models.WorkoutRecord{}, &models.BodyMetric{}))
 	return db
 }
 
@@ -87,9 +87,9 @@ func TestSummaryIntegration_GetHomeSummary(t *testing.T) {
 		require.Equal(t, 2, got.TotalTrainingDays)
 		require.NotNil(t, got.LatestWeight)
 		require.InDelta(t, 62.0, *got.LatestWeight, 0.001)
-		require.NotNil(t, got.LatestTrainedOn)
+		require.NotNil(t, got.LatestWeightOn)
 		expectedDate := now.Format("2006-01-02")
-		require.Equal(t, expectedDate, got.LatestTrainedOn)
+		require.Equal(t, expectedDate, got.LatestWeightOn)
 		require.NotNil(t, got.Height)
 		require.InDelta(t, 170.0, *got.Height, 0.001)
 		require.NotNil(t, got.GoalWeight)
@@ -122,12 +122,12 @@ func TestSummaryIntegration_GetHomeSummary(t *testing.T) {
 
 		require.Equal(t, http.StatusOK, rec.Code)
 
-		var got service.HomeSummary
+		var got SummaryResponseDTO
 		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
 
-		require.Equal(t, int64(0), got.TotalTrainingDays)
+		require.Equal(t, 0, got.TotalTrainingDays)
 		require.Nil(t, got.LatestWeight)
-		require.Nil(t, got.LatestTrainedOn)
+		require.Empty(t, got.LatestWeightOn)
 		require.NotNil(t, got.Height)
 		require.InDelta(t, 180.0, *got.Height, 0.001)
 		require.NotNil(t, got.GoalWeight)
@@ -0,0 +1,279 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RintaroNasu/muscle_diary_app/internal/handler"
+	"github.com/RintaroNasu/muscle_diary_app/internal/httpx"
+	"github.com/RintaroNasu/muscle_diary_app/internal/models"
+	"github.com/RintaroNasu/muscle_diary_app/internal/repository"
+	"github.com/RintaroNasu/muscle_diary_app/internal/service"
+	"github.com/RintaroNasu/muscle_diary_app/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type timelinePageDTO struct {
+	Items []struct {
+		Kind      string `json:"kind"`
+		SessionID uint   `json:"session_id"`
+		TrainedOn string `json:"trained_on"`
+		Recap     *struct {
+			RecapID uint `json:"recap_id"`
+		} `json:"recap"`
+	} `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func newTimelineIntegrationDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&models.User{},
+		&models.Exercise{},
+		&models.WorkoutSession{},
+		&models.WorkoutRecord{},
+		&models.WorkoutSet{},
+		&models.WorkoutLike{},
+		&models.Follow{},
+		&models.Recap{},
+		&models.WorkoutComment{},
+		&models.Notification{},
+	))
+	return db
+}
+
+func TestTimelineIntegration_Pagination(t *testing.T) {
+	e := echo.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	e.HTTPErrorHandler = httpx.HTTPErrorHandler(logger)
+
+	db := newTimelineIntegrationDB(t)
+	user := models.User{Email: "tl@example.com"}
+	require.NoError(t, db.Create(&user).Error)
+	bench := models.Exercise{Name: "ベンチプレス"}
+	squat := models.Exercise{Name: "スクワット"}
+	require.NoError(t, db.Create(&bench).Error)
+	require.NoError(t, db.Create(&squat).Error)
+
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 0, 0, 0, 0, time.UTC) }
+	session := func(d int, exerciseID uint) uint {
+		s := models.WorkoutSession{
+			UserID:    user.ID,
+			TrainedOn: day(d),
+			IsPublic:  true,
+			Records:   []models.WorkoutRecord{{UserID: user.ID, ExerciseID: exerciseID, TrainedOn: day(d)}},
+		}
+		require.NoError(t, db.Create(&s).Error)
+		return s.ID
+	}
+	s1 := session(1, bench.ID)
+	s2 := session(3, squat.ID)
+	s3 := session(3, bench.ID)
+	s4 := session(5, bench.ID)
+	s5 := session(6, squat.ID)
+
+	recap := func(start time.Time, publishedAt time.Time) uint {
+		r := models.Recap{UserID: user.ID, Period: models.RecapMonth, PeriodStart: start, IsPublic: true, PublishedAt: utils.Ptr(publishedAt.UTC())}
+		require.NoError(t, db.Create(&r).Error)
+		return r.ID
+	}
+	// 日本時間では 10/3 と 10/5 の公開
+	r1 := recap(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 2, 16, 0, 0, 0, time.UTC))
+	r2 := recap(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 10, 5, 12, 0, 0, 0, time.UTC))
+
+	h := handler.NewTimelineHandler(service.NewTimelineService(repository.NewTimelineRepository(db)))
+	fetch := func(t *testing.T, query url.Values) timelinePageDTO {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/timeline?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", user.ID)
+		if err := h.GetTimeline(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var page timelinePageDTO
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		return page
+	}
+	keys := func(page timelinePageDTO) []string {
+		out := make([]string, 0, len(page.Items))
+		for _, it := range page.Items {
+			if it.Kind == "recap" {
+				out = append(out, fmt.Sprintf("r%d", it.Recap.RecapID))
+			} else {
+				out = append(out, fmt.Sprintf("s%d", it.SessionID))
+			}
+		}
+		return out
+	}
+
+	want := []string{
+		fmt.Sprintf("s%d", s5),
+		fmt.Sprintf("r%d", r2), fmt.Sprintf("s%d", s4),
+		fmt.Sprintf("r%d", r1), fmt.Sprintf("s%d", s3), fmt.Sprintf("s%d", s2),
+		fmt.Sprintf("s%d", s1),
+	}
+
+	t.Run("【正常系】1ページで全件を日付・まとめ優先・ID の順に返すこと", func(t *testing.T) {
+		page := fetch(t, url.Values{"limit": {"100"}})
+		require.Equal(t, want, keys(page))
+		require.Nil(t, page.NextCursor)
+	})
+
+	for _, limit := range []string{"1", "2", "3"} {
+		t.Run("【正常系】limit="+limit+" で next_cursor をたどると重複・欠落なく全件を返すこと", func(t *testing.T) {
+			var got []string
+			query := url.Values{"limit": {limit}}
+			for i := 0; i < len(want)+1; i++ {
+				page := fetch(t, query)
+				got = append(got, keys(page)...)
+				if page.NextCursor == nil {
+					break
+				}
+				query.Set("cursor", *page.NextCursor)
+			}
+			require.Equal(t, want, got)
+		})
+	}
+
+	t.Run("【正常系】種目で絞り込むとその種目を含むセッションだけを返すこと", func(t *testing.T) {
+		page := fetch(t, url.Values{"exercise_id": {fmt.Sprint(squat.ID)}})
+		require.Equal(t, []string{fmt.Sprintf("s%d", s5), fmt.Sprintf("s%d", s2)}, keys(page))
+	})
+
+	t.Run("【正常系】期間で絞り込むとまとめは公開日で判定すること", func(t *testing.T) {
+		page := fetch(t, url.Values{"from": {"2025-10-03"}, "to": {"2025-10-03"}})
+		require.Equal(t, []string{fmt.Sprintf("r%d", r1), fmt.Sprintf("s%d", s3), fmt.Sprintf("s%d", s2)}, keys(page))
+	})
+
+	t.Run("【正常系】投稿者で絞り込めること", func(t *testing.T) {
+		page := fetch(t, url.Values{"user_id": {fmt.Sprint(user.ID + 1)}})
+		require.Empty(t, page.Items)
+	})
+}
+
+func TestTimelineIntegration_PrivateAccountLikers(t *testing.T) {
+	e := echo.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	e.HTTPErrorHandler = httpx.HTTPErrorHandler(logger)
+
+	db := newTimelineIntegrationDB(t)
+	owner := models.User{Email: "private@example.com", IsPrivate: true}
+	follower := models.User{Email: "follower@example.com"}
+	pending := models.User{Email: "pending@example.com"}
+	stranger := models.User{Email: "stranger@example.com"}
+	for _, u := range []*models.User{&owner, &follower, &pending, &stranger} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	require.NoError(t, db.Create(&models.Follow{FollowerID: follower.ID, FolloweeID: owner.ID, Status: models.FollowAccepted}).Error)
+	require.NoError(t, db.Create(&models.Follow{FollowerID: pending.ID, FolloweeID: owner.ID, Status: models.FollowPending}).Error)
+	session := models.WorkoutSession{UserID: owner.ID, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), IsPublic: true}
+	require.NoError(t, db.Create(&session).Error)
+
+	h := handler.NewWorkoutLikeHandler(service.NewWorkoutLikeService(repository.NewWorkoutLikeRepository(db)))
+	call := func(t *testing.T, method string, viewerID uint, fn func(echo.Context) error) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/timeline/%d/likes", session.ID), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("sessionId")
+		c.SetParamValues(fmt.Sprint(session.ID))
+		c.Set("user_id", viewerID)
+		if err := fn(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	t.Run("【正常系】承認済みのフォロワーはいいねしていいねしたユーザーを見られること", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, call(t, http.MethodPost, follower.ID, h.Like).Code)
+		rec := call(t, http.MethodGet, follower.ID, h.ListLikers)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"email":"follower@example.com"`)
+	})
+
+	for _, viewer := range []models.User{pending, stranger} {
+		t.Run("【異常系】"+viewer.Email+" にはいいねしたユーザーを見せず、いいねもさせないこと", func(t *testing.T) {
+			rec := call(t, http.MethodGet, viewer.ID, h.ListLikers)
+			require.Equal(t, http.StatusForbidden, rec.Code)
+			require.NotContains(t, rec.Body.String(), "follower@example.com")
+
+			require.Equal(t, http.StatusForbidden, call(t, http.MethodPost, viewer.ID, h.Like).Code)
+			require.Equal(t, http.StatusForbidden, call(t, http.MethodDelete, viewer.ID, h.Unlike).Code)
+		})
+	}
+
+	t.Run("【正常系】禁止されたいいねは通知されないこと", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&models.Notification{}).Where("user_id = ?", owner.ID).Count(&count).Error)
+		require.Equal(t, int64(1), count)
+	})
+}
+
+func TestTimelineIntegration_PrivateAccountComments(t *testing.T) {
+	e := echo.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	e.HTTPErrorHandler = httpx.HTTPErrorHandler(logger)
+
+	db := newTimelineIntegrationDB(t)
+	owner := models.User{Email: "private@example.com", IsPrivate: true}
+	follower := models.User{Email: "follower@example.com"}
+	pending := models.User{Email: "pending@example.com"}
+	stranger := models.User{Email: "stranger@example.com"}
+	for _, u := range []*models.User{&owner, &follower, &pending, &stranger} {
+		require.NoError(t, db.Create(u).Error)
+	}
+	require.NoError(t, db.Create(&models.Follow{FollowerID: follower.ID, FolloweeID: owner.ID, Status: models.FollowAccepted}).Error)
+	require.NoError(t, db.Create(&models.Follow{FollowerID: pending.ID, FolloweeID: owner.ID, Status: models.FollowPending}).Error)
+	session := models.WorkoutSession{UserID: owner.ID, TrainedOn: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), IsPublic: true}
+	require.NoError(t, db.Create(&session).Error)
+
+	h := handler.NewWorkoutCommentHandler(service.NewWorkoutCommentService(repository.NewWorkoutCommentRepository(db)))
+	call := func(t *testing.T, method string, viewerID uint, body string, fn func(echo.Context) error) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/timeline/%d/comments", session.ID), strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("sessionId")
+		c.SetParamValues(fmt.Sprint(session.ID))
+		c.Set("user_id", viewerID)
+		if err := fn(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	t.Run("【正常系】承認済みのフォロワーはコメントして一覧を見られること", func(t *testing.T) {
+		require.Equal(t, http.StatusCreated, call(t, http.MethodPost, follower.ID, `{"body":"ナイス"}`, h.CreateComment).Code)
+		rec := call(t, http.MethodGet, follower.ID, "", h.ListComments)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"body":"ナイス"`)
+	})
+
+	for _, viewer := range []models.User{pending, stranger} {
+		t.Run("【異常系】"+viewer.Email+" にはコメントを見せず、コメントもさせないこと", func(t *testing.T) {
+			rec := call(t, http.MethodGet, viewer.ID, "", h.ListComments)
+			require.Equal(t, http.StatusForbidden, rec.Code)
+			require.NotContains(t, rec.Body.String(), "ナイス")
+
+			require.Equal(t, http.StatusForbidden, call(t, http.MethodPost, viewer.ID, `{"body":"こんにちは"}`, h.CreateComment).Code)
+		})
+	}
+}
@@ -30,8 +30,14 @@ func newWorkoutIntegrationDB(t *testing.T) *gorm.DB {
 	require.NoError(t, db.AutoMigrate(
 		&models.User{},
 		&models.Exercise{},
+		&models.WorkoutSession{},
 		&models.WorkoutRecord{},
 		&models.WorkoutSet{},
+		&models.PersonalRecord{},
+		&models.UserProgram{},
+		&models.UserProgramLift{},
+		&models.Goal{},
+		&models.Notification{},
 	))
 	return db
 }
@@ -63,7 +69,7 @@ func TestWorkoutIntegration_NormalCases(t *testing.T) {
 
 		// ② DI: repo → svc → handler
 		repo := repository.NewWorkoutRepository(db)
-		svc := service.NewWorkoutService(repo)
+		svc := service.NewWorkoutService(repo, nil)
 		h := handler.NewWorkoutHandler(svc)
 
 		// パラメータ共通化
@@ -51,16 +51,19 @@ class TimelineController extends StateNotifier<TimelineState> {
     state = state.copyWith(clearError: true);
   }
 
-  Future<void> toggleLike(int recordId) async {
+  Future<void> toggleLike(int sessionId) async {
     final current = state.timelineAsync;
     if (!current.hasValue) return;
 
     final items = current.value!;
-    final index = items.indexWhere((e) => e.recordId == recordId);
+    final index = items.indexWhere((e) => e.sessionId == sessionId);
     if (index == -1) return;
 
     final before = items[index];
-    final optimistic = before.copyWith(likedByMe: !before.likedByMe);
+    final optimistic = before.copyWith(
+      likedByMe: !before.likedByMe,
+      likeCount: before.likeCount + (before.likedByMe ? -1 : 1),
+    );
 
     // 楽観更新
     final next = [...items];
@@ -69,9 +72,9 @@ class TimelineController extends StateNotifier<TimelineState> {
 
     try {
       if (!before.likedByMe) {
-        await _api.like(recordId);
+        await _api.like(sessionId);
       } else {
-        await _api.unlike(recordId);
+        await _api.unlike(sessionId);
       }
     } catch (e) {
       final rollback = [...next];
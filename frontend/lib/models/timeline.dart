@@ -1,48 +1,63 @@
 // lib/models/timeline.dart
 class TimelineItem {
-  final int recordId;
+  final int sessionId;
   final int userId;
   final String userEmail;
-  final String exerciseName;
+  final List<String> exerciseNames;
   final double? bodyWeight;
   final String trainedOn;
   final String? comment;
   final bool likedByMe;
+  final int likeCount;
+  final int commentCount;
 
   TimelineItem({
-    required this.recordId,
+    required this.sessionId,
     required this.userId,
     required this.userEmail,
-    required this.exerciseName,
+    required this.exerciseNames,
     this.bodyWeight,
     required this.trainedOn,
     this.comment,
     required this.likedByMe,
+    this.likeCount = 0,
+    this.commentCount = 0,
   });
 
-  TimelineItem copyWith({bool? likedByMe}) {
+  /// 一覧の見出しに使う、種目名を並び順どおりにつないだ文字列
+  String get title => exerciseNames.join(' / ');
+
+  TimelineItem copyWith({bool? likedByMe, int? likeCount}) {
     return TimelineItem(
-      recordId: recordId,
+      sessionId: sessionId,
       userId: userId,
       userEmail: userEmail,
-      exerciseName: exerciseName,
+      exerciseNames: exerciseNames,
       bodyWeight: bodyWeight,
       trainedOn: trainedOn,
       comment: comment,
       likedByMe: likedByMe ?? this.likedByMe,
+      likeCount: likeCount ?? this.likeCount,
+      commentCount: commentCount,
     );
   }
 
   factory TimelineItem.fromJson(Map<String, dynamic> json) {
+    final bodyWeight = (json['body_weight'] as num?)?.toDouble();
     return TimelineItem(
-      recordId: json['record_id'] as int,
+      sessionId: json['session_id'] as int,
       userId: json['user_id'] as int,
       userEmail: json['user_email'] as String,
-      exerciseName: json['exercise_name'] as String,
-      bodyWeight: (json['body_weight'] as num?)?.toDouble(),
+      exerciseNames: (json['exercise_names'] as List<dynamic>? ?? const [])
+          .whereType<String>()
+          .toList(),
+      // 体重は未入力だと 0 で返る
+      bodyWeight: bodyWeight != null && bodyWeight > 0 ? bodyWeight : null,
       trainedOn: json['trained_on'] as String,
       comment: json['comment'] as String?,
-      likedByMe: json['liked_by_me'] as bool,
+      likedByMe: json['liked_by_me'] as bool? ?? false,
+      likeCount: json['like_count'] as int? ?? 0,
+      commentCount: json['comment_count'] as int? ?? 0,
     );
   }
 }
@@ -48,7 +48,7 @@ class TimelinePage extends HookConsumerWidget {
               return Card(
                 child: ListTile(
                   title: Text(
-                    item.exerciseName,
+                    item.title,
                     style: const TextStyle(fontWeight: FontWeight.bold),
                   ),
                   subtitle: Column(
@@ -74,11 +74,19 @@ class TimelinePage extends HookConsumerWidget {
                         ),
                     ],
                   ),
-                  trailing: IconButton(
-                    icon: Icon(
-                      item.likedByMe ? Icons.favorite : Icons.favorite_border,
-                    ),
-                    onPressed: () => ctrl.toggleLike(item.recordId),
+                  trailing: Row(
+                    mainAxisSize: MainAxisSize.min,
+                    children: [
+                      IconButton(
+                        icon: Icon(
+                          item.likedByMe
+                              ? Icons.favorite
+                              : Icons.favorite_border,
+                        ),
+                        onPressed: () => ctrl.toggleLike(item.sessionId),
+                      ),
+                      Text('${item.likeCount}'),
+                    ],
                   ),
                 ),
               );
@@ -14,10 +14,13 @@ class TimelineApi {
     final res = await _api.get('/timeline');
 
     if (res.statusCode == 200) {
-      final data = jsonDecode(res.body);
+      final body = jsonDecode(res.body);
+      final data = body is Map<String, dynamic> ? body['items'] : body;
       if (data is List) {
+        // まとめ（kind: recap）はまだ表示しないため、トレーニングだけを返す
         return data
             .whereType<Map<String, dynamic>>()
+            .where((e) => (e['kind'] ?? 'session') == 'session')
             .map(TimelineItem.fromJson)
             .toList();
       }
@@ -31,15 +34,15 @@ class TimelineApi {
     throw Exception('タイムライン取得に失敗しました: ${res.statusCode}');
   }
 
-  Future<void> like(int recordId) async {
-    final res = await _api.post('/timeline/$recordId/like');
+  Future<void> like(int sessionId) async {
+    final res = await _api.post('/timeline/$sessionId/like');
     if (res.statusCode == 200) return;
     if (res.statusCode == 401) throw Exception('認証エラー: ログインし直してください');
     throw Exception('いいねに失敗しました: ${res.statusCode}');
   }
 
-  Future<void> unlike(int recordId) async {
-    final res = await _api.delete('/timeline/$recordId/like');
+  Future<void> unlike(int sessionId) async {
+    final res = await _api.delete('/timeline/$sessionId/like');
     if (res.statusCode == 200) return;
     if (res.statusCode == 401) throw Exception('認証エラー: ログインし直してください');
     throw Exception('いいね解除に失敗しました: ${res.statusCode}');
@@ -0,0 +1,108 @@
+import 'dart:convert';
+
+import 'package:flutter_test/flutter_test.dart';
+import 'package:http/http.dart' as http;
+import 'package:mocktail/mocktail.dart';
+
+import 'package:frontend/repositories/api.dart';
+import 'package:frontend/repositories/api/timeline.dart';
+
+class MockApiClient extends Mock implements ApiClient {}
+
+void main() {
+  late MockApiClient mockApi;
+  late TimelineApi api;
+
+  setUpAll(() {
+    registerFallbackValue(<String, String>{});
+  });
+
+  setUp(() {
+    mockApi = MockApiClient();
+    api = TimelineApi(mockApi);
+  });
+
+  group('fetchTimeline', () {
+    test('【正常系】セッション単位のタイムラインを取得できること', () async {
+      when(() => mockApi.get(any())).thenAnswer(
+        (_) async => http.Response(
+          jsonEncode({
+            'items': [
+              {
+                'kind': 'session',
+                'session_id': 10,
+                'user_id': 2,
+                'user_email': 'a@example.com',
+                'exercise_names': ['ベンチプレス', 'スクワット'],
+                'exercises': [],
+                'body_weight': 0,
+                'trained_on': '2025-10-01',
+                'comment': '',
+                'liked_by_me': true,
+                'like_count': 3,
+                'comment_count': 1,
+              },
+              {
+                'kind': 'recap',
+                'session_id': 0,
+                'user_id': 2,
+                'user_email': 'a@example.com',
+                'exercise_names': null,
+                'trained_on': '2025-10-01',
+                'liked_by_me': false,
+                'recap': {'recap_id': 1},
+              },
+            ],
+            'next_cursor': null,
+          }),
+          200,
+        ),
+      );
+
+      final result = await api.fetchTimeline();
+
+      expect(result, hasLength(1));
+      expect(result.first.sessionId, 10);
+      expect(result.first.title, 'ベンチプレス / スクワット');
+      expect(result.first.bodyWeight, isNull);
+      expect(result.first.likedByMe, isTrue);
+      expect(result.first.likeCount, 3);
+      expect(result.first.commentCount, 1);
+      verify(() => mockApi.get('/timeline')).called(1);
+    });
+
+    test('【異常系】401 の場合は認証エラーを返すこと', () async {
+      when(
+        () => mockApi.get(any()),
+      ).thenAnswer((_) async => http.Response('Unauthorized', 401));
+
+      await expectLater(
+        api.fetchTimeline(),
+        throwsA(
+          isA<Exception>().having(
+            (e) => e.toString(),
+            'message',
+            contains('認証エラー: ログインし直してください'),
+          ),
+        ),
+      );
+    });
+  });
+
+  group('like / unlike', () {
+    test('【正常系】セッションIDでいいね・解除できること', () async {
+      when(
+        () => mockApi.post(any()),
+      ).thenAnswer((_) async => http.Response('{}', 200));
+      when(
+        () => mockApi.delete(any()),
+      ).thenAnswer((_) async => http.Response('{}', 200));
+
+      await api.like(10);
+      await api.unlike(10);
+
+      verify(() => mockApi.post('/timeline/10/like')).called(1);
+      verify(() => mockApi.delete('/timeline/10/like')).called(1);
+    });
+  });
+}